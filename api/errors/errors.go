@@ -4,111 +4,71 @@ import (
 	"errors"
 	"net/http"
 
-	"github.com/go-chi/render"
-	epb "google.golang.org/genproto/googleapis/rpc/errdetails"
-	"google.golang.org/grpc/codes"
-	"google.golang.org/grpc/status"
-)
+	validation "github.com/go-ozzo/ozzo-validation/v4"
 
-var (
-	ErrInvalidArgument  = errors.New("invalid argument")
-	ErrAuthDataNotFound = ErrAuthData(errors.New("failed to fetch authentication data"))
-	ErrNotFound         = &ErrResponse{HTTPStatusCode: 404, ErrorText: "resource not found."}
+	"musobaqa/farm-competition/api/models"
+	errorspkg "musobaqa/farm-competition/internal/errors"
 )
 
-type ErrResponse struct {
-	Err            error `json:"-"` // low-level runtime error
-	HTTPStatusCode int   `json:"-"` // http response status code
-
-	AppCode   int64             `json:"code,omitempty"`  // application-specific error code
-	ErrorText string            `json:"error,omitempty"` // application-level error message, for debugging
-	Errors    map[string]string `json:"errors,omitempty"`
-}
-
-func (e *ErrResponse) Render(w http.ResponseWriter, r *http.Request) error {
-	render.Status(r, e.HTTPStatusCode)
-	return nil
-}
-
-func ErrInvalidRequestRender(err error) render.Renderer {
-	return &ErrResponse{
-		Err:            err,
-		HTTPStatusCode: 400,
-		ErrorText:      err.Error(),
-	}
-}
-
-func ErrInvalidArgumentRender(err error, errors map[string]string) render.Renderer {
-	return &ErrResponse{
-		Err:            err,
-		HTTPStatusCode: 422,
-		ErrorText:      err.Error(),
-		Errors:         errors,
-	}
-}
-
-func IsNotFound(err error) bool {
-	st, ok := status.FromError(err)
-	if !ok {
-		return false
+// Response maps an error a handler got from a usecase onto the status and
+// body of the answer. The code comes from the catalog in internal/errors,
+// errors outside of it are internal and their text is kept out of the body
+func Response(err error) (int, models.Error) {
+	var fields validation.Errors
+	if errors.As(err, &fields) {
+		return http.StatusUnprocessableEntity, models.Error{
+			Code:    string(errorspkg.CodeValidation),
+			Message: models.ValidationFailed,
+			Fields:  fieldErrors(fields),
+		}
 	}
 
-	return st.Code() == codes.NotFound
-}
-
-func ErrAuthData(err error) render.Renderer {
-	return &ErrResponse{
-		Err:            err,
-		HTTPStatusCode: 401,
-		ErrorText:      err.Error(),
-	}
-}
+	code := errorspkg.CodeOf(err)
+	body := models.Error{Code: string(code)}
 
-func Error(err error) render.Renderer {
-	st, ok := status.FromError(err)
-	if !ok {
-		return ErrInvalidRequestRender(err)
+	switch code {
+	case errorspkg.CodeValidation:
+		var invalid *errorspkg.ErrValidation
+		errors.As(err, &invalid)
+		body.Message = models.ValidationFailed
+		body.Fields = invalid.Errors
+		return http.StatusUnprocessableEntity, body
+	case errorspkg.CodeBadRequest, errorspkg.CodeUnknownEntity:
+		body.Message = models.WrongInfoMessage
+		return http.StatusBadRequest, body
+	case errorspkg.CodeNotFound:
+		var notFound *errorspkg.ErrNotFound
+		errors.As(err, &notFound)
+		body.Message = notFound.Error()
+		return http.StatusNotFound, body
+	case errorspkg.CodeConflict:
+		var conflict *errorspkg.ErrConflict
+		errors.As(err, &conflict)
+		body.Message = conflict.Error()
+		return http.StatusConflict, body
+	case errorspkg.CodeNotEnoughStock:
+		body.Message = models.NotEnoughStock
+		return http.StatusConflict, body
+	case errorspkg.CodeParentDeleted:
+		body.Message = models.ParentDeleted
+		return http.StatusConflict, body
+	case errorspkg.CodeTaskClosed:
+		body.Message = models.TaskClosed
+		return http.StatusConflict, body
+	case errorspkg.CodeVersionConflict:
+		body.Message = models.VersionConflict
+		return http.StatusPreconditionFailed, body
 	}
 
-	switch st.Code() {
-	// error bad request
-	case codes.OK:
-		return nil
-	// error unavailable
-	case codes.AlreadyExists:
-		return ErrInvalidRequestRender(errors.New(st.Message()))
-	// error unavailable
-	case codes.Unavailable:
-		return ErrInvalidRequestRender(errors.New("Service is unavailable. Try again soon."))
-	// error not found
-	case codes.NotFound:
-		return ErrNotFound
-	// error validation errors
-	case codes.InvalidArgument:
-		details := ErrorDetails(st)
-		return ErrInvalidArgumentRender(ErrInvalidArgument, details)
-	default:
-		var errStr string
-		for _, detail := range st.Details() {
-			if errorInfo, ok := detail.(*epb.ErrorInfo); ok {
-				errStr += " " + errorInfo.Reason
-			}
-		}
-		if len(errStr) != 0 {
-			return ErrInvalidRequestRender(errors.New(errStr))
-		}
-		return ErrInvalidRequestRender(err)
-	}
+	body.Message = models.InternalMessage
+	return http.StatusInternalServerError, body
 }
 
-func ErrorDetails(st *status.Status) map[string]string {
-	var data = make(map[string]string)
-	for _, detail := range st.Details() {
-		if badRequest, ok := detail.(*epb.BadRequest); ok {
-			for _, violation := range badRequest.GetFieldViolations() {
-				data[violation.GetField()] = violation.GetDescription()
-			}
-		}
+// fieldErrors flattens the errors of ozzo validation, keyed by json field
+func fieldErrors(errs validation.Errors) map[string]string {
+	fields := make(map[string]string, len(errs))
+	for field, err := range errs {
+		fields[field] = err.Error()
 	}
-	return data
+	return fields
 }
@@ -0,0 +1,101 @@
+package errors
+
+import (
+	"context"
+	"errors"
+	"sort"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"musobaqa/farm-competition/api/models"
+	errorspkg "musobaqa/farm-competition/internal/errors"
+)
+
+// Domain names the error catalog in the ErrorInfo of grpc statuses
+const Domain = "farm.v1"
+
+// Status is Response for the grpc api. The catalog code goes in the reason of
+// an ErrorInfo detail and validation errors list their fields in a BadRequest
+// detail, errors already turned into a status are kept as they are
+func Status(err error) *status.Status {
+	if s, ok := status.FromError(err); ok {
+		return s
+	}
+
+	var fields validation.Errors
+	if errors.As(err, &fields) {
+		return invalid(fieldErrors(fields))
+	}
+
+	switch code := errorspkg.CodeOf(err); code {
+	case errorspkg.CodeValidation:
+		var invalidErr *errorspkg.ErrValidation
+		errors.As(err, &invalidErr)
+		return invalid(invalidErr.Errors)
+	case errorspkg.CodeBadRequest, errorspkg.CodeUnknownEntity:
+		return NewStatus(codes.InvalidArgument, code, models.WrongInfoMessage)
+	case errorspkg.CodeNotFound:
+		var notFound *errorspkg.ErrNotFound
+		errors.As(err, &notFound)
+		return NewStatus(codes.NotFound, code, notFound.Error())
+	case errorspkg.CodeConflict:
+		var conflict *errorspkg.ErrConflict
+		errors.As(err, &conflict)
+		return NewStatus(codes.AlreadyExists, code, conflict.Error())
+	case errorspkg.CodeNotEnoughStock:
+		return NewStatus(codes.FailedPrecondition, code, models.NotEnoughStock)
+	case errorspkg.CodeParentDeleted:
+		return NewStatus(codes.FailedPrecondition, code, models.ParentDeleted)
+	case errorspkg.CodeTaskClosed:
+		return NewStatus(codes.FailedPrecondition, code, models.TaskClosed)
+	case errorspkg.CodeVersionConflict:
+		return NewStatus(codes.Aborted, code, models.VersionConflict)
+	}
+
+	switch {
+	case errors.Is(err, context.DeadlineExceeded):
+		return NewStatus(codes.DeadlineExceeded, errorspkg.CodeDeadlineExceeded, models.DeadlineExceeded)
+	case errors.Is(err, context.Canceled):
+		return status.New(codes.Canceled, context.Canceled.Error())
+	}
+
+	return NewStatus(codes.Internal, errorspkg.CodeInternal, models.InternalMessage)
+}
+
+// NewStatus builds a status carrying the catalog code in an ErrorInfo detail
+func NewStatus(c codes.Code, code errorspkg.Code, message string) *status.Status {
+	s, err := status.New(c, message).WithDetails(&errdetails.ErrorInfo{
+		Reason: string(code),
+		Domain: Domain,
+	})
+	if err != nil {
+		return status.New(c, message)
+	}
+	return s
+}
+
+// invalid answers a validation error with the failing fields as violations
+func invalid(fields map[string]string) *status.Status {
+	names := make([]string, 0, len(fields))
+	for field := range fields {
+		names = append(names, field)
+	}
+	sort.Strings(names)
+
+	violations := make([]*errdetails.BadRequest_FieldViolation, 0, len(fields))
+	for _, field := range names {
+		violations = append(violations, &errdetails.BadRequest_FieldViolation{
+			Field:       field,
+			Description: fields[field],
+		})
+	}
+
+	s := NewStatus(codes.InvalidArgument, errorspkg.CodeValidation, models.ValidationFailed)
+	if withFields, err := s.WithDetails(&errdetails.BadRequest{FieldViolations: violations}); err == nil {
+		return withFields
+	}
+	return s
+}
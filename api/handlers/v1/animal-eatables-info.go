@@ -3,7 +3,7 @@ package v1
 import (
 	"musobaqa/farm-competition/api/models"
 	"musobaqa/farm-competition/internal/entity"
-	l "musobaqa/farm-competition/internal/pkg/logger"
+	errorspkg "musobaqa/farm-competition/internal/errors"
 	"musobaqa/farm-competition/internal/pkg/otlp"
 	"musobaqa/farm-competition/internal/pkg/utils"
 	"net/http"
@@ -19,8 +19,11 @@ import (
 // @Accept json
 // @Produce json
 // @Param Animal-Eatables body models.AnimaEatablesInfoReq true "createModel"
+// @Param Idempotency-Key header string false "Key that makes retries of the request safe"
 // @Success 201 {object} models.AnimaEatablesInfoRes
 // @Failure 400 {object} models.Error
+// @Failure 409 {object} models.Error
+// @Failure 422 {object} models.Error
 // @Failure 500 {object} models.Error
 // @Router /v1/animals/eatables [post]
 func (h *HandlerV1) CreateEatablesInfo(c *gin.Context) {
@@ -37,19 +40,13 @@ func (h *HandlerV1) CreateEatablesInfo(c *gin.Context) {
 
 	err := c.ShouldBindJSON(&body)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, models.Error{
-			Message: models.WrongDateMessage,
-		})
-		h.Logger.Error(err.Error())
+		h.fail(c, errorspkg.NewErrBadRequest(err))
 		return
 	}
 
 	err = body.Validate()
 	if err != nil {
-		c.JSON(http.StatusBadRequest, models.Error{
-			Message: models.NotAvailable,
-		})
-		h.Logger.Error(err.Error())
+		h.fail(c, err)
 		return
 	}
 
@@ -75,13 +72,12 @@ func (h *HandlerV1) CreateEatablesInfo(c *gin.Context) {
 		Daily:     dailyReq,
 	})
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, models.Error{
-			Message: models.InternalMessage,
-		})
-		h.Logger.Error(err.Error())
+		h.fail(c, err)
 		return
 	}
 
+	h.audit(c, ctx, entity.AuditActionCreate, "eatable_info", eatablesRes.ID, nil)
+
 	var res []*models.Daily
 	for _, value := range eatablesRes.Daily {
 		res = append(res, &models.Daily{
@@ -106,9 +102,13 @@ func (h *HandlerV1) CreateEatablesInfo(c *gin.Context) {
 // @Accept json
 // @Produce json
 // @Param Animal-Eatables body models.AnimaEatablesInfoRes true "UpdateModel"
+// @Param If-Match header string false "ETag of the version being changed"
 // @Success 200 {object} models.AnimaEatablesInfoRes
 // @Failure 400 {object} models.Error
+// @Failure 404 {object} models.Error
+// @Failure 409 {object} models.Error
 // @Failure 500 {object} models.Error
+// @Failure 412 {object} models.Error
 // @Router /v1/animals/eatables [put]
 func (h *HandlerV1) UpdateEatablesInfo(c *gin.Context) {
 	ctx, span := otlp.Start(c, "api", "UpdateAnimalEatablesInfo")
@@ -124,10 +124,7 @@ func (h *HandlerV1) UpdateEatablesInfo(c *gin.Context) {
 
 	err := c.ShouldBindJSON(&body)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, models.Error{
-			Message: models.WrongInfoMessage,
-		})
-		h.Logger.Error("failed to bind json", l.Error(err))
+		h.fail(c, errorspkg.NewErrBadRequest(err))
 		return
 	}
 
@@ -146,19 +143,30 @@ func (h *HandlerV1) UpdateEatablesInfo(c *gin.Context) {
 		})
 	}
 
+	version, ok := h.ifMatch(c, ctx, "eatable_info", body.ID)
+	if !ok {
+		return
+	}
+
+	before := h.auditBefore(ctx, "eatable_info", body.ID)
+
 	res, err := h.EatablesInfo.Update(ctx, &entity.Eatables{
 		ID:        body.ID,
 		AnimalID:  body.AnimalID,
 		EatableID: body.EatablesID,
 		Category:  body.Category,
 		Daily:     dailyReq,
+		Version:   version,
 	})
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, models.InternalMessage)
-		h.Logger.Error(err.Error())
+		h.fail(c, err)
 		return
 	}
 
+	h.audit(c, ctx, entity.AuditActionUpdate, "eatable_info", body.ID, before)
+
+	c.Header("ETag", etag(res.Version))
+
 	var resDaily []*models.Daily
 	for _, value := range res.Daily {
 		resDaily = append(resDaily, &models.Daily{
@@ -183,9 +191,12 @@ func (h *HandlerV1) UpdateEatablesInfo(c *gin.Context) {
 // @Accept json
 // @Produce json
 // @Param id path string true "Animal Eatables ID"
+// @Param If-Match header string false "ETag of the version being changed"
 // @Success 200 {object} models.Result
 // @Failure 400 {object} models.Error
+// @Failure 404 {object} models.Error
 // @Failure 500 {object} models.Error
+// @Failure 412 {object} models.Error
 // @Router /v1/animals/eatables/{id} [delete]
 func (h *HandlerV1) DeleteEatablesInfo(c *gin.Context) {
 	ctx, span := otlp.Start(c, "api", "DeleteAnimalProduct")
@@ -197,13 +208,21 @@ func (h *HandlerV1) DeleteEatablesInfo(c *gin.Context) {
 
 	id := c.Param("id")
 
-	err := h.EatablesInfo.Delete(ctx, id)
+	version, ok := h.ifMatch(c, ctx, "eatable_info", id)
+	if !ok {
+		return
+	}
+
+	before := h.auditBefore(ctx, "eatable_info", id)
+
+	err := h.EatablesInfo.Delete(ctx, id, version)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, models.InternalMessage)
-		h.Logger.Error("failed to delete animal product", l.Error(err))
+		h.fail(c, err)
 		return
 	}
 
+	h.audit(c, ctx, entity.AuditActionDelete, "eatable_info", id, before)
+
 	c.JSON(http.StatusOK, &models.Result{
 		Message: "Animal eatables info has been deleted",
 	})
@@ -232,9 +251,7 @@ func (h *HandlerV1) ListFoodInfoByAnimalID(c *gin.Context) {
 	queryParams := c.Request.URL.Query()
 	params, errStr := utils.ParseQueryParam(queryParams)
 	if errStr != nil {
-		c.JSON(http.StatusBadRequest, models.Error{
-			Message: models.WrongInfoMessage,
-		})
+		h.fail(c, badQuery(errStr))
 		return
 	}
 
@@ -247,8 +264,7 @@ func (h *HandlerV1) ListFoodInfoByAnimalID(c *gin.Context) {
 
 	res, err := h.EatablesInfo.GetFoods(ctx, params.Page, params.Limit, animalID)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, models.InternalMessage)
-		h.Logger.Error("failed to list animal eatables info by animal id", l.Error(err))
+		h.fail(c, err)
 		return
 	}
 
@@ -307,9 +323,7 @@ func (h *HandlerV1) ListDrugInfoByAnimalID(c *gin.Context) {
 	queryParams := c.Request.URL.Query()
 	params, errStr := utils.ParseQueryParam(queryParams)
 	if errStr != nil {
-		c.JSON(http.StatusBadRequest, models.Error{
-			Message: models.WrongInfoMessage,
-		})
+		h.fail(c, badQuery(errStr))
 		return
 	}
 
@@ -322,8 +336,7 @@ func (h *HandlerV1) ListDrugInfoByAnimalID(c *gin.Context) {
 
 	res, err := h.EatablesInfo.GetDrugs(ctx, params.Page, params.Limit, animalID)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, models.InternalMessage)
-		h.Logger.Error("failed to list animal eatables info by animal id", l.Error(err))
+		h.fail(c, err)
 		return
 	}
 
@@ -3,8 +3,9 @@ package v1
 import (
 	"musobaqa/farm-competition/api/models"
 	"musobaqa/farm-competition/internal/entity"
-	l "musobaqa/farm-competition/internal/pkg/logger"
+	errorspkg "musobaqa/farm-competition/internal/errors"
 	"musobaqa/farm-competition/internal/pkg/otlp"
+	"musobaqa/farm-competition/internal/pkg/utils"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
@@ -18,8 +19,11 @@ import (
 // @Accept json
 // @Produce json
 // @Param Given-Eatables body models.AnimaGivenEatablesReq true "createModel"
+// @Param Idempotency-Key header string false "Key that makes retries of the request safe"
 // @Success 201 {object} models.AnimaGivenEatablesRes
 // @Failure 400 {object} models.Error
+// @Failure 409 {object} models.Error
+// @Failure 422 {object} models.Error
 // @Failure 500 {object} models.Error
 // @Router /v1/animals/given-eatables [post]
 func (h *HandlerV1) CreateGivenEatables(c *gin.Context) {
@@ -36,19 +40,13 @@ func (h *HandlerV1) CreateGivenEatables(c *gin.Context) {
 
 	err := c.ShouldBindJSON(&body)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, models.Error{
-			Message: models.WrongDateMessage,
-		})
-		h.Logger.Error(err.Error())
+		h.fail(c, errorspkg.NewErrBadRequest(err))
 		return
 	}
 
 	err = body.Validate()
 	if err != nil {
-		c.JSON(http.StatusBadRequest, models.Error{
-			Message: models.NotAvailable,
-		})
-		h.Logger.Error(err.Error())
+		h.fail(c, err)
 		return
 	}
 
@@ -75,13 +73,12 @@ func (h *HandlerV1) CreateGivenEatables(c *gin.Context) {
 		Day:        body.Day,
 	})
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, models.Error{
-			Message: models.InternalMessage,
-		})
-		h.Logger.Error(err.Error())
+		h.fail(c, err)
 		return
 	}
 
+	h.audit(c, ctx, entity.AuditActionCreate, "given_eatable", eatablesRes.ID, nil)
+
 	var res []*models.Daily
 	for _, value := range eatablesRes.Daily {
 		res = append(res, &models.Daily{
@@ -100,6 +97,67 @@ func (h *HandlerV1) CreateGivenEatables(c *gin.Context) {
 	})
 }
 
+// LIST ANIMAL GIVEN EATABLES
+// @Summary LIST ANIMAL GIVEN EATABLES
+// @Description Api for List given eatables, newest first, by page or by the next_cursor of the previous page
+// @Tags GIVEN-EATABLES
+// @Accept json
+// @Produce json
+// @Param request query models.Pagination true "request"
+// @Param request query models.ListQuery false "request"
+// @Param request query models.AnimaGivenEatablesFieldValues false "request"
+// @Success 200 {object} models.ListAnimaGivenEatablesRes
+// @Failure 400 {object} models.Error
+// @Failure 500 {object} models.Error
+// @Router /v1/animals/given-eatables [get]
+func (h *HandlerV1) ListGivenEatables(c *gin.Context) {
+	ctx, span := otlp.Start(c, "api", "ListGivenEatables")
+	span.SetAttributes(
+		attribute.Key("method").String(c.Request.Method),
+		attribute.Key("host").String(c.Request.Host),
+	)
+	defer span.End()
+
+	queryParams := c.Request.URL.Query()
+	params, errStr := utils.ParseQueryParam(queryParams)
+	if errStr != nil {
+		h.fail(c, badQuery(errStr))
+		return
+	}
+
+	res, err := h.Feeding.List(ctx, params.Page, params.Limit, listRequest(params))
+	if err != nil {
+		h.fail(c, err)
+		return
+	}
+
+	response := models.ListAnimaGivenEatablesRes{
+		GivenEatables: []*models.AnimaGivenEatablesRes{},
+		Count:         int64(res.TotalCount),
+		NextCursor:    res.NextCursor,
+	}
+	for _, i := range res.Feedings {
+		var daily []*models.Daily
+		for _, value := range i.Daily {
+			daily = append(daily, &models.Daily{
+				Time:     value.Time,
+				Capacity: value.Capacity,
+			})
+		}
+
+		response.GivenEatables = append(response.GivenEatables, &models.AnimaGivenEatablesRes{
+			ID:         i.ID,
+			AnimalID:   i.AnimalID,
+			EatablesID: i.Eatables.ID,
+			Daily:      daily,
+			Category:   i.Category,
+			Day:        i.Day,
+		})
+	}
+
+	c.JSON(http.StatusOK, &response)
+}
+
 // UPDATE ANIMAL GIVEN EATABLES
 // @Summary UPDATE ANIMAL GIVEN EATABLES
 // @Description Api for Update animal given eatables
@@ -107,9 +165,13 @@ func (h *HandlerV1) CreateGivenEatables(c *gin.Context) {
 // @Accept json
 // @Produce json
 // @Param Given-Eatables body models.AnimaGivenEatablesRes true "UpdateModel"
+// @Param If-Match header string false "ETag of the version being changed"
 // @Success 200 {object} models.AnimaGivenEatablesRes
 // @Failure 400 {object} models.Error
+// @Failure 404 {object} models.Error
+// @Failure 409 {object} models.Error
 // @Failure 500 {object} models.Error
+// @Failure 412 {object} models.Error
 // @Router /v1/animals/given-eatables [put]
 func (h *HandlerV1) UpdateGivenEatables(c *gin.Context) {
 	ctx, span := otlp.Start(c, "api", "UpdateAnimalEatablesInfo")
@@ -125,10 +187,7 @@ func (h *HandlerV1) UpdateGivenEatables(c *gin.Context) {
 
 	err := c.ShouldBindJSON(&body)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, models.Error{
-			Message: models.WrongInfoMessage,
-		})
-		h.Logger.Error("failed to bind json", l.Error(err))
+		h.fail(c, errorspkg.NewErrBadRequest(err))
 		return
 	}
 
@@ -147,6 +206,13 @@ func (h *HandlerV1) UpdateGivenEatables(c *gin.Context) {
 		})
 	}
 
+	version, ok := h.ifMatch(c, ctx, "given_eatable", body.ID)
+	if !ok {
+		return
+	}
+
+	before := h.auditBefore(ctx, "given_eatable", body.ID)
+
 	res, err := h.Feeding.Update(ctx, &entity.Feeding{
 		ID:         body.ID,
 		AnimalID:   body.AnimalID,
@@ -154,13 +220,17 @@ func (h *HandlerV1) UpdateGivenEatables(c *gin.Context) {
 		Category:   body.Category,
 		Daily:      dailyReq,
 		Day:        body.Day,
+		Version:    version,
 	})
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, models.InternalMessage)
-		h.Logger.Error(err.Error())
+		h.fail(c, err)
 		return
 	}
 
+	h.audit(c, ctx, entity.AuditActionUpdate, "given_eatable", body.ID, before)
+
+	c.Header("ETag", etag(res.Version))
+
 	var resDaily []*models.Daily
 	for _, value := range res.Daily {
 		resDaily = append(resDaily, &models.Daily{
@@ -187,9 +257,12 @@ func (h *HandlerV1) UpdateGivenEatables(c *gin.Context) {
 // @Accept json
 // @Produce json
 // @Param id path string true "Animal Given Eatables ID"
+// @Param If-Match header string false "ETag of the version being changed"
 // @Success 200 {object} models.Result
 // @Failure 400 {object} models.Error
+// @Failure 404 {object} models.Error
 // @Failure 500 {object} models.Error
+// @Failure 412 {object} models.Error
 // @Router /v1/animals/given-eatables/{id} [delete]
 func (h *HandlerV1) DeleteGivenEatables(c *gin.Context) {
 	ctx, span := otlp.Start(c, "api", "DeleteAnimalProduct")
@@ -201,13 +274,21 @@ func (h *HandlerV1) DeleteGivenEatables(c *gin.Context) {
 
 	id := c.Param("id")
 
-	err := h.Feeding.Delete(ctx, id)
+	version, ok := h.ifMatch(c, ctx, "given_eatable", id)
+	if !ok {
+		return
+	}
+
+	before := h.auditBefore(ctx, "given_eatable", id)
+
+	err := h.Feeding.Delete(ctx, id, version)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, models.InternalMessage)
-		h.Logger.Error("failed to delete animal given product", l.Error(err))
+		h.fail(c, err)
 		return
 	}
 
+	h.audit(c, ctx, entity.AuditActionDelete, "given_eatable", id, before)
+
 	c.JSON(http.StatusOK, &models.Result{
 		Message: "Animal given eatables has been deleted",
 	})
@@ -1,9 +1,9 @@
 package v1
 
 import (
+	errorspkg "musobaqa/farm-competition/internal/errors"
 	"musobaqa/farm-competition/api/models"
 	"musobaqa/farm-competition/internal/entity"
-	l "musobaqa/farm-competition/internal/pkg/logger"
 	"musobaqa/farm-competition/internal/pkg/otlp"
 	"musobaqa/farm-competition/internal/pkg/utils"
 	"net/http"
@@ -20,8 +20,11 @@ import (
 // @Accept json
 // @Produce json
 // @Param Animal-Product body models.AnimalProductReq true "createModel"
+// @Param Idempotency-Key header string false "Key that makes retries of the request safe"
 // @Success 201 {object} models.AnimalProductRes
 // @Failure 400 {object} models.Error
+// @Failure 409 {object} models.Error
+// @Failure 422 {object} models.Error
 // @Failure 500 {object} models.Error
 // @Router /v1/animals/products [post]
 func (h *HandlerV1) CreateAnimalProduct(c *gin.Context) {
@@ -38,19 +41,13 @@ func (h *HandlerV1) CreateAnimalProduct(c *gin.Context) {
 
 	err := c.ShouldBindJSON(&body)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, models.Error{
-			Message: models.WrongDateMessage,
-		})
-		h.Logger.Error(err.Error())
+		h.fail(c, errorspkg.NewErrBadRequest(err))
 		return
 	}
 
 	err = body.Validate()
 	if err != nil {
-		c.JSON(http.StatusBadRequest, models.Error{
-			Message: models.NotAvailable,
-		})
-		h.Logger.Error(err.Error())
+		h.fail(c, err)
 		return
 	}
 
@@ -61,13 +58,12 @@ func (h *HandlerV1) CreateAnimalProduct(c *gin.Context) {
 		GetTime:   body.GetTime,
 	})
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, models.Error{
-			Message: models.InternalMessage,
-		})
-		h.Logger.Error(err.Error())
+		h.fail(c, err)
 		return
 	}
 
+	h.audit(c, ctx, entity.AuditActionCreate, "animal_product", res.ID, nil)
+
 	_, err = h.Product.Update(ctx, &entity.Product{
 		ID:            body.ProductID,
 		Name:          res.Product.Name,
@@ -76,10 +72,7 @@ func (h *HandlerV1) CreateAnimalProduct(c *gin.Context) {
 		Description:   res.Product.Description,
 	})
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, models.Error{
-			Message: models.InternalMessage,
-		})
-		h.Logger.Error(err.Error())
+		h.fail(c, err)
 		return
 	}
 
@@ -103,7 +96,9 @@ func (h *HandlerV1) CreateAnimalProduct(c *gin.Context) {
 // @Produce json
 // @Param id path string true "Animal Product ID"
 // @Success 200 {object} models.AnimalProductRes
+// @Header 200 {string} ETag "Version of the entity"
 // @Failure 400 {object} models.Error
+// @Failure 404 {object} models.Error
 // @Failure 500 {object} models.Error
 // @Router /v1/animals/products/{id} [get]
 func (h *HandlerV1) GetAnimalProduct(c *gin.Context) {
@@ -118,13 +113,11 @@ func (h *HandlerV1) GetAnimalProduct(c *gin.Context) {
 
 	res, err := h.AnimalProduct.Get(ctx, id)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, models.Error{
-			Message: models.WrongInfoMessage,
-		})
-		h.Logger.Error(err.Error())
+		h.fail(c, err)
 		return
 	}
 
+	c.Header("ETag", etag(res.Version))
 	c.JSON(http.StatusOK, &models.AnimalProductRes{
 		Id:             res.ID,
 		AnimalID:       res.Animal.ID,
@@ -144,6 +137,7 @@ func (h *HandlerV1) GetAnimalProduct(c *gin.Context) {
 // @Accept json
 // @Produce json
 // @Param request query models.Pagination true "request"
+// @Param request query models.ListQuery false "request"
 // @Param request query models.AnimalProductFieldValues true "request"
 // @Success 200 {object} models.ListAnimalProductsRes
 // @Failure 400 {object} models.Error
@@ -160,24 +154,13 @@ func (h *HandlerV1) ListAnimalProducts(c *gin.Context) {
 	queryParams := c.Request.URL.Query()
 	params, errStr := utils.ParseQueryParam(queryParams)
 	if errStr != nil {
-		c.JSON(http.StatusBadRequest, models.Error{
-			Message: models.WrongInfoMessage,
-		})
+		h.fail(c, badQuery(errStr))
 		return
 	}
 
-	get_time := c.Query("get_time")
-
-	mapA := map[string]interface{}{
-		"get_time": get_time,
-	}
-
-	res, err := h.AnimalProduct.List(ctx, params.Page, params.Limit, mapA)
+	res, err := h.AnimalProduct.List(ctx, params.Page, params.Limit, listRequest(params))
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, models.Error{
-			Message: models.InternalMessage,
-		})
-		h.Logger.Error(err.Error())
+		h.fail(c, err)
 		return
 	}
 
@@ -200,6 +183,7 @@ func (h *HandlerV1) ListAnimalProducts(c *gin.Context) {
 	c.JSON(http.StatusOK, &models.ListAnimalProductsRes{
 		AnimalProducts: resList,
 		Count:          int64(res.TotalCount),
+		NextCursor:     res.NextCursor,
 	})
 }
 
@@ -210,9 +194,13 @@ func (h *HandlerV1) ListAnimalProducts(c *gin.Context) {
 // @Accept json
 // @Produce json
 // @Param Animal-Product body models.AnimalProductUpdateReq true "createModel"
+// @Param If-Match header string false "ETag of the version being changed"
 // @Success 200 {object} models.AnimalProductRes
 // @Failure 400 {object} models.Error
+// @Failure 404 {object} models.Error
+// @Failure 409 {object} models.Error
 // @Failure 500 {object} models.Error
+// @Failure 412 {object} models.Error
 // @Router /v1/animals/products [put]
 func (h *HandlerV1) UpdateAnimalProduct(c *gin.Context) {
 	ctx, span := otlp.Start(c, "api", "UpdateAnimalProduct")
@@ -228,26 +216,34 @@ func (h *HandlerV1) UpdateAnimalProduct(c *gin.Context) {
 
 	err := c.ShouldBindJSON(&body)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, models.Error{
-			Message: models.WrongInfoMessage,
-		})
-		h.Logger.Error("failed to bind json", l.Error(err))
+		h.fail(c, errorspkg.NewErrBadRequest(err))
 		return
 	}
 
+	version, ok := h.ifMatch(c, ctx, "animal_product", body.ID)
+	if !ok {
+		return
+	}
+
+	before := h.auditBefore(ctx, "animal_product", body.ID)
+
 	res, err := h.AnimalProduct.Update(ctx, &entity.AnimalProductReq{
 		ID:        body.ID,
 		AnimalID:  body.AnimalID,
 		ProductID: body.ProductID,
 		Capacity:  body.Capacity,
 		GetTime:   body.GetTime,
+		Version:   version,
 	})
 	if err != nil {
-		c.JSON(500, models.InternalMessage)
-		h.Logger.Error(err.Error())
+		h.fail(c, err)
 		return
 	}
 
+	h.audit(c, ctx, entity.AuditActionUpdate, "animal_product", body.ID, before)
+
+	c.Header("ETag", etag(res.Version))
+
 	c.JSON(http.StatusOK, &models.AnimalProductRes{
 		Id:             res.ID,
 		AnimalID:       res.Animal.ID,
@@ -267,9 +263,12 @@ func (h *HandlerV1) UpdateAnimalProduct(c *gin.Context) {
 // @Accept json
 // @Produce json
 // @Param id path string true "Animal Product ID"
+// @Param If-Match header string false "ETag of the version being changed"
 // @Success 200 {object} models.Result
 // @Failure 400 {object} models.Error
+// @Failure 404 {object} models.Error
 // @Failure 500 {object} models.Error
+// @Failure 412 {object} models.Error
 // @Router /v1/animals/products/{id} [delete]
 func (h *HandlerV1) DeleteAnimalProduct(c *gin.Context) {
 	ctx, span := otlp.Start(c, "api", "DeleteAnimalProduct")
@@ -283,20 +282,25 @@ func (h *HandlerV1) DeleteAnimalProduct(c *gin.Context) {
 
 	_, err := h.AnimalProduct.Get(ctx, id)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, models.Error{
-			Message: models.NotAvailable,
-		})
-		h.Logger.Error("failed to get animal product in delete", l.Error(err))
+		h.fail(c, err)
 		return
 	}
 
-	err = h.AnimalProduct.Delete(ctx, id)
+	version, ok := h.ifMatch(c, ctx, "animal_product", id)
+	if !ok {
+		return
+	}
+
+	before := h.auditBefore(ctx, "animal_product", id)
+
+	err = h.AnimalProduct.Delete(ctx, id, version)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, models.InternalMessage)
-		h.Logger.Error("failed to delete animal product", l.Error(err))
+		h.fail(c, err)
 		return
 	}
 
+	h.audit(c, ctx, entity.AuditActionDelete, "animal_product", id, before)
+
 	c.JSON(http.StatusOK, &models.Result{
 		Message: "Animal product has been deleted",
 	})
@@ -326,9 +330,7 @@ func (h *HandlerV1) ListAnimalProductsByAnimalID(c *gin.Context) {
 	queryParams := c.Request.URL.Query()
 	params, errStr := utils.ParseQueryParam(queryParams)
 	if errStr != nil {
-		c.JSON(http.StatusBadRequest, models.Error{
-			Message: models.WrongInfoMessage,
-		})
+		h.fail(c, badQuery(errStr))
 		return
 	}
 
@@ -341,8 +343,7 @@ func (h *HandlerV1) ListAnimalProductsByAnimalID(c *gin.Context) {
 
 	res, err := h.AnimalProduct.ListProducts(ctx, params.Page, params.Limit, animal_id)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, models.InternalMessage)
-		h.Logger.Error("failed to list animal product by product id", l.Error(err))
+		h.fail(c, err)
 		return
 	}
 
@@ -397,9 +398,7 @@ func (h *HandlerV1) ListAnimalProductsByProductID(c *gin.Context) {
 	queryParams := c.Request.URL.Query()
 	params, errStr := utils.ParseQueryParam(queryParams)
 	if errStr != nil {
-		c.JSON(http.StatusBadRequest, models.Error{
-			Message: models.WrongInfoMessage,
-		})
+		h.fail(c, badQuery(errStr))
 		return
 	}
 
@@ -412,8 +411,7 @@ func (h *HandlerV1) ListAnimalProductsByProductID(c *gin.Context) {
 
 	res, err := h.AnimalProduct.ListAnimals(ctx, params.Page, params.Limit, product_id)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, models.InternalMessage)
-		h.Logger.Error("failed to list animal product by animal id", l.Error(err))
+		h.fail(c, err)
 		return
 	}
 
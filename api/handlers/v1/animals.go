@@ -3,7 +3,7 @@ package v1
 import (
 	"musobaqa/farm-competition/api/models"
 	"musobaqa/farm-competition/internal/entity"
-	l "musobaqa/farm-competition/internal/pkg/logger"
+	errorspkg "musobaqa/farm-competition/internal/errors"
 	"musobaqa/farm-competition/internal/pkg/otlp"
 	"musobaqa/farm-competition/internal/pkg/utils"
 	"net/http"
@@ -21,8 +21,11 @@ import (
 // @Accept json
 // @Produce json
 // @Param Animal body models.AnimalReq true "createModel"
+// @Param Idempotency-Key header string false "Key that makes retries of the request safe"
 // @Success 201 {object} models.AnimalRes
 // @Failure 400 {object} models.Error
+// @Failure 409 {object} models.Error
+// @Failure 422 {object} models.Error
 // @Failure 500 {object} models.Error
 // @Router /v1/animal [post]
 func (h *HandlerV1) CreateAnimal(c *gin.Context) {
@@ -39,19 +42,13 @@ func (h *HandlerV1) CreateAnimal(c *gin.Context) {
 
 	err := c.ShouldBindJSON(&body)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, models.Error{
-			Message: models.WrongDateMessage,
-		})
-		l.Error(err)
+		h.fail(c, errorspkg.NewErrBadRequest(err))
 		return
 	}
 
 	err = body.Validate()
 	if err != nil {
-		c.JSON(http.StatusBadRequest, models.Error{
-			Message: models.NotAvailable,
-		})
-		h.Logger.Error(err.Error())
+		h.fail(c, err)
 		return
 	}
 
@@ -67,13 +64,12 @@ func (h *HandlerV1) CreateAnimal(c *gin.Context) {
 		Description:  body.Description,
 	})
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, models.Error{
-			Message: models.InternalMessage,
-		})
-		h.Logger.Error(err.Error())
+		h.fail(c, err)
 		return
 	}
 
+	h.audit(c, ctx, entity.AuditActionCreate, "animal", res.ID, nil)
+
 	c.JSON(http.StatusCreated, &models.AnimalRes{
 		Id:           res.ID,
 		Name:         res.Name,
@@ -95,7 +91,9 @@ func (h *HandlerV1) CreateAnimal(c *gin.Context) {
 // @Produce json
 // @Param id path string true "Animal_id"
 // @Success 200 {object} models.AnimalRes
+// @Header 200 {string} ETag "Version of the entity"
 // @Failure 400 {object} models.Error
+// @Failure 404 {object} models.Error
 // @Failure 500 {object} models.Error
 // @Router /v1/animals/{id} [get]
 func (h *HandlerV1) GetAnimal(c *gin.Context) {
@@ -110,13 +108,11 @@ func (h *HandlerV1) GetAnimal(c *gin.Context) {
 
 	res, err := h.Animals.Get(ctx, id)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, models.Error{
-			Message: models.WrongInfoMessage,
-		})
-		l.Error(err)
+		h.fail(c, err)
 		return
 	}
 
+	c.Header("ETag", etag(res.Version))
 	c.JSON(http.StatusOK, &models.AnimalRes{
 		Id:           res.ID,
 		Name:         res.Name,
@@ -153,9 +149,7 @@ func (h *HandlerV1) GetAnimalWithProducts(c *gin.Context) {
 	queryParams := c.Request.URL.Query()
 	params, errStr := utils.ParseQueryParam(queryParams)
 	if errStr != nil {
-		c.JSON(http.StatusBadRequest, models.Error{
-			Message: models.WrongDateMessage,
-		})
+		h.fail(c, badQuery(errStr))
 		return
 	}
 
@@ -186,7 +180,7 @@ func (h *HandlerV1) GetAnimalWithEatables(c *gin.Context) {
 	queryParams := c.Request.URL.Query()
 	params, errStr := utils.ParseQueryParam(queryParams)
 	if errStr != nil {
-		c.JSON(http.StatusBadRequest, models.WrongInfoMessage)
+		h.fail(c, badQuery(errStr))
 		return
 	}
 
@@ -201,6 +195,7 @@ func (h *HandlerV1) GetAnimalWithEatables(c *gin.Context) {
 // @Accept json
 // @Produce json
 // @Param request query models.Pagination true "request"
+// @Param request query models.ListQuery false "request"
 // @Param request query models.AnimalFieldValues true "request"
 // @Success 200 {object} models.ListAnimalsRes
 // @Failure 400 {object} models.Error
@@ -217,36 +212,17 @@ func (h *HandlerV1) ListAnimals(c *gin.Context) {
 	queryParams := c.Request.URL.Query()
 	params, errStr := utils.ParseQueryParam(queryParams)
 	if errStr != nil {
-		c.JSON(http.StatusBadRequest, models.Error{
-			Message: models.WrongInfoMessage,
-		})
+		h.fail(c, badQuery(errStr))
 		return
 	}
 
-	category := c.Query("category")
-	genus := c.Query("genus")
-	gender := c.Query("gender")
-	weight := c.Query("weight")
-	is_health := c.Query("is_health")
-
-	// if strings.ToLower(gender) != "male" || strings.ToLower(gender) != "female" {
-	// 	gender = ""
-	// }
-
-	mapA := map[string]interface{}{
-		"category":  category,
-		"genus":     genus,
-		"gender":    gender, // Empty string
-		"weight":    weight,
-		"is_health": is_health,
-	}
+	// a bare weight keeps matching animals within ten percent of it
+	request := listRequest(params)
+	request.Near("weight")
 
-	list, err := h.Animals.List(ctx, params.Page, params.Limit, mapA)
+	list, err := h.Animals.List(ctx, params.Page, params.Limit, request)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, models.Error{
-			Message: models.InternalMessage,
-		})
-		h.Logger.Error("failed to get list animal", l.Error(err))
+		h.fail(c, err)
 		return
 	}
 
@@ -278,9 +254,13 @@ func (h *HandlerV1) ListAnimals(c *gin.Context) {
 // @Accept json
 // @Produce json
 // @Param Animal body models.AnimalRes true "createModel"
+// @Param If-Match header string false "ETag of the version being changed"
 // @Success 200 {object} models.AnimalRes
 // @Failure 400 {object} models.Error
+// @Failure 404 {object} models.Error
+// @Failure 409 {object} models.Error
 // @Failure 500 {object} models.Error
+// @Failure 412 {object} models.Error
 // @Router /v1/animals [put]
 func (h *HandlerV1) UpdateAnimal(c *gin.Context) {
 	ctx, span := otlp.Start(c, "api", "UpdateUser")
@@ -296,13 +276,17 @@ func (h *HandlerV1) UpdateAnimal(c *gin.Context) {
 
 	err := c.ShouldBindJSON(&body)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, models.Error{
-			Message: models.WrongInfoMessage,
-		})
-		h.Logger.Error("failed to bind json", l.Error(err))
+		h.fail(c, errorspkg.NewErrBadRequest(err))
 		return
 	}
 
+	version, ok := h.ifMatch(c, ctx, "animal", body.Id)
+	if !ok {
+		return
+	}
+
+	before := h.auditBefore(ctx, "animal", body.Id)
+
 	resAnimals, err := h.Animals.Update(ctx, &entity.Animal{
 		ID:           body.Id,
 		Name:         body.Name,
@@ -313,13 +297,17 @@ func (h *HandlerV1) UpdateAnimal(c *gin.Context) {
 		Weight:       uint64(body.Weight),
 		IsHealth:     cast.ToString(body.IsHealth),
 		Description:  body.Description,
+		Version:      version,
 	})
 	if err != nil {
-		c.JSON(500, models.InternalMessage)
-		h.Logger.Error(err.Error())
+		h.fail(c, err)
 		return
 	}
 
+	h.audit(c, ctx, entity.AuditActionUpdate, "animal", body.Id, before)
+
+	c.Header("ETag", etag(resAnimals.Version))
+
 	c.JSON(http.StatusOK, &models.AnimalRes{
 		Id:           resAnimals.ID,
 		Name:         resAnimals.Name,
@@ -340,9 +328,12 @@ func (h *HandlerV1) UpdateAnimal(c *gin.Context) {
 // @Accept json
 // @Produce json
 // @Param id path string true "ID"
+// @Param If-Match header string false "ETag of the version being changed"
 // @Success 200 {object} models.Result
 // @Failure 400 {object} models.Error
+// @Failure 404 {object} models.Error
 // @Failure 500 {object} models.Error
+// @Failure 412 {object} models.Error
 // @Router /v1/animals/{id} [delete]
 func (h *HandlerV1) DeleteAnimal(c *gin.Context) {
 	ctx, span := otlp.Start(c, "api", "DeleteAnimal")
@@ -356,20 +347,25 @@ func (h *HandlerV1) DeleteAnimal(c *gin.Context) {
 
 	_, err := h.Animals.Get(ctx, id)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, models.Error{
-			Message: models.NotAvailable,
-		})
-		h.Logger.Error("failed to get animal in delete", l.Error(err))
+		h.fail(c, err)
+		return
+	}
+
+	version, ok := h.ifMatch(c, ctx, "animal", id)
+	if !ok {
 		return
 	}
 
-	err = h.Animals.Delete(ctx, id)
+	before := h.auditBefore(ctx, "animal", id)
+
+	err = h.Animals.Delete(ctx, id, version)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, models.InternalMessage)
-		h.Logger.Error("failed to delete animal", l.Error(err))
+		h.fail(c, err)
 		return
 	}
 
+	h.audit(c, ctx, entity.AuditActionDelete, "animal", id, before)
+
 	c.JSON(http.StatusOK, &models.Result{
 		Message: "Animal has been deleted",
 	})
@@ -397,17 +393,12 @@ func (h *HandlerV1) HungryAnimals(c *gin.Context) {
 	queryParams := c.Request.URL.Query()
 	params, errStr := utils.ParseQueryParam(queryParams)
 	if errStr != nil {
-		c.JSON(http.StatusBadRequest, models.Error{
-			Message: models.WrongInfoMessage,
-		})
+		h.fail(c, badQuery(errStr))
 		return
 	}
 	list, err := h.Animals.HungryAnimals(ctx, params.Page, params.Limit)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, models.Error{
-			Message: models.InternalMessage,
-		})
-		h.Logger.Error("failed to get list animal", l.Error(err))
+		h.fail(c, err)
 		return
 	}
 
@@ -0,0 +1,167 @@
+package v1
+
+import (
+	"context"
+	"encoding/json"
+	"musobaqa/farm-competition/api/middleware"
+	"musobaqa/farm-competition/api/models"
+	"musobaqa/farm-competition/internal/entity"
+	errorspkg "musobaqa/farm-competition/internal/errors"
+	l "musobaqa/farm-competition/internal/pkg/logger"
+	"musobaqa/farm-competition/internal/pkg/otlp"
+	"musobaqa/farm-competition/internal/pkg/utils"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// auditBefore snapshots the entity before it is updated or deleted
+func (h *HandlerV1) auditBefore(ctx context.Context, entityType, entityID string) json.RawMessage {
+	before, err := h.Audit.Snapshot(ctx, entityType, entityID)
+	if err != nil {
+		h.Logger.Error("failed to snapshot entity for audit", l.Error(err))
+	}
+	return before
+}
+
+// actor is the user making the request, anonymous without a valid token
+func (h *HandlerV1) actor(c *gin.Context) string {
+	actor, status := GetIdFromToken(c.Request, h.Config)
+	if status != http.StatusOK || actor == "" {
+		return "anonymous"
+	}
+	return actor
+}
+
+// audit records a mutation made by the request, failures are only logged
+func (h *HandlerV1) audit(c *gin.Context, ctx context.Context, action, entityType, entityID string, before json.RawMessage) {
+	err := h.Audit.Record(ctx, &entity.AuditLog{
+		Actor:      h.actor(c),
+		Action:     action,
+		EntityType: entityType,
+		EntityID:   entityID,
+		Before:     before,
+		RequestID:  c.GetString(middleware.RequestIDHeader),
+	})
+	if err != nil {
+		h.Logger.Error("failed to record audit log", l.Error(err))
+	}
+}
+
+// LIST AUDIT LOGS
+// @Summary LIST AUDIT LOGS
+// @Description Api for List recorded mutations filtered by actor, action, entity and date
+// @Tags AUDIT
+// @Accept json
+// @Produce json
+// @Param request query models.Pagination true "request"
+// @Param request query models.AuditFieldValues true "request"
+// @Success 200 {object} models.ListAuditLogsRes
+// @Failure 400 {object} models.Error
+// @Failure 500 {object} models.Error
+// @Router /v1/audit [get]
+func (h *HandlerV1) ListAuditLogs(c *gin.Context) {
+	ctx, span := otlp.Start(c, "api", "ListAuditLogs")
+	span.SetAttributes(
+		attribute.Key("method").String(c.Request.Method),
+		attribute.Key("host").String(c.Request.Host),
+	)
+	defer span.End()
+
+	queryParams := c.Request.URL.Query()
+	params, errStr := utils.ParseQueryParam(queryParams)
+	if errStr != nil {
+		h.fail(c, badQuery(errStr))
+		return
+	}
+
+	from := c.Query("from")
+	to := c.Query("to")
+	for _, date := range []string{from, to} {
+		if date == "" {
+			continue
+		}
+		if _, err := time.Parse(time.DateOnly, date); err != nil {
+			h.fail(c, errorspkg.NewErrBadRequest(err))
+			return
+		}
+	}
+
+	res, err := h.Audit.List(ctx, params.Page, params.Limit, map[string]interface{}{
+		"actor":       c.Query("actor"),
+		"action":      c.Query("action"),
+		"entity_type": c.Query("entity_type"),
+		"entity_id":   c.Query("entity_id"),
+		"request_id":  c.Query("request_id"),
+		"from":        from,
+		"to":          to,
+	})
+	if err != nil {
+		h.fail(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, auditLogsResponse(res))
+}
+
+// ENTITY HISTORY
+// @Summary ENTITY HISTORY
+// @Description Api for List the change history of a single entity, newest first
+// @Tags AUDIT
+// @Accept json
+// @Produce json
+// @Param entity_type path string true "Entity type, e.g. animal"
+// @Param id path string true "Entity ID"
+// @Param request query models.Pagination true "request"
+// @Success 200 {object} models.ListAuditLogsRes
+// @Failure 400 {object} models.Error
+// @Failure 404 {object} models.Error
+// @Failure 500 {object} models.Error
+// @Router /v1/audit/{entity_type}/{id} [get]
+func (h *HandlerV1) EntityHistory(c *gin.Context) {
+	ctx, span := otlp.Start(c, "api", "EntityHistory")
+	span.SetAttributes(
+		attribute.Key("method").String(c.Request.Method),
+		attribute.Key("host").String(c.Request.Host),
+	)
+	defer span.End()
+
+	queryParams := c.Request.URL.Query()
+	params, errStr := utils.ParseQueryParam(queryParams)
+	if errStr != nil {
+		h.fail(c, badQuery(errStr))
+		return
+	}
+
+	res, err := h.Audit.History(ctx, c.Param("entity_type"), c.Param("id"), params.Page, params.Limit)
+	if err != nil {
+		h.fail(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, auditLogsResponse(res))
+}
+
+func auditLogsResponse(logs *entity.ListAuditLogs) *models.ListAuditLogsRes {
+	response := models.ListAuditLogsRes{
+		AuditLogs: []*models.AuditLogRes{},
+		Count:     int64(logs.TotalCount),
+	}
+	for _, i := range logs.AuditLogs {
+		response.AuditLogs = append(response.AuditLogs, &models.AuditLogRes{
+			ID:         i.ID,
+			Actor:      i.Actor,
+			Action:     i.Action,
+			EntityType: i.EntityType,
+			EntityID:   i.EntityID,
+			Before:     i.Before,
+			After:      i.After,
+			Diff:       i.Diff,
+			RequestID:  i.RequestID,
+			CreatedAt:  i.CreatedAt.Format(time.RFC3339),
+		})
+	}
+	return &response
+}
@@ -0,0 +1,58 @@
+package v1
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"musobaqa/farm-competition/api/models"
+	errorspkg "musobaqa/farm-competition/internal/errors"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// etag formats an entity version as a strong entity tag
+func etag(version int64) string {
+	return fmt.Sprintf(`"%d"`, version)
+}
+
+// ifMatch checks the If-Match header against the current version of the
+// entity. It returns the version the change must be applied to, zero for an
+// unconditional request, and false once it has answered the request itself
+func (h *HandlerV1) ifMatch(c *gin.Context, ctx context.Context, entityType, entityID string) (int64, bool) {
+	header := c.GetHeader("If-Match")
+	if header == "" {
+		if h.Config.Server.RequireIfMatch {
+			c.JSON(http.StatusPreconditionRequired, models.Error{
+				Code:    string(errorspkg.CodePreconditionRequired),
+				Message: models.PreconditionRequired,
+			})
+			return 0, false
+		}
+		return 0, true
+	}
+
+	current, err := h.Version.Current(ctx, entityType, entityID)
+	if err != nil {
+		// a gone entity cannot match any tag
+		if !errors.Is(err, errorspkg.ErrorNotFound) {
+			h.fail(c, err)
+			return 0, false
+		}
+		current = -1
+	}
+
+	for _, tag := range strings.Split(header, ",") {
+		tag = strings.TrimSpace(tag)
+		if current >= 0 && (tag == "*" || tag == etag(current)) {
+			return current, true
+		}
+	}
+
+	c.JSON(http.StatusPreconditionFailed, models.Error{
+		Code:    string(errorspkg.CodeVersionConflict),
+		Message: models.VersionConflict,
+	})
+	return 0, false
+}
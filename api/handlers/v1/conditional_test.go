@@ -0,0 +1,76 @@
+package v1
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+
+	errorspkg "musobaqa/farm-competition/internal/errors"
+	"musobaqa/farm-competition/internal/pkg/config"
+)
+
+type versionStub map[string]int64
+
+func (s versionStub) Current(ctx context.Context, entityType, entityID string) (int64, error) {
+	version, ok := s[entityID]
+	if !ok {
+		return 0, errorspkg.NewErrNotFound(entityType)
+	}
+	return version, nil
+}
+
+func TestIfMatch(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	cfg := &config.Config{}
+	h := &HandlerV1{Config: cfg, Logger: zap.NewNop(), Version: versionStub{"bella": 3}}
+
+	ifMatch := func(entityID, header string) (int64, bool, *httptest.ResponseRecorder) {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodPut, "/", nil)
+		if header != "" {
+			c.Request.Header.Set("If-Match", header)
+		}
+		version, ok := h.ifMatch(c, context.Background(), "animal", entityID)
+		return version, ok, w
+	}
+
+	// Without the header the change is unconditional, unless it is required
+	version, ok, _ := ifMatch("bella", "")
+	assert.True(t, ok)
+	assert.Zero(t, version)
+
+	cfg.Server.RequireIfMatch = true
+	_, ok, w := ifMatch("bella", "")
+	assert.False(t, ok)
+	assert.Equal(t, http.StatusPreconditionRequired, w.Code)
+	assert.Contains(t, w.Body.String(), string(errorspkg.CodePreconditionRequired))
+
+	// The current version or * matches, any tag of a list may
+	version, ok, _ = ifMatch("bella", `"3"`)
+	assert.True(t, ok)
+	assert.Equal(t, int64(3), version)
+	version, ok, _ = ifMatch("bella", `"1", "3"`)
+	assert.True(t, ok)
+	assert.Equal(t, int64(3), version)
+	version, ok, _ = ifMatch("bella", "*")
+	assert.True(t, ok)
+	assert.Equal(t, int64(3), version)
+
+	// A stale tag, a weak tag or a gone entity gets 412
+	for _, tc := range []struct{ entityID, header string }{
+		{"bella", `"2"`},
+		{"bella", `W/"3"`},
+		{"gone", "*"},
+	} {
+		_, ok, w = ifMatch(tc.entityID, tc.header)
+		assert.False(t, ok, tc.header)
+		assert.Equal(t, http.StatusPreconditionFailed, w.Code, tc.header)
+		assert.Contains(t, w.Body.String(), string(errorspkg.CodeVersionConflict))
+	}
+}
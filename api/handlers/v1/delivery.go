@@ -1,17 +1,18 @@
 package v1
 
 import (
-	"errors"
 	"musobaqa/farm-competition/api/models"
 	"musobaqa/farm-competition/internal/entity"
-	l "musobaqa/farm-competition/internal/pkg/logger"
+	errorspkg "musobaqa/farm-competition/internal/errors"
 	"musobaqa/farm-competition/internal/pkg/otlp"
 	"musobaqa/farm-competition/internal/pkg/utils"
 	"net/http"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
 
 	"github.com/gin-gonic/gin"
-	"github.com/google/uuid"
-	"github.com/jackc/pgx/v4"
 	"go.opentelemetry.io/otel/attribute"
 )
 
@@ -22,8 +23,11 @@ import (
 // @Accept json
 // @Produce json
 // @Param Delivery body models.DeliveryCreateReq true "createModel"
+// @Param Idempotency-Key header string false "Key that makes retries of the request safe"
 // @Success 201 {object} models.DeliveryCreateRes
 // @Failure 400 {object} models.Error
+// @Failure 409 {object} models.Error
+// @Failure 422 {object} models.Error
 // @Failure 500 {object} models.Error
 // @Router /v1/delivery [post]
 func (h *HandlerV1) CreateDelivery(c *gin.Context) {
@@ -40,144 +44,52 @@ func (h *HandlerV1) CreateDelivery(c *gin.Context) {
 
 	err := c.ShouldBindJSON(&body)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, models.Error{
-			Message: models.WrongDateMessage,
-		})
-		h.Logger.Error(err.Error())
+		h.fail(c, errorspkg.NewErrBadRequest(err))
 		return
 	}
 
 	err = body.Validate()
 	if err != nil {
-		c.JSON(http.StatusBadRequest, models.Error{
-			Message: models.NotAvailable,
-		})
-		h.Logger.Error(err.Error())
+		h.fail(c, err)
 		return
 	}
 
-	_, err = h.Delivery.Create(ctx, &entity.Delivery{
-		Name:     body.ProductName,
-		Category: body.Category,
-		Capacity: body.Capacity,
-		Union:    body.Union,
-		Time:     body.Time,
-	})
-	if err != nil {
-		c.JSON(http.StatusInternalServerError, models.Error{
-			Message: models.InternalMessage,
-		})
-		h.Logger.Error(err.Error())
-		return
-	}
-
-	if body.Category == "food" {
-		foodRes, err := h.Food.Get(ctx, map[string]string{"name": body.ProductName})
-
+	if body.SupplierID != "" {
+		_, err = h.Supplier.Get(ctx, body.SupplierID)
 		if err != nil {
-			if errors.Is(err, pgx.ErrNoRows) {
-				println("\n check \n")
-				_, err := h.Food.Create(ctx, &entity.Food{
-					ID:          uuid.New().String(),
-					Name:        body.ProductName,
-					Capacity:    uint64(body.Capacity),
-					Union:       body.Union,
-					Description: body.Description,
-				})
-				if err != nil {
-					c.JSON(http.StatusInternalServerError, models.Error{
-						Message: models.InternalMessage,
-					})
-					h.Logger.Error(err.Error())
-					return
-				}
-				c.JSON(http.StatusCreated, &models.DeliveryCreateRes{
-					Message: "Product successfully created",
-				})
-				return
-			}
-			c.JSON(http.StatusInternalServerError, models.Error{
-				Message: models.InternalMessage,
-			})
-			h.Logger.Error(err.Error())
+			h.fail(c, err)
 			return
 		}
+	}
 
-		_, err = h.Food.Update(ctx, &entity.Food{
-			ID:          foodRes.ID,
-			Name:        foodRes.Name,
-			Capacity:    foodRes.Capacity + uint64(body.Capacity),
-			Union:       foodRes.Union,
-			Description: foodRes.Description,
-		})
-		if err != nil {
-			c.JSON(http.StatusInternalServerError, models.Error{
-				Message: models.InternalMessage,
-			})
-			h.Logger.Error(err.Error())
-			return
-		}
-		c.JSON(http.StatusCreated, &models.DeliveryCreateRes{
-			Message: "Product successfully updated",
-		})
+	deliveryRes, err := h.Delivery.Create(ctx, &entity.Delivery{
+		Name:          body.ProductName,
+		Category:      body.Category,
+		Capacity:      body.Capacity,
+		Union:         body.Union,
+		Time:          body.Time,
+		SupplierID:    body.SupplierID,
+		UnitPrice:     body.UnitPrice,
+		TotalCost:     body.TotalCost,
+		InvoiceNumber: body.InvoiceNumber,
+		LotNumber:     body.LotNumber,
+		ExpiryDate:    body.ExpiryDate,
+		Description:   body.Description,
+		Status:        body.Status,
+	})
+	if err != nil {
+		h.fail(c, err)
 		return
-
 	}
 
-	if body.Category == "drug" {
-		drugRes, err := h.Drug.Get(ctx, map[string]string{"name": body.ProductName})
-		if err != nil {
-			if errors.Is(err, pgx.ErrNoRows) {
-				_, err := h.Drug.Create(ctx, &entity.Drug{
-					ID:          uuid.NewString(),
-					Name:        body.ProductName,
-					Status:      body.Status,
-					Capacity:    uint64(body.Capacity),
-					Union:       body.Union,
-					Description: body.Description,
-				})
-				if err != nil {
-					c.JSON(http.StatusInternalServerError, models.Error{
-						Message: models.InternalMessage,
-					})
-					h.Logger.Error(err.Error())
-					return
-				}
-				c.JSON(http.StatusCreated, &models.DeliveryCreateRes{
-					Message: "Product successfully created",
-				})
-				return
-			}
-			c.JSON(http.StatusInternalServerError, models.Error{
-				Message: models.InternalMessage,
-			})
-			h.Logger.Error(err.Error())
-			return
-		}
+	h.audit(c, ctx, entity.AuditActionCreate, "delivery", deliveryRes.ID, nil)
 
-		_, err = h.Drug.Update(ctx, &entity.Drug{
-			ID:          drugRes.ID,
-			Name:        drugRes.Name,
-			Status:      drugRes.Status,
-			Capacity:    drugRes.Capacity + uint64(body.Capacity),
-			Union:       drugRes.Union,
-			Description: drugRes.Description,
-		})
-		if err != nil {
-			c.JSON(http.StatusInternalServerError, models.Error{
-				Message: models.InternalMessage,
-			})
-			h.Logger.Error(err.Error())
-			return
-		}
-		c.JSON(http.StatusCreated, &models.DeliveryCreateRes{
-			Message: "Product successfully updated",
-		})
-		return
+	message := "Product successfully updated"
+	if deliveryRes.EatableCreated {
+		message = "Product successfully created"
 	}
-
 	c.JSON(http.StatusCreated, &models.DeliveryCreateRes{
-		Message: "Warning! Went wrong",
+		Message: message,
 	})
 }
 
@@ -189,7 +101,9 @@ func (h *HandlerV1) CreateDelivery(c *gin.Context) {
 // @Produce json
 // @Param id path string true "Delivery ID"
 // @Success 200 {object} models.DeliveryRes
+// @Header 200 {string} ETag "Version of the entity"
 // @Failure 400 {object} models.Error
+// @Failure 404 {object} models.Error
 // @Failure 500 {object} models.Error
 // @Router /v1/delivery/{id} [get]
 func (h *HandlerV1) GetDelivery(c *gin.Context) {
@@ -204,21 +118,12 @@ func (h *HandlerV1) GetDelivery(c *gin.Context) {
 
 	res, err := h.Delivery.Get(ctx, id)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, models.Error{
-			Message: models.WrongInfoMessage,
-		})
-		h.Logger.Error(err.Error())
+		h.fail(c, err)
 		return
 	}
 
-	c.JSON(http.StatusOK, &models.DeliveryRes{
-		ID:          res.ID,
-		ProductName: res.Name,
-		Category:    res.Category,
-		Capacity:    res.Capacity,
-		Union:       res.Union,
-		Time:        res.Time,
-	})
+	c.Header("ETag", etag(res.Version))
+	c.JSON(http.StatusOK, deliveryResponse(res))
 }
 
 // LIST DELIVERY
@@ -228,6 +133,7 @@ func (h *HandlerV1) GetDelivery(c *gin.Context) {
 // @Accept json
 // @Produce json
 // @Param request query models.Pagination true "request"
+// @Param request query models.ListQuery false "request"
 // @Param request query models.DeliveryFieldValues true "request"
 // @Success 200 {object} models.ListDeliverysRes
 // @Failure 400 {object} models.Error
@@ -244,47 +150,25 @@ func (h *HandlerV1) ListDelivery(c *gin.Context) {
 	queryParams := c.Request.URL.Query()
 	params, errStr := utils.ParseQueryParam(queryParams)
 	if errStr != nil {
-		c.JSON(http.StatusBadRequest, models.Error{
-			Message: models.WrongInfoMessage,
-		})
+		h.fail(c, badQuery(errStr))
 		return
 	}
 
-	name := c.Query("name")
-	category := c.Query("category")
-	time := c.Query("time")
-
-	mapD := map[string]interface{}{
-		"name":     name,
-		"category": category,
-		"time":     time,
-	}
-
-	res, err := h.Delivery.List(ctx, params.Page, params.Limit, mapD)
+	res, err := h.Delivery.List(ctx, params.Page, params.Limit, listRequest(params))
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, models.Error{
-			Message: models.InternalMessage,
-		})
-		h.Logger.Error(err.Error())
+		h.fail(c, err)
 		return
 	}
 
 	var resList []*models.DeliveryRes
 	for _, i := range res.Deliveries {
-		var resItem models.DeliveryRes
-		resItem.ID = i.ID
-		resItem.ProductName = i.Name
-		resItem.Category = i.Category
-		resItem.Union = i.Union
-		resItem.Capacity = int64(i.Capacity)
-		resItem.Time = i.Time
-
-		resList = append(resList, &resItem)
+		resList = append(resList, deliveryResponse(i))
 	}
 
 	c.JSON(http.StatusOK, &models.ListDeliverysRes{
-		Delivery: resList,
-		Count:    res.TotalCount,
+		Delivery:   resList,
+		Count:      res.TotalCount,
+		NextCursor: res.NextCursor,
 	})
 }
 
@@ -295,9 +179,13 @@ func (h *HandlerV1) ListDelivery(c *gin.Context) {
 // @Accept json
 // @Produce json
 // @Param Delivery body models.DeliveryRes true "createModel"
+// @Param If-Match header string false "ETag of the version being changed"
 // @Success 200 {object} models.DeliveryRes
 // @Failure 400 {object} models.Error
+// @Failure 404 {object} models.Error
+// @Failure 409 {object} models.Error
 // @Failure 500 {object} models.Error
+// @Failure 412 {object} models.Error
 // @Router /v1/delivery [put]
 func (h *HandlerV1) UpdateDelivery(c *gin.Context) {
 	ctx, span := otlp.Start(c, "api", "UpdateDelivery")
@@ -313,34 +201,41 @@ func (h *HandlerV1) UpdateDelivery(c *gin.Context) {
 
 	err := c.ShouldBindJSON(&body)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, models.Error{
-			Message: models.WrongInfoMessage,
-		})
-		h.Logger.Error("failed to bind json", l.Error(err))
+		h.fail(c, errorspkg.NewErrBadRequest(err))
 		return
 	}
 
+	version, ok := h.ifMatch(c, ctx, "delivery", body.ID)
+	if !ok {
+		return
+	}
+
+	before := h.auditBefore(ctx, "delivery", body.ID)
+
 	res, err := h.Delivery.Update(ctx, &entity.Delivery{
-		ID:       body.ID,
-		Name:     body.ProductName,
-		Capacity: body.Capacity,
-		Union:    body.Union,
-		Time:     body.Time,
+		ID:            body.ID,
+		Name:          body.ProductName,
+		Category:      body.Category,
+		Capacity:      body.Capacity,
+		Union:         body.Union,
+		Time:          body.Time,
+		SupplierID:    body.SupplierID,
+		UnitPrice:     body.UnitPrice,
+		TotalCost:     body.TotalCost,
+		InvoiceNumber: body.InvoiceNumber,
+		InvoiceFile:   body.InvoiceFile,
+		Version:       version,
 	})
 	if err != nil {
-		c.JSON(500, models.InternalMessage)
-		h.Logger.Error(err.Error())
+		h.fail(c, err)
 		return
 	}
 
-	c.JSON(http.StatusOK, &models.DeliveryRes{
-		ID:          res.ID,
-		ProductName: res.Name,
-		Category:    res.Category,
-		Capacity:    res.Capacity,
-		Union:       res.Union,
-		Time:        res.Time,
-	})
+	h.audit(c, ctx, entity.AuditActionUpdate, "delivery", body.ID, before)
+
+	c.Header("ETag", etag(res.Version))
+
+	c.JSON(http.StatusOK, deliveryResponse(res))
 }
 
 // DELETE
@@ -350,9 +245,12 @@ func (h *HandlerV1) UpdateDelivery(c *gin.Context) {
 // @Accept json
 // @Produce json
 // @Param id path string true "Delivery ID"
+// @Param If-Match header string false "ETag of the version being changed"
 // @Success 200 {object} models.Result
 // @Failure 400 {object} models.Error
+// @Failure 404 {object} models.Error
 // @Failure 500 {object} models.Error
+// @Failure 412 {object} models.Error
 // @Router /v1/delivery/{id} [delete]
 func (h *HandlerV1) DeleteDelivery(c *gin.Context) {
 	ctx, span := otlp.Start(c, "api", "DeleteDelivery")
@@ -366,21 +264,118 @@ func (h *HandlerV1) DeleteDelivery(c *gin.Context) {
 
 	_, err := h.Delivery.Get(ctx, id)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, models.Error{
-			Message: models.NotAvailable,
-		})
-		h.Logger.Error(err.Error())
+		h.fail(c, err)
 		return
 	}
 
-	err = h.Delivery.Delete(ctx, id)
+	version, ok := h.ifMatch(c, ctx, "delivery", id)
+	if !ok {
+		return
+	}
+
+	before := h.auditBefore(ctx, "delivery", id)
+
+	err = h.Delivery.Delete(ctx, id, version)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, models.InternalMessage)
-		h.Logger.Error("failed to delete product", l.Error(err))
+		h.fail(c, err)
 		return
 	}
 
+	h.audit(c, ctx, entity.AuditActionDelete, "delivery", id, before)
+
 	c.JSON(http.StatusOK, &models.Result{
 		Message: "Delivery has been deleted",
 	})
 }
+
+// UPLOAD DELIVERY INVOICE
+// @Summary UPLOAD DELIVERY INVOICE
+// @Description Api for Attach an invoice document to the delivery
+// @Tags DELIVERY
+// @Accept multipart/form-data
+// @Produce json
+// @Param id path string true "Delivery ID"
+// @Param invoice formData file true "Invoice file"
+// @Param Idempotency-Key header string false "Key that makes retries of the request safe"
+// @Success 200 {object} models.DeliveryRes
+// @Failure 400 {object} models.Error
+// @Failure 404 {object} models.Error
+// @Failure 500 {object} models.Error
+// @Router /v1/delivery/{id}/invoice [post]
+func (h *HandlerV1) UploadDeliveryInvoice(c *gin.Context) {
+	ctx, span := otlp.Start(c, "api", "UploadDeliveryInvoice")
+	span.SetAttributes(
+		attribute.Key("method").String(c.Request.Method),
+		attribute.Key("host").String(c.Request.Host),
+	)
+	defer span.End()
+
+	id := c.Param("id")
+
+	_, err := h.Delivery.Get(ctx, id)
+	if err != nil {
+		h.fail(c, err)
+		return
+	}
+
+	file, err := c.FormFile("invoice")
+	if err != nil {
+		h.fail(c, errorspkg.NewErrBadRequest(err))
+		return
+	}
+
+	ext := strings.ToLower(filepath.Ext(file.Filename))
+	if !slices.Contains(invoiceExtensions, ext) {
+		h.fail(c, badRequest("invoice files must be one of "+strings.Join(invoiceExtensions, ", ")))
+		return
+	}
+
+	invoicePath := filepath.Join(invoiceDir, id+ext)
+	if err := os.MkdirAll(invoiceDir, 0o755); err != nil {
+		h.fail(c, errorspkg.Wrap(err, "create invoice directory"))
+		return
+	}
+	if err := c.SaveUploadedFile(file, invoicePath); err != nil {
+		h.fail(c, errorspkg.Wrap(err, "save invoice file"))
+		return
+	}
+
+	before := h.auditBefore(ctx, "delivery", id)
+
+	err = h.Delivery.SetInvoiceFile(ctx, id, "/"+filepath.ToSlash(invoicePath))
+	if err != nil {
+		h.fail(c, err)
+		return
+	}
+
+	h.audit(c, ctx, entity.AuditActionUpdate, "delivery", id, before)
+
+	res, err := h.Delivery.Get(ctx, id)
+	if err != nil {
+		h.fail(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, deliveryResponse(res))
+}
+
+// invoiceDir is served statically under /media by the router
+const invoiceDir = "media/invoices"
+
+var invoiceExtensions = []string{".pdf", ".png", ".jpg", ".jpeg"}
+
+func deliveryResponse(delivery *entity.Delivery) *models.DeliveryRes {
+	return &models.DeliveryRes{
+		ID:            delivery.ID,
+		ProductName:   delivery.Name,
+		Category:      delivery.Category,
+		Capacity:      delivery.Capacity,
+		Union:         delivery.Union,
+		Time:          delivery.Time,
+		SupplierID:    delivery.SupplierID,
+		UnitPrice:     delivery.UnitPrice,
+		TotalCost:     delivery.TotalCost,
+		InvoiceNumber: delivery.InvoiceNumber,
+		InvoiceFile:   delivery.InvoiceFile,
+	}
+}
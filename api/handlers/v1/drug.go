@@ -1,9 +1,9 @@
 package v1
 
 import (
+	errorspkg "musobaqa/farm-competition/internal/errors"
 	"musobaqa/farm-competition/api/models"
 	"musobaqa/farm-competition/internal/entity"
-	l "musobaqa/farm-competition/internal/pkg/logger"
 	"musobaqa/farm-competition/internal/pkg/otlp"
 	"musobaqa/farm-competition/internal/pkg/utils"
 	"net/http"
@@ -20,8 +20,11 @@ import (
 // @Accept json
 // @Produce json
 // @Param Drug body models.DrugReq true "createModel"
+// @Param Idempotency-Key header string false "Key that makes retries of the request safe"
 // @Success 201 {object} models.DrugRes
 // @Failure 400 {object} models.Error
+// @Failure 409 {object} models.Error
+// @Failure 422 {object} models.Error
 // @Failure 500 {object} models.Error
 // @Router /v1/drugs [post]
 func (h *HandlerV1) CreateDrug(c *gin.Context) {
@@ -39,41 +42,31 @@ func (h *HandlerV1) CreateDrug(c *gin.Context) {
 
 	err := c.ShouldBindJSON(&body)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, models.Error{
-			Message: models.WrongDateMessage,
-		})
-		h.Logger.Error(err.Error())
+		h.fail(c, errorspkg.NewErrBadRequest(err))
 		return
 	}
 
 	err = body.Validate()
 	if err != nil {
-		c.JSON(http.StatusBadRequest, models.Error{
-			Message: models.NotAvailable,
-		})
-		h.Logger.Error(err.Error())
+		h.fail(c, err)
 		return
 	}
 
 	resName, err := h.Drug.UniqueDrugName(ctx, body.DrugName)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, models.Error{
-			Message: models.InternalMessage,
-		})
-		h.Logger.Error(err.Error())
+		h.fail(c, err)
 		return
 	}
 
 	if resName != 0 {
 		getDrug, err := h.Drug.Get(ctx, map[string]string{"name": body.DrugName})
 		if err != nil {
-			c.JSON(http.StatusInternalServerError, models.Error{
-				Message: models.InternalMessage,
-			})
-			h.Logger.Error(err.Error())
+			h.fail(c, err)
 			return
 		}
 
+		before := h.auditBefore(ctx, "drug", getDrug.ID)
+
 		res, err = h.Drug.Update(ctx, &entity.Drug{
 			ID:          getDrug.ID,
 			Name:        getDrug.Name,
@@ -83,12 +76,11 @@ func (h *HandlerV1) CreateDrug(c *gin.Context) {
 			Description: getDrug.Description,
 		})
 		if err != nil {
-			c.JSON(http.StatusInternalServerError, models.Error{
-				Message: models.InternalMessage,
-			})
-			h.Logger.Error(err.Error())
+			h.fail(c, err)
 			return
 		}
+
+		h.audit(c, ctx, entity.AuditActionUpdate, "drug", res.ID, before)
 	} else {
 
 		res, err = h.Drug.Create(ctx, &entity.Drug{
@@ -100,12 +92,11 @@ func (h *HandlerV1) CreateDrug(c *gin.Context) {
 			Description: body.Description,
 		})
 		if err != nil {
-			c.JSON(http.StatusInternalServerError, models.Error{
-				Message: models.InternalMessage,
-			})
-			h.Logger.Error(err.Error())
+			h.fail(c, err)
 			return
 		}
+
+		h.audit(c, ctx, entity.AuditActionCreate, "drug", res.ID, nil)
 	}
 
 	c.JSON(http.StatusCreated, &models.DrugRes{
@@ -126,7 +117,9 @@ func (h *HandlerV1) CreateDrug(c *gin.Context) {
 // @Produce json
 // @Param id path string true "Drug ID"
 // @Success 200 {object} models.DrugRes
+// @Header 200 {string} ETag "Version of the entity"
 // @Failure 400 {object} models.Error
+// @Failure 404 {object} models.Error
 // @Failure 500 {object} models.Error
 // @Router /v1/drugs/{id} [get]
 func (h *HandlerV1) GetDrug(c *gin.Context) {
@@ -141,13 +134,11 @@ func (h *HandlerV1) GetDrug(c *gin.Context) {
 
 	res, err := h.Drug.Get(ctx, map[string]string{"id": id})
 	if err != nil {
-		c.JSON(http.StatusBadRequest, models.Error{
-			Message: models.WrongInfoMessage,
-		})
-		h.Logger.Error(err.Error())
+		h.fail(c, err)
 		return
 	}
 
+	c.Header("ETag", etag(res.Version))
 	c.JSON(http.StatusOK, &models.DrugRes{
 		Id:            res.ID,
 		DrugName:      res.Name,
@@ -165,6 +156,7 @@ func (h *HandlerV1) GetDrug(c *gin.Context) {
 // @Accept json
 // @Produce json
 // @Param request query models.Pagination true "request"
+// @Param request query models.ListQuery false "request"
 // @Param request query models.DrugFieldValues true "request"
 // @Success 200 {object} models.ListDrugsRes
 // @Failure 400 {object} models.Error
@@ -181,28 +173,13 @@ func (h *HandlerV1) ListDrug(c *gin.Context) {
 	queryParams := c.Request.URL.Query()
 	params, errStr := utils.ParseQueryParam(queryParams)
 	if errStr != nil {
-		c.JSON(http.StatusBadRequest, models.Error{
-			Message: models.WrongInfoMessage,
-		})
+		h.fail(c, badQuery(errStr))
 		return
 	}
 
-	name := c.Query("name")
-	union := c.Query("union")
-	status := c.Query("status")
-
-	mapD := map[string]interface{}{
-		"name":   name,
-		"union":  union,
-		"status": status,
-	}
-
-	res, err := h.Drug.List(ctx, params.Page, params.Limit, mapD)
+	res, err := h.Drug.List(ctx, params.Page, params.Limit, listRequest(params))
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, models.Error{
-			Message: models.InternalMessage,
-		})
-		h.Logger.Error(err.Error())
+		h.fail(c, err)
 		return
 	}
 
@@ -232,9 +209,13 @@ func (h *HandlerV1) ListDrug(c *gin.Context) {
 // @Accept json
 // @Produce json
 // @Param Drug body models.DrugRes true "createModel"
+// @Param If-Match header string false "ETag of the version being changed"
 // @Success 200 {object} models.DrugRes
 // @Failure 400 {object} models.Error
+// @Failure 404 {object} models.Error
+// @Failure 409 {object} models.Error
 // @Failure 500 {object} models.Error
+// @Failure 412 {object} models.Error
 // @Router /v1/drugs [put]
 func (h *HandlerV1) UpdateDrug(c *gin.Context) {
 	ctx, span := otlp.Start(c, "api", "UpdateDrug")
@@ -250,13 +231,17 @@ func (h *HandlerV1) UpdateDrug(c *gin.Context) {
 
 	err := c.ShouldBindJSON(&body)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, models.Error{
-			Message: models.WrongInfoMessage,
-		})
-		h.Logger.Error("failed to bind json", l.Error(err))
+		h.fail(c, errorspkg.NewErrBadRequest(err))
 		return
 	}
 
+	version, ok := h.ifMatch(c, ctx, "drug", body.Id)
+	if !ok {
+		return
+	}
+
+	before := h.auditBefore(ctx, "drug", body.Id)
+
 	res, err := h.Drug.Update(ctx, &entity.Drug{
 		ID:          body.Id,
 		Name:        body.DrugName,
@@ -264,13 +249,17 @@ func (h *HandlerV1) UpdateDrug(c *gin.Context) {
 		Capacity:    uint64(body.TotalCapacity),
 		Union:       body.Union,
 		Description: body.Description,
+		Version:     version,
 	})
 	if err != nil {
-		c.JSON(500, models.InternalMessage)
-		h.Logger.Error(err.Error())
+		h.fail(c, err)
 		return
 	}
 
+	h.audit(c, ctx, entity.AuditActionUpdate, "drug", body.Id, before)
+
+	c.Header("ETag", etag(res.Version))
+
 	c.JSON(http.StatusOK, &models.DrugRes{
 		Id:            res.ID,
 		DrugName:      res.Name,
@@ -288,9 +277,12 @@ func (h *HandlerV1) UpdateDrug(c *gin.Context) {
 // @Accept json
 // @Produce json
 // @Param id path string true "Drug ID"
+// @Param If-Match header string false "ETag of the version being changed"
 // @Success 200 {object} models.Result
 // @Failure 400 {object} models.Error
+// @Failure 404 {object} models.Error
 // @Failure 500 {object} models.Error
+// @Failure 412 {object} models.Error
 // @Router /v1/drugs/{id} [delete]
 func (h *HandlerV1) DeleteDrug(c *gin.Context) {
 	ctx, span := otlp.Start(c, "api", "DeleteDrug")
@@ -304,20 +296,25 @@ func (h *HandlerV1) DeleteDrug(c *gin.Context) {
 
 	_, err := h.Drug.Get(ctx, map[string]string{"id": id})
 	if err != nil {
-		c.JSON(http.StatusBadRequest, models.Error{
-			Message: models.NotAvailable,
-		})
-		h.Logger.Error("failed to get drug in delete", l.Error(err))
+		h.fail(c, err)
+		return
+	}
+
+	version, ok := h.ifMatch(c, ctx, "drug", id)
+	if !ok {
 		return
 	}
 
-	err = h.Drug.Delete(ctx, id)
+	before := h.auditBefore(ctx, "drug", id)
+
+	err = h.Drug.Delete(ctx, id, version)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, models.InternalMessage)
-		h.Logger.Error("failed to delete product", l.Error(err))
+		h.fail(c, err)
 		return
 	}
 
+	h.audit(c, ctx, entity.AuditActionDelete, "drug", id, before)
+
 	c.JSON(http.StatusOK, &models.Result{
 		Message: "Drug` has been deleted",
 	})
@@ -0,0 +1,30 @@
+package v1
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	errorspkg "musobaqa/farm-competition/internal/errors"
+)
+
+// fail logs err and leaves the answer to the error middleware, which picks
+// the status and body from the error catalog
+func (h *HandlerV1) fail(c *gin.Context, err error) {
+	h.Logger.Error(err.Error())
+	_ = c.Error(err)
+	c.Abort()
+}
+
+// badRequest is the error of a request the handler cannot read, it is
+// answered 400 like the bad requests of the usecases
+func badRequest(message string) error {
+	return errorspkg.NewErrBadRequest(errors.New(message))
+}
+
+// badQuery is the error of the query parameters utils.ParseQueryParam could
+// not read
+func badQuery(errStr []string) error {
+	return badRequest(strings.Join(errStr, ", "))
+}
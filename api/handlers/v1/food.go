@@ -3,7 +3,7 @@ package v1
 import (
 	"musobaqa/farm-competition/api/models"
 	"musobaqa/farm-competition/internal/entity"
-	l "musobaqa/farm-competition/internal/pkg/logger"
+	errorspkg "musobaqa/farm-competition/internal/errors"
 	"musobaqa/farm-competition/internal/pkg/otlp"
 	"musobaqa/farm-competition/internal/pkg/utils"
 	"net/http"
@@ -20,8 +20,11 @@ import (
 // @Accept json
 // @Produce json
 // @Param Food body models.FoodReq true "createModel"
+// @Param Idempotency-Key header string false "Key that makes retries of the request safe"
 // @Success 201 {object} models.FoodRes
 // @Failure 400 {object} models.Error
+// @Failure 409 {object} models.Error
+// @Failure 422 {object} models.Error
 // @Failure 500 {object} models.Error
 // @Router /v1/foods [post]
 func (h *HandlerV1) CreateFood(c *gin.Context) {
@@ -39,41 +42,31 @@ func (h *HandlerV1) CreateFood(c *gin.Context) {
 
 	err := c.ShouldBindJSON(&body)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, models.Error{
-			Message: models.WrongDateMessage,
-		})
-		h.Logger.Error(err.Error())
+		h.fail(c, errorspkg.NewErrBadRequest(err))
 		return
 	}
 
 	err = body.Validate()
 	if err != nil {
-		c.JSON(http.StatusBadRequest, models.Error{
-			Message: models.NotAvailable,
-		})
-		h.Logger.Error(err.Error())
+		h.fail(c, err)
 		return
 	}
 
 	checkRes, err := h.Food.UniqueFoodName(ctx, body.FoodName)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, models.Error{
-			Message: models.InternalMessage,
-		})
-		h.Logger.Error(err.Error())
+		h.fail(c, err)
 		return
 	}
 
 	if checkRes != 0 {
 		getFood, err := h.Food.Get(ctx, map[string]string{"name": body.FoodName})
 		if err != nil {
-			c.JSON(http.StatusInternalServerError, models.Error{
-				Message: models.InternalMessage,
-			})
-			h.Logger.Error(err.Error())
+			h.fail(c, err)
 			return
 		}
 
+		before := h.auditBefore(ctx, "food", getFood.ID)
+
 		res, err = h.Food.Update(ctx, &entity.Food{
 			ID:          getFood.ID,
 			Name:        getFood.Name,
@@ -82,12 +75,11 @@ func (h *HandlerV1) CreateFood(c *gin.Context) {
 			Description: getFood.Description,
 		})
 		if err != nil {
-			c.JSON(http.StatusInternalServerError, models.Error{
-				Message: models.InternalMessage,
-			})
-			h.Logger.Error(err.Error())
+			h.fail(c, err)
 			return
 		}
+
+		h.audit(c, ctx, entity.AuditActionUpdate, "food", res.ID, before)
 	} else {
 
 		res, err = h.Food.Create(ctx, &entity.Food{
@@ -98,12 +90,11 @@ func (h *HandlerV1) CreateFood(c *gin.Context) {
 			Description: body.Description,
 		})
 		if err != nil {
-			c.JSON(http.StatusInternalServerError, models.Error{
-				Message: models.InternalMessage,
-			})
-			h.Logger.Error(err.Error())
+			h.fail(c, err)
 			return
 		}
+
+		h.audit(c, ctx, entity.AuditActionCreate, "food", res.ID, nil)
 	}
 
 	c.JSON(http.StatusCreated, &models.FoodRes{
@@ -123,7 +114,9 @@ func (h *HandlerV1) CreateFood(c *gin.Context) {
 // @Produce json
 // @Param id path string true "Food ID"
 // @Success 200 {object} models.FoodRes
+// @Header 200 {string} ETag "Version of the entity"
 // @Failure 400 {object} models.Error
+// @Failure 404 {object} models.Error
 // @Failure 500 {object} models.Error
 // @Router /v1/foods/{id} [get]
 func (h *HandlerV1) GetFood(c *gin.Context) {
@@ -138,13 +131,11 @@ func (h *HandlerV1) GetFood(c *gin.Context) {
 
 	res, err := h.Food.Get(ctx, map[string]string{"id": id})
 	if err != nil {
-		c.JSON(http.StatusBadRequest, models.Error{
-			Message: models.WrongInfoMessage,
-		})
-		h.Logger.Error(err.Error())
+		h.fail(c, err)
 		return
 	}
 
+	c.Header("ETag", etag(res.Version))
 	c.JSON(http.StatusOK, &models.FoodRes{
 		Id:            res.ID,
 		FoodName:      res.Name,
@@ -161,6 +152,7 @@ func (h *HandlerV1) GetFood(c *gin.Context) {
 // @Accept json
 // @Produce json
 // @Param request query models.Pagination true "request"
+// @Param request query models.ListQuery false "request"
 // @Param request query models.FoodFieldValues true "request"
 // @Success 200 {object} models.ListFoodsRes
 // @Failure 400 {object} models.Error
@@ -177,28 +169,13 @@ func (h *HandlerV1) ListFood(c *gin.Context) {
 	queryParams := c.Request.URL.Query()
 	params, errStr := utils.ParseQueryParam(queryParams)
 	if errStr != nil {
-		c.JSON(http.StatusBadRequest, models.Error{
-			Message: models.WrongInfoMessage,
-		})
+		h.fail(c, badQuery(errStr))
 		return
 	}
 
-	println(params)
-
-	name := c.Query("name")
-	union := c.Query("union")
-
-	mapF := map[string]interface{}{
-		"name":  name,
-		"union": union,
-	}
-
-	res, err := h.Food.List(ctx, params.Page, params.Limit, mapF)
+	res, err := h.Food.List(ctx, params.Page, params.Limit, listRequest(params))
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, models.Error{
-			Message: models.InternalMessage,
-		})
-		h.Logger.Error(err.Error())
+		h.fail(c, err)
 		return
 	}
 
@@ -227,9 +204,13 @@ func (h *HandlerV1) ListFood(c *gin.Context) {
 // @Accept json
 // @Produce json
 // @Param Food body models.FoodRes true "createModel"
+// @Param If-Match header string false "ETag of the version being changed"
 // @Success 200 {object} models.FoodRes
 // @Failure 400 {object} models.Error
+// @Failure 404 {object} models.Error
+// @Failure 409 {object} models.Error
 // @Failure 500 {object} models.Error
+// @Failure 412 {object} models.Error
 // @Router /v1/foods [put]
 func (h *HandlerV1) UpdateFood(c *gin.Context) {
 	ctx, span := otlp.Start(c, "api", "UpdateFood")
@@ -245,26 +226,34 @@ func (h *HandlerV1) UpdateFood(c *gin.Context) {
 
 	err := c.ShouldBindJSON(&body)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, models.Error{
-			Message: models.WrongInfoMessage,
-		})
-		h.Logger.Error("failed to bind json", l.Error(err))
+		h.fail(c, errorspkg.NewErrBadRequest(err))
+		return
+	}
+
+	version, ok := h.ifMatch(c, ctx, "food", body.Id)
+	if !ok {
 		return
 	}
 
+	before := h.auditBefore(ctx, "food", body.Id)
+
 	res, err := h.Food.Update(ctx, &entity.Food{
 		ID:          body.Id,
 		Name:        body.FoodName,
 		Capacity:    uint64(body.TotalCapacity),
 		Union:       body.Union,
 		Description: body.Description,
+		Version:     version,
 	})
 	if err != nil {
-		c.JSON(500, models.InternalMessage)
-		h.Logger.Error(err.Error())
+		h.fail(c, err)
 		return
 	}
 
+	h.audit(c, ctx, entity.AuditActionUpdate, "food", body.Id, before)
+
+	c.Header("ETag", etag(res.Version))
+
 	c.JSON(http.StatusOK, &models.FoodRes{
 		Id:            res.ID,
 		FoodName:      res.Name,
@@ -281,9 +270,12 @@ func (h *HandlerV1) UpdateFood(c *gin.Context) {
 // @Accept json
 // @Produce json
 // @Param id path string true "Food ID"
+// @Param If-Match header string false "ETag of the version being changed"
 // @Success 200 {object} models.Result
 // @Failure 400 {object} models.Error
+// @Failure 404 {object} models.Error
 // @Failure 500 {object} models.Error
+// @Failure 412 {object} models.Error
 // @Router /v1/foods/{id} [delete]
 func (h *HandlerV1) DeleteFood(c *gin.Context) {
 	ctx, span := otlp.Start(c, "api", "DeleteFood")
@@ -297,20 +289,25 @@ func (h *HandlerV1) DeleteFood(c *gin.Context) {
 
 	_, err := h.Food.Get(ctx, map[string]string{"id": id})
 	if err != nil {
-		c.JSON(http.StatusBadRequest, models.Error{
-			Message: models.NotAvailable,
-		})
-		h.Logger.Error(err.Error())
+		h.fail(c, err)
+		return
+	}
+
+	version, ok := h.ifMatch(c, ctx, "food", id)
+	if !ok {
 		return
 	}
 
-	err = h.Food.Delete(ctx, id)
+	before := h.auditBefore(ctx, "food", id)
+
+	err = h.Food.Delete(ctx, id, version)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, models.InternalMessage)
-		h.Logger.Error("failed to delete product", l.Error(err))
+		h.fail(c, err)
 		return
 	}
 
+	h.audit(c, ctx, entity.AuditActionDelete, "food", id, before)
+
 	c.JSON(http.StatusOK, &models.Result{
 		Message: "Food has been deleted",
 	})
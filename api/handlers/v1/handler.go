@@ -6,6 +6,7 @@ import (
 	"go.uber.org/zap"
 
 	"musobaqa/farm-competition/internal/pkg/config"
+	"musobaqa/farm-competition/internal/pkg/scheduler"
 	tokens "musobaqa/farm-competition/internal/pkg/token"
 	animalproduct "musobaqa/farm-competition/internal/usecase/animal-product"
 	"musobaqa/farm-competition/internal/usecase/animals"
@@ -15,6 +16,18 @@ import (
 	"musobaqa/farm-competition/internal/usecase/feeding"
 	"musobaqa/farm-competition/internal/usecase/foods"
 	"musobaqa/farm-competition/internal/usecase/products"
+	"musobaqa/farm-competition/internal/usecase/suppliers"
+	"musobaqa/farm-competition/internal/usecase/health"
+	"musobaqa/farm-competition/internal/usecase/search"
+	"musobaqa/farm-competition/internal/usecase/versions"
+	"musobaqa/farm-competition/internal/usecase/trash"
+	"musobaqa/farm-competition/internal/usecase/audit"
+	"musobaqa/farm-competition/internal/usecase/lots"
+	"musobaqa/farm-competition/internal/usecase/valuation"
+	"musobaqa/farm-competition/internal/usecase/webhooks"
+	"musobaqa/farm-competition/internal/usecase/jobs"
+	"musobaqa/farm-competition/internal/usecase/reports"
+	"musobaqa/farm-competition/internal/usecase/tasks"
 )
 
 type HandlerV1 struct {
@@ -30,6 +43,19 @@ type HandlerV1 struct {
 	AnimalProduct  animalproduct.AnimalProduct
 	EatablesInfo eatables.Eatable
 	Feeding feeding.Feeding
+	Supplier       suppliers.Supplier
+	Health         health.Health
+	Search         search.Search
+	Version        versions.Version
+	Trash          trash.Trash
+	Audit          audit.Audit
+	Lot            lots.Lot
+	Valuation      valuation.Valuation
+	Webhook        webhooks.Webhook
+	Jobs           jobs.Jobs
+	Scheduler      *scheduler.Scheduler
+	Reports        reports.Reports
+	FeedingTask    tasks.FeedingTask
 }
 
 type HandlerV1Config struct {
@@ -45,6 +71,19 @@ type HandlerV1Config struct {
 	AnimalProduct  animalproduct.AnimalProduct
 	EatablesInfo eatables.Eatable
 	Feeding feeding.Feeding
+	Supplier       suppliers.Supplier
+	Health         health.Health
+	Search         search.Search
+	Version        versions.Version
+	Trash          trash.Trash
+	Audit          audit.Audit
+	Lot            lots.Lot
+	Valuation      valuation.Valuation
+	Webhook        webhooks.Webhook
+	Jobs           jobs.Jobs
+	Scheduler      *scheduler.Scheduler
+	Reports        reports.Reports
+	FeedingTask    tasks.FeedingTask
 }
 
 func New(c *HandlerV1Config) *HandlerV1 {
@@ -61,5 +100,18 @@ func New(c *HandlerV1Config) *HandlerV1 {
 		AnimalProduct:  c.AnimalProduct,
 		EatablesInfo: c.EatablesInfo,
 		Feeding: c.Feeding,
+		Supplier:       c.Supplier,
+		Health:         c.Health,
+		Search:         c.Search,
+		Version:        c.Version,
+		Trash:          c.Trash,
+		Audit:          c.Audit,
+		Lot:            c.Lot,
+		Valuation:      c.Valuation,
+		Webhook:        c.Webhook,
+		Jobs:           c.Jobs,
+		Scheduler:      c.Scheduler,
+		Reports:        c.Reports,
+		FeedingTask:    c.FeedingTask,
 	}
 }
@@ -0,0 +1,71 @@
+package v1
+
+import (
+	"musobaqa/farm-competition/api/models"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	statusOK       = "ok"
+	statusFailing  = "failing"
+	statusDraining = "draining"
+)
+
+// HEALTH
+// @Summary LIVENESS
+// @Description Api for Liveness probe, answers as long as the process serves requests
+// @Tags HEALTH
+// @Produce json
+// @Success 200 {object} models.HealthRes
+// @Router /healthz [get]
+func (h *HandlerV1) Healthz(c *gin.Context) {
+	c.JSON(http.StatusOK, models.HealthRes{
+		Status: statusOK,
+	})
+}
+
+// READINESS
+// @Summary READINESS
+// @Description Api for Readiness probe, checks Postgres and Redis and reports the migration version. Fails while the service shuts down
+// @Tags HEALTH
+// @Produce json
+// @Success 200 {object} models.ReadinessRes
+// @Failure 503 {object} models.ReadinessRes
+// @Router /readyz [get]
+func (h *HandlerV1) Readyz(c *gin.Context) {
+	readiness := h.Health.Ready(c.Request.Context())
+
+	res := models.ReadinessRes{
+		Status: statusOK,
+		Checks: make(map[string]*models.DependencyCheckRes, len(readiness.Checks)),
+	}
+	for _, check := range readiness.Checks {
+		checkRes := &models.DependencyCheckRes{
+			Status:    statusOK,
+			LatencyMs: check.Latency.Milliseconds(),
+			Error:     check.Error,
+			Version:   check.Version,
+			Dirty:     check.Dirty,
+		}
+		if !check.Ready || check.Dirty {
+			checkRes.Status = statusFailing
+		}
+		res.Checks[check.Name] = checkRes
+	}
+
+	switch {
+	case readiness.Draining:
+		res.Status = statusDraining
+	case !readiness.Ready:
+		res.Status = statusFailing
+	}
+
+	if !readiness.Ready {
+		c.JSON(http.StatusServiceUnavailable, res)
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
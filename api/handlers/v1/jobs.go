@@ -0,0 +1,125 @@
+package v1
+
+import (
+	"musobaqa/farm-competition/api/models"
+	"musobaqa/farm-competition/internal/entity"
+	"musobaqa/farm-competition/internal/pkg/otlp"
+	"musobaqa/farm-competition/internal/pkg/utils"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// LIST JOBS
+// @Summary LIST JOBS
+// @Description Api for List the scheduled jobs with their schedule, next run on this replica and last run on any replica
+// @Tags JOB
+// @Accept json
+// @Produce json
+// @Success 200 {object} models.ListJobsRes
+// @Failure 500 {object} models.Error
+// @Router /v1/jobs [get]
+func (h *HandlerV1) ListJobs(c *gin.Context) {
+	ctx, span := otlp.Start(c, "api", "ListJobs")
+	span.SetAttributes(
+		attribute.Key("method").String(c.Request.Method),
+		attribute.Key("host").String(c.Request.Host),
+	)
+	defer span.End()
+
+	lastRuns, err := h.Jobs.LastRuns(ctx)
+	if err != nil {
+		h.fail(c, err)
+		return
+	}
+
+	last := map[string]*entity.JobRun{}
+	for _, run := range lastRuns {
+		last[run.Job] = run
+	}
+
+	response := models.ListJobsRes{
+		Jobs: []*models.JobRes{},
+	}
+	for _, job := range h.Scheduler.Jobs() {
+		res := &models.JobRes{
+			Name:     job.Name,
+			Schedule: job.Schedule,
+			Timeout:  job.Timeout.String(),
+		}
+		if !job.NextRunAt.IsZero() {
+			res.NextRunAt = job.NextRunAt.Format(time.RFC3339)
+		}
+		if run, ok := last[job.Name]; ok {
+			res.LastRun = jobRunResponse(run)
+		}
+		response.Jobs = append(response.Jobs, res)
+	}
+
+	c.JSON(http.StatusOK, &response)
+}
+
+// LIST JOB RUNS
+// @Summary LIST JOB RUNS
+// @Description Api for List the run history of the scheduled jobs, newest first, by job and status
+// @Tags JOB
+// @Accept json
+// @Produce json
+// @Param request query models.Pagination true "request"
+// @Param request query models.JobRunFieldValues false "request"
+// @Success 200 {object} models.ListJobRunsRes
+// @Failure 400 {object} models.Error
+// @Failure 500 {object} models.Error
+// @Router /v1/jobs/runs [get]
+func (h *HandlerV1) ListJobRuns(c *gin.Context) {
+	ctx, span := otlp.Start(c, "api", "ListJobRuns")
+	span.SetAttributes(
+		attribute.Key("method").String(c.Request.Method),
+		attribute.Key("host").String(c.Request.Host),
+	)
+	defer span.End()
+
+	queryParams := c.Request.URL.Query()
+	params, errStr := utils.ParseQueryParam(queryParams)
+	if errStr != nil {
+		h.fail(c, badQuery(errStr))
+		return
+	}
+
+	res, err := h.Jobs.Runs(ctx, params.Page, params.Limit, map[string]interface{}{
+		"job":    c.Query("job"),
+		"status": c.Query("status"),
+	})
+	if err != nil {
+		h.fail(c, err)
+		return
+	}
+
+	response := models.ListJobRunsRes{
+		Runs:  []*models.JobRunRes{},
+		Count: int64(res.TotalCount),
+	}
+	for _, i := range res.Runs {
+		response.Runs = append(response.Runs, jobRunResponse(i))
+	}
+
+	c.JSON(http.StatusOK, &response)
+}
+
+func jobRunResponse(run *entity.JobRun) *models.JobRunRes {
+	response := models.JobRunRes{
+		ID:          run.ID,
+		Job:         run.Job,
+		ScheduledAt: run.ScheduledAt.Format(time.RFC3339),
+		Status:      run.Status,
+		Holder:      run.Holder,
+		StartedAt:   run.StartedAt.Format(time.RFC3339),
+		Error:       run.Error,
+	}
+	if run.FinishedAt != nil {
+		response.FinishedAt = run.FinishedAt.Format(time.RFC3339)
+	}
+	return &response
+}
@@ -0,0 +1,17 @@
+package v1
+
+import (
+	"musobaqa/farm-competition/internal/pkg/listquery"
+	"musobaqa/farm-competition/internal/pkg/utils"
+)
+
+// listRequest passes the filters, ordering and search of a list endpoint on
+// to the repository, which checks them against the entity whitelist
+func listRequest(params *utils.QueryParam) listquery.Request {
+	return listquery.Request{
+		Filters:  params.Filters,
+		Ordering: params.Ordering,
+		Search:   params.Search,
+		Cursor:   params.Cursor,
+	}
+}
@@ -0,0 +1,217 @@
+package v1
+
+import (
+	"musobaqa/farm-competition/api/models"
+	"musobaqa/farm-competition/internal/entity"
+	errorspkg "musobaqa/farm-competition/internal/errors"
+	"musobaqa/farm-competition/internal/pkg/otlp"
+	"musobaqa/farm-competition/internal/pkg/utils"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// GET STOCK LOT
+// @Summary GET STOCK LOT BY ID
+// @Description Api for Get stock lot by ID
+// @Tags STOCK LOT
+// @Accept json
+// @Produce json
+// @Param id path string true "Lot ID"
+// @Success 200 {object} models.StockLotRes
+// @Header 200 {string} ETag "Version of the entity"
+// @Failure 400 {object} models.Error
+// @Failure 404 {object} models.Error
+// @Failure 500 {object} models.Error
+// @Router /v1/lots/{id} [get]
+func (h *HandlerV1) GetStockLot(c *gin.Context) {
+	ctx, span := otlp.Start(c, "api", "GetStockLot")
+	span.SetAttributes(
+		attribute.Key("method").String(c.Request.Method),
+		attribute.Key("host").String(c.Request.Host),
+	)
+	defer span.End()
+
+	res, err := h.Lot.Get(ctx, c.Param("id"))
+	if err != nil {
+		h.fail(c, err)
+		return
+	}
+
+	c.Header("ETag", etag(res.Version))
+	c.JSON(http.StatusOK, stockLotResponse(res))
+}
+
+// LIST STOCK LOTS
+// @Summary LIST STOCK LOTS
+// @Description Api for List stock lots, first expiring first
+// @Tags STOCK LOT
+// @Accept json
+// @Produce json
+// @Param request query models.Pagination true "request"
+// @Param request query models.StockLotFieldValues true "request"
+// @Success 200 {object} models.ListStockLotsRes
+// @Failure 400 {object} models.Error
+// @Failure 500 {object} models.Error
+// @Router /v1/lots [get]
+func (h *HandlerV1) ListStockLots(c *gin.Context) {
+	ctx, span := otlp.Start(c, "api", "ListStockLots")
+	span.SetAttributes(
+		attribute.Key("method").String(c.Request.Method),
+		attribute.Key("host").String(c.Request.Host),
+	)
+	defer span.End()
+
+	queryParams := c.Request.URL.Query()
+	params, errStr := utils.ParseQueryParam(queryParams)
+	if errStr != nil {
+		h.fail(c, badQuery(errStr))
+		return
+	}
+
+	res, err := h.Lot.List(ctx, params.Page, params.Limit, map[string]interface{}{
+		"category":      c.Query("category"),
+		"name":          c.Query("name"),
+		"lot_number":    c.Query("lot_number"),
+		"include_empty": c.Query("include_empty"),
+	})
+	if err != nil {
+		h.fail(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, &models.ListStockLotsRes{
+		Lots:  stockLotsResponse(res.Lots),
+		Count: int64(res.TotalCount),
+	})
+}
+
+// EXPIRING STOCK LOTS
+// @Summary EXPIRING STOCK LOTS
+// @Description Api for List lots with stock left that expire within the given days
+// @Tags STOCK LOT
+// @Accept json
+// @Produce json
+// @Param days query int false "Days ahead, default 30"
+// @Success 200 {object} models.ListStockLotsRes
+// @Failure 400 {object} models.Error
+// @Failure 500 {object} models.Error
+// @Router /v1/lots/expiring [get]
+func (h *HandlerV1) ExpiringStockLots(c *gin.Context) {
+	ctx, span := otlp.Start(c, "api", "ExpiringStockLots")
+	span.SetAttributes(
+		attribute.Key("method").String(c.Request.Method),
+		attribute.Key("host").String(c.Request.Host),
+	)
+	defer span.End()
+
+	days, err := strconv.Atoi(c.DefaultQuery("days", "30"))
+	if err != nil || days < 0 {
+		h.fail(c, badRequest("days must be a number of days from today"))
+		return
+	}
+
+	res, err := h.Lot.Expiring(ctx, days)
+	if err != nil {
+		h.fail(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, &models.ListStockLotsRes{
+		Lots:  stockLotsResponse(res),
+		Count: int64(len(res)),
+	})
+}
+
+// WRITE OFF STOCK LOTS
+// @Summary WRITE OFF STOCK LOTS
+// @Description Api for Write off the given lot, or every expired lot when lot_id is empty
+// @Tags STOCK LOT
+// @Accept json
+// @Produce json
+// @Param WriteOff body models.WriteOffReq true "writeOffModel"
+// @Param Idempotency-Key header string false "Key that makes retries of the request safe"
+// @Success 200 {object} models.ListStockLotsRes
+// @Failure 400 {object} models.Error
+// @Failure 422 {object} models.Error
+// @Failure 500 {object} models.Error
+// @Router /v1/lots/write-off [post]
+func (h *HandlerV1) WriteOffStockLots(c *gin.Context) {
+	ctx, span := otlp.Start(c, "api", "WriteOffStockLots")
+	span.SetAttributes(
+		attribute.Key("method").String(c.Request.Method),
+		attribute.Key("host").String(c.Request.Host),
+	)
+	defer span.End()
+
+	var (
+		body models.WriteOffReq
+	)
+
+	err := c.ShouldBindJSON(&body)
+	if err != nil {
+		h.fail(c, errorspkg.NewErrBadRequest(err))
+		return
+	}
+
+	err = body.Validate()
+	if err != nil {
+		h.fail(c, err)
+		return
+	}
+
+	var writtenOff []*entity.StockLot
+	if body.LotID != "" {
+		before := h.auditBefore(ctx, "stock_lot", body.LotID)
+
+		lot, err := h.Lot.WriteOff(ctx, body.LotID, body.Reason)
+		if err != nil {
+			h.fail(c, err)
+			return
+		}
+		writtenOff = append(writtenOff, lot)
+
+		h.audit(c, ctx, entity.AuditActionUpdate, "stock_lot", lot.ID, before)
+	} else {
+		writtenOff, err = h.Lot.WriteOffExpired(ctx, body.Reason)
+		if err != nil {
+			h.fail(c, err)
+			return
+		}
+
+		for _, lot := range writtenOff {
+			h.audit(c, ctx, entity.AuditActionUpdate, "stock_lot", lot.ID, nil)
+		}
+	}
+
+	c.JSON(http.StatusOK, &models.ListStockLotsRes{
+		Lots:  stockLotsResponse(writtenOff),
+		Count: int64(len(writtenOff)),
+	})
+}
+
+func stockLotResponse(lot *entity.StockLot) *models.StockLotRes {
+	return &models.StockLotRes{
+		ID:             lot.ID,
+		DeliveryID:     lot.DeliveryID,
+		Category:       lot.Category,
+		Name:           lot.Name,
+		LotNumber:      lot.LotNumber,
+		ReceivedDate:   lot.ReceivedDate,
+		ExpiryDate:     lot.ExpiryDate,
+		Quantity:       lot.Quantity,
+		Remaining:      lot.Remaining,
+		WrittenOff:     lot.WrittenOff,
+		WriteOffReason: lot.WriteOffReason,
+	}
+}
+
+func stockLotsResponse(lots []*entity.StockLot) []*models.StockLotRes {
+	resList := []*models.StockLotRes{}
+	for _, lot := range lots {
+		resList = append(resList, stockLotResponse(lot))
+	}
+	return resList
+}
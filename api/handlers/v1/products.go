@@ -1,9 +1,9 @@
 package v1
 
 import (
+	errorspkg "musobaqa/farm-competition/internal/errors"
 	"musobaqa/farm-competition/api/models"
 	"musobaqa/farm-competition/internal/entity"
-	l "musobaqa/farm-competition/internal/pkg/logger"
 	"musobaqa/farm-competition/internal/pkg/otlp"
 	"musobaqa/farm-competition/internal/pkg/utils"
 	"net/http"
@@ -20,8 +20,11 @@ import (
 // @Accept json
 // @Produce json
 // @Param Product body models.ProductReq true "createModel"
+// @Param Idempotency-Key header string false "Key that makes retries of the request safe"
 // @Success 201 {object} models.ProductRes
 // @Failure 400 {object} models.Error
+// @Failure 409 {object} models.Error
+// @Failure 422 {object} models.Error
 // @Failure 500 {object} models.Error
 // @Router /v1/products [post]
 func (h *HandlerV1) CreateProduct(c *gin.Context) {
@@ -39,41 +42,31 @@ func (h *HandlerV1) CreateProduct(c *gin.Context) {
 
 	err := c.ShouldBindJSON(&body)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, models.Error{
-			Message: models.WrongDateMessage,
-		})
-		h.Logger.Error(err.Error())
+		h.fail(c, errorspkg.NewErrBadRequest(err))
 		return
 	}
 
 	err = body.Validate()
 	if err != nil {
-		c.JSON(http.StatusBadRequest, models.Error{
-			Message: models.NotAvailable,
-		})
-		h.Logger.Error(err.Error())
+		h.fail(c, err)
 		return
 	}
 
 	checkRes, err := h.Product.UniqueProductName(ctx, body.ProductName)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, models.Error{
-			Message: models.InternalMessage,
-		})
-		h.Logger.Error(err.Error())
+		h.fail(c, err)
 		return
 	}
 
 	if checkRes != 0 {
 		getProduct, err := h.Product.Get(ctx, map[string]string{"name": body.ProductName})
 		if err != nil {
-			c.JSON(http.StatusInternalServerError, models.Error{
-				Message: models.InternalMessage,
-			})
-			h.Logger.Error(err.Error())
+			h.fail(c, err)
 			return
 		}
 
+		before := h.auditBefore(ctx, "product", getProduct.ID)
+
 		res, err = h.Product.Update(ctx, &entity.Product{
 			ID:            getProduct.ID,
 			Name:          getProduct.Name,
@@ -82,12 +75,11 @@ func (h *HandlerV1) CreateProduct(c *gin.Context) {
 			Description:   getProduct.Description,
 		})
 		if err != nil {
-			c.JSON(http.StatusInternalServerError, models.Error{
-				Message: models.InternalMessage,
-			})
-			h.Logger.Error(err.Error())
+			h.fail(c, err)
 			return
 		}
+
+		h.audit(c, ctx, entity.AuditActionUpdate, "product", res.ID, before)
 	} else {
 
 		res, err = h.Product.Create(ctx, &entity.Product{
@@ -98,12 +90,11 @@ func (h *HandlerV1) CreateProduct(c *gin.Context) {
 			Description:   body.Description,
 		})
 		if err != nil {
-			c.JSON(http.StatusInternalServerError, models.Error{
-				Message: models.InternalMessage,
-			})
-			h.Logger.Error(err.Error())
+			h.fail(c, err)
 			return
 		}
+
+		h.audit(c, ctx, entity.AuditActionCreate, "product", res.ID, nil)
 	}
 
 	c.JSON(http.StatusCreated, &models.ProductRes{
@@ -123,7 +114,9 @@ func (h *HandlerV1) CreateProduct(c *gin.Context) {
 // @Produce json
 // @Param id path string true "Product ID"
 // @Success 200 {object} models.ProductRes
+// @Header 200 {string} ETag "Version of the entity"
 // @Failure 400 {object} models.Error
+// @Failure 404 {object} models.Error
 // @Failure 500 {object} models.Error
 // @Router /v1/products/{id} [get]
 func (h *HandlerV1) GetProduct(c *gin.Context) {
@@ -140,13 +133,11 @@ func (h *HandlerV1) GetProduct(c *gin.Context) {
 
 	res, err := h.Product.Get(ctx, map[string]string{"id": id})
 	if err != nil {
-		c.JSON(http.StatusBadRequest, models.Error{
-			Message: models.WrongInfoMessage,
-		})
-		h.Logger.Error(err.Error())
+		h.fail(c, err)
 		return
 	}
 
+	c.Header("ETag", etag(res.Version))
 	c.JSON(http.StatusOK, &models.ProductRes{
 		Id:            res.ID,
 		ProductName:   res.Name,
@@ -163,6 +154,7 @@ func (h *HandlerV1) GetProduct(c *gin.Context) {
 // @Accept json
 // @Produce json
 // @Param request query models.Pagination true "request"
+// @Param request query models.ListQuery false "request"
 // @Param request query models.ProductFieldValues true "request"
 // @Success 200 {object} models.ListProductsRes
 // @Failure 400 {object} models.Error
@@ -179,28 +171,13 @@ func (h *HandlerV1) ListProduct(c *gin.Context) {
 	queryParams := c.Request.URL.Query()
 	params, errStr := utils.ParseQueryParam(queryParams)
 	if errStr != nil {
-		c.JSON(http.StatusBadRequest, models.Error{
-			Message: models.WrongInfoMessage,
-		})
+		h.fail(c, badQuery(errStr))
 		return
 	}
 
-	println(params)
-
-	name := c.Query("name")
-	union := c.Query("union")
-
-	mapP := map[string]interface{}{
-		"name":  name,
-		"union": union,
-	}
-
-	res, err := h.Product.List(ctx, params.Page, params.Limit, mapP)
+	res, err := h.Product.List(ctx, params.Page, params.Limit, listRequest(params))
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, models.Error{
-			Message: models.InternalMessage,
-		})
-		h.Logger.Error(err.Error())
+		h.fail(c, err)
 		return
 	}
 
@@ -229,9 +206,13 @@ func (h *HandlerV1) ListProduct(c *gin.Context) {
 // @Accept json
 // @Produce json
 // @Param Product body models.ProductRes true "createModel"
+// @Param If-Match header string false "ETag of the version being changed"
 // @Success 200 {object} models.ProductRes
 // @Failure 400 {object} models.Error
+// @Failure 404 {object} models.Error
+// @Failure 409 {object} models.Error
 // @Failure 500 {object} models.Error
+// @Failure 412 {object} models.Error
 // @Router /v1/products [put]
 func (h *HandlerV1) UpdateProduct(c *gin.Context) {
 	ctx, span := otlp.Start(c, "api", "UpdateUser")
@@ -247,26 +228,34 @@ func (h *HandlerV1) UpdateProduct(c *gin.Context) {
 
 	err := c.ShouldBindJSON(&body)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, models.Error{
-			Message: models.WrongInfoMessage,
-		})
-		h.Logger.Error("failed to bind json", l.Error(err))
+		h.fail(c, errorspkg.NewErrBadRequest(err))
+		return
+	}
+
+	version, ok := h.ifMatch(c, ctx, "product", body.Id)
+	if !ok {
 		return
 	}
 
+	before := h.auditBefore(ctx, "product", body.Id)
+
 	res, err := h.Product.Update(ctx, &entity.Product{
 		ID:            body.Id,
 		Name:          body.ProductName,
 		Union:         body.Union,
 		TotalCapacity: int64(body.TotalCapacity),
 		Description:   body.Description,
+		Version:       version,
 	})
 	if err != nil {
-		c.JSON(500, models.InternalMessage)
-		h.Logger.Error(err.Error())
+		h.fail(c, err)
 		return
 	}
 
+	h.audit(c, ctx, entity.AuditActionUpdate, "product", body.Id, before)
+
+	c.Header("ETag", etag(res.Version))
+
 	c.JSON(http.StatusOK, &models.ProductRes{
 		Id:            res.ID,
 		ProductName:   res.Name,
@@ -283,9 +272,12 @@ func (h *HandlerV1) UpdateProduct(c *gin.Context) {
 // @Accept json
 // @Produce json
 // @Param id query string true "ID"
+// @Param If-Match header string false "ETag of the version being changed"
 // @Success 200 {object} models.Result
 // @Failure 400 {object} models.Error
+// @Failure 404 {object} models.Error
 // @Failure 500 {object} models.Error
+// @Failure 412 {object} models.Error
 // @Router /v1/products/{id} [delete]
 func (h *HandlerV1) DeleteProduct(c *gin.Context) {
 	ctx, span := otlp.Start(c, "api", "DeleteAnimal")
@@ -299,20 +291,25 @@ func (h *HandlerV1) DeleteProduct(c *gin.Context) {
 
 	_, err := h.Product.Get(ctx, map[string]string{"id": id})
 	if err != nil {
-		c.JSON(http.StatusBadRequest, models.Error{
-			Message: models.NotAvailable,
-		})
-		h.Logger.Error("failed to get product in delete", l.Error(err))
+		h.fail(c, err)
+		return
+	}
+
+	version, ok := h.ifMatch(c, ctx, "product", id)
+	if !ok {
 		return
 	}
 
-	err = h.Product.Delete(ctx, id)
+	before := h.auditBefore(ctx, "product", id)
+
+	err = h.Product.Delete(ctx, id, version)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, models.InternalMessage)
-		h.Logger.Error("failed to delete product", l.Error(err))
+		h.fail(c, err)
 		return
 	}
 
+	h.audit(c, ctx, entity.AuditActionDelete, "product", id, before)
+
 	c.JSON(http.StatusOK, &models.Result{
 		Message: "Product has been deleted",
 	})
@@ -0,0 +1,69 @@
+package v1
+
+import (
+	"musobaqa/farm-competition/api/models"
+	errorspkg "musobaqa/farm-competition/internal/errors"
+	"musobaqa/farm-competition/internal/pkg/otlp"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// LIST REPORT SNAPSHOTS
+// @Summary LIST REPORT SNAPSHOTS
+// @Description Api for List the daily snapshots of the inventory-valuation, feed-cost and spend reports between two days
+// @Tags REPORT
+// @Accept json
+// @Produce json
+// @Param request query models.ReportSnapshotFieldValues false "request"
+// @Success 200 {object} models.ListReportSnapshotsRes
+// @Failure 400 {object} models.Error
+// @Failure 500 {object} models.Error
+// @Router /v1/reports/snapshots [get]
+func (h *HandlerV1) ListReportSnapshots(c *gin.Context) {
+	ctx, span := otlp.Start(c, "api", "ListReportSnapshots")
+	span.SetAttributes(
+		attribute.Key("method").String(c.Request.Method),
+		attribute.Key("host").String(c.Request.Host),
+	)
+	defer span.End()
+
+	from := c.Query("from")
+	to := c.Query("to")
+	for _, date := range []string{from, to} {
+		if date == "" {
+			continue
+		}
+		if _, err := time.Parse(time.DateOnly, date); err != nil {
+			h.fail(c, errorspkg.NewErrBadRequest(err))
+			return
+		}
+	}
+
+	res, err := h.Reports.Snapshots(ctx, map[string]interface{}{
+		"report": c.Query("report"),
+		"from":   from,
+		"to":     to,
+	})
+	if err != nil {
+		h.fail(c, err)
+		return
+	}
+
+	response := models.ListReportSnapshotsRes{
+		Snapshots: []*models.ReportSnapshotRes{},
+	}
+	for _, i := range res {
+		response.Snapshots = append(response.Snapshots, &models.ReportSnapshotRes{
+			ID:        i.ID,
+			Report:    i.Report,
+			Day:       i.Day,
+			Data:      i.Data,
+			CreatedAt: i.CreatedAt.Format(time.RFC3339),
+		})
+	}
+
+	c.JSON(http.StatusOK, &response)
+}
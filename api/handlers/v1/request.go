@@ -4,24 +4,12 @@ import (
 	"musobaqa/farm-competition/internal/pkg/config"
 	tokens "musobaqa/farm-competition/internal/pkg/token"
 	"net/http"
-	"strings"
 
 	"github.com/spf13/cast"
 )
 
 func GetIdFromToken(r *http.Request, cfg *config.Config) (string, int) {
-	var softToken string
-	token := r.Header.Get("Authorization")
-
-	if token == "" {
-		return "unauthorized", http.StatusUnauthorized
-	} else if strings.Contains(token, "Bearer") {
-		softToken = strings.TrimPrefix(token, "Bearer ")
-	} else {
-		softToken = token
-	}
-
-	claims, err := tokens.ExtractClaim(softToken, []byte(cfg.Token.SignInKey))
+	claims, err := tokens.ExtractBearer(r.Header.Get("Authorization"), []byte(cfg.Token.SignInKey))
 	if err != nil {
 		return "unauthorized", http.StatusUnauthorized
 	}
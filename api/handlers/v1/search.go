@@ -0,0 +1,77 @@
+package v1
+
+import (
+	"musobaqa/farm-competition/api/models"
+	"musobaqa/farm-competition/internal/pkg/otlp"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/spf13/cast"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+const (
+	searchDefaultLimit = 20
+	searchMaxLimit     = 100
+)
+
+// SEARCH
+// @Summary SEARCH
+// @Description Api for Search animals, products, foods and drugs at once, best matches first. Words match as prefixes and similar names match too
+// @Tags SEARCH
+// @Accept json
+// @Produce json
+// @Param request query models.SearchFieldValues true "request"
+// @Success 200 {object} models.SearchRes
+// @Failure 400 {object} models.Error
+// @Failure 500 {object} models.Error
+// @Router /v1/search [get]
+func (h *HandlerV1) SearchEntities(c *gin.Context) {
+	ctx, span := otlp.Start(c, "api", "SearchEntities")
+	span.SetAttributes(
+		attribute.Key("method").String(c.Request.Method),
+		attribute.Key("host").String(c.Request.Host),
+	)
+	defer span.End()
+
+	text := strings.TrimSpace(c.Query("q"))
+	if text == "" {
+		h.fail(c, badRequest("q is required"))
+		return
+	}
+
+	var entityTypes []string
+	if types := c.Query("type"); types != "" {
+		entityTypes = strings.Split(types, ",")
+	}
+
+	limit := cast.ToUint64(c.Query("limit"))
+	if limit == 0 {
+		limit = searchDefaultLimit
+	}
+	if limit > searchMaxLimit {
+		limit = searchMaxLimit
+	}
+
+	hits, err := h.Search.Search(ctx, text, entityTypes, limit)
+	if err != nil {
+		h.fail(c, err)
+		return
+	}
+
+	response := models.SearchRes{
+		Hits: []*models.SearchHitRes{},
+	}
+	for _, i := range hits {
+		response.Hits = append(response.Hits, &models.SearchHitRes{
+			EntityType: i.EntityType,
+			ID:         i.ID,
+			Name:       i.Name,
+			Label:      i.Label,
+			Rank:       i.Rank,
+		})
+	}
+
+	c.JSON(http.StatusOK, &response)
+}
@@ -0,0 +1,394 @@
+package v1
+
+import (
+	"musobaqa/farm-competition/api/models"
+	"musobaqa/farm-competition/internal/entity"
+	errorspkg "musobaqa/farm-competition/internal/errors"
+	"musobaqa/farm-competition/internal/pkg/otlp"
+	"musobaqa/farm-competition/internal/pkg/utils"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// CREATE SUPPLIER
+// @Summary CREATE SUPPLIER
+// @Description Api for Create new supplier
+// @Tags SUPPLIER
+// @Accept json
+// @Produce json
+// @Param Supplier body models.SupplierReq true "createModel"
+// @Param Idempotency-Key header string false "Key that makes retries of the request safe"
+// @Success 201 {object} models.SupplierRes
+// @Failure 400 {object} models.Error
+// @Failure 409 {object} models.Error
+// @Failure 422 {object} models.Error
+// @Failure 500 {object} models.Error
+// @Router /v1/suppliers [post]
+func (h *HandlerV1) CreateSupplier(c *gin.Context) {
+	ctx, span := otlp.Start(c, "api", "CreateSupplier")
+	span.SetAttributes(
+		attribute.Key("method").String(c.Request.Method),
+		attribute.Key("host").String(c.Request.Host),
+	)
+	defer span.End()
+
+	var (
+		body models.SupplierReq
+	)
+
+	err := c.ShouldBindJSON(&body)
+	if err != nil {
+		h.fail(c, errorspkg.NewErrBadRequest(err))
+		return
+	}
+
+	err = body.Validate()
+	if err != nil {
+		h.fail(c, err)
+		return
+	}
+
+	res, err := h.Supplier.Create(ctx, &entity.Supplier{
+		Name:          body.Name,
+		ContactPerson: body.ContactPerson,
+		Phone:         body.Phone,
+		Email:         body.Email,
+		Address:       body.Address,
+		Description:   body.Description,
+	})
+	if err != nil {
+		h.fail(c, err)
+		return
+	}
+
+	h.audit(c, ctx, entity.AuditActionCreate, "supplier", res.ID, nil)
+
+	c.JSON(http.StatusCreated, supplierResponse(res))
+}
+
+// GET SUPPLIER
+// @Summary GET SUPPLIER BY ID
+// @Description Api for Get supplier by ID
+// @Tags SUPPLIER
+// @Accept json
+// @Produce json
+// @Param id path string true "Supplier ID"
+// @Success 200 {object} models.SupplierRes
+// @Header 200 {string} ETag "Version of the entity"
+// @Failure 400 {object} models.Error
+// @Failure 404 {object} models.Error
+// @Failure 500 {object} models.Error
+// @Router /v1/suppliers/{id} [get]
+func (h *HandlerV1) GetSupplier(c *gin.Context) {
+	ctx, span := otlp.Start(c, "api", "GetSupplier")
+	span.SetAttributes(
+		attribute.Key("method").String(c.Request.Method),
+		attribute.Key("host").String(c.Request.Host),
+	)
+	defer span.End()
+
+	id := c.Param("id")
+
+	res, err := h.Supplier.Get(ctx, id)
+	if err != nil {
+		h.fail(c, err)
+		return
+	}
+
+	c.Header("ETag", etag(res.Version))
+	c.JSON(http.StatusOK, supplierResponse(res))
+}
+
+// LIST SUPPLIERS
+// @Summary LIST SUPPLIERS
+// @Description Api for List suppliers by page limit and extra values
+// @Tags SUPPLIER
+// @Accept json
+// @Produce json
+// @Param request query models.Pagination true "request"
+// @Param request query models.ListQuery false "request"
+// @Param request query models.SupplierFieldValues true "request"
+// @Success 200 {object} models.ListSuppliersRes
+// @Failure 400 {object} models.Error
+// @Failure 500 {object} models.Error
+// @Router /v1/suppliers [get]
+func (h *HandlerV1) ListSuppliers(c *gin.Context) {
+	ctx, span := otlp.Start(c, "api", "ListSuppliers")
+	span.SetAttributes(
+		attribute.Key("method").String(c.Request.Method),
+		attribute.Key("host").String(c.Request.Host),
+	)
+	defer span.End()
+
+	queryParams := c.Request.URL.Query()
+	params, errStr := utils.ParseQueryParam(queryParams)
+	if errStr != nil {
+		h.fail(c, badQuery(errStr))
+		return
+	}
+
+	res, err := h.Supplier.List(ctx, params.Page, params.Limit, listRequest(params))
+	if err != nil {
+		h.fail(c, err)
+		return
+	}
+
+	var resList []*models.SupplierRes
+	for _, i := range res.Suppliers {
+		resList = append(resList, supplierResponse(i))
+	}
+
+	c.JSON(http.StatusOK, &models.ListSuppliersRes{
+		Suppliers: resList,
+		Count:     int64(res.TotalCount),
+	})
+}
+
+// UPDATE
+// @Summary UPDATE SUPPLIER
+// @Description Api for Update supplier by supplier id
+// @Tags SUPPLIER
+// @Accept json
+// @Produce json
+// @Param Supplier body models.SupplierRes true "updateModel"
+// @Param If-Match header string false "ETag of the version being changed"
+// @Success 200 {object} models.SupplierRes
+// @Failure 400 {object} models.Error
+// @Failure 404 {object} models.Error
+// @Failure 409 {object} models.Error
+// @Failure 500 {object} models.Error
+// @Failure 412 {object} models.Error
+// @Router /v1/suppliers [put]
+func (h *HandlerV1) UpdateSupplier(c *gin.Context) {
+	ctx, span := otlp.Start(c, "api", "UpdateSupplier")
+	span.SetAttributes(
+		attribute.Key("method").String(c.Request.Method),
+		attribute.Key("host").String(c.Request.Host),
+	)
+	defer span.End()
+
+	var (
+		body models.SupplierRes
+	)
+
+	err := c.ShouldBindJSON(&body)
+	if err != nil {
+		h.fail(c, errorspkg.NewErrBadRequest(err))
+		return
+	}
+
+	version, ok := h.ifMatch(c, ctx, "supplier", body.Id)
+	if !ok {
+		return
+	}
+
+	before := h.auditBefore(ctx, "supplier", body.Id)
+
+	res, err := h.Supplier.Update(ctx, &entity.Supplier{
+		ID:            body.Id,
+		Name:          body.Name,
+		ContactPerson: body.ContactPerson,
+		Phone:         body.Phone,
+		Email:         body.Email,
+		Address:       body.Address,
+		Description:   body.Description,
+		Version:       version,
+	})
+	if err != nil {
+		h.fail(c, err)
+		return
+	}
+
+	h.audit(c, ctx, entity.AuditActionUpdate, "supplier", body.Id, before)
+
+	c.Header("ETag", etag(res.Version))
+
+	c.JSON(http.StatusOK, supplierResponse(res))
+}
+
+// DELETE
+// @Summary DELETE SUPPLIER
+// @Description Api for Delete supplier by supplier ID
+// @Tags SUPPLIER
+// @Accept json
+// @Produce json
+// @Param id path string true "Supplier ID"
+// @Param If-Match header string false "ETag of the version being changed"
+// @Success 200 {object} models.Result
+// @Failure 400 {object} models.Error
+// @Failure 404 {object} models.Error
+// @Failure 500 {object} models.Error
+// @Failure 412 {object} models.Error
+// @Router /v1/suppliers/{id} [delete]
+func (h *HandlerV1) DeleteSupplier(c *gin.Context) {
+	ctx, span := otlp.Start(c, "api", "DeleteSupplier")
+	span.SetAttributes(
+		attribute.Key("method").String(c.Request.Method),
+		attribute.Key("host").String(c.Request.Host),
+	)
+	defer span.End()
+
+	id := c.Param("id")
+
+	_, err := h.Supplier.Get(ctx, id)
+	if err != nil {
+		h.fail(c, err)
+		return
+	}
+
+	version, ok := h.ifMatch(c, ctx, "supplier", id)
+	if !ok {
+		return
+	}
+
+	before := h.auditBefore(ctx, "supplier", id)
+
+	err = h.Supplier.Delete(ctx, id, version)
+	if err != nil {
+		h.fail(c, err)
+		return
+	}
+
+	h.audit(c, ctx, entity.AuditActionDelete, "supplier", id, before)
+
+	c.JSON(http.StatusOK, &models.Result{
+		Message: "Supplier has been deleted",
+	})
+}
+
+// LIST SUPPLIER DELIVERIES
+// @Summary LIST SUPPLIER PURCHASE HISTORY
+// @Description Api for List deliveries received from the supplier
+// @Tags SUPPLIER
+// @Accept json
+// @Produce json
+// @Param id path string true "Supplier ID"
+// @Param request query models.Pagination true "request"
+// @Param request query models.ListQuery false "request"
+// @Success 200 {object} models.ListDeliverysRes
+// @Failure 400 {object} models.Error
+// @Failure 500 {object} models.Error
+// @Router /v1/suppliers/{id}/deliveries [get]
+func (h *HandlerV1) ListSupplierDeliveries(c *gin.Context) {
+	ctx, span := otlp.Start(c, "api", "ListSupplierDeliveries")
+	span.SetAttributes(
+		attribute.Key("method").String(c.Request.Method),
+		attribute.Key("host").String(c.Request.Host),
+	)
+	defer span.End()
+
+	queryParams := c.Request.URL.Query()
+	params, errStr := utils.ParseQueryParam(queryParams)
+	if errStr != nil {
+		h.fail(c, badQuery(errStr))
+		return
+	}
+
+	id := c.Param("id")
+
+	_, err := h.Supplier.Get(ctx, id)
+	if err != nil {
+		h.fail(c, err)
+		return
+	}
+
+	params.Filters["supplier_id"] = id
+
+	res, err := h.Delivery.List(ctx, params.Page, params.Limit, listRequest(params))
+	if err != nil {
+		h.fail(c, err)
+		return
+	}
+
+	var resList []*models.DeliveryRes
+	for _, i := range res.Deliveries {
+		resList = append(resList, deliveryResponse(i))
+	}
+
+	c.JSON(http.StatusOK, &models.ListDeliverysRes{
+		Delivery:   resList,
+		Count:      res.TotalCount,
+		NextCursor: res.NextCursor,
+	})
+}
+
+// SPEND REPORT
+// @Summary SPEND REPORT
+// @Description Api for Delivery spend grouped by supplier, item or month
+// @Tags REPORT
+// @Accept json
+// @Produce json
+// @Param request query models.SpendReportFieldValues true "request"
+// @Success 200 {object} models.SpendReportRes
+// @Failure 400 {object} models.Error
+// @Failure 500 {object} models.Error
+// @Router /v1/reports/spend [get]
+func (h *HandlerV1) SpendReport(c *gin.Context) {
+	ctx, span := otlp.Start(c, "api", "SpendReport")
+	span.SetAttributes(
+		attribute.Key("method").String(c.Request.Method),
+		attribute.Key("host").String(c.Request.Host),
+	)
+	defer span.End()
+
+	groupBy := c.DefaultQuery("group_by", "supplier")
+	if groupBy != "supplier" && groupBy != "item" && groupBy != "month" {
+		h.fail(c, badRequest("group_by must be supplier, item or month"))
+		return
+	}
+
+	from := c.Query("from")
+	to := c.Query("to")
+	for _, date := range []string{from, to} {
+		if date == "" {
+			continue
+		}
+		if _, err := time.Parse(time.DateOnly, date); err != nil {
+			h.fail(c, errorspkg.NewErrBadRequest(err))
+			return
+		}
+	}
+
+	res, err := h.Supplier.SpendReport(ctx, groupBy, map[string]interface{}{
+		"from":        from,
+		"to":          to,
+		"supplier_id": c.Query("supplier_id"),
+		"category":    c.Query("category"),
+	})
+	if err != nil {
+		h.fail(c, err)
+		return
+	}
+
+	response := models.SpendReportRes{
+		GroupBy:   res.GroupBy,
+		TotalCost: res.TotalCost,
+		Items:     []*models.SpendReportItemRes{},
+	}
+	for _, i := range res.Items {
+		response.Items = append(response.Items, &models.SpendReportItemRes{
+			Key:        i.Key,
+			Label:      i.Label,
+			Capacity:   i.Capacity,
+			TotalCost:  i.TotalCost,
+			Deliveries: i.Deliveries,
+		})
+	}
+
+	c.JSON(http.StatusOK, &response)
+}
+
+func supplierResponse(supplier *entity.Supplier) *models.SupplierRes {
+	return &models.SupplierRes{
+		Id:            supplier.ID,
+		Name:          supplier.Name,
+		ContactPerson: supplier.ContactPerson,
+		Phone:         supplier.Phone,
+		Email:         supplier.Email,
+		Address:       supplier.Address,
+		Description:   supplier.Description,
+	}
+}
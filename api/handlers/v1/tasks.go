@@ -0,0 +1,331 @@
+package v1
+
+import (
+	"musobaqa/farm-competition/api/models"
+	"musobaqa/farm-competition/internal/entity"
+	errorspkg "musobaqa/farm-competition/internal/errors"
+	"musobaqa/farm-competition/internal/pkg/otlp"
+	"musobaqa/farm-competition/internal/pkg/utils"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// LIST TODAY FEEDING TASKS
+// @Summary LIST TODAY FEEDING TASKS
+// @Description Api for List the feeding tasks of today in the order they are due, by status, eatable category, animal and animal category
+// @Tags FEEDING TASK
+// @Accept json
+// @Produce json
+// @Param request query models.Pagination true "request"
+// @Param request query models.FeedingTaskFieldValues false "request"
+// @Success 200 {object} models.ListFeedingTasksRes
+// @Failure 400 {object} models.Error
+// @Failure 500 {object} models.Error
+// @Router /v1/feeding-tasks/today [get]
+func (h *HandlerV1) TodayFeedingTasks(c *gin.Context) {
+	h.listFeedingTasks(c, "TodayFeedingTasks", time.Now().Format(time.DateOnly))
+}
+
+// LIST FEEDING TASKS
+// @Summary LIST FEEDING TASKS
+// @Description Api for List the feeding tasks of any day in the order they are due, by day, status, eatable category, animal and animal category
+// @Tags FEEDING TASK
+// @Accept json
+// @Produce json
+// @Param request query models.Pagination true "request"
+// @Param request query models.FeedingTaskFieldValues false "request"
+// @Success 200 {object} models.ListFeedingTasksRes
+// @Failure 400 {object} models.Error
+// @Failure 500 {object} models.Error
+// @Router /v1/feeding-tasks [get]
+func (h *HandlerV1) ListFeedingTasks(c *gin.Context) {
+	h.listFeedingTasks(c, "ListFeedingTasks", c.Query("day"))
+}
+
+func (h *HandlerV1) listFeedingTasks(c *gin.Context, name, day string) {
+	ctx, span := otlp.Start(c, "api", name)
+	span.SetAttributes(
+		attribute.Key("method").String(c.Request.Method),
+		attribute.Key("host").String(c.Request.Host),
+	)
+	defer span.End()
+
+	queryParams := c.Request.URL.Query()
+	params, errStr := utils.ParseQueryParam(queryParams)
+	if errStr != nil {
+		h.fail(c, badQuery(errStr))
+		return
+	}
+
+	if day != "" {
+		if _, err := time.Parse(time.DateOnly, day); err != nil {
+			h.fail(c, errorspkg.NewErrBadRequest(err))
+			return
+		}
+	}
+
+	res, err := h.FeedingTask.List(ctx, params.Page, params.Limit, map[string]interface{}{
+		"day":             day,
+		"status":          c.Query("status"),
+		"category":        c.Query("category"),
+		"animal_id":       c.Query("animal_id"),
+		"animal_category": c.Query("animal_category"),
+	})
+	if err != nil {
+		h.fail(c, err)
+		return
+	}
+
+	response := models.ListFeedingTasksRes{
+		Tasks: []*models.FeedingTaskRes{},
+		Count: int64(res.TotalCount),
+	}
+	for _, i := range res.Tasks {
+		response.Tasks = append(response.Tasks, feedingTaskResponse(i))
+	}
+
+	c.JSON(http.StatusOK, &response)
+}
+
+// GET FEEDING TASK
+// @Summary GET FEEDING TASK BY ID
+// @Description Api for Get feeding task by ID
+// @Tags FEEDING TASK
+// @Accept json
+// @Produce json
+// @Param id path string true "Task ID"
+// @Success 200 {object} models.FeedingTaskRes
+// @Header 200 {string} ETag "Version of the entity"
+// @Failure 400 {object} models.Error
+// @Failure 404 {object} models.Error
+// @Failure 500 {object} models.Error
+// @Router /v1/feeding-tasks/{id} [get]
+func (h *HandlerV1) GetFeedingTask(c *gin.Context) {
+	ctx, span := otlp.Start(c, "api", "GetFeedingTask")
+	span.SetAttributes(
+		attribute.Key("method").String(c.Request.Method),
+		attribute.Key("host").String(c.Request.Host),
+	)
+	defer span.End()
+
+	res, err := h.FeedingTask.Get(ctx, c.Param("id"))
+	if err != nil {
+		h.fail(c, err)
+		return
+	}
+
+	c.Header("ETag", etag(res.Version))
+	c.JSON(http.StatusOK, feedingTaskResponse(res))
+}
+
+// COMPLETE FEEDING TASK
+// @Summary COMPLETE FEEDING TASK
+// @Description Api for Mark a feeding task done, it records the given eatables with the planned capacity unless actual_capacity is given
+// @Tags FEEDING TASK
+// @Accept json
+// @Produce json
+// @Param id path string true "Task ID"
+// @Param Done body models.FeedingTaskDoneReq false "doneModel"
+// @Param If-Match header string false "ETag of the version being changed"
+// @Param Idempotency-Key header string false "Key that makes retries of the request safe"
+// @Success 200 {object} models.FeedingTaskRes
+// @Failure 400 {object} models.Error
+// @Failure 404 {object} models.Error
+// @Failure 409 {object} models.Error
+// @Failure 412 {object} models.Error
+// @Failure 422 {object} models.Error
+// @Failure 500 {object} models.Error
+// @Router /v1/feeding-tasks/{id}/done [post]
+func (h *HandlerV1) CompleteFeedingTask(c *gin.Context) {
+	ctx, span := otlp.Start(c, "api", "CompleteFeedingTask")
+	span.SetAttributes(
+		attribute.Key("method").String(c.Request.Method),
+		attribute.Key("host").String(c.Request.Host),
+	)
+	defer span.End()
+
+	var (
+		body models.FeedingTaskDoneReq
+		id   = c.Param("id")
+	)
+
+	// the body is optional, the planned capacity is given without one
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&body); err != nil {
+			h.fail(c, errorspkg.NewErrBadRequest(err))
+			return
+		}
+	}
+
+	if err := body.Validate(); err != nil {
+		h.fail(c, err)
+		return
+	}
+
+	version, ok := h.ifMatch(c, ctx, "feeding_task", id)
+	if !ok {
+		return
+	}
+
+	before := h.auditBefore(ctx, "feeding_task", id)
+
+	res, err := h.FeedingTask.Done(ctx, &entity.FeedingTask{
+		ID:             id,
+		ActualCapacity: body.ActualCapacity,
+		CompletedBy:    h.actor(c),
+		Version:        version,
+	})
+	if err != nil {
+		h.fail(c, err)
+		return
+	}
+
+	h.audit(c, ctx, entity.AuditActionCreate, "given_eatable", res.GivenEatablesID, nil)
+	h.audit(c, ctx, entity.AuditActionUpdate, "feeding_task", res.ID, before)
+
+	c.Header("ETag", etag(res.Version))
+	c.JSON(http.StatusOK, feedingTaskResponse(res))
+}
+
+// SKIP FEEDING TASK
+// @Summary SKIP FEEDING TASK
+// @Description Api for Skip a feeding task, the reason is required
+// @Tags FEEDING TASK
+// @Accept json
+// @Produce json
+// @Param id path string true "Task ID"
+// @Param Skip body models.FeedingTaskSkipReq true "skipModel"
+// @Param If-Match header string false "ETag of the version being changed"
+// @Success 200 {object} models.FeedingTaskRes
+// @Failure 400 {object} models.Error
+// @Failure 404 {object} models.Error
+// @Failure 409 {object} models.Error
+// @Failure 412 {object} models.Error
+// @Failure 422 {object} models.Error
+// @Failure 500 {object} models.Error
+// @Router /v1/feeding-tasks/{id}/skip [post]
+func (h *HandlerV1) SkipFeedingTask(c *gin.Context) {
+	ctx, span := otlp.Start(c, "api", "SkipFeedingTask")
+	span.SetAttributes(
+		attribute.Key("method").String(c.Request.Method),
+		attribute.Key("host").String(c.Request.Host),
+	)
+	defer span.End()
+
+	var (
+		body models.FeedingTaskSkipReq
+		id   = c.Param("id")
+	)
+
+	err := c.ShouldBindJSON(&body)
+	if err != nil {
+		h.fail(c, errorspkg.NewErrBadRequest(err))
+		return
+	}
+
+	err = body.Validate()
+	if err != nil {
+		h.fail(c, err)
+		return
+	}
+
+	version, ok := h.ifMatch(c, ctx, "feeding_task", id)
+	if !ok {
+		return
+	}
+
+	before := h.auditBefore(ctx, "feeding_task", id)
+
+	res, err := h.FeedingTask.Skip(ctx, &entity.FeedingTask{
+		ID:          id,
+		SkipReason:  body.Reason,
+		CompletedBy: h.actor(c),
+		Version:     version,
+	})
+	if err != nil {
+		h.fail(c, err)
+		return
+	}
+
+	h.audit(c, ctx, entity.AuditActionUpdate, "feeding_task", res.ID, before)
+
+	c.Header("ETag", etag(res.Version))
+	c.JSON(http.StatusOK, feedingTaskResponse(res))
+}
+
+// GENERATE FEEDING TASKS
+// @Summary GENERATE FEEDING TASKS
+// @Description Api for Add the missing feeding tasks of a day from the daily plans, the feeding-tasks job does it for today
+// @Tags FEEDING TASK
+// @Accept json
+// @Produce json
+// @Param Generate body models.GenerateFeedingTasksReq true "generateModel"
+// @Success 200 {object} models.GenerateFeedingTasksRes
+// @Failure 400 {object} models.Error
+// @Failure 422 {object} models.Error
+// @Failure 500 {object} models.Error
+// @Router /v1/feeding-tasks/generate [post]
+func (h *HandlerV1) GenerateFeedingTasks(c *gin.Context) {
+	ctx, span := otlp.Start(c, "api", "GenerateFeedingTasks")
+	span.SetAttributes(
+		attribute.Key("method").String(c.Request.Method),
+		attribute.Key("host").String(c.Request.Host),
+	)
+	defer span.End()
+
+	var (
+		body models.GenerateFeedingTasksReq
+	)
+
+	err := c.ShouldBindJSON(&body)
+	if err != nil {
+		h.fail(c, errorspkg.NewErrBadRequest(err))
+		return
+	}
+
+	err = body.Validate()
+	if err != nil {
+		h.fail(c, err)
+		return
+	}
+
+	day, _ := time.Parse(time.DateOnly, body.Day)
+	generated, err := h.FeedingTask.Generate(ctx, day)
+	if err != nil {
+		h.fail(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, &models.GenerateFeedingTasksRes{
+		Day:       body.Day,
+		Generated: generated,
+	})
+}
+
+func feedingTaskResponse(task *entity.FeedingTask) *models.FeedingTaskRes {
+	response := models.FeedingTaskRes{
+		ID:              task.ID,
+		AnimalID:        task.AnimalID,
+		AnimalName:      task.AnimalName,
+		AnimalCategory:  task.AnimalCategory,
+		EatablesID:      task.EatablesID,
+		Category:        task.Category,
+		Name:            task.Name,
+		Union:           task.Union,
+		Day:             task.Day,
+		Time:            task.Slot,
+		Capacity:        task.Capacity,
+		Status:          task.Status,
+		ActualCapacity:  task.ActualCapacity,
+		SkipReason:      task.SkipReason,
+		GivenEatablesID: task.GivenEatablesID,
+		CompletedBy:     task.CompletedBy,
+	}
+	if task.CompletedAt != nil {
+		response.CompletedAt = task.CompletedAt.Format(time.RFC3339)
+	}
+	return &response
+}
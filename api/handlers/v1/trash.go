@@ -0,0 +1,133 @@
+package v1
+
+import (
+	"musobaqa/farm-competition/api/models"
+	"musobaqa/farm-competition/internal/entity"
+	"musobaqa/farm-competition/internal/pkg/otlp"
+	"musobaqa/farm-competition/internal/pkg/utils"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// LIST TRASH
+// @Summary LIST TRASH
+// @Description Api for List deleted entities of the given type, last deleted first
+// @Tags TRASH
+// @Accept json
+// @Produce json
+// @Param entity_type path string true "Entity type, e.g. animal"
+// @Param request query models.Pagination true "request"
+// @Success 200 {object} models.ListTrashRes
+// @Failure 400 {object} models.Error
+// @Failure 500 {object} models.Error
+// @Router /v1/trash/{entity_type} [get]
+func (h *HandlerV1) ListTrash(c *gin.Context) {
+	ctx, span := otlp.Start(c, "api", "ListTrash")
+	span.SetAttributes(
+		attribute.Key("method").String(c.Request.Method),
+		attribute.Key("host").String(c.Request.Host),
+	)
+	defer span.End()
+
+	queryParams := c.Request.URL.Query()
+	params, errStr := utils.ParseQueryParam(queryParams)
+	if errStr != nil {
+		h.fail(c, badQuery(errStr))
+		return
+	}
+
+	res, err := h.Trash.List(ctx, c.Param("entity_type"), params.Page, params.Limit)
+	if err != nil {
+		h.fail(c, err)
+		return
+	}
+
+	response := models.ListTrashRes{
+		Items: []*models.TrashItemRes{},
+		Count: int64(res.TotalCount),
+	}
+	for _, i := range res.Items {
+		response.Items = append(response.Items, &models.TrashItemRes{
+			EntityType: i.EntityType,
+			ID:         i.ID,
+			DeletedAt:  i.DeletedAt.Format(time.RFC3339),
+			Data:       i.Data,
+		})
+	}
+
+	c.JSON(http.StatusOK, &response)
+}
+
+// RESTORE FROM TRASH
+// @Summary RESTORE FROM TRASH
+// @Description Api for Restore a deleted entity with the rows deleted together with it
+// @Tags TRASH
+// @Accept json
+// @Produce json
+// @Param entity_type path string true "Entity type, e.g. animal"
+// @Param id path string true "Entity ID"
+// @Param Idempotency-Key header string false "Key that makes retries of the request safe"
+// @Success 200 {object} models.Result
+// @Failure 400 {object} models.Error
+// @Failure 404 {object} models.Error
+// @Failure 409 {object} models.Error
+// @Failure 500 {object} models.Error
+// @Router /v1/trash/{entity_type}/{id}/restore [post]
+func (h *HandlerV1) RestoreFromTrash(c *gin.Context) {
+	ctx, span := otlp.Start(c, "api", "RestoreFromTrash")
+	span.SetAttributes(
+		attribute.Key("method").String(c.Request.Method),
+		attribute.Key("host").String(c.Request.Host),
+	)
+	defer span.End()
+
+	var (
+		entityType = c.Param("entity_type")
+		id         = c.Param("id")
+	)
+
+	before := h.auditBefore(ctx, entityType, id)
+
+	err := h.Trash.Restore(ctx, entityType, id)
+	if err != nil {
+		h.fail(c, err)
+		return
+	}
+
+	h.audit(c, ctx, entity.AuditActionRestore, entityType, id, before)
+
+	c.JSON(http.StatusOK, &models.Result{
+		Message: "Entity has been restored",
+	})
+}
+
+// PURGE TRASH
+// @Summary PURGE TRASH
+// @Description Api for Remove for good the rows kept in the trash longer than the retention period
+// @Tags TRASH
+// @Accept json
+// @Produce json
+// @Success 200 {object} models.PurgeTrashRes
+// @Failure 500 {object} models.Error
+// @Router /v1/trash/purge [post]
+func (h *HandlerV1) PurgeTrash(c *gin.Context) {
+	ctx, span := otlp.Start(c, "api", "PurgeTrash")
+	span.SetAttributes(
+		attribute.Key("method").String(c.Request.Method),
+		attribute.Key("host").String(c.Request.Host),
+	)
+	defer span.End()
+
+	purged, err := h.Trash.Purge(ctx)
+	if err != nil {
+		h.fail(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, &models.PurgeTrashRes{
+		Purged: purged,
+	})
+}
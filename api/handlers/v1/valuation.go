@@ -0,0 +1,189 @@
+package v1
+
+import (
+	"musobaqa/farm-competition/api/models"
+	errorspkg "musobaqa/farm-competition/internal/errors"
+	"musobaqa/farm-competition/internal/pkg/costing"
+	"musobaqa/farm-competition/internal/pkg/otlp"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// INVENTORY VALUATION
+// @Summary INVENTORY VALUATION
+// @Description Api for Value food and drug stock by FIFO or weighted average cost
+// @Tags REPORT
+// @Accept json
+// @Produce json
+// @Param request query models.ValuationFieldValues true "request"
+// @Success 200 {object} models.InventoryValuationRes
+// @Failure 400 {object} models.Error
+// @Failure 500 {object} models.Error
+// @Router /v1/reports/inventory-valuation [get]
+func (h *HandlerV1) InventoryValuation(c *gin.Context) {
+	ctx, span := otlp.Start(c, "api", "InventoryValuation")
+	span.SetAttributes(
+		attribute.Key("method").String(c.Request.Method),
+		attribute.Key("host").String(c.Request.Host),
+	)
+	defer span.End()
+
+	method := c.Query("method")
+	if method != "" {
+		if _, err := costing.ParseMethod(method); err != nil {
+			h.fail(c, errorspkg.NewErrBadRequest(err))
+			return
+		}
+	}
+
+	res, err := h.Valuation.Inventory(ctx, method, c.Query("category"))
+	if err != nil {
+		h.fail(c, err)
+		return
+	}
+
+	response := models.InventoryValuationRes{
+		Method:     res.Method,
+		TotalValue: res.TotalValue,
+		Items:      []*models.ValuationItemRes{},
+	}
+	for _, i := range res.Items {
+		response.Items = append(response.Items, &models.ValuationItemRes{
+			Category: i.Category,
+			Name:     i.Name,
+			Quantity: i.Quantity,
+			UnitCost: i.UnitCost,
+			Value:    i.Value,
+		})
+	}
+
+	c.JSON(http.StatusOK, &response)
+}
+
+// FEED COST REPORT
+// @Summary FEED COST REPORT
+// @Description Api for Cost of given eatables grouped by animal or animal category
+// @Tags REPORT
+// @Accept json
+// @Produce json
+// @Param request query models.FeedCostFieldValues true "request"
+// @Success 200 {object} models.FeedCostReportRes
+// @Failure 400 {object} models.Error
+// @Failure 500 {object} models.Error
+// @Router /v1/reports/feed-cost [get]
+func (h *HandlerV1) FeedCostReport(c *gin.Context) {
+	ctx, span := otlp.Start(c, "api", "FeedCostReport")
+	span.SetAttributes(
+		attribute.Key("method").String(c.Request.Method),
+		attribute.Key("host").String(c.Request.Host),
+	)
+	defer span.End()
+
+	method := c.Query("method")
+	if method != "" {
+		if _, err := costing.ParseMethod(method); err != nil {
+			h.fail(c, errorspkg.NewErrBadRequest(err))
+			return
+		}
+	}
+
+	groupBy := c.DefaultQuery("group_by", "animal")
+	if groupBy != "animal" && groupBy != "category" {
+		h.fail(c, badRequest("group_by must be animal or category"))
+		return
+	}
+
+	from := c.Query("from")
+	to := c.Query("to")
+	for _, date := range []string{from, to} {
+		if date == "" {
+			continue
+		}
+		if _, err := time.Parse(time.DateOnly, date); err != nil {
+			h.fail(c, errorspkg.NewErrBadRequest(err))
+			return
+		}
+	}
+
+	res, err := h.Valuation.FeedCostReport(ctx, method, groupBy, map[string]interface{}{
+		"animal_id": c.Query("animal_id"),
+		"category":  c.Query("category"),
+		"from":      from,
+		"to":        to,
+	})
+	if err != nil {
+		h.fail(c, err)
+		return
+	}
+
+	response := models.FeedCostReportRes{
+		Method:    res.Method,
+		GroupBy:   res.GroupBy,
+		TotalCost: res.TotalCost,
+		Items:     []*models.FeedCostItemRes{},
+	}
+	for _, i := range res.Items {
+		response.Items = append(response.Items, &models.FeedCostItemRes{
+			Key:      i.Key,
+			Label:    i.Label,
+			Quantity: i.Quantity,
+			Cost:     i.Cost,
+			Feedings: i.Feedings,
+		})
+	}
+
+	c.JSON(http.StatusOK, &response)
+}
+
+// GIVEN EATABLES COST
+// @Summary GIVEN EATABLES COST
+// @Description Api for Cost of a single given eatables record
+// @Tags ANIMAL GIVEN EATABLES
+// @Accept json
+// @Produce json
+// @Param id path string true "Given eatables ID"
+// @Param method query string false "fifo or weighted_average"
+// @Success 200 {object} models.FeedingCostRes
+// @Failure 400 {object} models.Error
+// @Failure 404 {object} models.Error
+// @Failure 500 {object} models.Error
+// @Router /v1/animals/given-eatables/{id}/cost [get]
+func (h *HandlerV1) GetGivenEatablesCost(c *gin.Context) {
+	ctx, span := otlp.Start(c, "api", "GetGivenEatablesCost")
+	span.SetAttributes(
+		attribute.Key("method").String(c.Request.Method),
+		attribute.Key("host").String(c.Request.Host),
+	)
+	defer span.End()
+
+	method := c.Query("method")
+	if method != "" {
+		if _, err := costing.ParseMethod(method); err != nil {
+			h.fail(c, errorspkg.NewErrBadRequest(err))
+			return
+		}
+	}
+
+	res, err := h.Valuation.FeedingCost(ctx, method, c.Param("id"))
+	if err != nil {
+		h.fail(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, &models.FeedingCostRes{
+		FeedingID: res.FeedingID,
+		AnimalID:  res.AnimalID,
+		EatableID: res.EatableID,
+		Category:  res.Category,
+		Name:      res.Name,
+		Day:       res.Day,
+		Quantity:  res.Quantity,
+		Shortage:  res.Shortage,
+		UnitCost:  res.UnitCost,
+		Cost:      res.Cost,
+		Method:    res.Method,
+	})
+}
@@ -0,0 +1,373 @@
+package v1
+
+import (
+	"musobaqa/farm-competition/api/models"
+	"musobaqa/farm-competition/internal/entity"
+	errorspkg "musobaqa/farm-competition/internal/errors"
+	"musobaqa/farm-competition/internal/pkg/otlp"
+	"musobaqa/farm-competition/internal/pkg/utils"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// CREATE WEBHOOK
+// @Summary CREATE WEBHOOK
+// @Description Api for Subscribe a url to farm events, the secret signs every delivery and is only shown here, one is generated when none is sent
+// @Tags WEBHOOK
+// @Accept json
+// @Produce json
+// @Param Webhook body models.WebhookReq true "createModel"
+// @Success 201 {object} models.WebhookRes
+// @Failure 400 {object} models.Error
+// @Failure 422 {object} models.Error
+// @Failure 500 {object} models.Error
+// @Router /v1/webhooks [post]
+func (h *HandlerV1) CreateWebhook(c *gin.Context) {
+	ctx, span := otlp.Start(c, "api", "CreateWebhook")
+	span.SetAttributes(
+		attribute.Key("method").String(c.Request.Method),
+		attribute.Key("host").String(c.Request.Host),
+	)
+	defer span.End()
+
+	var (
+		body models.WebhookReq
+	)
+
+	err := c.ShouldBindJSON(&body)
+	if err != nil {
+		h.fail(c, errorspkg.NewErrBadRequest(err))
+		return
+	}
+
+	err = body.Validate()
+	if err != nil {
+		h.fail(c, err)
+		return
+	}
+
+	res, err := h.Webhook.Create(ctx, &entity.WebhookSubscription{
+		URL:         body.URL,
+		Secret:      body.Secret,
+		Events:      body.Events,
+		Active:      body.Active == nil || *body.Active,
+		Description: body.Description,
+	})
+	if err != nil {
+		h.fail(c, err)
+		return
+	}
+
+	h.audit(c, ctx, entity.AuditActionCreate, "webhook", res.ID, nil)
+
+	response := webhookResponse(res)
+	response.Secret = res.Secret
+	c.JSON(http.StatusCreated, response)
+}
+
+// GET WEBHOOK
+// @Summary GET WEBHOOK BY ID
+// @Description Api for Get webhook subscription by ID
+// @Tags WEBHOOK
+// @Accept json
+// @Produce json
+// @Param id path string true "Webhook ID"
+// @Success 200 {object} models.WebhookRes
+// @Header 200 {string} ETag "Version of the entity"
+// @Failure 400 {object} models.Error
+// @Failure 404 {object} models.Error
+// @Failure 500 {object} models.Error
+// @Router /v1/webhooks/{id} [get]
+func (h *HandlerV1) GetWebhook(c *gin.Context) {
+	ctx, span := otlp.Start(c, "api", "GetWebhook")
+	span.SetAttributes(
+		attribute.Key("method").String(c.Request.Method),
+		attribute.Key("host").String(c.Request.Host),
+	)
+	defer span.End()
+
+	res, err := h.Webhook.Get(ctx, c.Param("id"))
+	if err != nil {
+		h.fail(c, err)
+		return
+	}
+
+	c.Header("ETag", etag(res.Version))
+	c.JSON(http.StatusOK, webhookResponse(res))
+}
+
+// LIST WEBHOOKS
+// @Summary LIST WEBHOOKS
+// @Description Api for List webhook subscriptions by page limit, event and active
+// @Tags WEBHOOK
+// @Accept json
+// @Produce json
+// @Param request query models.Pagination true "request"
+// @Param request query models.WebhookFieldValues false "request"
+// @Success 200 {object} models.ListWebhooksRes
+// @Failure 400 {object} models.Error
+// @Failure 500 {object} models.Error
+// @Router /v1/webhooks [get]
+func (h *HandlerV1) ListWebhooks(c *gin.Context) {
+	ctx, span := otlp.Start(c, "api", "ListWebhooks")
+	span.SetAttributes(
+		attribute.Key("method").String(c.Request.Method),
+		attribute.Key("host").String(c.Request.Host),
+	)
+	defer span.End()
+
+	queryParams := c.Request.URL.Query()
+	params, errStr := utils.ParseQueryParam(queryParams)
+	if errStr != nil {
+		h.fail(c, badQuery(errStr))
+		return
+	}
+
+	res, err := h.Webhook.List(ctx, params.Page, params.Limit, map[string]interface{}{
+		"event":  c.Query("event"),
+		"active": c.Query("active"),
+	})
+	if err != nil {
+		h.fail(c, err)
+		return
+	}
+
+	response := models.ListWebhooksRes{
+		Webhooks: []*models.WebhookRes{},
+		Count:    int64(res.TotalCount),
+	}
+	for _, i := range res.Subscriptions {
+		response.Webhooks = append(response.Webhooks, webhookResponse(i))
+	}
+
+	c.JSON(http.StatusOK, &response)
+}
+
+// UPDATE WEBHOOK
+// @Summary UPDATE WEBHOOK
+// @Description Api for Update webhook subscription by id, an empty secret keeps the current one
+// @Tags WEBHOOK
+// @Accept json
+// @Produce json
+// @Param Webhook body models.WebhookUpdateReq true "updateModel"
+// @Param If-Match header string false "ETag of the version being changed"
+// @Success 200 {object} models.WebhookRes
+// @Failure 400 {object} models.Error
+// @Failure 404 {object} models.Error
+// @Failure 409 {object} models.Error
+// @Failure 412 {object} models.Error
+// @Failure 422 {object} models.Error
+// @Failure 500 {object} models.Error
+// @Router /v1/webhooks [put]
+func (h *HandlerV1) UpdateWebhook(c *gin.Context) {
+	ctx, span := otlp.Start(c, "api", "UpdateWebhook")
+	span.SetAttributes(
+		attribute.Key("method").String(c.Request.Method),
+		attribute.Key("host").String(c.Request.Host),
+	)
+	defer span.End()
+
+	var (
+		body models.WebhookUpdateReq
+	)
+
+	err := c.ShouldBindJSON(&body)
+	if err != nil {
+		h.fail(c, errorspkg.NewErrBadRequest(err))
+		return
+	}
+
+	err = body.Validate()
+	if err != nil {
+		h.fail(c, err)
+		return
+	}
+
+	version, ok := h.ifMatch(c, ctx, "webhook", body.Id)
+	if !ok {
+		return
+	}
+
+	before := h.auditBefore(ctx, "webhook", body.Id)
+
+	res, err := h.Webhook.Update(ctx, &entity.WebhookSubscription{
+		ID:          body.Id,
+		URL:         body.URL,
+		Secret:      body.Secret,
+		Events:      body.Events,
+		Active:      body.Active == nil || *body.Active,
+		Description: body.Description,
+		Version:     version,
+	})
+	if err != nil {
+		h.fail(c, err)
+		return
+	}
+
+	h.audit(c, ctx, entity.AuditActionUpdate, "webhook", body.Id, before)
+
+	c.Header("ETag", etag(res.Version))
+	c.JSON(http.StatusOK, webhookResponse(res))
+}
+
+// DELETE WEBHOOK
+// @Summary DELETE WEBHOOK
+// @Description Api for Delete webhook subscription by ID, its pending deliveries are not sent
+// @Tags WEBHOOK
+// @Accept json
+// @Produce json
+// @Param id path string true "Webhook ID"
+// @Param If-Match header string false "ETag of the version being changed"
+// @Success 200 {object} models.Result
+// @Failure 400 {object} models.Error
+// @Failure 404 {object} models.Error
+// @Failure 412 {object} models.Error
+// @Failure 500 {object} models.Error
+// @Router /v1/webhooks/{id} [delete]
+func (h *HandlerV1) DeleteWebhook(c *gin.Context) {
+	ctx, span := otlp.Start(c, "api", "DeleteWebhook")
+	span.SetAttributes(
+		attribute.Key("method").String(c.Request.Method),
+		attribute.Key("host").String(c.Request.Host),
+	)
+	defer span.End()
+
+	id := c.Param("id")
+
+	_, err := h.Webhook.Get(ctx, id)
+	if err != nil {
+		h.fail(c, err)
+		return
+	}
+
+	version, ok := h.ifMatch(c, ctx, "webhook", id)
+	if !ok {
+		return
+	}
+
+	before := h.auditBefore(ctx, "webhook", id)
+
+	err = h.Webhook.Delete(ctx, id, version)
+	if err != nil {
+		h.fail(c, err)
+		return
+	}
+
+	h.audit(c, ctx, entity.AuditActionDelete, "webhook", id, before)
+
+	c.JSON(http.StatusOK, &models.Result{
+		Message: "Webhook has been deleted",
+	})
+}
+
+// LIST WEBHOOK DELIVERIES
+// @Summary LIST WEBHOOK DELIVERIES
+// @Description Api for List the delivery log, newest first, by subscription, event and status
+// @Tags WEBHOOK
+// @Accept json
+// @Produce json
+// @Param request query models.Pagination true "request"
+// @Param request query models.WebhookDeliveryFieldValues false "request"
+// @Success 200 {object} models.ListWebhookDeliveriesRes
+// @Failure 400 {object} models.Error
+// @Failure 500 {object} models.Error
+// @Router /v1/webhooks/deliveries [get]
+func (h *HandlerV1) ListWebhookDeliveries(c *gin.Context) {
+	ctx, span := otlp.Start(c, "api", "ListWebhookDeliveries")
+	span.SetAttributes(
+		attribute.Key("method").String(c.Request.Method),
+		attribute.Key("host").String(c.Request.Host),
+	)
+	defer span.End()
+
+	queryParams := c.Request.URL.Query()
+	params, errStr := utils.ParseQueryParam(queryParams)
+	if errStr != nil {
+		h.fail(c, badQuery(errStr))
+		return
+	}
+
+	res, err := h.Webhook.Deliveries(ctx, params.Page, params.Limit, map[string]interface{}{
+		"subscription_id": c.Query("subscription_id"),
+		"event":           c.Query("event"),
+		"status":          c.Query("status"),
+	})
+	if err != nil {
+		h.fail(c, err)
+		return
+	}
+
+	response := models.ListWebhookDeliveriesRes{
+		Deliveries: []*models.WebhookDeliveryRes{},
+		Count:      int64(res.TotalCount),
+	}
+	for _, i := range res.Deliveries {
+		response.Deliveries = append(response.Deliveries, webhookDeliveryResponse(i))
+	}
+
+	c.JSON(http.StatusOK, &response)
+}
+
+// REDELIVER WEBHOOK
+// @Summary REDELIVER WEBHOOK
+// @Description Api for Send a delivery again on the next dispatch, with a fresh set of attempts
+// @Tags WEBHOOK
+// @Accept json
+// @Produce json
+// @Param id path string true "Delivery ID"
+// @Success 202 {object} models.WebhookDeliveryRes
+// @Failure 400 {object} models.Error
+// @Failure 404 {object} models.Error
+// @Failure 500 {object} models.Error
+// @Router /v1/webhooks/deliveries/{id}/redeliver [post]
+func (h *HandlerV1) RedeliverWebhook(c *gin.Context) {
+	ctx, span := otlp.Start(c, "api", "RedeliverWebhook")
+	span.SetAttributes(
+		attribute.Key("method").String(c.Request.Method),
+		attribute.Key("host").String(c.Request.Host),
+	)
+	defer span.End()
+
+	res, err := h.Webhook.Redeliver(ctx, c.Param("id"))
+	if err != nil {
+		h.fail(c, err)
+		return
+	}
+
+	c.JSON(http.StatusAccepted, webhookDeliveryResponse(res))
+}
+
+// webhookResponse leaves the secret out, it is only shown on create
+func webhookResponse(subscription *entity.WebhookSubscription) *models.WebhookRes {
+	return &models.WebhookRes{
+		Id:          subscription.ID,
+		URL:         subscription.URL,
+		Events:      subscription.Events,
+		Active:      subscription.Active,
+		Description: subscription.Description,
+		CreatedAt:   subscription.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:   subscription.UpdatedAt.Format(time.RFC3339),
+	}
+}
+
+func webhookDeliveryResponse(delivery *entity.WebhookDelivery) *models.WebhookDeliveryRes {
+	response := models.WebhookDeliveryRes{
+		ID:             delivery.ID,
+		SubscriptionID: delivery.SubscriptionID,
+		Event:          delivery.Event,
+		Payload:        delivery.Payload,
+		Status:         delivery.Status,
+		Attempts:       delivery.Attempts,
+		NextAttemptAt:  delivery.NextAttemptAt.Format(time.RFC3339),
+		ResponseStatus: delivery.ResponseStatus,
+		LastError:      delivery.LastError,
+		CreatedAt:      delivery.CreatedAt.Format(time.RFC3339),
+	}
+	if delivery.DeliveredAt != nil {
+		response.DeliveredAt = delivery.DeliveredAt.Format(time.RFC3339)
+	}
+	return &response
+}
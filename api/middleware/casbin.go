@@ -5,7 +5,6 @@ import (
 	"musobaqa/farm-competition/internal/pkg/config"
 	tokens "musobaqa/farm-competition/internal/pkg/token"
 	"net/http"
-	"strings"
 
 	"github.com/casbin/casbin/v2"
 	"github.com/gin-gonic/gin"
@@ -40,17 +39,7 @@ func CheckCasbinPermission(casbin *casbin.Enforcer, cfg config.Config) gin.Handl
 }
 
 func (casb *JwtRoleAuth) GetRole(c *gin.Context) (string, int) {
-	var t string
-	token := c.Request.Header.Get("Authorization")
-	if token == "" {
-		return "unauthorized", http.StatusUnauthorized
-	} else if strings.Contains(token, "Bearer") {
-		t = strings.TrimPrefix(token, "Bearer ")
-	} else {
-		t = token
-	}
-
-	claims, err := tokens.ExtractClaim(t, []byte(casb.cfg.Token.SignInKey))
+	claims, err := tokens.ExtractBearer(c.Request.Header.Get("Authorization"), []byte(casb.cfg.Token.SignInKey))
 	if err != nil {
 		return "unauthorized", http.StatusUnauthorized
 	}
@@ -0,0 +1,27 @@
+package middleware
+
+import (
+	"github.com/gin-gonic/gin"
+
+	apierrors "musobaqa/farm-competition/api/errors"
+)
+
+// Errors answers requests whose handler gave up with c.Error, the last error
+// is mapped by api/errors so every route reports failures the same way. A
+// handler that failed past the deadline of its request gets 504 whatever the
+// error. Handlers that already wrote a response are left alone
+func Errors() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Next()
+
+		last := c.Errors.Last()
+		if last == nil || c.Writer.Written() {
+			return
+		}
+
+		if abortDone(c) {
+			return
+		}
+		c.JSON(apierrors.Response(last.Err))
+	}
+}
@@ -0,0 +1,85 @@
+package middleware_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"musobaqa/farm-competition/api/middleware"
+	errorspkg "musobaqa/farm-competition/internal/errors"
+)
+
+func TestErrors(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(middleware.Timeout(20*time.Millisecond, nil))
+	router.Use(middleware.Errors())
+
+	fail := func(err error) gin.HandlerFunc {
+		return func(c *gin.Context) {
+			_ = c.Error(err)
+			c.Abort()
+		}
+	}
+	router.GET("/v1/ok", func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"name": "bella"})
+	})
+	router.GET("/v1/missing", fail(errorspkg.Wrap(errorspkg.NewErrNotFound("animal"), "get animal %s", "bella")))
+	router.GET("/v1/bad", fail(errorspkg.NewErrBadRequest(errors.New("limit is not a number"))))
+	router.GET("/v1/stale", fail(errorspkg.Wrap(errorspkg.ErrorVersionConflict, "update animal")))
+	router.GET("/v1/broken", fail(errors.New("pq: password authentication failed")))
+	router.GET("/v1/answered", func(c *gin.Context) {
+		c.JSON(http.StatusAccepted, gin.H{})
+		_ = c.Error(errors.New("failed after answering"))
+	})
+	router.GET("/v1/slow", func(c *gin.Context) {
+		<-c.Request.Context().Done()
+		_ = c.Error(c.Request.Context().Err())
+		c.Abort()
+	})
+
+	get := func(path string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+		return w
+	}
+
+	// Requests without errors are left alone
+	w := get("/v1/ok")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"name":"bella"}`, w.Body.String())
+
+	// Errors are answered with the status and code of the catalog
+	for _, tc := range []struct {
+		path   string
+		status int
+		code   errorspkg.Code
+	}{
+		{"/v1/missing", http.StatusNotFound, errorspkg.CodeNotFound},
+		{"/v1/bad", http.StatusBadRequest, errorspkg.CodeBadRequest},
+		{"/v1/stale", http.StatusPreconditionFailed, errorspkg.CodeVersionConflict},
+		{"/v1/broken", http.StatusInternalServerError, errorspkg.CodeInternal},
+	} {
+		w = get(tc.path)
+		assert.Equal(t, tc.status, w.Code, tc.path)
+		assert.Contains(t, w.Body.String(), `"code":"`+string(tc.code)+`"`, tc.path)
+	}
+
+	// The text of internal errors is kept out of the body
+	assert.NotContains(t, get("/v1/broken").Body.String(), "password")
+
+	// A written answer is kept whatever failed after it
+	w = get("/v1/answered")
+	assert.Equal(t, http.StatusAccepted, w.Code)
+	assert.JSONEq(t, `{}`, w.Body.String())
+
+	// A handler that failed past its deadline gets 504
+	w = get("/v1/slow")
+	assert.Equal(t, http.StatusGatewayTimeout, w.Code)
+	assert.Contains(t, w.Body.String(), string(errorspkg.CodeDeadlineExceeded))
+}
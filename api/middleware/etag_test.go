@@ -0,0 +1,74 @@
+package middleware_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"musobaqa/farm-competition/api/middleware"
+)
+
+func TestETag(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(middleware.ETag())
+	router.GET("/weak", func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"name": "bella"})
+	})
+	router.GET("/strong", func(c *gin.Context) {
+		c.Header("ETag", `"3"`)
+		c.JSON(http.StatusOK, gin.H{"name": "bella"})
+	})
+	router.GET("/missing", func(c *gin.Context) {
+		c.JSON(http.StatusNotFound, gin.H{"code": "NOT_FOUND"})
+	})
+	router.POST("/weak", func(c *gin.Context) {
+		c.JSON(http.StatusCreated, gin.H{"name": "bella"})
+	})
+
+	get := func(path, noneMatch string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		if noneMatch != "" {
+			req.Header.Set("If-None-Match", noneMatch)
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	// Responses without a tag get a weak one hashed from the body
+	w := get("/weak", "")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"name":"bella"}`, w.Body.String())
+	weak := w.Header().Get("ETag")
+	assert.Regexp(t, `^W/"[0-9a-f]{40}"$`, weak)
+	assert.Equal(t, weak, get("/weak", "").Header().Get("ETag"))
+
+	// A matching If-None-Match gets 304 without a body, tags compare weakly
+	w = get("/weak", weak)
+	assert.Equal(t, http.StatusNotModified, w.Code)
+	assert.Empty(t, w.Body.String())
+	assert.Equal(t, weak, w.Header().Get("ETag"))
+
+	// Strong tags set by the handler are kept
+	w = get("/strong", "")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, `"3"`, w.Header().Get("ETag"))
+	assert.Equal(t, http.StatusNotModified, get("/strong", `"1", W/"3"`).Code)
+	assert.Equal(t, http.StatusNotModified, get("/strong", "*").Code)
+	assert.Equal(t, http.StatusOK, get("/strong", `"2"`).Code)
+
+	// Other statuses and methods pass through untagged
+	w = get("/missing", "*")
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	assert.JSONEq(t, `{"code":"NOT_FOUND"}`, w.Body.String())
+	assert.Empty(t, w.Header().Get("ETag"))
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/weak", nil))
+	assert.Equal(t, http.StatusCreated, w.Code)
+	assert.Empty(t, w.Header().Get("ETag"))
+}
@@ -0,0 +1,219 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"hash"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"musobaqa/farm-competition/api/models"
+	errorspkg "musobaqa/farm-competition/internal/errors"
+	"musobaqa/farm-competition/internal/pkg/config"
+	"musobaqa/farm-competition/internal/pkg/redis"
+)
+
+const (
+	IdempotencyKeyHeader      = "Idempotency-Key"
+	IdempotencyReplayedHeader = "Idempotent-Replayed"
+	idempotencyPrefix         = "idempotency:"
+)
+
+// idempotentResponse is stored under the key, Done is false while the first
+// request is still being handled
+type idempotentResponse struct {
+	Hash        string `json:"hash"`
+	Done        bool   `json:"done"`
+	Status      int    `json:"status"`
+	ContentType string `json:"content_type"`
+	Body        []byte `json:"body"`
+}
+
+// recordingWriter passes the response through and keeps a copy of the body
+type recordingWriter struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (w *recordingWriter) Write(data []byte) (int, error) {
+	w.body.Write(data)
+	return w.ResponseWriter.Write(data)
+}
+
+func (w *recordingWriter) WriteString(s string) (int, error) {
+	w.body.WriteString(s)
+	return w.ResponseWriter.WriteString(s)
+}
+
+// Idempotency makes POST requests carrying an Idempotency-Key safe to retry.
+// The first request is handled and its response stored for ttl, a retry with
+// the same body gets the stored response back, a retry with another body gets
+// 422 and a retry while the first one is still running gets 409. Server errors
+// and panics are not stored so the request can be retried. Keys are scoped to
+// the client, as the rate limits tell them apart, and the route, so clients
+// cannot replay each other's responses. When Redis is unavailable the request
+// is handled as if it carried no key
+func Idempotency(rdb *redis.RedisDB, live *config.Live, ttl time.Duration) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		key := c.GetHeader(IdempotencyKeyHeader)
+		if key == "" || c.Request.Method != http.MethodPost {
+			c.Next()
+			return
+		}
+
+		body, err := io.ReadAll(c.Request.Body)
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, models.Error{
+				Code:    string(errorspkg.CodeBadRequest),
+				Message: models.WrongInfoMessage,
+			})
+			return
+		}
+		c.Request.Body = io.NopCloser(bytes.NewReader(body))
+
+		sum := sha256.New()
+		sum.Write([]byte(c.Request.Method + " " + c.Request.URL.RequestURI() + "\n"))
+		writeBody(sum, c.ContentType(), c.GetHeader("Content-Type"), body)
+		hash := hex.EncodeToString(sum.Sum(nil))
+
+		var (
+			ctx      = context.Background()
+			redisKey = idempotencyPrefix + identity(c, live.Get()) + ":" + c.FullPath() + ":" + key
+		)
+
+		pending, err := json.Marshal(idempotentResponse{Hash: hash})
+		if err != nil {
+			c.Next()
+			return
+		}
+
+		acquired, err := rdb.Client.SetNX(ctx, redisKey, pending, ttl).Result()
+		if err != nil {
+			c.Next()
+			return
+		}
+
+		if !acquired {
+			replay(c, rdb, redisKey, hash)
+			return
+		}
+
+		// a handler that panics leaves no response to store, the key is
+		// freed so the request can be retried
+		defer func() {
+			if r := recover(); r != nil {
+				rdb.Client.Del(ctx, redisKey)
+				panic(r)
+			}
+		}()
+
+		writer := &recordingWriter{ResponseWriter: c.Writer}
+		c.Writer = writer
+		c.Next()
+
+		if writer.Status() >= http.StatusInternalServerError {
+			rdb.Client.Del(ctx, redisKey)
+			return
+		}
+
+		stored, err := json.Marshal(idempotentResponse{
+			Hash:        hash,
+			Done:        true,
+			Status:      writer.Status(),
+			ContentType: writer.Header().Get("Content-Type"),
+			Body:        writer.body.Bytes(),
+		})
+		if err != nil {
+			rdb.Client.Del(ctx, redisKey)
+			return
+		}
+		rdb.Client.Set(ctx, redisKey, stored, ttl)
+	}
+}
+
+// writeBody adds the body to the hash. Multipart bodies are added part by
+// part, their boundary is random so a retry of the same upload would not
+// match otherwise. A body that does not parse is added as it is
+func writeBody(sum hash.Hash, contentType, header string, body []byte) {
+	if contentType != "multipart/form-data" {
+		sum.Write(body)
+		return
+	}
+
+	_, params, err := mime.ParseMediaType(header)
+	if err != nil || params["boundary"] == "" {
+		sum.Write(body)
+		return
+	}
+
+	parts := sha256.New()
+	reader := multipart.NewReader(bytes.NewReader(body), params["boundary"])
+	for {
+		part, err := reader.NextPart()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			sum.Write(body)
+			return
+		}
+
+		content, err := io.ReadAll(part)
+		if err != nil {
+			sum.Write(body)
+			return
+		}
+		fmt.Fprintf(parts, "%q %q %q %d\n", part.FormName(), part.FileName(),
+			strings.ToLower(part.Header.Get("Content-Type")), len(content))
+		parts.Write(content)
+	}
+	sum.Write(parts.Sum(nil))
+}
+
+// replay answers a request whose key has been seen before
+func replay(c *gin.Context, rdb *redis.RedisDB, redisKey, hash string) {
+	raw, err := rdb.Client.Get(context.Background(), redisKey).Bytes()
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusConflict, models.Error{
+			Code:    string(errorspkg.CodeIdempotencyInProgress),
+			Message: models.IdempotencyInProgress,
+		})
+		return
+	}
+
+	var stored idempotentResponse
+	if err := json.Unmarshal(raw, &stored); err != nil {
+		c.AbortWithStatusJSON(http.StatusInternalServerError, models.Error{
+			Code:    string(errorspkg.CodeInternal),
+			Message: models.InternalMessage,
+		})
+		return
+	}
+
+	switch {
+	case stored.Hash != hash:
+		c.AbortWithStatusJSON(http.StatusUnprocessableEntity, models.Error{
+			Code:    string(errorspkg.CodeIdempotencyKeyReused),
+			Message: models.IdempotencyKeyReused,
+		})
+	case !stored.Done:
+		c.AbortWithStatusJSON(http.StatusConflict, models.Error{
+			Code:    string(errorspkg.CodeIdempotencyInProgress),
+			Message: models.IdempotencyInProgress,
+		})
+	default:
+		c.Header(IdempotencyReplayedHeader, "true")
+		c.Data(stored.Status, stored.ContentType, stored.Body)
+		c.Abort()
+	}
+}
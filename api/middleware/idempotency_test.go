@@ -0,0 +1,175 @@
+package middleware_test
+
+import (
+	"bytes"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/gin-gonic/gin"
+	goredis "github.com/go-redis/redis/v8"
+	"github.com/stretchr/testify/assert"
+
+	"musobaqa/farm-competition/api/middleware"
+	errorspkg "musobaqa/farm-competition/internal/errors"
+	"musobaqa/farm-competition/internal/pkg/config"
+	"musobaqa/farm-competition/internal/pkg/redis"
+)
+
+// newRedis starts an in-memory Redis that is stopped with the test
+func newRedis(t *testing.T) (*miniredis.Miniredis, *redis.RedisDB) {
+	server := miniredis.RunT(t)
+	client := goredis.NewClient(&goredis.Options{Addr: server.Addr()})
+	t.Cleanup(func() { client.Close() })
+	return server, &redis.RedisDB{Client: *client}
+}
+
+func TestIdempotency(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	server, rdb := newRedis(t)
+
+	var (
+		router  = gin.New()
+		handled = 0
+		status  = http.StatusCreated
+		nested  *httptest.ResponseRecorder
+	)
+	router.Use(middleware.Idempotency(rdb, config.NewLive(&config.Config{}), time.Hour))
+	router.POST("/v1/animals", func(c *gin.Context) {
+		handled++
+		body, _ := c.GetRawData()
+		c.Data(status, "application/json", body)
+	})
+
+	post := func(key, remoteAddr, contentType string, body []byte) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/v1/animals", bytes.NewReader(body))
+		req.Header.Set("Content-Type", contentType)
+		if key != "" {
+			req.Header.Set(middleware.IdempotencyKeyHeader, key)
+		}
+		if remoteAddr != "" {
+			req.RemoteAddr = remoteAddr
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+	postJSON := func(key, body string) *httptest.ResponseRecorder {
+		return post(key, "", "application/json", []byte(body))
+	}
+
+	// Requests without a key are handled every time
+	postJSON("", `{"name":"bella"}`)
+	postJSON("", `{"name":"bella"}`)
+	assert.Equal(t, 2, handled)
+
+	// A retry gets the stored response back without being handled again
+	w := postJSON("create-bella", `{"name":"bella"}`)
+	assert.Equal(t, http.StatusCreated, w.Code)
+	assert.Empty(t, w.Header().Get(middleware.IdempotencyReplayedHeader))
+	w = postJSON("create-bella", `{"name":"bella"}`)
+	assert.Equal(t, http.StatusCreated, w.Code)
+	assert.Equal(t, "true", w.Header().Get(middleware.IdempotencyReplayedHeader))
+	assert.Equal(t, "application/json", w.Header().Get("Content-Type"))
+	assert.JSONEq(t, `{"name":"bella"}`, w.Body.String())
+	assert.Equal(t, 3, handled)
+
+	// The key expires with the ttl
+	assert.Len(t, server.Keys(), 1)
+	assert.Equal(t, time.Hour, server.TTL(server.Keys()[0]))
+
+	// The same key with another body is refused
+	w = postJSON("create-bella", `{"name":"milka"}`)
+	assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+	assert.Contains(t, w.Body.String(), string(errorspkg.CodeIdempotencyKeyReused))
+	assert.Equal(t, 3, handled)
+
+	// Another client may use the same key
+	w = post("create-bella", "198.51.100.7:1234", "application/json", []byte(`{"name":"milka"}`))
+	assert.Equal(t, http.StatusCreated, w.Code)
+	assert.Empty(t, w.Header().Get(middleware.IdempotencyReplayedHeader))
+	assert.Equal(t, 4, handled)
+
+	// A retry while the first request is still handled gets 409
+	router.POST("/v1/foods", func(c *gin.Context) {
+		handled++
+		req := httptest.NewRequest(http.MethodPost, "/v1/foods", strings.NewReader(`{}`))
+		req.Header.Set(middleware.IdempotencyKeyHeader, "create-hay")
+		nested = httptest.NewRecorder()
+		router.ServeHTTP(nested, req)
+		c.Status(http.StatusCreated)
+	})
+	req := httptest.NewRequest(http.MethodPost, "/v1/foods", strings.NewReader(`{}`))
+	req.Header.Set(middleware.IdempotencyKeyHeader, "create-hay")
+	router.ServeHTTP(httptest.NewRecorder(), req)
+	assert.Equal(t, http.StatusConflict, nested.Code)
+	assert.Contains(t, nested.Body.String(), string(errorspkg.CodeIdempotencyInProgress))
+	assert.Equal(t, 5, handled)
+
+	// Server errors are not stored, the retry is handled
+	status = http.StatusInternalServerError
+	assert.Equal(t, http.StatusInternalServerError, postJSON("create-milka", `{"name":"milka"}`).Code)
+	status = http.StatusCreated
+	w = postJSON("create-milka", `{"name":"milka"}`)
+	assert.Equal(t, http.StatusCreated, w.Code)
+	assert.Empty(t, w.Header().Get(middleware.IdempotencyReplayedHeader))
+	assert.Equal(t, 7, handled)
+
+	// A retried upload matches whatever its boundary
+	upload := func(boundary, content string) *httptest.ResponseRecorder {
+		var body bytes.Buffer
+		writer := multipart.NewWriter(&body)
+		assert.NoError(t, writer.SetBoundary(boundary))
+		part, err := writer.CreateFormFile("file", "invoice.pdf")
+		assert.NoError(t, err)
+		_, _ = part.Write([]byte(content))
+		assert.NoError(t, writer.Close())
+		return post("upload-invoice", "", writer.FormDataContentType(), body.Bytes())
+	}
+	assert.Empty(t, upload("first-boundary", "%PDF-1.7").Header().Get(middleware.IdempotencyReplayedHeader))
+	assert.Equal(t, "true", upload("second-boundary", "%PDF-1.7").Header().Get(middleware.IdempotencyReplayedHeader))
+	assert.Equal(t, http.StatusUnprocessableEntity, upload("third-boundary", "%PDF-1.4").Code)
+	assert.Equal(t, 8, handled)
+
+	// Without Redis the request is handled as if it carried no key
+	server.Close()
+	assert.Equal(t, http.StatusCreated, postJSON("create-bella", `{"name":"bella"}`).Code)
+	assert.Equal(t, 9, handled)
+}
+
+func TestIdempotencyPanic(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	server, rdb := newRedis(t)
+
+	handled := 0
+	router := gin.New()
+	router.Use(gin.CustomRecovery(func(c *gin.Context, err any) {
+		c.AbortWithStatus(http.StatusInternalServerError)
+	}))
+	router.Use(middleware.Idempotency(rdb, config.NewLive(&config.Config{}), time.Hour))
+	router.POST("/v1/drugs", func(c *gin.Context) {
+		handled++
+		if handled == 1 {
+			panic("lost the connection")
+		}
+		c.Status(http.StatusCreated)
+	})
+
+	post := func() *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/v1/drugs", strings.NewReader(`{}`))
+		req.Header.Set(middleware.IdempotencyKeyHeader, "create-aspirin")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	// A panic frees the key, the retry is handled instead of getting 409
+	assert.Equal(t, http.StatusInternalServerError, post().Code)
+	assert.Empty(t, server.Keys())
+	assert.Equal(t, http.StatusCreated, post().Code)
+	assert.Equal(t, 2, handled)
+}
@@ -0,0 +1,131 @@
+package middleware
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	goredis "github.com/go-redis/redis/v8"
+	"github.com/google/uuid"
+	"github.com/spf13/cast"
+
+	"musobaqa/farm-competition/api/models"
+	errorspkg "musobaqa/farm-competition/internal/errors"
+	"musobaqa/farm-competition/internal/pkg/config"
+	"musobaqa/farm-competition/internal/pkg/redis"
+	tokens "musobaqa/farm-competition/internal/pkg/token"
+)
+
+const (
+	APIKeyHeader             = "X-Api-Key"
+	RateLimitLimitHeader     = "X-RateLimit-Limit"
+	RateLimitRemainingHeader = "X-RateLimit-Remaining"
+	RateLimitResetHeader     = "X-RateLimit-Reset"
+	rateLimitPrefix          = "rate_limit:"
+)
+
+// slidingWindow keeps the requests of the last window in a sorted set scored
+// by time. It drops the ones that left the window, admits the request when
+// there is room and returns whether it did, how many requests the window
+// holds and the time of the oldest one
+var slidingWindow = goredis.NewScript(`
+local now = tonumber(ARGV[1])
+local window = tonumber(ARGV[2])
+local limit = tonumber(ARGV[3])
+redis.call("ZREMRANGEBYSCORE", KEYS[1], "-inf", now - window)
+local count = redis.call("ZCARD", KEYS[1])
+local allowed = 0
+if count < limit then
+	redis.call("ZADD", KEYS[1], now, ARGV[4])
+	count = count + 1
+	allowed = 1
+end
+redis.call("PEXPIRE", KEYS[1], window)
+local oldest = redis.call("ZRANGE", KEYS[1], 0, 0, "WITHSCORES")
+return {allowed, count, tonumber(oldest[2]) or now}
+`)
+
+// RateLimit allows every client the configured requests to the routes of the
+// group in any window and answers 429 with Retry-After past that. A client is
+// the sub of a valid JWT, else its configured API key, else its IP. Limits are read from
+// live so a reload applies to the next request. When Redis is unavailable
+// requests are let through
+func RateLimit(rdb *redis.RedisDB, group string, live *config.Live) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		cfg := live.Get()
+		limit := cfg.RateLimit[group]
+		if limit.Requests <= 0 {
+			c.Next()
+			return
+		}
+
+		var (
+			now    = time.Now()
+			window = limit.Window.Milliseconds()
+			key    = rateLimitPrefix + group + ":" + identity(c, cfg)
+		)
+
+		res, err := slidingWindow.Run(context.Background(), rdb.Client, []string{key},
+			now.UnixMilli(), window, limit.Requests, uuid.NewString()).Int64Slice()
+		if err != nil || len(res) != 3 {
+			c.Next()
+			return
+		}
+		allowed, count, oldest := res[0] == 1, res[1], res[2]
+
+		// a slot is freed when the oldest request leaves the window
+		reset := time.Duration(oldest+window-now.UnixMilli()) * time.Millisecond
+		resetSeconds := strconv.FormatInt(int64((reset+time.Second-1)/time.Second), 10)
+
+		c.Header(RateLimitLimitHeader, strconv.FormatInt(limit.Requests, 10))
+		c.Header(RateLimitRemainingHeader, strconv.FormatInt(limit.Requests-count, 10))
+		c.Header(RateLimitResetHeader, resetSeconds)
+
+		if !allowed {
+			c.Header("Retry-After", resetSeconds)
+			c.AbortWithStatusJSON(http.StatusTooManyRequests, models.Error{
+				Code:    string(errorspkg.CodeTooManyRequests),
+				Message: models.TooManyRequests,
+			})
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// identity names the client a request is counted against. Only API keys in
+// the config count, any other key would give each request a bucket of its
+// own. Keys are hashed so they are not kept in Redis
+func identity(c *gin.Context, cfg *config.Config) string {
+	token := strings.TrimPrefix(c.GetHeader("Authorization"), "Bearer ")
+	if token != "" {
+		if claims, err := tokens.ExtractClaim(token, []byte(cfg.Token.SignInKey)); err == nil {
+			if sub := cast.ToString(claims["sub"]); sub != "" {
+				return "sub:" + sub
+			}
+		}
+	}
+
+	if apiKey := c.GetHeader(APIKeyHeader); apiKey != "" && knownAPIKey(cfg.Clients.APIKeys, apiKey) {
+		sum := sha256.Sum256([]byte(apiKey))
+		return "key:" + hex.EncodeToString(sum[:])
+	}
+
+	return "ip:" + c.ClientIP()
+}
+
+func knownAPIKey(keys []string, apiKey string) bool {
+	for _, key := range keys {
+		if subtle.ConstantTimeCompare([]byte(key), []byte(apiKey)) == 1 {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,114 @@
+package middleware_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"musobaqa/farm-competition/api/middleware"
+	errorspkg "musobaqa/farm-competition/internal/errors"
+	"musobaqa/farm-competition/internal/pkg/config"
+	tokens "musobaqa/farm-competition/internal/pkg/token"
+)
+
+func TestRateLimit(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	server, rdb := newRedis(t)
+
+	cfg := &config.Config{}
+	cfg.Token.SignInKey = "secret"
+	cfg.Clients.APIKeys = []string{"0123456789abcdef"}
+	cfg.RateLimit = map[string]config.RateLimit{"api": {Requests: 2, Window: 200 * time.Millisecond}}
+	live := config.NewLive(cfg)
+
+	router := gin.New()
+	router.Use(middleware.RateLimit(rdb, "api", live))
+	router.GET("/v1/animals", func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+
+	get := func(header, value, remoteAddr string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/v1/animals", nil)
+		if header != "" {
+			req.Header.Set(header, value)
+		}
+		if remoteAddr != "" {
+			req.RemoteAddr = remoteAddr
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	// The window admits the limit and reports what is left
+	w := get("", "", "")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "2", w.Header().Get(middleware.RateLimitLimitHeader))
+	assert.Equal(t, "1", w.Header().Get(middleware.RateLimitRemainingHeader))
+	assert.Equal(t, "1", w.Header().Get(middleware.RateLimitResetHeader))
+	w = get("", "", "")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "0", w.Header().Get(middleware.RateLimitRemainingHeader))
+
+	// Past the limit the request is refused until the oldest one leaves
+	w = get("", "", "")
+	assert.Equal(t, http.StatusTooManyRequests, w.Code)
+	assert.Equal(t, "1", w.Header().Get("Retry-After"))
+	assert.Contains(t, w.Body.String(), string(errorspkg.CodeTooManyRequests))
+
+	// Refused requests do not take a slot, the window slides
+	members, err := server.ZMembers("rate_limit:api:ip:192.0.2.1")
+	require.NoError(t, err)
+	assert.Len(t, members, 2)
+	time.Sleep(250 * time.Millisecond)
+	assert.Equal(t, http.StatusOK, get("", "", "").Code)
+
+	// Clients are counted apart, by the sub of their token, a configured API
+	// key or their address
+	access, _, err := (&tokens.JwtHandler{Sub: "user-1", Role: "admin", SigninKey: "secret", Log: zap.NewNop()}).GenerateJwt()
+	require.NoError(t, err)
+	for i := 0; i < 2; i++ {
+		assert.Equal(t, http.StatusOK, get("Authorization", "Bearer "+access, "").Code)
+		assert.Equal(t, http.StatusOK, get(middleware.APIKeyHeader, "0123456789abcdef", "").Code)
+		assert.Equal(t, http.StatusOK, get("", "", "198.51.100.7:1234").Code)
+	}
+	assert.Equal(t, http.StatusTooManyRequests, get("Authorization", "Bearer "+access, "").Code)
+	assert.Equal(t, http.StatusTooManyRequests, get(middleware.APIKeyHeader, "0123456789abcdef", "").Code)
+	assert.True(t, server.Exists("rate_limit:api:sub:user-1"))
+
+	// Unknown keys count against the address of the client
+	assert.Equal(t, http.StatusOK, get(middleware.APIKeyHeader, "fedcba9876543210", "203.0.113.9:1234").Code)
+	assert.Equal(t, http.StatusOK, get(middleware.APIKeyHeader, "another-made-up-key", "203.0.113.9:1234").Code)
+	assert.Equal(t, http.StatusTooManyRequests, get(middleware.APIKeyHeader, "yet-another-key", "203.0.113.9:1234").Code)
+
+	// A reload applies to the next request, no limit lets everything through
+	next := *cfg
+	next.RateLimit = map[string]config.RateLimit{"api": {Requests: 5, Window: time.Minute}}
+	live.Reload(&next)
+	w = get("", "", "203.0.113.9:1234")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "5", w.Header().Get(middleware.RateLimitLimitHeader))
+	reset, err := strconv.Atoi(w.Header().Get(middleware.RateLimitResetHeader))
+	require.NoError(t, err)
+	assert.InDelta(t, 60, reset, 1)
+
+	next.RateLimit = map[string]config.RateLimit{}
+	live.Reload(&next)
+	w = get("", "", "203.0.113.9:1234")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Empty(t, w.Header().Get(middleware.RateLimitLimitHeader))
+
+	// Without Redis requests are let through
+	live.Reload(cfg)
+	server.Close()
+	for i := 0; i < 3; i++ {
+		assert.Equal(t, http.StatusOK, get("", "", "").Code)
+	}
+}
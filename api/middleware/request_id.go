@@ -0,0 +1,22 @@
+package middleware
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// RequestID keeps the X-Request-Id sent by the client or generates a new one,
+// stores it in the gin context and echoes it in the response
+func RequestID() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		requestID := c.GetHeader(RequestIDHeader)
+		if requestID == "" {
+			requestID = uuid.New().String()
+		}
+
+		c.Set(RequestIDHeader, requestID)
+		c.Header(RequestIDHeader, requestID)
+
+		c.Next()
+	}
+}
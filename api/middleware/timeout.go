@@ -0,0 +1,60 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"musobaqa/farm-competition/api/models"
+	errorspkg "musobaqa/farm-competition/internal/errors"
+)
+
+// StatusClientClosedRequest is logged for requests whose client went away
+// before the answer was ready, nobody reads the response anyway
+const StatusClientClosedRequest = 499
+
+// Timeout puts a deadline on the request context, routes listed in overrides
+// by their full path get their own one. Handlers pass gin.Context down to
+// pgx, so queries stop at the deadline or as soon as the client disconnects.
+// Errors reports a handler that failed past its deadline as 504, a handler
+// that wrote nothing gets 504 here. A response written in time is kept
+func Timeout(timeout time.Duration, overrides map[string]time.Duration) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		deadline := timeout
+		if override, ok := overrides[c.FullPath()]; ok {
+			deadline = override
+		}
+
+		ctx, cancel := context.WithTimeout(c.Request.Context(), deadline)
+		defer cancel()
+		c.Request = c.Request.WithContext(ctx)
+
+		c.Next()
+
+		if !c.Writer.Written() {
+			abortDone(c)
+		}
+	}
+}
+
+// abortDone answers 504 past the deadline of the request and 499 when its
+// client went away, it reports whether it answered
+func abortDone(c *gin.Context) bool {
+	switch err := c.Request.Context().Err(); {
+	case errors.Is(err, context.DeadlineExceeded):
+		c.Writer.Header().Del("ETag")
+		c.AbortWithStatusJSON(http.StatusGatewayTimeout, models.Error{
+			Code:    string(errorspkg.CodeDeadlineExceeded),
+			Message: models.DeadlineExceeded,
+		})
+		return true
+	case errors.Is(err, context.Canceled):
+		c.AbortWithStatus(StatusClientClosedRequest)
+		return true
+	}
+
+	return false
+}
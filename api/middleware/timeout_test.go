@@ -0,0 +1,81 @@
+package middleware_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"musobaqa/farm-competition/api/middleware"
+	errorspkg "musobaqa/farm-competition/internal/errors"
+)
+
+func TestTimeout(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(middleware.Timeout(20*time.Millisecond, map[string]time.Duration{
+		"/v1/reports": time.Second,
+	}))
+	router.Use(middleware.ETag())
+
+	var deadline time.Duration
+	router.GET("/v1/animals", func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"name": "bella"})
+	})
+	router.GET("/v1/queued", func(c *gin.Context) {
+		c.Status(http.StatusAccepted)
+	})
+	router.GET("/v1/reports", func(c *gin.Context) {
+		at, _ := c.Request.Context().Deadline()
+		deadline = time.Until(at)
+		c.JSON(http.StatusOK, gin.H{})
+	})
+	router.GET("/v1/slow", func(c *gin.Context) {
+		c.Header("ETag", `"3"`)
+		<-c.Request.Context().Done()
+	})
+	router.POST("/v1/late", func(c *gin.Context) {
+		<-c.Request.Context().Done()
+		c.JSON(http.StatusCreated, gin.H{"name": "bella"})
+	})
+
+	serve := func(req *http.Request) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	// Responses in time are left alone
+	w := serve(httptest.NewRequest(http.MethodGet, "/v1/animals", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"name":"bella"}`, w.Body.String())
+	assert.NotEmpty(t, w.Header().Get("ETag"))
+	assert.Equal(t, http.StatusAccepted, serve(httptest.NewRequest(http.MethodGet, "/v1/queued", nil)).Code)
+
+	// Routes get their own deadline
+	assert.Equal(t, http.StatusOK, serve(httptest.NewRequest(http.MethodGet, "/v1/reports", nil)).Code)
+	assert.Greater(t, deadline, 500*time.Millisecond)
+
+	// A handler that wrote nothing by the deadline gets 504, without the tag
+	// of the entity it did not send
+	w = serve(httptest.NewRequest(http.MethodGet, "/v1/slow", nil))
+	assert.Equal(t, http.StatusGatewayTimeout, w.Code)
+	assert.Contains(t, w.Body.String(), string(errorspkg.CodeDeadlineExceeded))
+	assert.Empty(t, w.Header().Get("ETag"))
+
+	// A response written past the deadline is kept, the change it reports
+	// has been made
+	w = serve(httptest.NewRequest(http.MethodPost, "/v1/late", nil))
+	assert.Equal(t, http.StatusCreated, w.Code)
+	assert.JSONEq(t, `{"name":"bella"}`, w.Body.String())
+
+	// A client that went away is logged as 499
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	w = serve(httptest.NewRequest(http.MethodGet, "/v1/slow", nil).WithContext(ctx))
+	assert.Equal(t, middleware.StatusClientClosedRequest, w.Code)
+}
@@ -1,71 +1,58 @@
 package middleware
 
-// import (
-// 	"bufio"
-// 	"errors"
-// 	"net"
-// 	"net/http"
-
-// 	"github.com/gin-gonic/gin"
-// 	"go.opencensus.io/trace"
-// 	"go.opentelemetry.io/otel"
-// 	"go.opentelemetry.io/otel/attribute"
-
-// 	// "musobaqa/farm-competition/api/response"
-// 	// "musobaqa/farm-competition/internal/pkg/otlp"
-// )
-
-// // Tracing middleware function
-// func Tracing(c *gin.Context) {
-// 	ctx := c.Request.Context()
-// 	tracer := otel.GetTracerProvider().Tracer("api-service-booking")
-// 	ctx, span := tracer.Start(ctx, "HTTP "+c.Request.Method+" "+c.FullPath(), trace.WithSpanKind(trace.SpanKindServer))
-// 	defer span.End()
-
-// 	c.Writer.Header().Add("TraceID", span.SpanContext().TraceID().String())
-
-// 	rw := &responseWriter{c.Writer, http.StatusOK}
-
-// 	c.Writer = rw
-// 	c.Request = c.Request.WithContext(ctx)
-
-// 	c.Next()
-
-// 	span.SetAttributes(
-// 		attribute.String("http.method", c.Request.Method),
-// 		attribute.String("http.url", c.FullPath()),
-// 		attribute.Int("http.status_code", rw.statusCode),
-// 	)
-// }
-
-// type responseWriter struct {
-// 	gin.ResponseWriter
-// 	statusCode int
-// }
-
-// func (rw *responseWriter) WriteHeader(statusCode int) {
-// 	rw.statusCode = statusCode
-// 	rw.ResponseWriter.WriteHeader(statusCode)
-// }
-
-// func (rw *responseWriter) Write(data []byte) (int, error) {
-// 	return rw.ResponseWriter.Write(data)
-// }
-
-// func (rw *responseWriter) WriteString(s string) (int, error) {
-// 	return rw.ResponseWriter.WriteString(s)
-// }
-
-// func (rw *responseWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
-// 	if hijacker, ok := rw.ResponseWriter.(http.Hijacker); ok {
-// 		return hijacker.Hijack()
-// 	}
-// 	return nil, nil, errors.New("response writer does not support hijacking")
-// }
-
-// func (rw *responseWriter) CloseNotify() <-chan bool {
-// 	if notifier, ok := rw.ResponseWriter.(http.CloseNotifier); ok {
-// 		return notifier.CloseNotify()
-// 	}
-// 	return make(chan bool)
-// }
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.7.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const TraceIDHeader = "X-Trace-Id"
+
+// Tracing starts the server span of a request, continuing the trace of the
+// caller when it sends a W3C traceparent header. The span goes into the
+// request context, so with ContextWithFallback on the engine the spans the
+// handlers start on gin.Context become its children
+func Tracing() gin.HandlerFunc {
+	tracer := otel.Tracer("api")
+
+	return func(c *gin.Context) {
+		ctx := otel.GetTextMapPropagator().Extract(c.Request.Context(), propagation.HeaderCarrier(c.Request.Header))
+
+		route := c.FullPath()
+		if route == "" {
+			route = "unmatched"
+		}
+
+		ctx, span := tracer.Start(ctx, c.Request.Method+" "+route,
+			trace.WithSpanKind(trace.SpanKindServer),
+			trace.WithAttributes(
+				semconv.HTTPMethodKey.String(c.Request.Method),
+				semconv.HTTPRouteKey.String(route),
+				semconv.HTTPTargetKey.String(c.Request.URL.Path),
+				semconv.HTTPClientIPKey.String(c.ClientIP()),
+			),
+		)
+		defer span.End()
+
+		if span.SpanContext().HasTraceID() {
+			c.Header(TraceIDHeader, span.SpanContext().TraceID().String())
+		}
+
+		c.Request = c.Request.WithContext(ctx)
+		c.Next()
+
+		status := c.Writer.Status()
+		span.SetAttributes(semconv.HTTPStatusCodeKey.Int(status))
+		if status >= http.StatusInternalServerError {
+			span.SetStatus(codes.Error, http.StatusText(status))
+		}
+		for _, err := range c.Errors {
+			span.RecordError(err.Err)
+		}
+	}
+}
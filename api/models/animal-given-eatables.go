@@ -43,6 +43,19 @@ type AnimaGivenEatablesRes struct {
 	Day      string   `json:"day"`
 }
 
+type ListAnimaGivenEatablesRes struct {
+	GivenEatables []*AnimaGivenEatablesRes `json:"given_eatables"`
+	Count         int64                    `json:"count"`
+	NextCursor    string                   `json:"next_cursor"`
+}
+
+type AnimaGivenEatablesFieldValues struct {
+	AnimalID   string `json:"animal_id"`
+	EatablesID string `json:"eatables_id"`
+	Category   string `json:"category" example:"food"`
+	Day        string `json:"day"`
+}
+
 func (t *AnimaGivenEatablesReq) Validate() error {
 	t.Category = strings.ToLower(t.Category)
 	_, err := time.Parse(time.DateOnly, t.Day)
@@ -47,6 +47,7 @@ type AnimalProductByProductIdFieldValues struct {
 type ListAnimalProductsRes struct {
 	AnimalProducts []*AnimalProductRes `json:"animal_products"`
 	Count int64 `json:"count"`
+	NextCursor string `json:"next_cursor"`
 }
 
 type AnimalProductByAnimalIdRes struct {
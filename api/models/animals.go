@@ -73,6 +73,16 @@ type Pagination struct {
 	Page  int `json:"page"`
 }
 
+// ListQuery sorts and searches list endpoints. Filters also take the form
+// field[op]=value, op being one of eq, in, gte, lte, ilike and between.
+// History lists return a next_cursor, passing it as cursor continues the list
+// after the last row instead of using page
+type ListQuery struct {
+	Ordering string `json:"ordering" example:"-created_at,name"`
+	Search   string `json:"search"`
+	Cursor   string `json:"cursor"`
+}
+
 type ListAnimalsRes struct {
 	Animals []*AnimalRes `json:"animals"`
 	Count int64 `json:"count"`
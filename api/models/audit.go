@@ -0,0 +1,31 @@
+package models
+
+import "encoding/json"
+
+type AuditLogRes struct {
+	ID         string          `json:"id"`
+	Actor      string          `json:"actor"`
+	Action     string          `json:"action" example:"update"`
+	EntityType string          `json:"entity_type" example:"animal"`
+	EntityID   string          `json:"entity_id"`
+	Before     json.RawMessage `json:"before" swaggertype:"object"`
+	After      json.RawMessage `json:"after" swaggertype:"object"`
+	Diff       json.RawMessage `json:"diff" swaggertype:"object"`
+	RequestID  string          `json:"request_id"`
+	CreatedAt  string          `json:"created_at"`
+}
+
+type ListAuditLogsRes struct {
+	AuditLogs []*AuditLogRes `json:"audit_logs"`
+	Count     int64          `json:"count"`
+}
+
+type AuditFieldValues struct {
+	Actor      string `json:"actor"`
+	Action     string `json:"action" example:"delete"`
+	EntityType string `json:"entity_type" example:"animal"`
+	EntityID   string `json:"entity_id"`
+	RequestID  string `json:"request_id"`
+	From       string `json:"from" example:"2024-01-01"`
+	To         string `json:"to" example:"2024-12-31"`
+}
@@ -16,6 +16,12 @@ type DeliveryCreateReq struct {
 	Time        string `json:"time" example:"2024-01-01"`
 	Status string `json:"status"`
 	Description string `json:"description"`
+	SupplierID    string  `json:"supplier_id"`
+	UnitPrice     float64 `json:"unit_price" example:"12.50"`
+	TotalCost     float64 `json:"total_cost"`
+	InvoiceNumber string  `json:"invoice_number"`
+	LotNumber     string  `json:"lot_number"`
+	ExpiryDate    string  `json:"expiry_date" example:"2025-01-01"`
 }
 
 type DeliveryReq struct {
@@ -37,17 +43,24 @@ type DeliveryRes struct {
 	Capacity    int64  `json:"capacity"`
 	Union       string `json:"union"`
 	Time        string `json:"time" example:"2024-01-01 12:00:00"`
+	SupplierID    string  `json:"supplier_id"`
+	UnitPrice     float64 `json:"unit_price"`
+	TotalCost     float64 `json:"total_cost"`
+	InvoiceNumber string  `json:"invoice_number"`
+	InvoiceFile   string  `json:"invoice_file"`
 }
 
 type ListDeliverysRes struct {
 	Delivery []*DeliveryRes `json:"deliveries"`
 	Count int64 `json:"count"`
+	NextCursor string `json:"next_cursor"`
 }
 
 type DeliveryFieldValues struct {
 	Name string `json:"name"`
 	Category string `json:"category"`
 	Time string `json:"time"`
+	SupplierID string `json:"supplier_id"`
 }
 
 func (t *DeliveryCreateReq) Validate() error {
@@ -79,5 +92,17 @@ func (t *DeliveryCreateReq) Validate() error {
 			validation.Required,
 			validation.Date(time.DateTime),
 		),
+		validation.Field(
+			&t.UnitPrice,
+			validation.Min(0.0),
+		),
+		validation.Field(
+			&t.TotalCost,
+			validation.Min(0.0),
+		),
+		validation.Field(
+			&t.ExpiryDate,
+			validation.Date(time.DateOnly),
+		),
 	)
 }
@@ -2,7 +2,9 @@ package models
 
 // Error ...
 type Error struct {
-	Message string `json:"message"`
+	Code    string            `json:"code,omitempty"`
+	Message string            `json:"message"`
+	Fields  map[string]string `json:"fields,omitempty"`
 }
 
 const (
@@ -17,4 +19,14 @@ const (
 	NotAddedMessage   = "Data not added"
 	InternalMessage   = "Something went wrong"
 	NotAvailable = "Not available"
+	NotEnoughStock = "Not enough stock"
+	ParentDeleted = "Restore the entity it belongs to first"
+	TaskClosed = "Task is already done or skipped"
+	VersionConflict = "Entity was changed, fetch it again"
+	PreconditionRequired = "If-Match header is required"
+	IdempotencyKeyReused = "Idempotency key was used with another request"
+	IdempotencyInProgress = "Request with this idempotency key is still in progress"
+	TooManyRequests = "Too many requests, try again later"
+	DeadlineExceeded = "Request took too long, try again later"
+	ValidationFailed = "Some fields are invalid"
 )
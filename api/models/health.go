@@ -0,0 +1,18 @@
+package models
+
+type HealthRes struct {
+	Status string `json:"status" example:"ok"`
+}
+
+type ReadinessRes struct {
+	Status string                         `json:"status" example:"ok"`
+	Checks map[string]*DependencyCheckRes `json:"checks"`
+}
+
+type DependencyCheckRes struct {
+	Status    string `json:"status" example:"ok"`
+	LatencyMs int64  `json:"latency_ms"`
+	Error     string `json:"error,omitempty"`
+	Version   *int64 `json:"migration_version,omitempty"`
+	Dirty     bool   `json:"migration_dirty,omitempty"`
+}
@@ -0,0 +1,34 @@
+package models
+
+type JobRunRes struct {
+	ID          string `json:"id"`
+	Job         string `json:"job"`
+	ScheduledAt string `json:"scheduled_at"`
+	Status      string `json:"status" example:"succeeded"`
+	Holder      string `json:"holder"`
+	StartedAt   string `json:"started_at"`
+	FinishedAt  string `json:"finished_at"`
+	Error       string `json:"error"`
+}
+
+type JobRes struct {
+	Name      string     `json:"name" example:"low-stock"`
+	Schedule  string     `json:"schedule" example:"*/15 * * * *"`
+	Timeout   string     `json:"timeout" example:"10m0s"`
+	NextRunAt string     `json:"next_run_at"`
+	LastRun   *JobRunRes `json:"last_run"`
+}
+
+type ListJobsRes struct {
+	Jobs []*JobRes `json:"jobs"`
+}
+
+type ListJobRunsRes struct {
+	Runs  []*JobRunRes `json:"runs"`
+	Count int64        `json:"count"`
+}
+
+type JobRunFieldValues struct {
+	Job    string `json:"job" example:"purge-trash"`
+	Status string `json:"status" example:"failed"`
+}
@@ -0,0 +1,45 @@
+package models
+
+import (
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+)
+
+type StockLotRes struct {
+	ID             string `json:"id"`
+	DeliveryID     string `json:"delivery_id"`
+	Category       string `json:"category"`
+	Name           string `json:"name"`
+	LotNumber      string `json:"lot_number"`
+	ReceivedDate   string `json:"received_date" example:"2024-01-01"`
+	ExpiryDate     string `json:"expiry_date" example:"2025-01-01"`
+	Quantity       int64  `json:"quantity"`
+	Remaining      int64  `json:"remaining"`
+	WrittenOff     int64  `json:"written_off"`
+	WriteOffReason string `json:"write_off_reason"`
+}
+
+type ListStockLotsRes struct {
+	Lots  []*StockLotRes `json:"lots"`
+	Count int64          `json:"count"`
+}
+
+type StockLotFieldValues struct {
+	Category     string `json:"category" example:"drug"`
+	Name         string `json:"name"`
+	LotNumber    string `json:"lot_number"`
+	IncludeEmpty bool   `json:"include_empty"`
+}
+
+type WriteOffReq struct {
+	LotID  string `json:"lot_id"`
+	Reason string `json:"reason" example:"expired"`
+}
+
+func (t *WriteOffReq) Validate() error {
+	return validation.ValidateStruct(t,
+		validation.Field(
+			&t.Reason,
+			validation.Required,
+		),
+	)
+}
@@ -0,0 +1,21 @@
+package models
+
+import "encoding/json"
+
+type ReportSnapshotRes struct {
+	ID        string          `json:"id"`
+	Report    string          `json:"report" example:"feed-cost"`
+	Day       string          `json:"day" example:"2024-05-14"`
+	Data      json.RawMessage `json:"data" swaggertype:"object"`
+	CreatedAt string          `json:"created_at"`
+}
+
+type ListReportSnapshotsRes struct {
+	Snapshots []*ReportSnapshotRes `json:"snapshots"`
+}
+
+type ReportSnapshotFieldValues struct {
+	Report string `json:"report" example:"inventory-valuation"`
+	From   string `json:"from" example:"2024-05-01"`
+	To     string `json:"to" example:"2024-05-31"`
+}
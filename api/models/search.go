@@ -0,0 +1,19 @@
+package models
+
+type SearchHitRes struct {
+	EntityType string  `json:"entity_type" example:"animal"`
+	ID         string  `json:"id"`
+	Name       string  `json:"name"`
+	Label      string  `json:"label"`
+	Rank       float64 `json:"rank"`
+}
+
+type SearchRes struct {
+	Hits []*SearchHitRes `json:"hits"`
+}
+
+type SearchFieldValues struct {
+	Q     string `json:"q"`
+	Type  string `json:"type" example:"animal,food"`
+	Limit int    `json:"limit"`
+}
@@ -0,0 +1,74 @@
+package models
+
+import (
+	"strings"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/go-ozzo/ozzo-validation/v4/is"
+)
+
+type SupplierReq struct {
+	Name          string `json:"name"`
+	ContactPerson string `json:"contact_person"`
+	Phone         string `json:"phone" example:"+998901234567"`
+	Email         string `json:"email" example:"supplier@example.com"`
+	Address       string `json:"address"`
+	Description   string `json:"description"`
+}
+
+type SupplierRes struct {
+	Id            string `json:"id"`
+	Name          string `json:"name"`
+	ContactPerson string `json:"contact_person"`
+	Phone         string `json:"phone"`
+	Email         string `json:"email"`
+	Address       string `json:"address"`
+	Description   string `json:"description"`
+}
+
+type SupplierFieldValues struct {
+	Name  string `json:"name"`
+	Phone string `json:"phone"`
+}
+
+type ListSuppliersRes struct {
+	Suppliers []*SupplierRes `json:"suppliers"`
+	Count     int64          `json:"count"`
+}
+
+type SpendReportFieldValues struct {
+	GroupBy    string `json:"group_by" example:"supplier"`
+	From       string `json:"from" example:"2024-01-01"`
+	To         string `json:"to" example:"2024-12-31"`
+	SupplierID string `json:"supplier_id"`
+	Category   string `json:"category"`
+}
+
+type SpendReportItemRes struct {
+	Key        string  `json:"key"`
+	Label      string  `json:"label"`
+	Capacity   int64   `json:"capacity"`
+	TotalCost  float64 `json:"total_cost"`
+	Deliveries int64   `json:"deliveries"`
+}
+
+type SpendReportRes struct {
+	GroupBy   string                `json:"group_by"`
+	Items     []*SpendReportItemRes `json:"items"`
+	TotalCost float64               `json:"total_cost"`
+}
+
+func (t *SupplierReq) Validate() error {
+	t.Name = strings.TrimSpace(t.Name)
+	t.Email = strings.ToLower(strings.TrimSpace(t.Email))
+	return validation.ValidateStruct(t,
+		validation.Field(
+			&t.Name,
+			validation.Required,
+		),
+		validation.Field(
+			&t.Email,
+			is.EmailFormat,
+		),
+	)
+}
@@ -0,0 +1,87 @@
+package models
+
+import (
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+)
+
+type FeedingTaskRes struct {
+	ID              string `json:"id"`
+	AnimalID        string `json:"animal_id"`
+	AnimalName      string `json:"animal_name"`
+	AnimalCategory  string `json:"animal_category" example:"cow"`
+	EatablesID      string `json:"eatables_id"`
+	Category        string `json:"category" example:"food"`
+	Name            string `json:"name"`
+	Union           string `json:"union" example:"kg"`
+	Day             string `json:"day" example:"2024-01-01"`
+	Time            string `json:"time" example:"14:00"`
+	Capacity        int64  `json:"capacity"`
+	Status          string `json:"status" example:"pending"`
+	ActualCapacity  int64  `json:"actual_capacity"`
+	SkipReason      string `json:"skip_reason"`
+	GivenEatablesID string `json:"given_eatables_id"`
+	CompletedBy     string `json:"completed_by"`
+	CompletedAt     string `json:"completed_at"`
+}
+
+type ListFeedingTasksRes struct {
+	Tasks []*FeedingTaskRes `json:"tasks"`
+	Count int64             `json:"count"`
+}
+
+type FeedingTaskFieldValues struct {
+	Day            string `json:"day" example:"2024-01-01"`
+	Status         string `json:"status" example:"pending"`
+	Category       string `json:"category" example:"food"`
+	AnimalID       string `json:"animal_id"`
+	AnimalCategory string `json:"animal_category" example:"cow"`
+}
+
+// FeedingTaskDoneReq leaves ActualCapacity empty when the planned capacity
+// was given
+type FeedingTaskDoneReq struct {
+	ActualCapacity int64 `json:"actual_capacity"`
+}
+
+func (t *FeedingTaskDoneReq) Validate() error {
+	return validation.ValidateStruct(t,
+		validation.Field(
+			&t.ActualCapacity,
+			validation.Min(int64(0)),
+		),
+	)
+}
+
+type FeedingTaskSkipReq struct {
+	Reason string `json:"reason" example:"animal is sick"`
+}
+
+func (t *FeedingTaskSkipReq) Validate() error {
+	return validation.ValidateStruct(t,
+		validation.Field(
+			&t.Reason,
+			validation.Required,
+		),
+	)
+}
+
+type GenerateFeedingTasksReq struct {
+	Day string `json:"day" example:"2024-01-01"`
+}
+
+func (t *GenerateFeedingTasksReq) Validate() error {
+	return validation.ValidateStruct(t,
+		validation.Field(
+			&t.Day,
+			validation.Required,
+			validation.Date(time.DateOnly),
+		),
+	)
+}
+
+type GenerateFeedingTasksRes struct {
+	Day       string `json:"day" example:"2024-01-01"`
+	Generated int64  `json:"generated"`
+}
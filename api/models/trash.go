@@ -0,0 +1,19 @@
+package models
+
+import "encoding/json"
+
+type TrashItemRes struct {
+	EntityType string          `json:"entity_type" example:"animal"`
+	ID         string          `json:"id"`
+	DeletedAt  string          `json:"deleted_at"`
+	Data       json.RawMessage `json:"data" swaggertype:"object"`
+}
+
+type ListTrashRes struct {
+	Items []*TrashItemRes `json:"items"`
+	Count int64           `json:"count"`
+}
+
+type PurgeTrashRes struct {
+	Purged map[string]int64 `json:"purged"`
+}
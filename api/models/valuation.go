@@ -0,0 +1,58 @@
+package models
+
+type ValuationFieldValues struct {
+	Method   string `json:"method" example:"fifo"`
+	Category string `json:"category" example:"food"`
+}
+
+type ValuationItemRes struct {
+	Category string  `json:"category"`
+	Name     string  `json:"name"`
+	Quantity int64   `json:"quantity"`
+	UnitCost float64 `json:"unit_cost"`
+	Value    float64 `json:"value"`
+}
+
+type InventoryValuationRes struct {
+	Method     string              `json:"method"`
+	Items      []*ValuationItemRes `json:"items"`
+	TotalValue float64             `json:"total_value"`
+}
+
+type FeedingCostRes struct {
+	FeedingID string  `json:"feeding_id"`
+	AnimalID  string  `json:"animal_id"`
+	EatableID string  `json:"eatable_id"`
+	Category  string  `json:"category"`
+	Name      string  `json:"name"`
+	Day       string  `json:"day"`
+	Quantity  int64   `json:"quantity"`
+	Shortage  int64   `json:"shortage"`
+	UnitCost  float64 `json:"unit_cost"`
+	Cost      float64 `json:"cost"`
+	Method    string  `json:"method"`
+}
+
+type FeedCostFieldValues struct {
+	Method   string `json:"method" example:"fifo"`
+	GroupBy  string `json:"group_by" example:"animal"`
+	AnimalID string `json:"animal_id"`
+	Category string `json:"category" example:"food"`
+	From     string `json:"from" example:"2024-01-01"`
+	To       string `json:"to" example:"2024-12-31"`
+}
+
+type FeedCostItemRes struct {
+	Key      string  `json:"key"`
+	Label    string  `json:"label"`
+	Quantity int64   `json:"quantity"`
+	Cost     float64 `json:"cost"`
+	Feedings int64   `json:"feedings"`
+}
+
+type FeedCostReportRes struct {
+	Method    string             `json:"method"`
+	GroupBy   string             `json:"group_by"`
+	Items     []*FeedCostItemRes `json:"items"`
+	TotalCost float64            `json:"total_cost"`
+}
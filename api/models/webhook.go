@@ -0,0 +1,103 @@
+package models
+
+import (
+	"encoding/json"
+	"regexp"
+	"strings"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/go-ozzo/ozzo-validation/v4/is"
+
+	"musobaqa/farm-competition/internal/entity"
+)
+
+type WebhookReq struct {
+	URL         string   `json:"url" example:"https://example.com/farm-hooks"`
+	Secret      string   `json:"secret"`
+	Events      []string `json:"events" example:"stock.low,feeding.missed"`
+	Active      *bool    `json:"active"`
+	Description string   `json:"description"`
+}
+
+type WebhookUpdateReq struct {
+	Id string `json:"id"`
+	WebhookReq
+}
+
+type WebhookRes struct {
+	Id          string   `json:"id"`
+	URL         string   `json:"url"`
+	Secret      string   `json:"secret,omitempty"`
+	Events      []string `json:"events"`
+	Active      bool     `json:"active"`
+	Description string   `json:"description"`
+	CreatedAt   string   `json:"created_at"`
+	UpdatedAt   string   `json:"updated_at"`
+}
+
+type ListWebhooksRes struct {
+	Webhooks []*WebhookRes `json:"webhooks"`
+	Count    int64         `json:"count"`
+}
+
+type WebhookFieldValues struct {
+	Event  string `json:"event" example:"stock.low"`
+	Active string `json:"active" example:"true"`
+}
+
+type WebhookDeliveryRes struct {
+	ID             string          `json:"id"`
+	SubscriptionID string          `json:"subscription_id"`
+	Event          string          `json:"event"`
+	Payload        json.RawMessage `json:"payload" swaggertype:"object"`
+	Status         string          `json:"status" example:"pending"`
+	Attempts       int             `json:"attempts"`
+	NextAttemptAt  string          `json:"next_attempt_at"`
+	ResponseStatus int             `json:"response_status"`
+	LastError      string          `json:"last_error"`
+	DeliveredAt    string          `json:"delivered_at"`
+	CreatedAt      string          `json:"created_at"`
+}
+
+type ListWebhookDeliveriesRes struct {
+	Deliveries []*WebhookDeliveryRes `json:"deliveries"`
+	Count      int64                 `json:"count"`
+}
+
+type WebhookDeliveryFieldValues struct {
+	SubscriptionID string `json:"subscription_id"`
+	Event          string `json:"event" example:"stock.low"`
+	Status         string `json:"status" example:"failed"`
+}
+
+var webhookURL = regexp.MustCompile(`^https?://`)
+
+func (t *WebhookReq) Validate() error {
+	t.URL = strings.TrimSpace(t.URL)
+	for i := range t.Events {
+		t.Events[i] = strings.ToLower(strings.TrimSpace(t.Events[i]))
+	}
+
+	events := make([]interface{}, 0, len(entity.WebhookEvents))
+	for _, event := range entity.WebhookEvents {
+		events = append(events, event)
+	}
+
+	return validation.ValidateStruct(t,
+		validation.Field(
+			&t.URL,
+			validation.Required,
+			is.RequestURL,
+			validation.Match(webhookURL).Error("must be an http or https url"),
+		),
+		validation.Field(
+			&t.Secret,
+			validation.Length(16, 255),
+		),
+		validation.Field(
+			&t.Events,
+			validation.Required,
+			validation.Each(validation.In(events...)),
+		),
+	)
+}
@@ -0,0 +1,272 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.4.0
+// - protoc             (unknown)
+// source: farm/v1/feeding.proto
+
+package farmv1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.62.0 or later.
+const _ = grpc.SupportPackageIsVersion8
+
+const (
+	FeedingService_CreateFeeding_FullMethodName       = "/farm.v1.FeedingService/CreateFeeding"
+	FeedingService_UpdateFeeding_FullMethodName       = "/farm.v1.FeedingService/UpdateFeeding"
+	FeedingService_DeleteFeeding_FullMethodName       = "/farm.v1.FeedingService/DeleteFeeding"
+	FeedingService_ListFeedings_FullMethodName        = "/farm.v1.FeedingService/ListFeedings"
+	FeedingService_ListOverdueFeedings_FullMethodName = "/farm.v1.FeedingService/ListOverdueFeedings"
+)
+
+// FeedingServiceClient is the client API for FeedingService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// FeedingService records the foods and drugs given to animals, every
+// feeding takes its quantity out of the stock lots
+type FeedingServiceClient interface {
+	CreateFeeding(ctx context.Context, in *CreateFeedingRequest, opts ...grpc.CallOption) (*Feeding, error)
+	UpdateFeeding(ctx context.Context, in *UpdateFeedingRequest, opts ...grpc.CallOption) (*Feeding, error)
+	DeleteFeeding(ctx context.Context, in *DeleteRequest, opts ...grpc.CallOption) (*DeleteResponse, error)
+	ListFeedings(ctx context.Context, in *ListRequest, opts ...grpc.CallOption) (*ListFeedingsResponse, error)
+	// ListOverdueFeedings lists what animals were due today, up to now, and
+	// were not given yet
+	ListOverdueFeedings(ctx context.Context, in *ListOverdueFeedingsRequest, opts ...grpc.CallOption) (*ListOverdueFeedingsResponse, error)
+}
+
+type feedingServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewFeedingServiceClient(cc grpc.ClientConnInterface) FeedingServiceClient {
+	return &feedingServiceClient{cc}
+}
+
+func (c *feedingServiceClient) CreateFeeding(ctx context.Context, in *CreateFeedingRequest, opts ...grpc.CallOption) (*Feeding, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Feeding)
+	err := c.cc.Invoke(ctx, FeedingService_CreateFeeding_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *feedingServiceClient) UpdateFeeding(ctx context.Context, in *UpdateFeedingRequest, opts ...grpc.CallOption) (*Feeding, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Feeding)
+	err := c.cc.Invoke(ctx, FeedingService_UpdateFeeding_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *feedingServiceClient) DeleteFeeding(ctx context.Context, in *DeleteRequest, opts ...grpc.CallOption) (*DeleteResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteResponse)
+	err := c.cc.Invoke(ctx, FeedingService_DeleteFeeding_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *feedingServiceClient) ListFeedings(ctx context.Context, in *ListRequest, opts ...grpc.CallOption) (*ListFeedingsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListFeedingsResponse)
+	err := c.cc.Invoke(ctx, FeedingService_ListFeedings_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *feedingServiceClient) ListOverdueFeedings(ctx context.Context, in *ListOverdueFeedingsRequest, opts ...grpc.CallOption) (*ListOverdueFeedingsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListOverdueFeedingsResponse)
+	err := c.cc.Invoke(ctx, FeedingService_ListOverdueFeedings_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// FeedingServiceServer is the server API for FeedingService service.
+// All implementations must embed UnimplementedFeedingServiceServer
+// for forward compatibility
+//
+// FeedingService records the foods and drugs given to animals, every
+// feeding takes its quantity out of the stock lots
+type FeedingServiceServer interface {
+	CreateFeeding(context.Context, *CreateFeedingRequest) (*Feeding, error)
+	UpdateFeeding(context.Context, *UpdateFeedingRequest) (*Feeding, error)
+	DeleteFeeding(context.Context, *DeleteRequest) (*DeleteResponse, error)
+	ListFeedings(context.Context, *ListRequest) (*ListFeedingsResponse, error)
+	// ListOverdueFeedings lists what animals were due today, up to now, and
+	// were not given yet
+	ListOverdueFeedings(context.Context, *ListOverdueFeedingsRequest) (*ListOverdueFeedingsResponse, error)
+	mustEmbedUnimplementedFeedingServiceServer()
+}
+
+// UnimplementedFeedingServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedFeedingServiceServer struct {
+}
+
+func (UnimplementedFeedingServiceServer) CreateFeeding(context.Context, *CreateFeedingRequest) (*Feeding, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateFeeding not implemented")
+}
+func (UnimplementedFeedingServiceServer) UpdateFeeding(context.Context, *UpdateFeedingRequest) (*Feeding, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateFeeding not implemented")
+}
+func (UnimplementedFeedingServiceServer) DeleteFeeding(context.Context, *DeleteRequest) (*DeleteResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteFeeding not implemented")
+}
+func (UnimplementedFeedingServiceServer) ListFeedings(context.Context, *ListRequest) (*ListFeedingsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListFeedings not implemented")
+}
+func (UnimplementedFeedingServiceServer) ListOverdueFeedings(context.Context, *ListOverdueFeedingsRequest) (*ListOverdueFeedingsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListOverdueFeedings not implemented")
+}
+func (UnimplementedFeedingServiceServer) mustEmbedUnimplementedFeedingServiceServer() {}
+
+// UnsafeFeedingServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to FeedingServiceServer will
+// result in compilation errors.
+type UnsafeFeedingServiceServer interface {
+	mustEmbedUnimplementedFeedingServiceServer()
+}
+
+func RegisterFeedingServiceServer(s grpc.ServiceRegistrar, srv FeedingServiceServer) {
+	s.RegisterService(&FeedingService_ServiceDesc, srv)
+}
+
+func _FeedingService_CreateFeeding_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateFeedingRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FeedingServiceServer).CreateFeeding(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FeedingService_CreateFeeding_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FeedingServiceServer).CreateFeeding(ctx, req.(*CreateFeedingRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FeedingService_UpdateFeeding_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateFeedingRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FeedingServiceServer).UpdateFeeding(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FeedingService_UpdateFeeding_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FeedingServiceServer).UpdateFeeding(ctx, req.(*UpdateFeedingRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FeedingService_DeleteFeeding_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FeedingServiceServer).DeleteFeeding(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FeedingService_DeleteFeeding_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FeedingServiceServer).DeleteFeeding(ctx, req.(*DeleteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FeedingService_ListFeedings_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FeedingServiceServer).ListFeedings(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FeedingService_ListFeedings_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FeedingServiceServer).ListFeedings(ctx, req.(*ListRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FeedingService_ListOverdueFeedings_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListOverdueFeedingsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FeedingServiceServer).ListOverdueFeedings(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FeedingService_ListOverdueFeedings_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FeedingServiceServer).ListOverdueFeedings(ctx, req.(*ListOverdueFeedingsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// FeedingService_ServiceDesc is the grpc.ServiceDesc for FeedingService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var FeedingService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "farm.v1.FeedingService",
+	HandlerType: (*FeedingServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateFeeding",
+			Handler:    _FeedingService_CreateFeeding_Handler,
+		},
+		{
+			MethodName: "UpdateFeeding",
+			Handler:    _FeedingService_UpdateFeeding_Handler,
+		},
+		{
+			MethodName: "DeleteFeeding",
+			Handler:    _FeedingService_DeleteFeeding_Handler,
+		},
+		{
+			MethodName: "ListFeedings",
+			Handler:    _FeedingService_ListFeedings_Handler,
+		},
+		{
+			MethodName: "ListOverdueFeedings",
+			Handler:    _FeedingService_ListOverdueFeedings_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "farm/v1/feeding.proto",
+}
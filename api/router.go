@@ -9,10 +9,25 @@ import (
 	"musobaqa/farm-competition/internal/usecase/feeding"
 	"musobaqa/farm-competition/internal/usecase/foods"
 	"musobaqa/farm-competition/internal/usecase/products"
+	"musobaqa/farm-competition/internal/usecase/suppliers"
+	"musobaqa/farm-competition/internal/usecase/health"
+	"musobaqa/farm-competition/internal/usecase/search"
+	"musobaqa/farm-competition/internal/usecase/versions"
+	"musobaqa/farm-competition/internal/usecase/trash"
+	"musobaqa/farm-competition/internal/usecase/audit"
+	"musobaqa/farm-competition/internal/usecase/lots"
+	"musobaqa/farm-competition/internal/usecase/valuation"
+	"musobaqa/farm-competition/internal/usecase/webhooks"
+	"musobaqa/farm-competition/internal/usecase/jobs"
+	"musobaqa/farm-competition/internal/usecase/reports"
+	"musobaqa/farm-competition/internal/usecase/tasks"
 	"time"
 
 	_ "musobaqa/farm-competition/api/docs"
 	v1 "musobaqa/farm-competition/api/handlers/v1"
+	"musobaqa/farm-competition/api/middleware"
+	"musobaqa/farm-competition/internal/pkg/metrics"
+	"musobaqa/farm-competition/internal/pkg/scheduler"
 
 	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
@@ -22,13 +37,17 @@ import (
 	"go.uber.org/zap"
 
 	"musobaqa/farm-competition/internal/pkg/config"
+	"musobaqa/farm-competition/internal/pkg/redis"
 	tokens "musobaqa/farm-competition/internal/pkg/token"
 )
 
 type RouteOption struct {
 	Config         *config.Config
+	Live           *config.Live
 	Logger         *zap.Logger
+	RedisDB        *redis.RedisDB
 	ContextTimeout time.Duration
+	ReportTimeout  time.Duration
 	JwtHandler     tokens.JwtHandler
 	Product        products.Product
 	Animals        animals.Animal
@@ -38,6 +57,19 @@ type RouteOption struct {
 	AnimalProduct  animalproduct.AnimalProduct
 	Eatables       eatables.Eatable
 	Feeding feeding.Feeding
+	Supplier       suppliers.Supplier
+	Health         health.Health
+	Search         search.Search
+	Version        versions.Version
+	Trash          trash.Trash
+	Audit          audit.Audit
+	Lot            lots.Lot
+	Valuation      valuation.Valuation
+	Webhook        webhooks.Webhook
+	Jobs           jobs.Jobs
+	Scheduler      *scheduler.Scheduler
+	Reports        reports.Reports
+	FeedingTask    tasks.FeedingTask
 }
 
 // NewRoute
@@ -46,9 +78,20 @@ type RouteOption struct {
 func NewRoute(option RouteOption) *gin.Engine {
 
 	router := gin.New()
+	// handlers pass gin.Context on, this lets it carry the span, deadline and
+	// cancellation of the request context
+	router.ContextWithFallback = true
+	// X-Forwarded-For is only believed from the configured proxies, without
+	// any the client IP is the remote address
+	if err := router.SetTrustedProxies(option.Config.Clients.TrustedProxies); err != nil {
+		option.Logger.Error("trusted proxies", zap.Error(err))
+	}
 
 	router.Use(gin.Logger())
 	router.Use(gin.Recovery())
+	router.Use(middleware.RequestID())
+	router.Use(middleware.Tracing())
+	router.Use(middleware.Metrics())
 
 	HandlerV1 := v1.New(&v1.HandlerV1Config{
 		Config:         option.Config,
@@ -63,6 +106,19 @@ func NewRoute(option RouteOption) *gin.Engine {
 		AnimalProduct:  option.AnimalProduct,
 		EatablesInfo:   option.Eatables,
 		Feeding: option.Feeding,
+		Supplier:       option.Supplier,
+		Health:         option.Health,
+		Search:         option.Search,
+		Version:        option.Version,
+		Trash:          option.Trash,
+		Audit:          option.Audit,
+		Lot:            option.Lot,
+		Valuation:      option.Valuation,
+		Webhook:        option.Webhook,
+		Jobs:           option.Jobs,
+		Scheduler:      option.Scheduler,
+		Reports:        option.Reports,
+		FeedingTask:    option.FeedingTask,
 	})
 
 	corsConfig := cors.DefaultConfig()
@@ -73,11 +129,24 @@ func NewRoute(option RouteOption) *gin.Engine {
 	corsConfig.AllowMethods = []string{"*"}
 	router.Use(cors.New(corsConfig))
 
-	// router.Use(middleware.Tracing)
 	// router.Use(middleware.CheckCasbinPermission(option.Enforcer, *option.Config))
 
 	router.Static("/media", "./media")
 	api := router.Group("/v1")
+	api.Use(middleware.RateLimit(option.RedisDB, "api", option.Live))
+	api.Use(middleware.Timeout(option.ContextTimeout, map[string]time.Duration{
+		"/v1/reports/spend":               option.ReportTimeout,
+		"/v1/reports/inventory-valuation": option.ReportTimeout,
+		"/v1/reports/feed-cost":           option.ReportTimeout,
+	}))
+	api.Use(middleware.ETag())
+	api.Use(middleware.Idempotency(option.RedisDB, option.Live, option.Config.Idempotency.TTL))
+	// innermost, so the error answer goes through the middlewares above
+	api.Use(middleware.Errors())
+
+	// expensive routes have their own limits on top of the api one
+	searchLimit := middleware.RateLimit(option.RedisDB, "search", option.Live)
+	uploadLimit := middleware.RateLimit(option.RedisDB, "upload", option.Live)
 
 	// ANIMAL METHODS
 	api.POST("/animal", HandlerV1.CreateAnimal)
@@ -114,6 +183,52 @@ func NewRoute(option RouteOption) *gin.Engine {
 	api.GET("/delivery", HandlerV1.ListDelivery)
 	api.PUT("/delivery", HandlerV1.UpdateDelivery)
 	api.DELETE("/delivery/:id", HandlerV1.DeleteDelivery)
+	api.POST("/delivery/:id/invoice", uploadLimit, HandlerV1.UploadDeliveryInvoice)
+
+	// SUPPLIER METHODS
+	api.POST("/suppliers", HandlerV1.CreateSupplier)
+	api.GET("/suppliers/:id", HandlerV1.GetSupplier)
+	api.GET("/suppliers", HandlerV1.ListSuppliers)
+	api.PUT("/suppliers", HandlerV1.UpdateSupplier)
+	api.DELETE("/suppliers/:id", HandlerV1.DeleteSupplier)
+	api.GET("/suppliers/:id/deliveries", HandlerV1.ListSupplierDeliveries)
+
+	// STOCK LOT METHODS
+	api.GET("/lots", HandlerV1.ListStockLots)
+	api.GET("/lots/expiring", HandlerV1.ExpiringStockLots)
+	api.GET("/lots/:id", HandlerV1.GetStockLot)
+	api.POST("/lots/write-off", HandlerV1.WriteOffStockLots)
+
+	// WEBHOOK METHODS
+	api.POST("/webhooks", HandlerV1.CreateWebhook)
+	api.GET("/webhooks/:id", HandlerV1.GetWebhook)
+	api.GET("/webhooks", HandlerV1.ListWebhooks)
+	api.PUT("/webhooks", HandlerV1.UpdateWebhook)
+	api.DELETE("/webhooks/:id", HandlerV1.DeleteWebhook)
+	api.GET("/webhooks/deliveries", HandlerV1.ListWebhookDeliveries)
+	api.POST("/webhooks/deliveries/:id/redeliver", HandlerV1.RedeliverWebhook)
+
+	// AUDIT METHODS
+	api.GET("/audit", HandlerV1.ListAuditLogs)
+	api.GET("/audit/:entity_type/:id", HandlerV1.EntityHistory)
+
+	// SEARCH METHODS
+	api.GET("/search", searchLimit, HandlerV1.SearchEntities)
+
+	// TRASH METHODS
+	api.GET("/trash/:entity_type", HandlerV1.ListTrash)
+	api.POST("/trash/:entity_type/:id/restore", HandlerV1.RestoreFromTrash)
+	api.POST("/trash/purge", HandlerV1.PurgeTrash)
+
+	// REPORT METHODS
+	api.GET("/reports/spend", HandlerV1.SpendReport)
+	api.GET("/reports/inventory-valuation", HandlerV1.InventoryValuation)
+	api.GET("/reports/feed-cost", HandlerV1.FeedCostReport)
+	api.GET("/reports/snapshots", HandlerV1.ListReportSnapshots)
+
+	// JOB METHODS
+	api.GET("/jobs", HandlerV1.ListJobs)
+	api.GET("/jobs/runs", HandlerV1.ListJobRuns)
 
 	// ANIMAL PRODUCT METHODS
 	api.POST("/animals/products", HandlerV1.CreateAnimalProduct)
@@ -133,8 +248,24 @@ func NewRoute(option RouteOption) *gin.Engine {
 
 	// ANIMAL GIVEN EATABLES
 	api.POST("/animals/given-eatables", HandlerV1.CreateGivenEatables)
+	api.GET("/animals/given-eatables", HandlerV1.ListGivenEatables)
 	api.PUT("/animals/given-eatables", HandlerV1.UpdateGivenEatables)
 	api.DELETE("//animals/given-eatables/:id", HandlerV1.DeleteGivenEatables)
+	api.GET("/animals/given-eatables/:id/cost", HandlerV1.GetGivenEatablesCost)
+
+	// FEEDING TASK METHODS
+	api.GET("/feeding-tasks/today", HandlerV1.TodayFeedingTasks)
+	api.GET("/feeding-tasks", HandlerV1.ListFeedingTasks)
+	api.GET("/feeding-tasks/:id", HandlerV1.GetFeedingTask)
+	api.POST("/feeding-tasks/:id/done", HandlerV1.CompleteFeedingTask)
+	api.POST("/feeding-tasks/:id/skip", HandlerV1.SkipFeedingTask)
+	api.POST("/feeding-tasks/generate", HandlerV1.GenerateFeedingTasks)
+
+	// probes
+	router.GET("/healthz", HandlerV1.Healthz)
+	router.GET("/readyz", HandlerV1.Readyz)
+
+	router.GET("/metrics", gin.WrapH(metrics.Handler()))
 
 	url := ginSwagger.URL("swagger/doc.json")
 	api.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler, url))
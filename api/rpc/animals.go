@@ -0,0 +1,181 @@
+package rpc
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/spf13/cast"
+
+	"musobaqa/farm-competition/api/models"
+	farmv1 "musobaqa/farm-competition/api/proto/farm/v1"
+	"musobaqa/farm-competition/internal/entity"
+	"musobaqa/farm-competition/internal/pkg/otlp"
+	"musobaqa/farm-competition/internal/usecase/animals"
+)
+
+type animalService struct {
+	farmv1.UnimplementedAnimalServiceServer
+	base
+	animals animals.Animal
+}
+
+func (s *animalService) CreateAnimal(ctx context.Context, req *farmv1.CreateAnimalRequest) (*farmv1.Animal, error) {
+	ctx, span := otlp.Start(ctx, "rpc", "CreateAnimal")
+	defer span.End()
+
+	body := models.AnimalReq{
+		Name:         req.GetName(),
+		CategoryName: req.GetCategoryName(),
+		Gender:       req.GetGender(),
+		DateOfBirth:  req.GetDateOfBirth(),
+		Description:  req.GetDescription(),
+		Genus:        req.GetGenus(),
+		Weight:       float32(req.GetWeight()),
+		IsHealth:     req.GetIsHealth(),
+	}
+	if err := body.Validate(); err != nil {
+		return nil, err
+	}
+
+	res, err := s.animals.Create(ctx, &entity.Animal{
+		ID:           uuid.NewString(),
+		Name:         body.Name,
+		CategoryName: body.CategoryName,
+		Gender:       body.Gender,
+		BirthDay:     body.DateOfBirth,
+		Genus:        body.Genus,
+		Weight:       req.GetWeight(),
+		IsHealth:     cast.ToString(body.IsHealth),
+		Description:  body.Description,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.record(ctx, entity.AuditActionCreate, "animal", res.ID, nil)
+
+	return animalMessage(res), nil
+}
+
+func (s *animalService) GetAnimal(ctx context.Context, req *farmv1.GetRequest) (*farmv1.Animal, error) {
+	ctx, span := otlp.Start(ctx, "rpc", "GetAnimal")
+	defer span.End()
+
+	res, err := s.animals.Get(ctx, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+
+	return animalMessage(res), nil
+}
+
+func (s *animalService) UpdateAnimal(ctx context.Context, req *farmv1.UpdateAnimalRequest) (*farmv1.Animal, error) {
+	ctx, span := otlp.Start(ctx, "rpc", "UpdateAnimal")
+	defer span.End()
+
+	version, err := s.ifMatch(ctx, "animal", req.GetId(), req.GetVersion())
+	if err != nil {
+		return nil, err
+	}
+
+	before := s.auditBefore(ctx, "animal", req.GetId())
+
+	res, err := s.animals.Update(ctx, &entity.Animal{
+		ID:           req.GetId(),
+		Name:         req.GetName(),
+		CategoryName: req.GetCategoryName(),
+		Gender:       req.GetGender(),
+		BirthDay:     req.GetDateOfBirth(),
+		Genus:        req.GetGenus(),
+		Weight:       req.GetWeight(),
+		IsHealth:     cast.ToString(req.GetIsHealth()),
+		Description:  req.GetDescription(),
+		Version:      version,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.record(ctx, entity.AuditActionUpdate, "animal", req.GetId(), before)
+
+	return animalMessage(res), nil
+}
+
+func (s *animalService) DeleteAnimal(ctx context.Context, req *farmv1.DeleteRequest) (*farmv1.DeleteResponse, error) {
+	ctx, span := otlp.Start(ctx, "rpc", "DeleteAnimal")
+	defer span.End()
+
+	if _, err := s.animals.Get(ctx, req.GetId()); err != nil {
+		return nil, err
+	}
+
+	version, err := s.ifMatch(ctx, "animal", req.GetId(), req.GetVersion())
+	if err != nil {
+		return nil, err
+	}
+
+	before := s.auditBefore(ctx, "animal", req.GetId())
+
+	if err := s.animals.Delete(ctx, req.GetId(), version); err != nil {
+		return nil, err
+	}
+
+	s.record(ctx, entity.AuditActionDelete, "animal", req.GetId(), before)
+
+	return &farmv1.DeleteResponse{}, nil
+}
+
+func (s *animalService) ListAnimals(ctx context.Context, req *farmv1.ListRequest) (*farmv1.ListAnimalsResponse, error) {
+	ctx, span := otlp.Start(ctx, "rpc", "ListAnimals")
+	defer span.End()
+
+	page, limit, request := listRequest(req)
+
+	// a bare weight keeps matching animals within ten percent of it
+	request.Near("weight")
+
+	list, err := s.animals.List(ctx, page, limit, request)
+	if err != nil {
+		return nil, err
+	}
+
+	return animalList(list), nil
+}
+
+func (s *animalService) ListHungryAnimals(ctx context.Context, req *farmv1.ListRequest) (*farmv1.ListAnimalsResponse, error) {
+	ctx, span := otlp.Start(ctx, "rpc", "ListHungryAnimals")
+	defer span.End()
+
+	page, limit, _ := listRequest(req)
+
+	list, err := s.animals.HungryAnimals(ctx, page, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	return animalList(list), nil
+}
+
+func animalMessage(animal *entity.Animal) *farmv1.Animal {
+	return &farmv1.Animal{
+		Id:           animal.ID,
+		Name:         animal.Name,
+		CategoryName: animal.CategoryName,
+		Gender:       animal.Gender,
+		DateOfBirth:  animal.BirthDay,
+		Genus:        animal.Genus,
+		Weight:       animal.Weight,
+		IsHealth:     cast.ToBool(animal.IsHealth),
+		Description:  animal.Description,
+		Version:      animal.Version,
+		CreatedAt:    timestamp(animal.CreatedAt),
+	}
+}
+
+func animalList(list *entity.ListAnimal) *farmv1.ListAnimalsResponse {
+	res := &farmv1.ListAnimalsResponse{Count: list.TotalCount}
+	for _, animal := range list.Animals {
+		res.Animals = append(res.Animals, animalMessage(animal))
+	}
+	return res
+}
@@ -0,0 +1,181 @@
+package rpc
+
+import (
+	"context"
+
+	"musobaqa/farm-competition/api/models"
+	farmv1 "musobaqa/farm-competition/api/proto/farm/v1"
+	"musobaqa/farm-competition/internal/entity"
+	"musobaqa/farm-competition/internal/pkg/otlp"
+	"musobaqa/farm-competition/internal/usecase/feeding"
+)
+
+type feedingService struct {
+	farmv1.UnimplementedFeedingServiceServer
+	base
+	feeding feeding.Feeding
+}
+
+func (s *feedingService) CreateFeeding(ctx context.Context, req *farmv1.CreateFeedingRequest) (*farmv1.Feeding, error) {
+	ctx, span := otlp.Start(ctx, "rpc", "CreateFeeding")
+	defer span.End()
+
+	body := models.AnimaGivenEatablesReq{
+		AnimalID:   req.GetAnimalId(),
+		EatablesID: req.GetEatablesId(),
+		Category:   req.GetCategory(),
+		Day:        req.GetDay(),
+	}
+	if err := body.Validate(); err != nil {
+		return nil, err
+	}
+
+	res, err := s.feeding.Create(ctx, &entity.Feeding{
+		AnimalID:   body.AnimalID,
+		EatablesID: body.EatablesID,
+		Category:   body.Category,
+		Daily:      daily(req.GetDaily()),
+		Day:        body.Day,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.record(ctx, entity.AuditActionCreate, "given_eatable", res.ID, nil)
+
+	return feedingMessage(res), nil
+}
+
+func (s *feedingService) UpdateFeeding(ctx context.Context, req *farmv1.UpdateFeedingRequest) (*farmv1.Feeding, error) {
+	ctx, span := otlp.Start(ctx, "rpc", "UpdateFeeding")
+	defer span.End()
+
+	version, err := s.ifMatch(ctx, "given_eatable", req.GetId(), req.GetVersion())
+	if err != nil {
+		return nil, err
+	}
+
+	before := s.auditBefore(ctx, "given_eatable", req.GetId())
+
+	res, err := s.feeding.Update(ctx, &entity.Feeding{
+		ID:         req.GetId(),
+		AnimalID:   req.GetAnimalId(),
+		EatablesID: req.GetEatablesId(),
+		Category:   req.GetCategory(),
+		Daily:      daily(req.GetDaily()),
+		Day:        req.GetDay(),
+		Version:    version,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.record(ctx, entity.AuditActionUpdate, "given_eatable", req.GetId(), before)
+
+	return feedingMessage(res), nil
+}
+
+func (s *feedingService) DeleteFeeding(ctx context.Context, req *farmv1.DeleteRequest) (*farmv1.DeleteResponse, error) {
+	ctx, span := otlp.Start(ctx, "rpc", "DeleteFeeding")
+	defer span.End()
+
+	version, err := s.ifMatch(ctx, "given_eatable", req.GetId(), req.GetVersion())
+	if err != nil {
+		return nil, err
+	}
+
+	before := s.auditBefore(ctx, "given_eatable", req.GetId())
+
+	if err := s.feeding.Delete(ctx, req.GetId(), version); err != nil {
+		return nil, err
+	}
+
+	s.record(ctx, entity.AuditActionDelete, "given_eatable", req.GetId(), before)
+
+	return &farmv1.DeleteResponse{}, nil
+}
+
+func (s *feedingService) ListFeedings(ctx context.Context, req *farmv1.ListRequest) (*farmv1.ListFeedingsResponse, error) {
+	ctx, span := otlp.Start(ctx, "rpc", "ListFeedings")
+	defer span.End()
+
+	page, limit, request := listRequest(req)
+
+	list, err := s.feeding.List(ctx, page, limit, request)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &farmv1.ListFeedingsResponse{
+		Count:      list.TotalCount,
+		NextCursor: list.NextCursor,
+	}
+	for _, feeding := range list.Feedings {
+		res.Feedings = append(res.Feedings, feedingMessage(feeding))
+	}
+	return res, nil
+}
+
+func (s *feedingService) ListOverdueFeedings(ctx context.Context, req *farmv1.ListOverdueFeedingsRequest) (*farmv1.ListOverdueFeedingsResponse, error) {
+	ctx, span := otlp.Start(ctx, "rpc", "ListOverdueFeedings")
+	defer span.End()
+
+	overdue, err := s.feeding.Overdue(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &farmv1.ListOverdueFeedingsResponse{}
+	for _, feeding := range overdue {
+		res.Feedings = append(res.Feedings, &farmv1.OverdueFeeding{
+			AnimalId:   feeding.AnimalID,
+			AnimalName: feeding.AnimalName,
+			EatablesId: feeding.EatablesID,
+			Category:   feeding.Category,
+			Name:       feeding.Name,
+			Due:        feeding.Due,
+			Given:      feeding.Given,
+		})
+	}
+	return res, nil
+}
+
+// daily turns the portions of a request into the daily plan of a feeding
+func daily(portions []*farmv1.Portion) []struct {
+	Capacity int64  `json:"capacity"`
+	Time     string `json:"time"`
+} {
+	var plan []struct {
+		Capacity int64  `json:"capacity"`
+		Time     string `json:"time"`
+	}
+	for _, portion := range portions {
+		plan = append(plan, struct {
+			Capacity int64  `json:"capacity"`
+			Time     string `json:"time"`
+		}{
+			Capacity: portion.GetCapacity(),
+			Time:     portion.GetTime(),
+		})
+	}
+	return plan
+}
+
+func feedingMessage(feeding *entity.FeedingRes) *farmv1.Feeding {
+	res := &farmv1.Feeding{
+		Id:         feeding.ID,
+		AnimalId:   feeding.AnimalID,
+		EatablesId: feeding.Eatables.ID,
+		Category:   feeding.Category,
+		Day:        feeding.Day,
+		Version:    feeding.Version,
+		CreatedAt:  timestamp(feeding.CreatedAt),
+	}
+	for _, portion := range feeding.Daily {
+		res.Daily = append(res.Daily, &farmv1.Portion{
+			Capacity: portion.Capacity,
+			Time:     portion.Time,
+		})
+	}
+	return res
+}
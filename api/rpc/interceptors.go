@@ -0,0 +1,122 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+	"runtime/debug"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/spf13/cast"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	apierrors "musobaqa/farm-competition/api/errors"
+	"musobaqa/farm-competition/api/middleware"
+	"musobaqa/farm-competition/api/models"
+	errorspkg "musobaqa/farm-competition/internal/errors"
+	"musobaqa/farm-competition/internal/pkg/config"
+	tokens "musobaqa/farm-competition/internal/pkg/token"
+)
+
+// anonymous is the actor of calls sent without a token
+const anonymous = "anonymous"
+
+type callerKey struct{}
+
+// caller is who sent a call, the audit log records it
+type caller struct {
+	actor     string
+	requestID string
+}
+
+func callerFrom(ctx context.Context) caller {
+	c, ok := ctx.Value(callerKey{}).(caller)
+	if !ok {
+		return caller{actor: anonymous}
+	}
+	return c
+}
+
+// Recovery answers a panicking call with Internal instead of taking the
+// server down
+func Recovery(logger *zap.Logger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp any, err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				logger.Error("grpc panic", zap.String("method", info.FullMethod), zap.Any("panic", r), zap.ByteString("stack", debug.Stack()))
+				err = apierrors.NewStatus(codes.Internal, errorspkg.CodeInternal, models.InternalMessage).Err()
+			}
+		}()
+		return handler(ctx, req)
+	}
+}
+
+// Caller reads the token and the request id from the metadata, the same
+// Authorization value the REST api takes. A call without a token is made by
+// anonymous, a token that does not verify is rejected
+func Caller(cfg *config.Config) grpc.UnaryServerInterceptor {
+	requestIDKey := metadataKey(middleware.RequestIDHeader)
+
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		md, _ := metadata.FromIncomingContext(ctx)
+
+		c := caller{actor: anonymous, requestID: first(md, requestIDKey)}
+		if c.requestID == "" {
+			c.requestID = uuid.New().String()
+		}
+		_ = grpc.SetHeader(ctx, metadata.Pairs(requestIDKey, c.requestID))
+
+		claims, err := tokens.ExtractBearer(first(md, "authorization"), []byte(cfg.Token.SignInKey))
+		switch {
+		case errors.Is(err, tokens.ErrNoToken):
+		case err != nil:
+			return nil, status.Error(codes.Unauthenticated, "invalid token")
+		default:
+			if sub := cast.ToString(claims["sub"]); sub != "" {
+				c.actor = sub
+			}
+		}
+
+		return handler(context.WithValue(ctx, callerKey{}, c), req)
+	}
+}
+
+// Timeout puts the request deadline of the config on every call, a shorter
+// deadline sent by the client is kept
+func Timeout(timeout time.Duration) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		ctx, cancel := context.WithTimeout(ctx, timeout)
+		defer cancel()
+		return handler(ctx, req)
+	}
+}
+
+// Errors logs the errors of the services and turns them into grpc statuses
+// from the error catalog, like the error middleware does for the REST api
+func Errors(logger *zap.Logger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		resp, err := handler(ctx, req)
+		if err != nil {
+			logger.Error(err.Error(), zap.String("method", info.FullMethod))
+			return nil, apierrors.Status(err).Err()
+		}
+		return resp, nil
+	}
+}
+
+// metadataKey is the grpc form of an http header name
+func metadataKey(header string) string {
+	return strings.ToLower(header)
+}
+
+func first(md metadata.MD, key string) string {
+	if values := md.Get(key); len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
@@ -0,0 +1,487 @@
+package rpc
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"musobaqa/farm-competition/api/models"
+	farmv1 "musobaqa/farm-competition/api/proto/farm/v1"
+	"musobaqa/farm-competition/internal/entity"
+	"musobaqa/farm-competition/internal/pkg/otlp"
+	"musobaqa/farm-competition/internal/usecase/drugs"
+	"musobaqa/farm-competition/internal/usecase/foods"
+	"musobaqa/farm-competition/internal/usecase/products"
+)
+
+type inventoryService struct {
+	farmv1.UnimplementedInventoryServiceServer
+	base
+	food    foods.Food
+	drug    drugs.Drug
+	product products.Product
+}
+
+// CreateFood adds the capacity to the food of the same name when there is
+// one, like the REST api does
+func (s *inventoryService) CreateFood(ctx context.Context, req *farmv1.CreateFoodRequest) (*farmv1.Food, error) {
+	ctx, span := otlp.Start(ctx, "rpc", "CreateFood")
+	defer span.End()
+
+	body := models.FoodReq{
+		FoodName:      req.GetName(),
+		Union:         req.GetUnion(),
+		Description:   req.GetDescription(),
+		TotalCapacity: int64(req.GetCapacity()),
+	}
+	if err := body.Validate(); err != nil {
+		return nil, err
+	}
+
+	exists, err := s.food.UniqueFoodName(ctx, body.FoodName)
+	if err != nil {
+		return nil, err
+	}
+
+	if exists != 0 {
+		food, err := s.food.Get(ctx, map[string]string{"name": body.FoodName})
+		if err != nil {
+			return nil, err
+		}
+
+		before := s.auditBefore(ctx, "food", food.ID)
+
+		res, err := s.food.Update(ctx, &entity.Food{
+			ID:          food.ID,
+			Name:        food.Name,
+			Capacity:    food.Capacity + req.GetCapacity(),
+			Union:       food.Union,
+			Description: food.Description,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		s.record(ctx, entity.AuditActionUpdate, "food", res.ID, before)
+		return foodMessage(res), nil
+	}
+
+	res, err := s.food.Create(ctx, &entity.Food{
+		ID:          uuid.New().String(),
+		Name:        body.FoodName,
+		Capacity:    req.GetCapacity(),
+		Union:       body.Union,
+		Description: body.Description,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.record(ctx, entity.AuditActionCreate, "food", res.ID, nil)
+	return foodMessage(res), nil
+}
+
+func (s *inventoryService) GetFood(ctx context.Context, req *farmv1.GetRequest) (*farmv1.Food, error) {
+	ctx, span := otlp.Start(ctx, "rpc", "GetFood")
+	defer span.End()
+
+	res, err := s.food.Get(ctx, map[string]string{"id": req.GetId()})
+	if err != nil {
+		return nil, err
+	}
+
+	return foodMessage(res), nil
+}
+
+func (s *inventoryService) UpdateFood(ctx context.Context, req *farmv1.UpdateFoodRequest) (*farmv1.Food, error) {
+	ctx, span := otlp.Start(ctx, "rpc", "UpdateFood")
+	defer span.End()
+
+	version, err := s.ifMatch(ctx, "food", req.GetId(), req.GetVersion())
+	if err != nil {
+		return nil, err
+	}
+
+	before := s.auditBefore(ctx, "food", req.GetId())
+
+	res, err := s.food.Update(ctx, &entity.Food{
+		ID:          req.GetId(),
+		Name:        req.GetName(),
+		Capacity:    req.GetCapacity(),
+		Union:       req.GetUnion(),
+		Description: req.GetDescription(),
+		Version:     version,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.record(ctx, entity.AuditActionUpdate, "food", req.GetId(), before)
+
+	return foodMessage(res), nil
+}
+
+func (s *inventoryService) DeleteFood(ctx context.Context, req *farmv1.DeleteRequest) (*farmv1.DeleteResponse, error) {
+	ctx, span := otlp.Start(ctx, "rpc", "DeleteFood")
+	defer span.End()
+
+	if _, err := s.food.Get(ctx, map[string]string{"id": req.GetId()}); err != nil {
+		return nil, err
+	}
+
+	version, err := s.ifMatch(ctx, "food", req.GetId(), req.GetVersion())
+	if err != nil {
+		return nil, err
+	}
+
+	before := s.auditBefore(ctx, "food", req.GetId())
+
+	if err := s.food.Delete(ctx, req.GetId(), version); err != nil {
+		return nil, err
+	}
+
+	s.record(ctx, entity.AuditActionDelete, "food", req.GetId(), before)
+
+	return &farmv1.DeleteResponse{}, nil
+}
+
+func (s *inventoryService) ListFoods(ctx context.Context, req *farmv1.ListRequest) (*farmv1.ListFoodsResponse, error) {
+	ctx, span := otlp.Start(ctx, "rpc", "ListFoods")
+	defer span.End()
+
+	page, limit, request := listRequest(req)
+
+	list, err := s.food.List(ctx, page, limit, request)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &farmv1.ListFoodsResponse{Count: list.TotalCount}
+	for _, food := range list.Foods {
+		res.Foods = append(res.Foods, foodMessage(food))
+	}
+	return res, nil
+}
+
+// CreateDrug adds the capacity to the drug of the same name when there is
+// one, like the REST api does
+func (s *inventoryService) CreateDrug(ctx context.Context, req *farmv1.CreateDrugRequest) (*farmv1.Drug, error) {
+	ctx, span := otlp.Start(ctx, "rpc", "CreateDrug")
+	defer span.End()
+
+	body := models.DrugReq{
+		DrugName:      req.GetName(),
+		Union:         req.GetUnion(),
+		Description:   req.GetDescription(),
+		TotalCapacity: int64(req.GetCapacity()),
+		Status:        req.GetStatus(),
+	}
+	if err := body.Validate(); err != nil {
+		return nil, err
+	}
+
+	exists, err := s.drug.UniqueDrugName(ctx, body.DrugName)
+	if err != nil {
+		return nil, err
+	}
+
+	if exists != 0 {
+		drug, err := s.drug.Get(ctx, map[string]string{"name": body.DrugName})
+		if err != nil {
+			return nil, err
+		}
+
+		before := s.auditBefore(ctx, "drug", drug.ID)
+
+		res, err := s.drug.Update(ctx, &entity.Drug{
+			ID:          drug.ID,
+			Name:        drug.Name,
+			Status:      drug.Status,
+			Capacity:    drug.Capacity + req.GetCapacity(),
+			Union:       drug.Union,
+			Description: drug.Description,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		s.record(ctx, entity.AuditActionUpdate, "drug", res.ID, before)
+		return drugMessage(res), nil
+	}
+
+	res, err := s.drug.Create(ctx, &entity.Drug{
+		ID:          uuid.New().String(),
+		Name:        body.DrugName,
+		Status:      body.Status,
+		Capacity:    req.GetCapacity(),
+		Union:       body.Union,
+		Description: body.Description,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.record(ctx, entity.AuditActionCreate, "drug", res.ID, nil)
+	return drugMessage(res), nil
+}
+
+func (s *inventoryService) GetDrug(ctx context.Context, req *farmv1.GetRequest) (*farmv1.Drug, error) {
+	ctx, span := otlp.Start(ctx, "rpc", "GetDrug")
+	defer span.End()
+
+	res, err := s.drug.Get(ctx, map[string]string{"id": req.GetId()})
+	if err != nil {
+		return nil, err
+	}
+
+	return drugMessage(res), nil
+}
+
+func (s *inventoryService) UpdateDrug(ctx context.Context, req *farmv1.UpdateDrugRequest) (*farmv1.Drug, error) {
+	ctx, span := otlp.Start(ctx, "rpc", "UpdateDrug")
+	defer span.End()
+
+	version, err := s.ifMatch(ctx, "drug", req.GetId(), req.GetVersion())
+	if err != nil {
+		return nil, err
+	}
+
+	before := s.auditBefore(ctx, "drug", req.GetId())
+
+	res, err := s.drug.Update(ctx, &entity.Drug{
+		ID:          req.GetId(),
+		Name:        req.GetName(),
+		Status:      req.GetStatus(),
+		Capacity:    req.GetCapacity(),
+		Union:       req.GetUnion(),
+		Description: req.GetDescription(),
+		Version:     version,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.record(ctx, entity.AuditActionUpdate, "drug", req.GetId(), before)
+
+	return drugMessage(res), nil
+}
+
+func (s *inventoryService) DeleteDrug(ctx context.Context, req *farmv1.DeleteRequest) (*farmv1.DeleteResponse, error) {
+	ctx, span := otlp.Start(ctx, "rpc", "DeleteDrug")
+	defer span.End()
+
+	if _, err := s.drug.Get(ctx, map[string]string{"id": req.GetId()}); err != nil {
+		return nil, err
+	}
+
+	version, err := s.ifMatch(ctx, "drug", req.GetId(), req.GetVersion())
+	if err != nil {
+		return nil, err
+	}
+
+	before := s.auditBefore(ctx, "drug", req.GetId())
+
+	if err := s.drug.Delete(ctx, req.GetId(), version); err != nil {
+		return nil, err
+	}
+
+	s.record(ctx, entity.AuditActionDelete, "drug", req.GetId(), before)
+
+	return &farmv1.DeleteResponse{}, nil
+}
+
+func (s *inventoryService) ListDrugs(ctx context.Context, req *farmv1.ListRequest) (*farmv1.ListDrugsResponse, error) {
+	ctx, span := otlp.Start(ctx, "rpc", "ListDrugs")
+	defer span.End()
+
+	page, limit, request := listRequest(req)
+
+	list, err := s.drug.List(ctx, page, limit, request)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &farmv1.ListDrugsResponse{Count: list.TotalCount}
+	for _, drug := range list.Drugs {
+		res.Drugs = append(res.Drugs, drugMessage(drug))
+	}
+	return res, nil
+}
+
+// CreateProduct adds the capacity to the product of the same name when there
+// is one, like the REST api does
+func (s *inventoryService) CreateProduct(ctx context.Context, req *farmv1.CreateProductRequest) (*farmv1.Product, error) {
+	ctx, span := otlp.Start(ctx, "rpc", "CreateProduct")
+	defer span.End()
+
+	body := models.ProductReq{
+		ProductName:   req.GetName(),
+		Union:         req.GetUnion(),
+		Description:   req.GetDescription(),
+		TotalCapacity: req.GetTotalCapacity(),
+	}
+	if err := body.Validate(); err != nil {
+		return nil, err
+	}
+
+	exists, err := s.product.UniqueProductName(ctx, body.ProductName)
+	if err != nil {
+		return nil, err
+	}
+
+	if exists != 0 {
+		product, err := s.product.Get(ctx, map[string]string{"name": body.ProductName})
+		if err != nil {
+			return nil, err
+		}
+
+		before := s.auditBefore(ctx, "product", product.ID)
+
+		res, err := s.product.Update(ctx, &entity.Product{
+			ID:            product.ID,
+			Name:          product.Name,
+			TotalCapacity: product.TotalCapacity + body.TotalCapacity,
+			Union:         product.Union,
+			Description:   product.Description,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		s.record(ctx, entity.AuditActionUpdate, "product", res.ID, before)
+		return productMessage(res), nil
+	}
+
+	res, err := s.product.Create(ctx, &entity.Product{
+		ID:            uuid.New().String(),
+		Name:          body.ProductName,
+		Union:         body.Union,
+		TotalCapacity: body.TotalCapacity,
+		Description:   body.Description,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.record(ctx, entity.AuditActionCreate, "product", res.ID, nil)
+	return productMessage(res), nil
+}
+
+func (s *inventoryService) GetProduct(ctx context.Context, req *farmv1.GetRequest) (*farmv1.Product, error) {
+	ctx, span := otlp.Start(ctx, "rpc", "GetProduct")
+	defer span.End()
+
+	res, err := s.product.Get(ctx, map[string]string{"id": req.GetId()})
+	if err != nil {
+		return nil, err
+	}
+
+	return productMessage(res), nil
+}
+
+func (s *inventoryService) UpdateProduct(ctx context.Context, req *farmv1.UpdateProductRequest) (*farmv1.Product, error) {
+	ctx, span := otlp.Start(ctx, "rpc", "UpdateProduct")
+	defer span.End()
+
+	version, err := s.ifMatch(ctx, "product", req.GetId(), req.GetVersion())
+	if err != nil {
+		return nil, err
+	}
+
+	before := s.auditBefore(ctx, "product", req.GetId())
+
+	res, err := s.product.Update(ctx, &entity.Product{
+		ID:            req.GetId(),
+		Name:          req.GetName(),
+		Union:         req.GetUnion(),
+		TotalCapacity: req.GetTotalCapacity(),
+		Description:   req.GetDescription(),
+		Version:       version,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.record(ctx, entity.AuditActionUpdate, "product", req.GetId(), before)
+
+	return productMessage(res), nil
+}
+
+func (s *inventoryService) DeleteProduct(ctx context.Context, req *farmv1.DeleteRequest) (*farmv1.DeleteResponse, error) {
+	ctx, span := otlp.Start(ctx, "rpc", "DeleteProduct")
+	defer span.End()
+
+	if _, err := s.product.Get(ctx, map[string]string{"id": req.GetId()}); err != nil {
+		return nil, err
+	}
+
+	version, err := s.ifMatch(ctx, "product", req.GetId(), req.GetVersion())
+	if err != nil {
+		return nil, err
+	}
+
+	before := s.auditBefore(ctx, "product", req.GetId())
+
+	if err := s.product.Delete(ctx, req.GetId(), version); err != nil {
+		return nil, err
+	}
+
+	s.record(ctx, entity.AuditActionDelete, "product", req.GetId(), before)
+
+	return &farmv1.DeleteResponse{}, nil
+}
+
+func (s *inventoryService) ListProducts(ctx context.Context, req *farmv1.ListRequest) (*farmv1.ListProductsResponse, error) {
+	ctx, span := otlp.Start(ctx, "rpc", "ListProducts")
+	defer span.End()
+
+	page, limit, request := listRequest(req)
+
+	list, err := s.product.List(ctx, page, limit, request)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &farmv1.ListProductsResponse{Count: list.TotalCount}
+	for _, product := range list.Products {
+		res.Products = append(res.Products, productMessage(product))
+	}
+	return res, nil
+}
+
+func foodMessage(food *entity.Food) *farmv1.Food {
+	return &farmv1.Food{
+		Id:          food.ID,
+		Name:        food.Name,
+		Union:       food.Union,
+		Description: food.Description,
+		Capacity:    food.Capacity,
+		Version:     food.Version,
+		CreatedAt:   timestamp(food.CreatedAt),
+	}
+}
+
+func drugMessage(drug *entity.Drug) *farmv1.Drug {
+	return &farmv1.Drug{
+		Id:          drug.ID,
+		Name:        drug.Name,
+		Status:      drug.Status,
+		Union:       drug.Union,
+		Description: drug.Description,
+		Capacity:    drug.Capacity,
+		Version:     drug.Version,
+		CreatedAt:   timestamp(drug.CreatedAt),
+	}
+}
+
+func productMessage(product *entity.Product) *farmv1.Product {
+	return &farmv1.Product{
+		Id:            product.ID,
+		Name:          product.Name,
+		Union:         product.Union,
+		Description:   product.Description,
+		TotalCapacity: product.TotalCapacity,
+		Version:       product.Version,
+		CreatedAt:     timestamp(product.CreatedAt),
+	}
+}
@@ -0,0 +1,77 @@
+// Package rpc serves the farm over grpc, next to the REST api. The services
+// are defined in api/proto and run on the same usecases as the gin handlers,
+// with the same validation, version checks and audit trail
+package rpc
+
+import (
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
+
+	farmv1 "musobaqa/farm-competition/api/proto/farm/v1"
+	"musobaqa/farm-competition/internal/pkg/config"
+	animalproduct "musobaqa/farm-competition/internal/usecase/animal-product"
+	"musobaqa/farm-competition/internal/usecase/animals"
+	"musobaqa/farm-competition/internal/usecase/audit"
+	"musobaqa/farm-competition/internal/usecase/drugs"
+	"musobaqa/farm-competition/internal/usecase/feeding"
+	"musobaqa/farm-competition/internal/usecase/foods"
+	"musobaqa/farm-competition/internal/usecase/products"
+	"musobaqa/farm-competition/internal/usecase/versions"
+)
+
+type ServerOption struct {
+	Config        *config.Config
+	Logger        *zap.Logger
+	Animals       animals.Animal
+	Food          foods.Food
+	Drug          drugs.Drug
+	Product       products.Product
+	Feeding       feeding.Feeding
+	AnimalProduct animalproduct.AnimalProduct
+	Version       versions.Version
+	Audit         audit.Audit
+}
+
+// NewServer registers the farm services, reflection and the grpc health
+// service. The health server is returned so that it can fail before the
+// server stops, like readiness does on the REST api
+func NewServer(option ServerOption) (*grpc.Server, *health.Server) {
+	server := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(
+			Recovery(option.Logger),
+			Caller(option.Config),
+			Timeout(option.Config.Context.Timeout),
+			Errors(option.Logger),
+		),
+	)
+
+	base := base{
+		config:  option.Config,
+		logger:  option.Logger,
+		version: option.Version,
+		audit:   option.Audit,
+	}
+
+	farmv1.RegisterAnimalServiceServer(server, &animalService{base: base, animals: option.Animals})
+	farmv1.RegisterInventoryServiceServer(server, &inventoryService{
+		base:    base,
+		food:    option.Food,
+		drug:    option.Drug,
+		product: option.Product,
+	})
+	farmv1.RegisterFeedingServiceServer(server, &feedingService{base: base, feeding: option.Feeding})
+	farmv1.RegisterYieldServiceServer(server, &yieldService{
+		base:          base,
+		animalProduct: option.AnimalProduct,
+		product:       option.Product,
+	})
+
+	healthServer := health.NewServer()
+	healthpb.RegisterHealthServer(server, healthServer)
+	reflection.Register(server)
+
+	return server, healthServer
+}
@@ -0,0 +1,163 @@
+package rpc_test
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	farmv1 "musobaqa/farm-competition/api/proto/farm/v1"
+	"musobaqa/farm-competition/api/rpc"
+	"musobaqa/farm-competition/internal/entity"
+	"musobaqa/farm-competition/internal/pkg/config"
+	"musobaqa/farm-competition/internal/pkg/listquery"
+	tokens "musobaqa/farm-competition/internal/pkg/token"
+)
+
+type animalsStub struct {
+	animals map[string]*entity.Animal
+}
+
+func (s *animalsStub) Create(ctx context.Context, animal *entity.Animal) (*entity.Animal, error) {
+	animal.Version = 1
+	s.animals[animal.ID] = animal
+	return animal, nil
+}
+
+func (s *animalsStub) Update(ctx context.Context, animal *entity.Animal) (*entity.Animal, error) {
+	animal.Version = s.animals[animal.ID].Version + 1
+	s.animals[animal.ID] = animal
+	return animal, nil
+}
+
+func (s *animalsStub) Delete(ctx context.Context, animalID string, version int64) error { return nil }
+
+func (s *animalsStub) Get(ctx context.Context, animalID string) (*entity.Animal, error) {
+	return s.animals[animalID], nil
+}
+
+func (s *animalsStub) List(ctx context.Context, page, limit uint64, request listquery.Request) (*entity.ListAnimal, error) {
+	return &entity.ListAnimal{}, nil
+}
+
+func (s *animalsStub) HungryAnimals(ctx context.Context, page, limit uint64) (*entity.ListAnimal, error) {
+	return &entity.ListAnimal{}, nil
+}
+
+func (s *animalsStub) Current(ctx context.Context, entityType, entityID string) (int64, error) {
+	return s.animals[entityID].Version, nil
+}
+
+type auditStub struct {
+	logs []*entity.AuditLog
+}
+
+func (s *auditStub) Record(ctx context.Context, log *entity.AuditLog) error {
+	s.logs = append(s.logs, log)
+	return nil
+}
+
+func (s *auditStub) Snapshot(ctx context.Context, entityType, entityID string) (json.RawMessage, error) {
+	return nil, nil
+}
+
+func (s *auditStub) List(ctx context.Context, page, limit uint64, params map[string]any) (*entity.ListAuditLogs, error) {
+	return nil, nil
+}
+
+func (s *auditStub) History(ctx context.Context, entityType, entityID string, page, limit uint64) (*entity.ListAuditLogs, error) {
+	return nil, nil
+}
+
+func TestServer(t *testing.T) {
+	var cfg config.Config
+	cfg.Token.SignInKey = "secret"
+	cfg.Context.Timeout = time.Second
+
+	animals := &animalsStub{animals: map[string]*entity.Animal{}}
+	audit := &auditStub{}
+
+	server, _ := rpc.NewServer(rpc.ServerOption{
+		Config:  &cfg,
+		Logger:  zap.NewNop(),
+		Animals: animals,
+		Version: animals,
+		Audit:   audit,
+	})
+	listener := bufconn.Listen(1 << 20)
+	go server.Serve(listener)
+	defer server.Stop()
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return listener.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	defer conn.Close()
+
+	client := farmv1.NewAnimalServiceClient(conn)
+	ctx := context.Background()
+
+	// validation failures name the fields
+	_, err = client.CreateAnimal(ctx, &farmv1.CreateAnimalRequest{Name: "bella", Gender: "cow", DateOfBirth: "2024-01-01"})
+	s := status.Convert(err)
+	assert.Equal(t, codes.InvalidArgument, s.Code())
+	var fields []string
+	for _, detail := range s.Details() {
+		switch detail := detail.(type) {
+		case *errdetails.ErrorInfo:
+			assert.Equal(t, "validation_failed", detail.Reason)
+		case *errdetails.BadRequest:
+			for _, violation := range detail.FieldViolations {
+				fields = append(fields, violation.Field)
+			}
+		}
+	}
+	assert.Equal(t, []string{"gender"}, fields)
+
+	// a bad token is rejected, a good one names the actor of the audit log
+	bad := metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer nope")
+	_, err = client.CreateAnimal(bad, &farmv1.CreateAnimalRequest{Name: "bella", Gender: "female", DateOfBirth: "2024-01-01"})
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	access, _, err := (&tokens.JwtHandler{Sub: "user-1", Role: "admin", SigninKey: "secret", Log: zap.NewNop()}).GenerateJwt()
+	require.NoError(t, err)
+	authorized := metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer "+access, "x-request-id", "req-1")
+
+	animal, err := client.CreateAnimal(authorized, &farmv1.CreateAnimalRequest{Name: "bella", Gender: "Female", DateOfBirth: "2024-01-01", Weight: 420})
+	require.NoError(t, err)
+	assert.Equal(t, "female", animal.Gender)
+	require.Len(t, audit.logs, 1)
+	assert.Equal(t, "user-1", audit.logs[0].Actor)
+	assert.Equal(t, "req-1", audit.logs[0].RequestID)
+
+	// the version field works like If-Match
+	_, err = client.UpdateAnimal(ctx, &farmv1.UpdateAnimalRequest{Id: animal.Id, Name: "daisy", Version: 7})
+	assert.Equal(t, codes.Aborted, status.Code(err))
+
+	updated, err := client.UpdateAnimal(ctx, &farmv1.UpdateAnimalRequest{Id: animal.Id, Name: "daisy", Version: animal.Version})
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), updated.Version)
+	assert.Equal(t, "anonymous", audit.logs[1].Actor)
+
+	cfg.Server.RequireIfMatch = true
+	_, err = client.DeleteAnimal(ctx, &farmv1.DeleteRequest{Id: animal.Id})
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+
+	health, err := healthpb.NewHealthClient(conn).Check(ctx, &healthpb.HealthCheckRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, healthpb.HealthCheckResponse_SERVING, health.Status)
+}
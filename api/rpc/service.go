@@ -0,0 +1,113 @@
+package rpc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	apierrors "musobaqa/farm-competition/api/errors"
+	farmv1 "musobaqa/farm-competition/api/proto/farm/v1"
+	"musobaqa/farm-competition/internal/entity"
+	errorspkg "musobaqa/farm-competition/internal/errors"
+	"musobaqa/farm-competition/internal/pkg/config"
+	"musobaqa/farm-competition/internal/pkg/listquery"
+	"musobaqa/farm-competition/internal/usecase/audit"
+	"musobaqa/farm-competition/internal/usecase/versions"
+)
+
+// versionRequired answers changes sent without a version while the config
+// requires one, like a missing If-Match does on the REST api
+const versionRequired = "version is required"
+
+// base holds what every service shares with the gin handlers
+type base struct {
+	config  *config.Config
+	logger  *zap.Logger
+	version versions.Version
+	audit   audit.Audit
+}
+
+// ifMatch checks the version a change was sent for against the current
+// version of the entity, the version field plays the part of If-Match. It
+// returns the version the change must be applied to, zero for an
+// unconditional call
+func (b *base) ifMatch(ctx context.Context, entityType, entityID string, version int64) (int64, error) {
+	if version == 0 {
+		if b.config.Server.RequireIfMatch {
+			return 0, apierrors.NewStatus(codes.FailedPrecondition, errorspkg.CodePreconditionRequired, versionRequired).Err()
+		}
+		return 0, nil
+	}
+
+	current, err := b.version.Current(ctx, entityType, entityID)
+	if err != nil {
+		// a gone entity cannot match any version
+		if !errors.Is(err, errorspkg.ErrorNotFound) {
+			return 0, err
+		}
+		return 0, errorspkg.ErrorVersionConflict
+	}
+	if current != version {
+		return 0, errorspkg.ErrorVersionConflict
+	}
+
+	return current, nil
+}
+
+// auditBefore snapshots the entity before it is updated or deleted
+func (b *base) auditBefore(ctx context.Context, entityType, entityID string) json.RawMessage {
+	before, err := b.audit.Snapshot(ctx, entityType, entityID)
+	if err != nil {
+		b.logger.Error("failed to snapshot entity for audit", zap.Error(err))
+	}
+	return before
+}
+
+// record writes a mutation made by the call to the audit log, failures are
+// only logged
+func (b *base) record(ctx context.Context, action, entityType, entityID string, before json.RawMessage) {
+	c := callerFrom(ctx)
+
+	err := b.audit.Record(ctx, &entity.AuditLog{
+		Actor:      c.actor,
+		Action:     action,
+		EntityType: entityType,
+		EntityID:   entityID,
+		Before:     before,
+		RequestID:  c.requestID,
+	})
+	if err != nil {
+		b.logger.Error("failed to record audit log", zap.Error(err))
+	}
+}
+
+// listRequest reads a list request the way the REST list endpoints read
+// their query, listquery.Page defaults and caps page and limit
+func listRequest(req *farmv1.ListRequest) (uint64, uint64, listquery.Request) {
+	page, limit := listquery.Page(req.GetPage(), req.GetLimit())
+
+	filters := make(map[string]string, len(req.GetFilters()))
+	for key, value := range req.GetFilters() {
+		filters[key] = value
+	}
+
+	return page, limit, listquery.Request{
+		Filters:  filters,
+		Ordering: req.GetOrdering(),
+		Search:   req.GetSearch(),
+		Cursor:   req.GetCursor(),
+	}
+}
+
+// timestamp leaves the creation time out when the usecase did not load it
+func timestamp(t time.Time) *timestamppb.Timestamp {
+	if t.IsZero() {
+		return nil
+	}
+	return timestamppb.New(t)
+}
@@ -0,0 +1,164 @@
+package rpc
+
+import (
+	"context"
+
+	"musobaqa/farm-competition/api/models"
+	farmv1 "musobaqa/farm-competition/api/proto/farm/v1"
+	"musobaqa/farm-competition/internal/entity"
+	"musobaqa/farm-competition/internal/pkg/otlp"
+	animalproduct "musobaqa/farm-competition/internal/usecase/animal-product"
+	"musobaqa/farm-competition/internal/usecase/products"
+)
+
+type yieldService struct {
+	farmv1.UnimplementedYieldServiceServer
+	base
+	animalProduct animalproduct.AnimalProduct
+	product       products.Product
+}
+
+// CreateYield records the yield and adds its capacity to the product, like
+// the REST api does
+func (s *yieldService) CreateYield(ctx context.Context, req *farmv1.CreateYieldRequest) (*farmv1.Yield, error) {
+	ctx, span := otlp.Start(ctx, "rpc", "CreateYield")
+	defer span.End()
+
+	body := models.AnimalProductReq{
+		AnimalID:  req.GetAnimalId(),
+		ProductID: req.GetProductId(),
+		Capacity:  req.GetCapacity(),
+		GetTime:   req.GetGetTime(),
+	}
+	if err := body.Validate(); err != nil {
+		return nil, err
+	}
+
+	res, err := s.animalProduct.Create(ctx, &entity.AnimalProductReq{
+		AnimalID:  body.AnimalID,
+		ProductID: body.ProductID,
+		Capacity:  body.Capacity,
+		GetTime:   body.GetTime,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.record(ctx, entity.AuditActionCreate, "animal_product", res.ID, nil)
+
+	_, err = s.product.Update(ctx, &entity.Product{
+		ID:            body.ProductID,
+		Name:          res.Product.Name,
+		Union:         res.Product.Union,
+		TotalCapacity: res.Product.TotalCapacity + body.Capacity,
+		Description:   res.Product.Description,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	yield := yieldMessage(res)
+	yield.ProductId = body.ProductID
+	return yield, nil
+}
+
+func (s *yieldService) GetYield(ctx context.Context, req *farmv1.GetRequest) (*farmv1.Yield, error) {
+	ctx, span := otlp.Start(ctx, "rpc", "GetYield")
+	defer span.End()
+
+	res, err := s.animalProduct.Get(ctx, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+
+	return yieldMessage(res), nil
+}
+
+func (s *yieldService) UpdateYield(ctx context.Context, req *farmv1.UpdateYieldRequest) (*farmv1.Yield, error) {
+	ctx, span := otlp.Start(ctx, "rpc", "UpdateYield")
+	defer span.End()
+
+	version, err := s.ifMatch(ctx, "animal_product", req.GetId(), req.GetVersion())
+	if err != nil {
+		return nil, err
+	}
+
+	before := s.auditBefore(ctx, "animal_product", req.GetId())
+
+	res, err := s.animalProduct.Update(ctx, &entity.AnimalProductReq{
+		ID:        req.GetId(),
+		AnimalID:  req.GetAnimalId(),
+		ProductID: req.GetProductId(),
+		Capacity:  req.GetCapacity(),
+		GetTime:   req.GetGetTime(),
+		Version:   version,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.record(ctx, entity.AuditActionUpdate, "animal_product", req.GetId(), before)
+
+	return yieldMessage(res), nil
+}
+
+func (s *yieldService) DeleteYield(ctx context.Context, req *farmv1.DeleteRequest) (*farmv1.DeleteResponse, error) {
+	ctx, span := otlp.Start(ctx, "rpc", "DeleteYield")
+	defer span.End()
+
+	if _, err := s.animalProduct.Get(ctx, req.GetId()); err != nil {
+		return nil, err
+	}
+
+	version, err := s.ifMatch(ctx, "animal_product", req.GetId(), req.GetVersion())
+	if err != nil {
+		return nil, err
+	}
+
+	before := s.auditBefore(ctx, "animal_product", req.GetId())
+
+	if err := s.animalProduct.Delete(ctx, req.GetId(), version); err != nil {
+		return nil, err
+	}
+
+	s.record(ctx, entity.AuditActionDelete, "animal_product", req.GetId(), before)
+
+	return &farmv1.DeleteResponse{}, nil
+}
+
+func (s *yieldService) ListYields(ctx context.Context, req *farmv1.ListRequest) (*farmv1.ListYieldsResponse, error) {
+	ctx, span := otlp.Start(ctx, "rpc", "ListYields")
+	defer span.End()
+
+	page, limit, request := listRequest(req)
+
+	list, err := s.animalProduct.List(ctx, page, limit, request)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &farmv1.ListYieldsResponse{
+		Count:      list.TotalCount,
+		NextCursor: list.NextCursor,
+	}
+	for _, yield := range list.AnimalProducts {
+		res.Yields = append(res.Yields, yieldMessage(yield))
+	}
+	return res, nil
+}
+
+func yieldMessage(yield *entity.AnimalProductRes) *farmv1.Yield {
+	return &farmv1.Yield{
+		Id:             yield.ID,
+		AnimalId:       yield.Animal.ID,
+		AnimalName:     yield.Animal.Name,
+		AnimalCategory: yield.Animal.CategoryName,
+		ProductId:      yield.Product.ID,
+		ProductName:    yield.Product.Name,
+		Union:          yield.Product.Union,
+		Capacity:       yield.Capacity,
+		GetTime:        yield.GetTime,
+		Version:        yield.Version,
+		CreatedAt:      timestamp(yield.CreatedAt),
+	}
+}
@@ -1,32 +1,17 @@
 package api
 
 import (
-	"fmt"
 	"net/http"
-	"time"
 
 	"musobaqa/farm-competition/internal/pkg/config"
 )
 
-func NewServer(cfg *config.Config, handler http.Handler) (*http.Server, error) {
-	readTimeout, err := time.ParseDuration(cfg.Server.ReadTimeout)
-	if err != nil {
-		return nil, fmt.Errorf("error while parsing server idle timeout: %v", err)
-	}
-	writeTimeout, err := time.ParseDuration(cfg.Server.WriteTimeout)
-	if err != nil {
-		return nil, fmt.Errorf("error while parsing server idle timeout: %v", err)
-	}
-	idleTimeout, err := time.ParseDuration(cfg.Server.IdleTimeout)
-	if err != nil {
-		return nil, fmt.Errorf("error while parsing server idle timeout: %v", err)
-	}
-
+func NewServer(cfg *config.Config, handler http.Handler) *http.Server {
 	return &http.Server{
 		Addr:         cfg.Server.Host + cfg.Server.Port,
 		Handler:      handler,
-		ReadTimeout:  readTimeout,
-		WriteTimeout: writeTimeout,
-		IdleTimeout:  idleTimeout,
-	}, nil
+		ReadTimeout:  cfg.Server.ReadTimeout,
+		WriteTimeout: cfg.Server.WriteTimeout,
+		IdleTimeout:  cfg.Server.IdleTimeout,
+	}
 }
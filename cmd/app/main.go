@@ -1,9 +1,12 @@
 package main
 
 import (
+	"fmt"
 	"log"
 	"os"
 	"os/signal"
+	"strconv"
+	"strings"
 	"syscall"
 
 	"go.uber.org/zap"
@@ -13,12 +16,30 @@ import (
 )
 
 func main() {
+	// app [migrate up|down|version|force | seed] [flags]
+	command, args := subcommand(os.Args[1:])
+
 	// config
-	config, err := configpkg.NewConfig()
+	config, err := configpkg.Load(args)
 	if err != nil {
 		log.Fatal(err)
 	}
 
+	if len(command) > 0 {
+		switch command[0] {
+		case "migrate":
+			err = app.Migrate(*config, command[1:])
+		case "seed":
+			err = app.Seed(*config)
+		default:
+			err = fmt.Errorf("unknown command %q, use migrate or seed", command[0])
+		}
+		if err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
 	// app
 	app, err := app.NewApp(*config)
 	if err != nil {
@@ -27,17 +48,41 @@ func main() {
 
 	// run application
 	go func() {
-		app.Logger.Info("Listen: ", zap.String("address", config.Server.Host+config.Server.Port))
+		app.Logger.Info("Listen: ", zap.String("address", config.Server.Host+config.Server.Port), zap.String("grpc", config.Server.Host+config.Server.GRPCPort))
 		if err := app.Run(); err != nil {
 			app.Logger.Error("app run", zap.Error(err))
 		}
 	}()
 
+	// SIGHUP reloads the config, a config that does not load or validate
+	// is logged and the running one is kept
 	sigs := make(chan os.Signal, 1)
-	signal.Notify(sigs, syscall.SIGINT, syscall.SIGTERM)
-	<-sigs
+	signal.Notify(sigs, syscall.SIGINT, syscall.SIGTERM, syscall.SIGHUP)
+	for sig := range sigs {
+		if sig != syscall.SIGHUP {
+			break
+		}
+
+		reloaded, err := configpkg.Load(args)
+		if err != nil {
+			app.Logger.Error("config reload", zap.Error(err))
+			continue
+		}
+		app.Reload(reloaded)
+	}
 
 	// app stops
 	app.Logger.Info("api gateway service stops")
 	app.Stop()
 }
+
+// subcommand splits the words naming a command from the config flags after
+// them, a negative number is a word so that migrate force -1 works
+func subcommand(args []string) ([]string, []string) {
+	for i, arg := range args {
+		if _, err := strconv.Atoi(arg); err != nil && strings.HasPrefix(arg, "-") {
+			return args[:i], args[i:]
+		}
+	}
+	return args, nil
+}
@@ -0,0 +1,442 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"musobaqa/farm-competition/internal/entity"
+	"musobaqa/farm-competition/internal/pkg/costing"
+	"musobaqa/farm-competition/internal/pkg/listquery"
+)
+
+// command is run with the positional arguments after its two words,
+// offline ones do not need the database
+type command struct {
+	args    int
+	offline bool
+	run     func(ctx context.Context, f *farm, o *options, args []string) (*table, error)
+}
+
+var commands = map[string]command{
+	"users list":             {run: listUsers},
+	"users create":           {args: 1, run: createUser},
+	"users set-role":         {args: 2, run: setRole},
+	"users delete":           {args: 1, run: deleteUser},
+	"roles list":             {offline: true, run: listRoles},
+	"animals list":           {run: listAnimals},
+	"animals hungry":         {run: hungryAnimals},
+	"animals get":            {args: 1, run: getAnimal},
+	"stock levels":           {run: stockLevels},
+	"stock lots":             {run: stockLots},
+	"stock expiring":         {run: expiringLots},
+	"deliveries list":        {run: listDeliveries},
+	"deliveries get":         {args: 1, run: getDelivery},
+	"feedings overdue":       {run: overdueFeedings},
+	"reports spend":          {run: spendReport},
+	"reports inventory":      {run: inventoryReport},
+	"reports feed-cost":      {run: feedCostReport},
+	"jobs purge-trash":       {run: purgeTrash},
+	"jobs write-off-expired": {run: writeOffExpired},
+	"jobs recalculate-stock": {run: recalculateStock},
+}
+
+// lookup finds the command named by the first two words, the rest are its
+// arguments and have to be as many as it takes
+func lookup(words []string) (command, []string, bool) {
+	if len(words) < 2 {
+		return command{}, nil, false
+	}
+
+	cmd, ok := commands[words[0]+" "+words[1]]
+	if !ok || len(words)-2 != cmd.args {
+		return command{}, nil, false
+	}
+
+	return cmd, words[2:], true
+}
+
+func userTable() *table {
+	return &table{Columns: []string{"id", "email", "full_name", "role", "created_at"}}
+}
+
+func (t *table) addUser(user *entity.User) {
+	t.add(user.ID, user.Email, user.FullName, user.Role, user.CreatedAt.Format(time.DateTime))
+}
+
+func listUsers(ctx context.Context, f *farm, o *options, _ []string) (*table, error) {
+	res, err := f.users.List(ctx, o.page, o.limit, o.role)
+	if err != nil {
+		return nil, err
+	}
+
+	t := userTable()
+	for _, user := range res.Users {
+		t.addUser(user)
+	}
+	t.sum("total", res.TotalCount)
+
+	return t, nil
+}
+
+// createUser takes the password from FARMCTL_PASSWORD when it is not given,
+// keeping it out of the shell history
+func createUser(ctx context.Context, f *farm, o *options, args []string) (*table, error) {
+	password := o.password
+	if password == "" {
+		password = os.Getenv("FARMCTL_PASSWORD")
+	}
+
+	user, err := f.users.Create(ctx, &entity.User{
+		Email:    args[0],
+		FullName: o.name,
+		Password: password,
+		Role:     o.role,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	t := userTable()
+	t.addUser(user)
+
+	return t, nil
+}
+
+func setRole(ctx context.Context, f *farm, _ *options, args []string) (*table, error) {
+	user, err := f.users.SetRole(ctx, args[0], args[1])
+	if err != nil {
+		return nil, err
+	}
+
+	t := userTable()
+	t.addUser(user)
+
+	return t, nil
+}
+
+func deleteUser(ctx context.Context, f *farm, _ *options, args []string) (*table, error) {
+	user, err := f.users.Get(ctx, args[0])
+	if err != nil {
+		return nil, err
+	}
+	if err := f.users.Delete(ctx, args[0]); err != nil {
+		return nil, err
+	}
+
+	t := userTable()
+	t.addUser(user)
+
+	return t, nil
+}
+
+func listRoles(context.Context, *farm, *options, []string) (*table, error) {
+	t := &table{Columns: []string{"role"}}
+	for _, role := range entity.Roles {
+		t.add(role)
+	}
+
+	return t, nil
+}
+
+func animalTable(animals []*entity.Animal) *table {
+	t := &table{Columns: []string{"id", "name", "category", "gender", "birth_day", "weight", "is_health"}}
+	for _, a := range animals {
+		t.add(a.ID, a.Name, a.CategoryName, a.Gender, a.BirthDay, a.Weight, a.IsHealth)
+	}
+
+	return t
+}
+
+func listAnimals(ctx context.Context, f *farm, o *options, _ []string) (*table, error) {
+	res, err := f.animals.List(ctx, o.page, o.limit, listquery.Request{Search: o.search})
+	if err != nil {
+		return nil, err
+	}
+
+	t := animalTable(res.Animals)
+	t.sum("total", res.TotalCount)
+
+	return t, nil
+}
+
+func hungryAnimals(ctx context.Context, f *farm, o *options, _ []string) (*table, error) {
+	res, err := f.animals.HungryAnimals(ctx, o.page, o.limit)
+	if err != nil {
+		return nil, err
+	}
+
+	t := animalTable(res.Animals)
+	t.sum("total", res.TotalCount)
+
+	return t, nil
+}
+
+func getAnimal(ctx context.Context, f *farm, _ *options, args []string) (*table, error) {
+	animal, err := f.animals.Get(ctx, args[0])
+	if err != nil {
+		return nil, err
+	}
+
+	return animalTable([]*entity.Animal{animal}), nil
+}
+
+func levelTable(levels []*entity.StockLevel) *table {
+	t := &table{Columns: []string{"category", "name", "quantity", "union"}}
+	for _, level := range levels {
+		t.add(level.Category, level.Name, level.Quantity, level.Union)
+	}
+
+	return t
+}
+
+func stockLevels(ctx context.Context, f *farm, o *options, _ []string) (*table, error) {
+	levels, err := f.lots.Levels(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if o.category != "" {
+		levels = slices.DeleteFunc(levels, func(level *entity.StockLevel) bool {
+			return level.Category != o.category
+		})
+	}
+
+	return levelTable(levels), nil
+}
+
+func lotTable(lots []*entity.StockLot) *table {
+	t := &table{Columns: []string{"id", "category", "name", "lot_number", "received_date", "expiry_date", "quantity", "remaining", "written_off"}}
+	for _, lot := range lots {
+		t.add(lot.ID, lot.Category, lot.Name, lot.LotNumber, lot.ReceivedDate, lot.ExpiryDate, lot.Quantity, lot.Remaining, lot.WrittenOff)
+	}
+
+	return t
+}
+
+func stockLots(ctx context.Context, f *farm, o *options, _ []string) (*table, error) {
+	res, err := f.lots.List(ctx, o.page, o.limit, map[string]any{
+		"category": o.category,
+		"name":     o.name,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	t := lotTable(res.Lots)
+	t.sum("total", res.TotalCount)
+
+	return t, nil
+}
+
+func expiringLots(ctx context.Context, f *farm, o *options, _ []string) (*table, error) {
+	if o.days < 0 {
+		return nil, fmt.Errorf("days cannot be negative")
+	}
+
+	res, err := f.lots.Expiring(ctx, o.days)
+	if err != nil {
+		return nil, err
+	}
+
+	return lotTable(res), nil
+}
+
+func deliveryTable(deliveries []*entity.Delivery) *table {
+	t := &table{Columns: []string{"id", "time", "category", "name", "capacity", "union", "supplier_id", "total_cost", "invoice_number"}}
+	for _, d := range deliveries {
+		t.add(d.ID, d.Time, d.Category, d.Name, d.Capacity, d.Union, d.SupplierID, d.TotalCost, d.InvoiceNumber)
+	}
+
+	return t
+}
+
+func listDeliveries(ctx context.Context, f *farm, o *options, _ []string) (*table, error) {
+	res, err := f.delivery.List(ctx, o.page, o.limit, listquery.Request{Search: o.search})
+	if err != nil {
+		return nil, err
+	}
+
+	t := deliveryTable(res.Deliveries)
+	t.sum("total", res.TotalCount)
+
+	return t, nil
+}
+
+func getDelivery(ctx context.Context, f *farm, _ *options, args []string) (*table, error) {
+	res, err := f.delivery.Get(ctx, args[0])
+	if err != nil {
+		return nil, err
+	}
+
+	return deliveryTable([]*entity.Delivery{res}), nil
+}
+
+func overdueFeedings(ctx context.Context, f *farm, _ *options, _ []string) (*table, error) {
+	res, err := f.feeding.Overdue(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	t := &table{Columns: []string{"animal_id", "animal", "category", "eatable", "due", "given"}}
+	var missed int64
+	for _, feeding := range res {
+		t.add(feeding.AnimalID, feeding.AnimalName, feeding.Category, feeding.Name, feeding.Due, feeding.Given)
+		missed += feeding.Due - feeding.Given
+	}
+	t.sum("missed", missed)
+
+	return t, nil
+}
+
+// reportOptions checks the grouping and the dates of a report, an empty
+// grouping is the first one allowed
+func reportOptions(o *options, groupings ...string) (string, error) {
+	groupBy := o.groupBy
+	if groupBy == "" {
+		groupBy = groupings[0]
+	}
+	if !slices.Contains(groupings, groupBy) {
+		return "", fmt.Errorf("unknown group-by %q, use %s", groupBy, strings.Join(groupings, ", "))
+	}
+
+	for _, date := range []string{o.from, o.to} {
+		if date == "" {
+			continue
+		}
+		if _, err := time.Parse(time.DateOnly, date); err != nil {
+			return "", fmt.Errorf("date %q is not YYYY-MM-DD", date)
+		}
+	}
+
+	return groupBy, nil
+}
+
+func spendReport(ctx context.Context, f *farm, o *options, _ []string) (*table, error) {
+	groupBy, err := reportOptions(o, "supplier", "item", "month")
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := f.supplier.SpendReport(ctx, groupBy, map[string]any{
+		"from":     o.from,
+		"to":       o.to,
+		"category": o.category,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	t := &table{Columns: []string{"key", "label", "capacity", "deliveries", "total_cost"}}
+	for _, item := range res.Items {
+		t.add(item.Key, item.Label, item.Capacity, item.Deliveries, item.TotalCost)
+	}
+	t.sum("group_by", res.GroupBy)
+	t.sum("total_cost", res.TotalCost)
+
+	return t, nil
+}
+
+func inventoryReport(ctx context.Context, f *farm, o *options, _ []string) (*table, error) {
+	if _, err := costing.ParseMethod(o.method); err != nil {
+		return nil, err
+	}
+
+	res, err := f.valuation.Inventory(ctx, o.method, o.category)
+	if err != nil {
+		return nil, err
+	}
+
+	t := &table{Columns: []string{"category", "name", "quantity", "unit_cost", "value"}}
+	for _, item := range res.Items {
+		t.add(item.Category, item.Name, item.Quantity, item.UnitCost, item.Value)
+	}
+	t.sum("method", res.Method)
+	t.sum("total_value", res.TotalValue)
+
+	return t, nil
+}
+
+func feedCostReport(ctx context.Context, f *farm, o *options, _ []string) (*table, error) {
+	if _, err := costing.ParseMethod(o.method); err != nil {
+		return nil, err
+	}
+	groupBy, err := reportOptions(o, "animal", "category")
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := f.valuation.FeedCostReport(ctx, o.method, groupBy, map[string]any{
+		"category": o.category,
+		"from":     o.from,
+		"to":       o.to,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	t := &table{Columns: []string{"key", "label", "quantity", "feedings", "cost"}}
+	for _, item := range res.Items {
+		t.add(item.Key, item.Label, item.Quantity, item.Feedings, item.Cost)
+	}
+	t.sum("method", res.Method)
+	t.sum("group_by", res.GroupBy)
+	t.sum("total_cost", res.TotalCost)
+
+	return t, nil
+}
+
+func purgeTrash(ctx context.Context, f *farm, _ *options, _ []string) (*table, error) {
+	purged, err := f.trash.Purge(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	t := &table{Columns: []string{"entity", "purged"}}
+	var names []string
+	for name := range purged {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	for _, name := range names {
+		t.add(name, purged[name])
+	}
+
+	return t, nil
+}
+
+// writeOffExpired writes off the expired lots and records each one in the
+// audit log like the stock lots endpoint does
+func writeOffExpired(ctx context.Context, f *farm, o *options, _ []string) (*table, error) {
+	writtenOff, err := f.lots.WriteOffExpired(ctx, o.reason)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, lot := range writtenOff {
+		err := f.audit.Record(ctx, &entity.AuditLog{
+			Actor:      actor,
+			Action:     entity.AuditActionUpdate,
+			EntityType: "stock_lot",
+			EntityID:   lot.ID,
+		})
+		if err != nil {
+			log.Printf("failed to record audit log of stock lot %s: %v", lot.ID, err)
+		}
+	}
+
+	return lotTable(writtenOff), nil
+}
+
+func recalculateStock(ctx context.Context, f *farm, _ *options, _ []string) (*table, error) {
+	levels, err := f.lots.Recalculate(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return levelTable(levels), nil
+}
@@ -0,0 +1,89 @@
+package main
+
+import (
+	"musobaqa/farm-competition/internal/infrastructure/repository/postgresql"
+	redisrepo "musobaqa/farm-competition/internal/infrastructure/repository/redis"
+	"musobaqa/farm-competition/internal/pkg/config"
+	"musobaqa/farm-competition/internal/pkg/costing"
+	"musobaqa/farm-competition/internal/pkg/postgres"
+	"musobaqa/farm-competition/internal/pkg/redis"
+
+	"musobaqa/farm-competition/internal/usecase/animals"
+	"musobaqa/farm-competition/internal/usecase/audit"
+	"musobaqa/farm-competition/internal/usecase/delivery"
+	"musobaqa/farm-competition/internal/usecase/feeding"
+	"musobaqa/farm-competition/internal/usecase/lots"
+	"musobaqa/farm-competition/internal/usecase/suppliers"
+	"musobaqa/farm-competition/internal/usecase/trash"
+	"musobaqa/farm-competition/internal/usecase/users"
+	"musobaqa/farm-competition/internal/usecase/valuation"
+)
+
+// actor is recorded in the audit log for changes made by farmctl
+const actor = "farmctl"
+
+// farm holds the usecases the commands run, built like the server builds
+// them. Their writes drop the cached reads of the server, stock changes made
+// by lots and feedings are dropped when the server relays their events
+type farm struct {
+	config    *config.Config
+	db        *postgres.PostgresDB
+	redis     *redis.RedisDB
+	users     users.User
+	animals   animals.Animal
+	lots      lots.Lot
+	delivery  delivery.Delivery
+	feeding   feeding.Feeding
+	supplier  suppliers.Supplier
+	valuation valuation.Valuation
+	trash     trash.Trash
+	audit     audit.Audit
+}
+
+func newFarm(cfg *config.Config) (*farm, error) {
+	db, err := postgres.New(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	costingMethod, err := costing.ParseMethod(cfg.Costing.Method)
+	if err != nil {
+		db.Close()
+		return nil, err
+	}
+
+	// without redis the writes still go through, the cached reads then run
+	// out with their TTL
+	redisdb, err := redis.New(cfg)
+	if err != nil {
+		db.Close()
+		return nil, err
+	}
+	cache := redisrepo.NewNamespaces(redisrepo.NewCache(redisdb), config.NewLive(cfg))
+
+	// commands may scan whole tables, they get the report deadline
+	timeout := cfg.Context.ReportTimeout
+
+	lotRepo := postgresql.NewStockLot(db)
+	appLotUseCase := lots.NewLotService(timeout, lotRepo, postgresql.NewOutbox(db), db)
+
+	return &farm{
+		config:    cfg,
+		db:        db,
+		redis:     redisdb,
+		users:     users.NewUserService(timeout, postgresql.NewUser(db)),
+		animals:   animals.NewAnimalCache(animals.NewAnimalService(timeout, postgresql.NewAnimal(db), postgresql.NewOutbox(db), db), cache),
+		lots:      appLotUseCase,
+		delivery:  delivery.NewDeliveryCache(delivery.NewDeliveryService(timeout, postgresql.NewDelivery(db), appLotUseCase, postgresql.NewOutbox(db), db), cache),
+		feeding:   feeding.NewFeedingService(timeout, postgresql.NewFeeding(db), lotRepo, postgresql.NewOutbox(db), db),
+		supplier:  suppliers.NewSupplierCache(suppliers.NewSupplierService(timeout, postgresql.NewSupplier(db)), cache),
+		valuation: valuation.NewValuationService(timeout, postgresql.NewCosting(db), costingMethod),
+		trash:     trash.NewTrashCache(trash.NewTrashService(timeout, postgresql.NewTrash(db), cfg.Trash.Retention), cache),
+		audit:     audit.NewAuditService(timeout, postgresql.NewAudit(db)),
+	}, nil
+}
+
+func (f *farm) close() {
+	_ = f.redis.Client.Close()
+	f.db.Close()
+}
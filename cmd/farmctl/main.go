@@ -0,0 +1,174 @@
+// Command farmctl runs admin tasks against the farm database through the same
+// usecases as the server, with the config loaded the same way: defaults, the
+// YAML file of -config or CONFIG_FILE, the environment and the config flags.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"syscall"
+
+	configpkg "musobaqa/farm-competition/internal/pkg/config"
+)
+
+const usage = `usage: farmctl <command> [options] [config flags]
+
+commands:
+  users list [-role R]                  users, optionally of one role
+  users create EMAIL -name N -role R    password from -password or FARMCTL_PASSWORD
+  users set-role EMAIL ROLE
+  users delete EMAIL
+  roles list
+  animals list [-search S]
+  animals hungry
+  animals get ID
+  stock levels                          quantity of every food, drug and product
+  stock lots [-category C] [-name N]
+  stock expiring [-days N]
+  deliveries list [-search S]
+  deliveries get ID
+  feedings overdue                      feedings due today and not given yet
+  reports spend [-group-by supplier|item|month] [-from D] [-to D] [-category C]
+  reports inventory [-method M] [-category C]
+  reports feed-cost [-method M] [-group-by animal|category] [-from D] [-to D] [-category C]
+  jobs purge-trash                      drop rows kept past the trash retention
+  jobs write-off-expired [-reason R]
+  jobs recalculate-stock                set capacities to what is left in the lots
+
+options:
+`
+
+func main() {
+	log.SetFlags(0)
+
+	// farmctl <command words> [options] [config flags]
+	command, args := subcommand(os.Args[1:])
+
+	opts := newOptions()
+	own, configArgs := opts.split(args)
+	if err := opts.flags.Parse(own); err != nil {
+		os.Exit(2)
+	}
+	cmd, cmdArgs, ok := lookup(command)
+	if !ok {
+		opts.flags.Usage()
+		os.Exit(2)
+	}
+
+	out, err := newPrinter(os.Stdout, opts.output)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	config, err := configpkg.Load(configArgs)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	var f *farm
+	if !cmd.offline {
+		f, err = newFarm(config)
+		if err != nil {
+			log.Fatal(err)
+		}
+		defer f.close()
+	}
+
+	res, err := cmd.run(ctx, f, opts, cmdArgs)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := out.print(res); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// options are the flags of farmctl itself, each command reads the ones it
+// needs. Every other flag is a config flag
+type options struct {
+	flags    *flag.FlagSet
+	output   string
+	page     uint64
+	limit    uint64
+	search   string
+	role     string
+	name     string
+	password string
+	category string
+	days     int
+	reason   string
+	method   string
+	groupBy  string
+	from     string
+	to       string
+}
+
+func newOptions() *options {
+	o := &options{flags: flag.NewFlagSet("farmctl", flag.ContinueOnError)}
+	o.flags.StringVar(&o.output, "output", "table", "output format, table or json")
+	o.flags.Uint64Var(&o.page, "page", 1, "page of a list")
+	o.flags.Uint64Var(&o.limit, "limit", 50, "rows per page of a list")
+	o.flags.StringVar(&o.search, "search", "", "full text search of a list")
+	o.flags.StringVar(&o.role, "role", "", "role of a user, one of admin, manager or worker")
+	o.flags.StringVar(&o.name, "name", "", "full name of a user, name of a stock item")
+	o.flags.StringVar(&o.password, "password", "", "password of a new user, FARMCTL_PASSWORD when not given")
+	o.flags.StringVar(&o.category, "category", "", "food, drug or product")
+	o.flags.IntVar(&o.days, "days", 7, "days ahead for expiring lots")
+	o.flags.StringVar(&o.reason, "reason", "expired", "reason recorded on written off lots")
+	o.flags.StringVar(&o.method, "method", "", "costing method, fifo or weighted_average")
+	o.flags.StringVar(&o.groupBy, "group-by", "", "grouping of a report")
+	o.flags.StringVar(&o.from, "from", "", "first day of a report, YYYY-MM-DD")
+	o.flags.StringVar(&o.to, "to", "", "last day of a report, YYYY-MM-DD")
+	o.flags.Usage = func() {
+		fmt.Fprint(o.flags.Output(), usage)
+		o.flags.PrintDefaults()
+	}
+
+	return o
+}
+
+// split separates the flags farmctl owns from the config flags, keeping the
+// order of each. The value of an owned flag goes with it
+func (o *options) split(args []string) ([]string, []string) {
+	var own, rest []string
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		name, _, hasValue := strings.Cut(strings.TrimLeft(arg, "-"), "=")
+		if strings.HasPrefix(arg, "-") && (name == "h" || name == "help") {
+			own = append(own, arg)
+			continue
+		}
+		if !strings.HasPrefix(arg, "-") || o.flags.Lookup(name) == nil {
+			rest = append(rest, arg)
+			continue
+		}
+
+		own = append(own, arg)
+		if !hasValue && i+1 < len(args) {
+			i++
+			own = append(own, args[i])
+		}
+	}
+
+	return own, rest
+}
+
+// subcommand splits the command words and their arguments from the flags
+// after them, a negative number is a word
+func subcommand(args []string) ([]string, []string) {
+	for i, arg := range args {
+		if _, err := strconv.Atoi(arg); err != nil && strings.HasPrefix(arg, "-") {
+			return args[:i], args[i:]
+		}
+	}
+	return args, nil
+}
@@ -0,0 +1,46 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSplit(t *testing.T) {
+	command, args := subcommand([]string{"reports", "spend", "-group-by", "month", "-config", "farm.yaml", "-output=json", "-log-level", "debug"})
+	assert.Equal(t, []string{"reports", "spend"}, command)
+
+	// Flags of farmctl are taken out with their values, the rest go to config
+	own, rest := newOptions().split(args)
+	assert.Equal(t, []string{"-group-by", "month", "-output=json"}, own)
+	assert.Equal(t, []string{"-config", "farm.yaml", "-log-level", "debug"}, rest)
+
+	_, _, ok := lookup([]string{"users", "set-role", "admin@farm.uz"})
+	assert.False(t, ok)
+	_, args, ok = lookup([]string{"users", "set-role", "admin@farm.uz", "manager"})
+	assert.True(t, ok)
+	assert.Equal(t, []string{"admin@farm.uz", "manager"}, args)
+}
+
+func TestPrint(t *testing.T) {
+	report := &table{Columns: []string{"key", "label", "cost"}}
+	report.add("food:hay", "hay", 12.5)
+	report.add("drug:zinc", "", 3.0)
+	report.sum("total_cost", 15.5)
+
+	var out bytes.Buffer
+	p, err := newPrinter(&out, "table")
+	assert.NoError(t, err)
+	assert.NoError(t, p.print(report))
+	assert.Equal(t, "KEY        LABEL  COST\nfood:hay   hay    12.50\ndrug:zinc  -      3.00\ntotal_cost: 15.50\n", out.String())
+
+	out.Reset()
+	p, err = newPrinter(&out, "json")
+	assert.NoError(t, err)
+	assert.NoError(t, p.print(report))
+	assert.JSONEq(t, `{"items": [{"key": "food:hay", "label": "hay", "cost": 12.5}, {"key": "drug:zinc", "label": "", "cost": 3}], "total_cost": 15.5}`, out.String())
+
+	_, err = newPrinter(&out, "csv")
+	assert.Error(t, err)
+}
@@ -0,0 +1,110 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+)
+
+// table is what a command prints, as aligned columns or as JSON objects
+// keyed by column. Summary holds totals printed after the rows
+type table struct {
+	Columns []string
+	Rows    [][]any
+	Summary []total
+}
+
+type total struct {
+	Name  string
+	Value any
+}
+
+func (t *table) add(row ...any) {
+	t.Rows = append(t.Rows, row)
+}
+
+func (t *table) sum(name string, value any) {
+	t.Summary = append(t.Summary, total{Name: name, Value: value})
+}
+
+type printer struct {
+	w      io.Writer
+	format string
+}
+
+func newPrinter(w io.Writer, format string) (*printer, error) {
+	if format != "table" && format != "json" {
+		return nil, fmt.Errorf("unknown output %q, use table or json", format)
+	}
+
+	return &printer{w: w, format: format}, nil
+}
+
+func (p *printer) print(t *table) error {
+	if p.format == "json" {
+		return p.json(t)
+	}
+
+	w := tabwriter.NewWriter(p.w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, strings.ToUpper(strings.Join(t.Columns, "\t")))
+	for _, row := range t.Rows {
+		cells := make([]string, len(row))
+		for i, value := range row {
+			cells[i] = cell(value)
+		}
+		fmt.Fprintln(w, strings.Join(cells, "\t"))
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	for _, total := range t.Summary {
+		if _, err := fmt.Fprintf(p.w, "%s: %s\n", total.Name, cell(total.Value)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// json writes the rows as an array, or as the items of an object holding
+// the summary when there is one
+func (p *printer) json(t *table) error {
+	items := make([]map[string]any, 0, len(t.Rows))
+	for _, row := range t.Rows {
+		item := make(map[string]any, len(row))
+		for i, value := range row {
+			item[t.Columns[i]] = value
+		}
+		items = append(items, item)
+	}
+
+	encoder := json.NewEncoder(p.w)
+	encoder.SetIndent("", "  ")
+	if len(t.Summary) == 0 {
+		return encoder.Encode(items)
+	}
+
+	object := map[string]any{"items": items}
+	for _, total := range t.Summary {
+		object[total.Name] = total.Value
+	}
+
+	return encoder.Encode(object)
+}
+
+func cell(value any) string {
+	switch v := value.(type) {
+	case float64:
+		return fmt.Sprintf("%.2f", v)
+	case string:
+		if v == "" {
+			return "-"
+		}
+		return v
+	}
+
+	return fmt.Sprint(value)
+}
@@ -2,36 +2,67 @@ package app
 
 import (
 	"context"
-	"fmt"
 	animalproduct "musobaqa/farm-competition/internal/usecase/animal-product"
 	"musobaqa/farm-competition/internal/usecase/eatables"
 	"musobaqa/farm-competition/internal/usecase/feeding"
+	"net"
 	"net/http"
+	"os"
+	"sync"
 	"time"
 
 	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	grpchealth "google.golang.org/grpc/health"
 
 	"musobaqa/farm-competition/api"
+	"musobaqa/farm-competition/api/rpc"
+	"musobaqa/farm-competition/internal/entity"
 	"musobaqa/farm-competition/internal/infrastructure/repository/postgresql"
+	redisrepo "musobaqa/farm-competition/internal/infrastructure/repository/redis"
 	"musobaqa/farm-competition/internal/pkg/config"
+	"musobaqa/farm-competition/internal/pkg/costing"
+	"musobaqa/farm-competition/internal/pkg/events"
 	"musobaqa/farm-competition/internal/pkg/logger"
+	"musobaqa/farm-competition/internal/pkg/metrics"
 	"musobaqa/farm-competition/internal/pkg/otlp"
 	"musobaqa/farm-competition/internal/pkg/postgres"
 	"musobaqa/farm-competition/internal/pkg/redis"
+	"musobaqa/farm-competition/internal/pkg/scheduler"
+	"musobaqa/farm-competition/internal/pkg/webhook"
 
 	"musobaqa/farm-competition/internal/usecase/animals"
+	"musobaqa/farm-competition/internal/usecase/audit"
 	"musobaqa/farm-competition/internal/usecase/delivery"
 	"musobaqa/farm-competition/internal/usecase/drugs"
 	"musobaqa/farm-competition/internal/usecase/foods"
+	"musobaqa/farm-competition/internal/usecase/health"
+	"musobaqa/farm-competition/internal/usecase/jobs"
+	"musobaqa/farm-competition/internal/usecase/lots"
+	"musobaqa/farm-competition/internal/usecase/outbox"
 	"musobaqa/farm-competition/internal/usecase/products"
+	"musobaqa/farm-competition/internal/usecase/reports"
+	"musobaqa/farm-competition/internal/usecase/search"
+	"musobaqa/farm-competition/internal/usecase/suppliers"
+	"musobaqa/farm-competition/internal/usecase/tasks"
+	"musobaqa/farm-competition/internal/usecase/trash"
+	"musobaqa/farm-competition/internal/usecase/valuation"
+	"musobaqa/farm-competition/internal/usecase/versions"
+	"musobaqa/farm-competition/internal/usecase/webhooks"
 )
 
 type App struct {
 	Config        *config.Config
+	Live          *config.Live
 	Logger        *zap.Logger
+	logLevel      zap.AtomicLevel
 	DB            *postgres.PostgresDB
 	RedisDB       *redis.RedisDB
 	server        *http.Server
+	grpcServer    *grpc.Server
+	grpcHealth    *grpchealth.Server
+	stopJobs      context.CancelFunc
+	background    sync.WaitGroup
 	ShutdownOTLP  func() error
 	Product       products.Product
 	Animals       animals.Animal
@@ -41,66 +72,97 @@ type App struct {
 	AnimalProduct animalproduct.AnimalProduct
 	Eatable       eatables.Eatable
 	Feeding       feeding.Feeding
+	Supplier      suppliers.Supplier
+	Health        health.Health
+	Search        search.Search
+	Version       versions.Version
+	Trash         trash.Trash
+	Audit         audit.Audit
+	Lot           lots.Lot
+	Valuation     valuation.Valuation
+	Webhook       webhooks.Webhook
+	Events        *events.Bus
+	Outbox        outbox.Outbox
+	Jobs          jobs.Jobs
+	Reports       reports.Reports
+	FeedingTask   tasks.FeedingTask
+	Scheduler     *scheduler.Scheduler
 }
 
 func NewApp(cfg config.Config) (*App, error) {
 	// logger init
-	logger, err := logger.New(cfg.LogLevel, cfg.Environment, cfg.APP+".log")
+	logLevel := zap.NewAtomicLevelAt(logger.ParseLevel(cfg.LogLevel))
+	logger, err := logger.New(logLevel, cfg.Environment, cfg.APP+".log")
 	if err != nil {
 		return nil, err
 	}
 
+	// settings reloaded on SIGHUP
+	live := config.NewLive(&cfg)
+
 	// postgres init
 	db, err := postgres.New(&cfg)
 	if err != nil {
 		return nil, err
 	}
 
+	// schema, replicas starting together take turns on the advisory lock
+	applied, err := autoMigrate(context.Background(), cfg, db)
+	if err != nil {
+		return nil, err
+	}
+	if applied > 0 {
+		logger.Info("database migrated", zap.Int("applied", applied))
+	}
+
 	// redis init
 	redisdb, err := redis.New(&cfg)
 	if err != nil {
 		return nil, err
 	}
 
+	// cache of usecase reads
+	cache := redisrepo.NewNamespaces(redisrepo.NewCache(redisdb), live)
+
 	// otlp collector init
 	shutdownOTLP, err := otlp.InitOTLPProvider(&cfg)
 	if err != nil {
 		return nil, err
 	}
 
-	var (
-		contextTimeout time.Duration
-	)
+	contextTimeout := cfg.Context.Timeout
 
-	// context timeout initialization
-	contextTimeout, err = time.ParseDuration(cfg.Context.Timeout)
-	if err != nil {
-		return nil, err
-	}
+	// domain events are stored with the change that causes them
+	outboxRepo := postgresql.NewOutbox(db)
 
 	// product
 	productRepo := postgresql.NewProduct(db)
-	appProductUseCase := products.NewFoodService(contextTimeout, productRepo)
+	appProductUseCase := products.NewProductCache(products.NewFoodService(contextTimeout, productRepo), cache)
 
 	// animals
 	animalRepo := postgresql.NewAnimal(db)
-	appAnimalUseCase := animals.NewAnimalService(contextTimeout, animalRepo)
+	appAnimalUseCase := animals.NewAnimalCache(animals.NewAnimalService(contextTimeout, animalRepo, outboxRepo, db), cache)
+
+	// stock lots, foods and drugs keep their capacity in them
+	lotRepo := postgresql.NewStockLot(db)
 
 	// drugs
 	drugRepo := postgresql.NewDrug(db)
-	appDrugUseCase := drugs.NewDrugService(contextTimeout, drugRepo)
+	appDrugUseCase := drugs.NewDrugCache(drugs.NewDrugService(contextTimeout, drugRepo, lotRepo, db), cache)
 
 	// food
 	foodRepo := postgresql.NewFood(db)
-	appFoodUseCase := foods.NewFoodService(contextTimeout, foodRepo)
+	appFoodUseCase := foods.NewFoodCache(foods.NewFoodService(contextTimeout, foodRepo, lotRepo, db), cache)
+
+	appLotUseCase := lots.NewLotService(contextTimeout, lotRepo, outboxRepo, db)
 
 	// delivery
 	deliveryRepo := postgresql.NewDelivery(db)
-	appDeliveryUseCase := delivery.NewDeliveryService(contextTimeout, deliveryRepo)
+	appDeliveryUseCase := delivery.NewDeliveryCache(delivery.NewDeliveryService(contextTimeout, deliveryRepo, appLotUseCase, outboxRepo, db), cache)
 
 	// animal-product
 	animalProductRepo := postgresql.NewAnimalProduct(db)
-	appAnimalProductUseCase := animalproduct.NewAnimalProductService(contextTimeout, animalProductRepo)
+	appAnimalProductUseCase := animalproduct.NewAnimalProductService(contextTimeout, animalProductRepo, outboxRepo, db)
 
 	// eatable
 	eatableRepo := postgresql.NewEatable(db)
@@ -108,11 +170,91 @@ func NewApp(cfg config.Config) (*App, error) {
 
 	// feeding
 	feedingRepo := postgresql.NewFeeding(db)
-	appFeedingUseCase := feeding.NewFeedingService(contextTimeout, feedingRepo)
+	appFeedingUseCase := feeding.NewFeedingService(contextTimeout, feedingRepo, lotRepo, outboxRepo, db)
+
+	// feeding tasks, done ones are recorded through the feeding usecase
+	feedingTaskRepo := postgresql.NewFeedingTask(db)
+	appFeedingTaskUseCase := tasks.NewFeedingTaskService(contextTimeout, feedingTaskRepo, appFeedingUseCase, db)
+
+	// supplier
+	supplierRepo := postgresql.NewSupplier(db)
+	appSupplierUseCase := suppliers.NewSupplierCache(suppliers.NewSupplierService(contextTimeout, supplierRepo), cache)
+
+	// health
+	healthRepo := postgresql.NewHealth(db)
+	appHealthUseCase := health.NewHealthService(contextTimeout, healthRepo, redisdb)
+
+	// metrics
+	metrics.RegisterPool(db.Pool)
+	metrics.RegisterFarm(postgresql.NewMetrics(db), contextTimeout)
+
+	// search
+	searchRepo := postgresql.NewSearch(db)
+	appSearchUseCase := search.NewSearchService(contextTimeout, searchRepo)
+
+	// versions
+	versionRepo := postgresql.NewVersion(db)
+	appVersionUseCase := versions.NewVersionService(contextTimeout, versionRepo)
+
+	// trash
+	trashRepo := postgresql.NewTrash(db)
+	appTrashUseCase := trash.NewTrashCache(trash.NewTrashService(contextTimeout, trashRepo, cfg.Trash.Retention), cache)
+
+	// audit
+	auditRepo := postgresql.NewAudit(db)
+	appAuditUseCase := audit.NewAuditService(contextTimeout, auditRepo)
+
+	// valuation
+	costingMethod, err := costing.ParseMethod(cfg.Costing.Method)
+	if err != nil {
+		return nil, err
+	}
+	costingRepo := postgresql.NewCosting(db)
+	appValuationUseCase := valuation.NewValuationService(contextTimeout, costingRepo, costingMethod)
+
+	// webhooks
+	webhookRepo := postgresql.NewWebhook(db)
+	appWebhookUseCase := webhooks.NewWebhookService(contextTimeout, webhookRepo, webhook.NewClient(cfg.Webhooks.Timeout), cfg.Webhooks.BatchSize, webhooks.Retry{
+		MaxAttempts: cfg.Webhooks.MaxAttempts,
+		Backoff:     cfg.Webhooks.RetryBackoff,
+		MaxBackoff:  cfg.Webhooks.RetryMaxBackoff,
+	}, cfg.Webhooks.Retention)
+
+	// events
+	bus := events.NewBus()
+	for _, event := range entity.WebhookEvents {
+		bus.Subscribe(event, appWebhookUseCase.Publish)
+	}
+	// stock changes made by lots, feedings and farmctl drop the cached
+	// foods and drugs
+	for _, event := range []string{entity.EventStockChanged, entity.EventDeliveryReceived} {
+		bus.Subscribe(event, func(ctx context.Context, event *entity.DomainEvent) error {
+			cache.Invalidate(ctx, "food", "drug")
+			return nil
+		})
+	}
+	appOutboxUseCase := outbox.NewOutboxService(contextTimeout, outboxRepo, bus, cfg.Outbox.BatchSize, outbox.Retry{
+		MaxAttempts: cfg.Outbox.MaxAttempts,
+		Backoff:     cfg.Outbox.RetryBackoff,
+	}, cfg.Outbox.Retention)
+
+	// reports
+	reportRepo := postgresql.NewReportSnapshot(db)
+	appReportUseCase := reports.NewReportService(contextTimeout, reportRepo, appValuationUseCase, appSupplierUseCase)
 
-	return &App{
+	// jobs, the runs are recorded under the host name of the replica
+	holder, err := os.Hostname()
+	if err != nil {
+		holder = cfg.APP
+	}
+	jobRepo := postgresql.NewJob(db)
+	appJobUseCase := jobs.NewJobService(contextTimeout, jobRepo, db, holder, cfg.Jobs.History)
+
+	app := &App{
 		Config:        &cfg,
+		Live:          live,
 		Logger:        logger,
+		logLevel:      logLevel,
 		DB:            db,
 		RedisDB:       redisdb,
 		ShutdownOTLP:  shutdownOTLP,
@@ -124,20 +266,38 @@ func NewApp(cfg config.Config) (*App, error) {
 		AnimalProduct: appAnimalProductUseCase,
 		Eatable:       appEatableUseCase,
 		Feeding:       appFeedingUseCase,
-	}, nil
+		Supplier:      appSupplierUseCase,
+		Health:        appHealthUseCase,
+		Search:        appSearchUseCase,
+		Version:       appVersionUseCase,
+		Trash:         appTrashUseCase,
+		Audit:         appAuditUseCase,
+		Lot:           appLotUseCase,
+		Valuation:     appValuationUseCase,
+		Webhook:       appWebhookUseCase,
+		Events:        bus,
+		Outbox:        appOutboxUseCase,
+		Jobs:          appJobUseCase,
+		Reports:       appReportUseCase,
+		FeedingTask:   appFeedingTaskUseCase,
+		Scheduler:     scheduler.New(appJobUseCase, logger),
+	}
+	if err := app.registerJobs(); err != nil {
+		return nil, err
+	}
+
+	return app, nil
 }
 
 func (a *App) Run() error {
-	contextTimeout, err := time.ParseDuration(a.Config.Context.Timeout)
-	if err != nil {
-		return fmt.Errorf("error while parsing context timeout: %v", err)
-	}
-
 	// api init
 	handler := api.NewRoute(api.RouteOption{
 		Config:         a.Config,
+		Live:           a.Live,
 		Logger:         a.Logger,
-		ContextTimeout: contextTimeout,
+		RedisDB:        a.RedisDB,
+		ContextTimeout: a.Config.Context.Timeout,
+		ReportTimeout:  a.Config.Context.ReportTimeout,
 		Product:        a.Product,
 		Animals:        a.Animals,
 		Food:           a.Food,
@@ -146,25 +306,130 @@ func (a *App) Run() error {
 		AnimalProduct:  a.AnimalProduct,
 		Eatables:       a.Eatable,
 		Feeding:        a.Feeding,
+		Supplier:       a.Supplier,
+		Health:         a.Health,
+		Search:         a.Search,
+		Version:        a.Version,
+		Trash:          a.Trash,
+		Audit:          a.Audit,
+		Lot:            a.Lot,
+		Valuation:      a.Valuation,
+		Webhook:        a.Webhook,
+		Jobs:           a.Jobs,
+		Scheduler:      a.Scheduler,
+		Reports:        a.Reports,
+		FeedingTask:    a.FeedingTask,
 	})
 
 	// server init
-	a.server, err = api.NewServer(a.Config, handler)
+	a.server = api.NewServer(a.Config, handler)
+
+	// grpc init, on its own port next to the REST api
+	a.grpcServer, a.grpcHealth = rpc.NewServer(rpc.ServerOption{
+		Config:        a.Config,
+		Logger:        a.Logger,
+		Animals:       a.Animals,
+		Food:          a.Food,
+		Drug:          a.Drug,
+		Product:       a.Product,
+		Feeding:       a.Feeding,
+		AnimalProduct: a.AnimalProduct,
+		Version:       a.Version,
+		Audit:         a.Audit,
+	})
+	listener, err := net.Listen("tcp", a.Config.Server.Host+a.Config.Server.GRPCPort)
 	if err != nil {
-		return fmt.Errorf("error while initializing server: %v", err)
+		return err
+	}
+	go func() {
+		if err := a.grpcServer.Serve(listener); err != nil {
+			a.Logger.Error("grpc serve", zap.Error(err))
+		}
+	}()
+
+	// background jobs init
+	jobsCtx, stopJobs := context.WithCancel(context.Background())
+	a.stopJobs = stopJobs
+	a.Scheduler.Start(jobsCtx)
+	for _, loop := range []func(context.Context){a.dispatchWebhooks, a.relayOutbox} {
+		a.background.Add(1)
+		go func(loop func(context.Context)) {
+			defer a.background.Done()
+			loop(jobsCtx)
+		}(loop)
 	}
 
 	return a.server.ListenAndServe()
 }
 
-func (a *App) Stop() {
+// Reload applies the settings of cfg that can change while the app runs,
+// changes to the others are logged and wait for a restart
+func (a *App) Reload(cfg *config.Config) {
+	restart := a.Live.Reload(cfg)
+	a.logLevel.SetLevel(logger.ParseLevel(cfg.LogLevel))
+	a.Logger.Info("config reloaded", zap.String("log_level", cfg.LogLevel))
 
-	// close database
-	a.DB.Close()
+	if len(restart) > 0 {
+		a.Logger.Warn("config changes need a restart", zap.Strings("settings", restart))
+	}
+}
+
+// Stop fails readiness first and gives load balancers the drain delay to
+// move traffic away, then lets the requests in flight finish before the
+// connections they use are closed
+func (a *App) Stop() {
+	// fail readiness
+	a.Health.Drain()
+	if a.grpcHealth != nil {
+		a.grpcHealth.Shutdown()
+	}
+	time.Sleep(a.Config.Server.DrainDelay)
 
 	// shutdown server http
-	if err := a.server.Shutdown(context.Background()); err != nil {
-		a.Logger.Error("shutdown server http ", zap.Error(err))
+	if a.server != nil {
+		ctx, cancel := context.WithTimeout(context.Background(), a.Config.Server.ShutdownTimeout)
+		if err := a.server.Shutdown(ctx); err != nil {
+			a.Logger.Error("shutdown server http ", zap.Error(err))
+		}
+		cancel()
+	}
+
+	// shutdown server grpc, calls still running at the timeout are cut
+	if a.grpcServer != nil {
+		stopped := make(chan struct{})
+		go func() {
+			a.grpcServer.GracefulStop()
+			close(stopped)
+		}()
+		select {
+		case <-stopped:
+		case <-time.After(a.Config.Server.ShutdownTimeout):
+			a.grpcServer.Stop()
+		}
+	}
+
+	// stop background jobs and wait for the runs and batches in progress to
+	// record how they went, the database is closed after them
+	if a.stopJobs != nil {
+		a.stopJobs()
+
+		stopped := make(chan struct{})
+		go func() {
+			a.Scheduler.Wait()
+			a.background.Wait()
+			close(stopped)
+		}()
+		select {
+		case <-stopped:
+		case <-time.After(a.Config.Server.ShutdownTimeout):
+			a.Logger.Warn("background jobs still running at shutdown")
+		}
+	}
+
+	// close database and redis
+	a.DB.Close()
+	if err := a.RedisDB.Client.Close(); err != nil {
+		a.Logger.Error("close redis", zap.Error(err))
 	}
 
 	// shutdown otlp collector
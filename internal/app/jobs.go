@@ -0,0 +1,93 @@
+package app
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// registerJobs puts the recurring farm jobs on the schedules of the config,
+// the scheduler runs each of them on one replica at a time
+func (a *App) registerJobs() error {
+	for _, job := range []struct {
+		name string
+		spec string
+		run  func(ctx context.Context) error
+	}{
+		{"purge-trash", a.Config.Jobs.PurgeTrash, a.purgeTrash},
+		{"feeding-tasks", a.Config.Jobs.FeedingTasks, a.generateFeedingTasks},
+		{"missed-feedings", a.Config.Jobs.MissedFeedings, a.publishMissedFeedings},
+		{"low-stock", a.Config.Jobs.LowStock, a.publishLowStock},
+		{"report-snapshot", a.Config.Jobs.ReportSnapshot, a.snapshotReports},
+		{"purge-job-runs", a.Config.Jobs.PurgeRuns, a.purgeJobRuns},
+		{"purge-events", a.Config.Jobs.PurgeEvents, a.purgeEvents},
+	} {
+		if err := a.Scheduler.Register(job.name, job.spec, a.Config.Jobs.Timeout, job.run); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// purgeTrash removes rows kept in the trash longer than the retention period
+func (a *App) purgeTrash(ctx context.Context) error {
+	purged, err := a.Trash.Purge(ctx)
+	if err != nil {
+		return err
+	}
+
+	a.Logger.Info("trash purged", zap.Any("rows", purged))
+	return nil
+}
+
+// generateFeedingTasks adds the tasks of today, each run also picks up the
+// plans added since the one before
+func (a *App) generateFeedingTasks(ctx context.Context) error {
+	generated, err := a.FeedingTask.Generate(ctx, time.Now())
+	if err != nil {
+		return err
+	}
+
+	a.Logger.Info("feeding tasks generated", zap.Int64("tasks", generated))
+	return nil
+}
+
+// snapshotReports keeps the reports of the day before, the job runs
+// shortly after midnight
+func (a *App) snapshotReports(ctx context.Context) error {
+	snapshots, err := a.Reports.Snapshot(ctx, time.Now().AddDate(0, 0, -1))
+	if err != nil {
+		return err
+	}
+
+	a.Logger.Info("reports snapshotted", zap.Int("reports", len(snapshots)))
+	return nil
+}
+
+func (a *App) purgeJobRuns(ctx context.Context) error {
+	purged, err := a.Jobs.Purge(ctx)
+	if err != nil {
+		return err
+	}
+
+	a.Logger.Info("job runs purged", zap.Int64("runs", purged))
+	return nil
+}
+
+// purgeEvents removes the outbox events and webhook deliveries kept longer
+// than their retention
+func (a *App) purgeEvents(ctx context.Context) error {
+	purged, err := a.Outbox.Purge(ctx)
+	if err != nil {
+		return err
+	}
+	deliveries, err := a.Webhook.Purge(ctx)
+	if err != nil {
+		return err
+	}
+
+	a.Logger.Info("events purged", zap.Int64("events", purged), zap.Int64("deliveries", deliveries))
+	return nil
+}
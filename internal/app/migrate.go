@@ -0,0 +1,131 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"strconv"
+
+	"musobaqa/farm-competition/internal/pkg/config"
+	"musobaqa/farm-competition/internal/pkg/migrate"
+	"musobaqa/farm-competition/internal/pkg/postgres"
+	"musobaqa/farm-competition/migrations"
+)
+
+var errMigrateUsage = errors.New("usage: app migrate up | down [N|all] | version | force V")
+
+// Migrate runs app migrate against the embedded migrations. down reverts one
+// migration unless told how many, all reverts every one of them
+func Migrate(cfg config.Config, args []string) error {
+	if len(args) == 0 {
+		return errMigrateUsage
+	}
+
+	db, err := postgres.New(&cfg)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	migrator, err := migrate.New(db.Pool, migrations.FS)
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	switch args[0] {
+	case "up":
+		applied, err := migrator.Up(ctx)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("applied %d migrations\n", applied)
+	case "down":
+		steps := 1
+		if len(args) > 1 {
+			if args[1] == "all" {
+				steps = 0
+			} else if steps, err = strconv.Atoi(args[1]); err != nil || steps < 1 {
+				return errMigrateUsage
+			}
+		}
+		reverted, err := migrator.Down(ctx, steps)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("reverted %d migrations\n", reverted)
+	case "version":
+		version, dirty, err := migrator.Version(ctx)
+		if err != nil {
+			return err
+		}
+		if version == migrate.NilVersion {
+			fmt.Println("no migration applied")
+			return nil
+		}
+		if dirty {
+			fmt.Printf("%d (dirty)\n", version)
+			return nil
+		}
+		fmt.Println(version)
+	case "force":
+		if len(args) < 2 {
+			return errMigrateUsage
+		}
+		version, err := strconv.ParseInt(args[1], 10, 64)
+		if err != nil {
+			return errMigrateUsage
+		}
+		if err := migrator.Force(ctx, version); err != nil {
+			return err
+		}
+		fmt.Printf("forced version %d\n", version)
+	default:
+		return errMigrateUsage
+	}
+
+	return nil
+}
+
+// Seed runs app seed, loading the embedded sample data. Rows already there
+// are left as they are, so it can run any number of times
+func Seed(cfg config.Config) error {
+	db, err := postgres.New(&cfg)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	migrator, err := migrate.New(db.Pool, migrations.FS)
+	if err != nil {
+		return err
+	}
+
+	seed, err := fs.Sub(migrations.Seed, "seed")
+	if err != nil {
+		return err
+	}
+
+	files, err := migrator.Seed(context.Background(), seed)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("seeded %d files\n", len(files))
+
+	return nil
+}
+
+// autoMigrate brings the schema up to date on startup when it is turned on
+func autoMigrate(ctx context.Context, cfg config.Config, db *postgres.PostgresDB) (int, error) {
+	if !cfg.DB.AutoMigrate {
+		return 0, nil
+	}
+
+	migrator, err := migrate.New(db.Pool, migrations.FS)
+	if err != nil {
+		return 0, err
+	}
+
+	return migrator.Up(ctx)
+}
@@ -0,0 +1,123 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+
+	"musobaqa/farm-competition/internal/entity"
+	"musobaqa/farm-competition/internal/pkg/events"
+)
+
+// dispatchWebhooks sends the due webhook deliveries on every dispatch
+// interval until the app stops
+func (a *App) dispatchWebhooks(ctx context.Context) {
+	ticker := time.NewTicker(a.Config.Webhooks.DispatchInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			delivered, err := a.Webhook.Dispatch(ctx)
+			if err != nil && ctx.Err() == nil {
+				a.Logger.Error("dispatch webhooks", zap.Error(err))
+			}
+			if delivered > 0 {
+				a.Logger.Debug("webhooks delivered", zap.Int("deliveries", delivered))
+			}
+		}
+	}
+}
+
+// publishLowStock sends stock.low for every food and drug under the
+// threshold, once a day for each of them. No request causes it, so it goes
+// straight to the bus, one that is lost is found again on the next check
+func (a *App) publishLowStock(ctx context.Context) error {
+	levels, err := a.Lot.Levels(ctx)
+	if err != nil {
+		return err
+	}
+
+	today := time.Now().Format(time.DateOnly)
+	for _, level := range levels {
+		if level.Quantity >= a.Config.Webhooks.LowStock {
+			continue
+		}
+		event, err := events.New(entity.EventStockLow, "stock", level.Category+":"+level.Name, &entity.StockLow{
+			Category:  level.Category,
+			Name:      level.Name,
+			Union:     level.Union,
+			Quantity:  level.Quantity,
+			Threshold: a.Config.Webhooks.LowStock,
+		})
+		if err != nil {
+			return err
+		}
+		event.Key = fmt.Sprintf("%s:%s:%s", level.Category, level.Name, today)
+
+		if err := a.Events.Publish(ctx, event); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// publishMissedFeedings sends feeding.missed for the feedings overdue
+// today, again only when one more portion is missed
+func (a *App) publishMissedFeedings(ctx context.Context) error {
+	overdue, err := a.Feeding.Overdue(ctx)
+	if err != nil {
+		return err
+	}
+
+	today := time.Now().Format(time.DateOnly)
+	for _, feeding := range overdue {
+		event, err := events.New(entity.EventFeedingMissed, "animal", feeding.AnimalID, &entity.FeedingMissed{
+			AnimalID:   feeding.AnimalID,
+			AnimalName: feeding.AnimalName,
+			EatablesID: feeding.EatablesID,
+			Category:   feeding.Category,
+			Name:       feeding.Name,
+			Due:        feeding.Due,
+			Given:      feeding.Given,
+			Day:        today,
+		})
+		if err != nil {
+			return err
+		}
+		event.Key = fmt.Sprintf("%s:%s:%s:%d", feeding.AnimalID, feeding.EatablesID, today, feeding.Due)
+
+		if err := a.Events.Publish(ctx, event); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// relayOutbox hands the stored domain events to the bus on every relay
+// interval until the app stops
+func (a *App) relayOutbox(ctx context.Context) {
+	ticker := time.NewTicker(a.Config.Outbox.RelayInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			published, err := a.Outbox.Relay(ctx)
+			if err != nil && ctx.Err() == nil {
+				a.Logger.Error("relay outbox", zap.Error(err))
+			}
+			if published > 0 {
+				a.Logger.Debug("outbox events published", zap.Int("events", published))
+			}
+		}
+	}
+}
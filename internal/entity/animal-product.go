@@ -8,21 +8,25 @@ type AnimalProductReq struct {
 	ProductID string
 	Capacity  int64
 	GetTime   string
+	Version   int64
 	CreatedAt time.Time
 	UpdatedAt time.Time
 }
 
 type AnimalProductRes struct {
-	ID       string
-	Animal   Animal
-	Product  Product
-	Capacity int64
-	GetTime  string
+	ID        string
+	Animal    Animal
+	Product   Product
+	Capacity  int64
+	GetTime   string
+	Version   int64
+	CreatedAt time.Time
 }
 
 type ListAnimalProduct struct {
 	AnimalProducts []*AnimalProductRes
 	TotalCount     uint64
+	NextCursor     string
 }
 
 type ProductsWithAnimal struct {
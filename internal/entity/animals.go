@@ -14,6 +14,7 @@ type Animal struct {
 	Weight       uint64
 	IsHealth     string
 	Description  string
+	Version      int64
 	CreatedAt    time.Time
 	UpdatedAt    time.Time
 }
@@ -0,0 +1,31 @@
+package entity
+
+import (
+	"encoding/json"
+	"time"
+)
+
+const (
+	AuditActionCreate  = "create"
+	AuditActionUpdate  = "update"
+	AuditActionDelete  = "delete"
+	AuditActionRestore = "restore"
+)
+
+type AuditLog struct {
+	ID         string
+	Actor      string
+	Action     string
+	EntityType string
+	EntityID   string
+	Before     json.RawMessage
+	After      json.RawMessage
+	Diff       json.RawMessage
+	RequestID  string
+	CreatedAt  time.Time
+}
+
+type ListAuditLogs struct {
+	AuditLogs  []*AuditLog
+	TotalCount uint64
+}
@@ -0,0 +1,68 @@
+package entity
+
+import "time"
+
+type StockReceipt struct {
+	ID       string
+	Category string
+	Name     string
+	Time     time.Time
+	Quantity int64
+	UnitCost float64
+}
+
+type StockIssue struct {
+	ID             string
+	AnimalID       string
+	AnimalName     string
+	AnimalCategory string
+	EatableID      string
+	Category       string
+	Name           string
+	Day            string
+	Time           time.Time
+	Quantity       int64
+}
+
+type ValuationItem struct {
+	Category string  `json:"category"`
+	Name     string  `json:"name"`
+	Quantity int64   `json:"quantity"`
+	UnitCost float64 `json:"unit_cost"`
+	Value    float64 `json:"value"`
+}
+
+type InventoryValuation struct {
+	Method     string           `json:"method"`
+	Items      []*ValuationItem `json:"items"`
+	TotalValue float64          `json:"total_value"`
+}
+
+type FeedingCost struct {
+	FeedingID string
+	AnimalID  string
+	EatableID string
+	Category  string
+	Name      string
+	Day       string
+	Quantity  int64
+	Shortage  int64
+	UnitCost  float64
+	Cost      float64
+	Method    string
+}
+
+type FeedCostItem struct {
+	Key      string  `json:"key"`
+	Label    string  `json:"label"`
+	Quantity int64   `json:"quantity"`
+	Cost     float64 `json:"cost"`
+	Feedings int64   `json:"feedings"`
+}
+
+type FeedCostReport struct {
+	Method    string          `json:"method"`
+	GroupBy   string          `json:"group_by"`
+	Items     []*FeedCostItem `json:"items"`
+	TotalCost float64         `json:"total_cost"`
+}
@@ -3,17 +3,32 @@ package entity
 import "time"
 
 type Delivery struct {
-	ID        string
-	Name      string
-	Category  string
-	Capacity  int64
-	Union     string
-	Time      string
-	CreatedAt time.Time
-	UpdatedAt time.Time
+	ID            string
+	Name          string
+	Category      string
+	Capacity      int64
+	Union         string
+	Time          string
+	SupplierID    string
+	UnitPrice     float64
+	TotalCost     float64
+	InvoiceNumber string
+	InvoiceFile   string
+	// LotNumber and ExpiryDate open the stock lot of the delivery
+	LotNumber  string
+	ExpiryDate string
+	// Description and Status describe the food or drug a delivery brings in
+	// for the first time, EatableCreated is set when it did
+	Description    string
+	Status         string
+	EatableCreated bool
+	Version        int64
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
 }
 
 type ListDelivery struct {
 	Deliveries []*Delivery
 	TotalCount int64
+	NextCursor string
 }
@@ -9,6 +9,7 @@ type Drug struct {
 	Capacity    uint64
 	Union       string
 	Description string
+	Version     int64
 	CreatedAt   time.Time
 	UpdatedAt   time.Time
 }
@@ -11,6 +11,7 @@ type Eatables struct {
 		Capacity int64  `json:"capacity"`
 		Time     string `json:"time"`
 	} `json:"daily"`
+	Version   int64
 	CreatedAt time.Time
 	UpdatedAt time.Time
 }
@@ -31,6 +32,7 @@ type EatablesRes struct {
 		Capacity int64  `json:"capacity"`
 		Time     string `json:"time"`
 	} `json:"daily"`
+	Version int64
 }
 
 type EatablesFoodRes struct {
@@ -0,0 +1,95 @@
+package entity
+
+import (
+	"encoding/json"
+	"time"
+)
+
+const (
+	EventDeliveryReceived    = "delivery.received"
+	EventStockLow            = "stock.low"
+	EventFeedingMissed       = "feeding.missed"
+	EventYieldRecorded       = "yield.recorded"
+	EventAnimalHealthChanged = "animal.health_changed"
+	// EventStockChanged is not offered to webhooks, it tells the app the
+	// capacity of a food or drug changed outside its usecase
+	EventStockChanged = "stock.changed"
+)
+
+// DomainEvent is a change the usecases tell the rest of the app about. Key
+// is the same for every repeat of one happening, the ID unless it is set
+type DomainEvent struct {
+	ID            string
+	Type          string
+	Key           string
+	AggregateType string
+	AggregateID   string
+	Payload       json.RawMessage
+	Attempts      int
+	OccurredAt    time.Time
+}
+
+// DeliveryReceived is the payload of delivery.received
+type DeliveryReceived struct {
+	DeliveryID string  `json:"delivery_id"`
+	Category   string  `json:"category"`
+	Name       string  `json:"name"`
+	Capacity   int64   `json:"capacity"`
+	Union      string  `json:"union"`
+	Time       string  `json:"time"`
+	SupplierID string  `json:"supplier_id,omitempty"`
+	UnitPrice  float64 `json:"unit_price"`
+	TotalCost  float64 `json:"total_cost"`
+	LotNumber  string  `json:"lot_number"`
+}
+
+// YieldRecorded is the payload of yield.recorded
+type YieldRecorded struct {
+	YieldID     string `json:"yield_id"`
+	AnimalID    string `json:"animal_id"`
+	AnimalName  string `json:"animal_name"`
+	ProductID   string `json:"product_id"`
+	ProductName string `json:"product_name"`
+	Capacity    int64  `json:"capacity"`
+	Union       string `json:"union"`
+	GetTime     string `json:"get_time"`
+}
+
+// AnimalHealthChanged is the payload of animal.health_changed
+type AnimalHealthChanged struct {
+	AnimalID     string `json:"animal_id"`
+	Name         string `json:"name"`
+	CategoryName string `json:"category_name"`
+	IsHealth     bool   `json:"is_health"`
+	WasHealth    bool   `json:"was_health"`
+}
+
+// StockLow is the payload of stock.low
+type StockLow struct {
+	Category  string `json:"category"`
+	Name      string `json:"name"`
+	Union     string `json:"union"`
+	Quantity  int64  `json:"quantity"`
+	Threshold int64  `json:"threshold"`
+}
+
+// StockChanged is the payload of stock.changed, Reason is feeding,
+// write_off or recalculate. Feedings carry the food or drug as aggregate
+// and no name
+type StockChanged struct {
+	Category string `json:"category"`
+	Name     string `json:"name,omitempty"`
+	Reason   string `json:"reason"`
+}
+
+// FeedingMissed is the payload of feeding.missed
+type FeedingMissed struct {
+	AnimalID   string `json:"animal_id"`
+	AnimalName string `json:"animal_name"`
+	EatablesID string `json:"eatables_id"`
+	Category   string `json:"category"`
+	Name       string `json:"name"`
+	Due        int64  `json:"due"`
+	Given      int64  `json:"given"`
+	Day        string `json:"day"`
+}
@@ -12,6 +12,7 @@ type Feeding struct {
 		Capacity int64  `json:"capacity"`
 		Time     string `json:"time"`
 	} `json:"daily"`
+	Version   int64     `json:"version"`
 	CreatedAt time.Time `json:"created_at"`
 	UpdatedAt time.Time `json:"updated_at"`
 }
@@ -33,4 +34,24 @@ type FeedingRes struct {
 		Capacity int64  `json:"capacity"`
 		Time     string `json:"time"`
 	} `json:"daily"`
+	Version   int64
+	CreatedAt time.Time
+}
+
+type ListFeedings struct {
+	Feedings   []*FeedingRes
+	TotalCount uint64
+	NextCursor string
+}
+
+// OverdueFeeding is an eatable an animal was due more times today, up to
+// now, than it was given
+type OverdueFeeding struct {
+	AnimalID   string
+	AnimalName string
+	EatablesID string
+	Category   string
+	Name       string
+	Due        int64
+	Given      int64
 }
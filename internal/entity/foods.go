@@ -8,6 +8,7 @@ type Food struct {
 	Capacity    uint64
 	Union       string
 	Description string
+	Version     int64
 	CreatedAt   time.Time
 	UpdatedAt   time.Time
 }
@@ -0,0 +1,21 @@
+package entity
+
+import "time"
+
+// Readiness tells whether the service can take traffic, Ready is false as
+// soon as one dependency fails or the service is shutting down
+type Readiness struct {
+	Ready    bool
+	Draining bool
+	Checks   []*DependencyCheck
+}
+
+type DependencyCheck struct {
+	Name    string
+	Ready   bool
+	Latency time.Duration
+	Error   string
+	// migration version of the database, nil for other dependencies
+	Version *int64
+	Dirty   bool
+}
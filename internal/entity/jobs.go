@@ -0,0 +1,38 @@
+package entity
+
+import "time"
+
+const (
+	JobRunRunning   = "running"
+	JobRunSucceeded = "succeeded"
+	JobRunFailed    = "failed"
+)
+
+// Job is a recurring job registered in the scheduler, LastRun is the latest
+// run on any replica
+type Job struct {
+	Name      string
+	Schedule  string
+	Timeout   time.Duration
+	NextRunAt time.Time
+	LastRun   *JobRun
+}
+
+// JobRun is one occurrence of a job, Holder is the replica that ran it. A
+// run still running after LeaseUntil is taken as lost
+type JobRun struct {
+	ID          string
+	Job         string
+	ScheduledAt time.Time
+	Status      string
+	Holder      string
+	StartedAt   time.Time
+	LeaseUntil  time.Time
+	FinishedAt  *time.Time
+	Error       string
+}
+
+type ListJobRuns struct {
+	Runs       []*JobRun
+	TotalCount uint64
+}
@@ -0,0 +1,44 @@
+package entity
+
+import "time"
+
+type StockLot struct {
+	ID             string
+	DeliveryID     string
+	Category       string
+	Name           string
+	LotNumber      string
+	ReceivedDate   string
+	ExpiryDate     string
+	Quantity       int64
+	Remaining      int64
+	WrittenOff     int64
+	WriteOffReason string
+	Version        int64
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
+
+type ListStockLots struct {
+	Lots       []*StockLot
+	TotalCount uint64
+}
+
+// Restock is stock coming in for a food or drug. Union, Description and
+// Status describe the food or drug when there is none with the name yet
+type Restock struct {
+	Category    string
+	Name        string
+	Union       string
+	Description string
+	Status      string
+	Quantity    int64
+}
+
+type LotConsumption struct {
+	ID        string
+	LotID     string
+	FeedingID string
+	Quantity  int64
+	CreatedAt time.Time
+}
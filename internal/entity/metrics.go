@@ -0,0 +1,22 @@
+package entity
+
+// FarmGauges is the state of the farm exported on /metrics
+type FarmGauges struct {
+	Stock           []*StockLevel
+	HeadCounts      []*HeadCount
+	OverdueFeedings int64
+	YieldsToday     []*StockLevel
+}
+
+// StockLevel is the quantity of one food, drug or product
+type StockLevel struct {
+	Category string
+	Name     string
+	Union    string
+	Quantity int64
+}
+
+type HeadCount struct {
+	Category string
+	Count    int64
+}
@@ -8,6 +8,7 @@ type Product struct {
 	Union         string
 	TotalCapacity int64
 	Description   string
+	Version       int64
 	CreatedAt     time.Time
 	UpdatedAt     time.Time
 }
@@ -0,0 +1,22 @@
+package entity
+
+import (
+	"encoding/json"
+	"time"
+)
+
+const (
+	ReportInventoryValuation = "inventory-valuation"
+	ReportFeedCost           = "feed-cost"
+	ReportSpend              = "spend"
+)
+
+// ReportSnapshot is a report as it was on Day, Data is the report in the
+// shape the live report endpoint answers with
+type ReportSnapshot struct {
+	ID        string
+	Report    string
+	Day       string
+	Data      json.RawMessage
+	CreatedAt time.Time
+}
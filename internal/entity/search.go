@@ -0,0 +1,9 @@
+package entity
+
+type SearchHit struct {
+	EntityType string
+	ID         string
+	Name       string
+	Label      string
+	Rank       float64
+}
@@ -0,0 +1,35 @@
+package entity
+
+import "time"
+
+type Supplier struct {
+	ID            string
+	Name          string
+	ContactPerson string
+	Phone         string
+	Email         string
+	Address       string
+	Description   string
+	Version       int64
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+}
+
+type ListSuppliers struct {
+	Suppliers  []*Supplier
+	TotalCount uint64
+}
+
+type SpendReportItem struct {
+	Key        string  `json:"key"`
+	Label      string  `json:"label"`
+	Capacity   int64   `json:"capacity"`
+	TotalCost  float64 `json:"total_cost"`
+	Deliveries int64   `json:"deliveries"`
+}
+
+type SpendReport struct {
+	GroupBy   string             `json:"group_by"`
+	Items     []*SpendReportItem `json:"items"`
+	TotalCost float64            `json:"total_cost"`
+}
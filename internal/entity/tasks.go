@@ -0,0 +1,40 @@
+package entity
+
+import "time"
+
+const (
+	FeedingTaskPending = "pending"
+	FeedingTaskDone    = "done"
+	FeedingTaskSkipped = "skipped"
+)
+
+// FeedingTask is one time slot of the daily plan of an animal for an
+// eatable on a day. A done task points to the given eatables it recorded,
+// ActualCapacity is what was given and may differ from the planned Capacity
+type FeedingTask struct {
+	ID              string
+	AnimalID        string
+	AnimalName      string
+	AnimalCategory  string
+	EatablesID      string
+	Category        string
+	Name            string
+	Union           string
+	Day             string
+	Slot            string
+	Capacity        int64
+	Status          string
+	ActualCapacity  int64
+	SkipReason      string
+	GivenEatablesID string
+	CompletedBy     string
+	CompletedAt     *time.Time
+	Version         int64
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+}
+
+type ListFeedingTasks struct {
+	Tasks      []*FeedingTask
+	TotalCount uint64
+}
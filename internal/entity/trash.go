@@ -0,0 +1,18 @@
+package entity
+
+import (
+	"encoding/json"
+	"time"
+)
+
+type TrashItem struct {
+	EntityType string
+	ID         string
+	DeletedAt  time.Time
+	Data       json.RawMessage
+}
+
+type ListTrash struct {
+	Items      []*TrashItem
+	TotalCount uint64
+}
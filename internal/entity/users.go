@@ -0,0 +1,29 @@
+package entity
+
+import "time"
+
+// Roles a user can have, from the most to the least privileged
+const (
+	RoleAdmin   = "admin"
+	RoleManager = "manager"
+	RoleWorker  = "worker"
+)
+
+var Roles = []string{RoleAdmin, RoleManager, RoleWorker}
+
+type User struct {
+	ID           string
+	Email        string
+	FullName     string
+	Password     string
+	PasswordHash string
+	Role         string
+	Version      int64
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+}
+
+type ListUsers struct {
+	Users      []*User
+	TotalCount uint64
+}
@@ -0,0 +1,72 @@
+package entity
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// WebhookEvents are the events a subscription can ask for
+var WebhookEvents = []string{
+	EventDeliveryReceived,
+	EventStockLow,
+	EventFeedingMissed,
+	EventYieldRecorded,
+	EventAnimalHealthChanged,
+}
+
+const (
+	WebhookDeliveryPending   = "pending"
+	WebhookDeliveryDelivered = "delivered"
+	WebhookDeliveryFailed    = "failed"
+)
+
+type WebhookSubscription struct {
+	ID          string
+	URL         string
+	Secret      string
+	Events      []string
+	Active      bool
+	Description string
+	Version     int64
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+type ListWebhookSubscriptions struct {
+	Subscriptions []*WebhookSubscription
+	TotalCount    uint64
+}
+
+// WebhookEvent is what happened on the farm, Key tells repeats of the same
+// happening apart from new ones. Payload is the body every subscriber gets
+type WebhookEvent struct {
+	ID         string
+	Type       string
+	Key        string
+	Payload    json.RawMessage
+	OccurredAt time.Time
+}
+
+// WebhookDelivery is one event sent to one subscription, URL and Secret are
+// filled in when it is claimed to be sent
+type WebhookDelivery struct {
+	ID             string
+	SubscriptionID string
+	Event          string
+	EventKey       string
+	Payload        json.RawMessage
+	Status         string
+	Attempts       int
+	NextAttemptAt  time.Time
+	ResponseStatus int
+	LastError      string
+	DeliveredAt    *time.Time
+	CreatedAt      time.Time
+	URL            string
+	Secret         string
+}
+
+type ListWebhookDeliveries struct {
+	Deliveries []*WebhookDelivery
+	TotalCount uint64
+}
@@ -2,39 +2,135 @@ package errors
 
 import (
 	"errors"
+	"fmt"
 )
 
 var (
-	ErrorConflict       = NewErrConflict("object")
-	ErrorNotFound       = NewErrNotFound("object")
-	ErrorInvalidOTPCode = errors.New("code is invalid")
-	ErrorOTPExpired     = errors.New("one time password has expired")
+	ErrorConflict        = NewErrConflict("object")
+	ErrorNotFound        = NewErrNotFound("object")
+	ErrorInvalidOTPCode  = errors.New("code is invalid")
+	ErrorOTPExpired      = errors.New("one time password has expired")
+	ErrorNotEnoughStock  = errors.New("not enough stock in usable lots")
+	ErrorParentDeleted   = errors.New("entity points to a deleted entity")
+	ErrorUnknownEntity   = errors.New("unknown entity type")
+	ErrorVersionConflict = errors.New("entity was changed by someone else")
+	ErrorTaskClosed      = errors.New("feeding task is already done or skipped")
 )
 
+// Code is the stable name of an error kind, clients switch on it instead of
+// the message which is meant for people and may change
+type Code string
+
+const (
+	CodeInternal              Code = "internal"
+	CodeBadRequest            Code = "bad_request"
+	CodeValidation            Code = "validation_failed"
+	CodeNotFound              Code = "not_found"
+	CodeConflict              Code = "already_exists"
+	CodeNotEnoughStock        Code = "not_enough_stock"
+	CodeParentDeleted         Code = "parent_deleted"
+	CodeUnknownEntity         Code = "unknown_entity"
+	CodeVersionConflict       Code = "version_conflict"
+	CodeTaskClosed            Code = "task_closed"
+	CodePreconditionRequired  Code = "precondition_required"
+	CodeIdempotencyKeyReused  Code = "idempotency_key_reused"
+	CodeIdempotencyInProgress Code = "idempotency_in_progress"
+	CodeTooManyRequests       Code = "too_many_requests"
+	CodeDeadlineExceeded      Code = "deadline_exceeded"
+)
+
+// CodeOf returns the code of the first known error in the chain, anything
+// else is internal
+func CodeOf(err error) Code {
+	var (
+		notFound   *ErrNotFound
+		conflict   *ErrConflict
+		validation *ErrValidation
+		badRequest *ErrBadRequest
+	)
+
+	switch {
+	case err == nil:
+		return ""
+	case errors.As(err, &validation):
+		return CodeValidation
+	case errors.As(err, &badRequest):
+		return CodeBadRequest
+	case errors.Is(err, ErrorVersionConflict):
+		return CodeVersionConflict
+	case errors.Is(err, ErrorNotEnoughStock):
+		return CodeNotEnoughStock
+	case errors.Is(err, ErrorParentDeleted):
+		return CodeParentDeleted
+	case errors.Is(err, ErrorUnknownEntity):
+		return CodeUnknownEntity
+	case errors.Is(err, ErrorTaskClosed):
+		return CodeTaskClosed
+	case errors.As(err, &notFound):
+		return CodeNotFound
+	case errors.As(err, &conflict):
+		return CodeConflict
+	}
+	return CodeInternal
+}
+
+// Wrap says what the caller was doing when err happened, the result still
+// matches the typed errors below. A nil err stays nil
+func Wrap(err error, format string, args ...any) error {
+	if err == nil {
+		return nil
+	}
+	return fmt.Errorf(format+": %w", append(args, err)...)
+}
+
 // error not found
 type ErrNotFound struct {
 	name string
+	// Err is the driver error behind it, kept so errors.Is still sees it
+	Err error
 }
 
 func (e *ErrNotFound) Error() string {
 	return e.name + " not found"
 }
 
+func (e *ErrNotFound) Unwrap() error {
+	return e.Err
+}
+
+// Is makes every not found error match ErrorNotFound whatever its name
+func (e *ErrNotFound) Is(target error) bool {
+	_, ok := target.(*ErrNotFound)
+	return ok
+}
+
 func NewErrNotFound(text string) *ErrNotFound {
-	return &ErrNotFound{text}
+	return &ErrNotFound{name: text}
 }
 
 // error conflict
 type ErrConflict struct {
 	name string
+	// Err is the driver error behind it, kept so errors.Is still sees it
+	Err error
 }
 
 func (e *ErrConflict) Error() string {
 	return e.name + " already exist"
 }
 
+func (e *ErrConflict) Unwrap() error {
+	return e.Err
+}
+
+// Is makes every conflict error match ErrorConflict whatever its name
+func (e *ErrConflict) Is(target error) bool {
+	_, ok := target.(*ErrConflict)
+	return ok
+}
+
 func NewErrConflict(text string) *ErrConflict {
-	return &ErrConflict{text}
+	return &ErrConflict{name: text}
 }
 
 // error validation
@@ -47,6 +143,10 @@ func (e ErrValidation) Error() string {
 	return e.Err.Error()
 }
 
+func (e ErrValidation) Unwrap() error {
+	return e.Err
+}
+
 func NewErrValidation() *ErrValidation {
 	return &ErrValidation{Errors: make(map[string]string)}
 }
@@ -60,6 +160,10 @@ func (e ErrBadRequest) Error() string {
 	return e.Err.Error()
 }
 
+func (e ErrBadRequest) Unwrap() error {
+	return e.Err
+}
+
 func NewErrBadRequest(err error) *ErrBadRequest {
 	return &ErrBadRequest{err}
 }
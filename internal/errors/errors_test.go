@@ -0,0 +1,41 @@
+package errors_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/jackc/pgx/v4"
+	"github.com/stretchr/testify/assert"
+
+	errorspkg "musobaqa/farm-competition/internal/errors"
+)
+
+func TestCodeOf(t *testing.T) {
+	notFound := errorspkg.NewErrNotFound("animal")
+	notFound.Err = pgx.ErrNoRows
+
+	// Context added by usecases keeps the kind of the error
+	err := errorspkg.Wrap(notFound, "get animal %s", "42")
+	assert.Equal(t, "get animal 42: animal not found", err.Error())
+	assert.Equal(t, errorspkg.CodeNotFound, errorspkg.CodeOf(err))
+	assert.ErrorIs(t, err, errorspkg.ErrorNotFound)
+	assert.ErrorIs(t, err, pgx.ErrNoRows)
+	assert.NoError(t, errorspkg.Wrap(nil, "get animal"))
+
+	validation := errorspkg.NewErrValidation()
+	validation.Err = errors.New("invalid")
+
+	for err, code := range map[error]errorspkg.Code{
+		errorspkg.NewErrConflict("supplier"): errorspkg.CodeConflict,
+		validation:                           errorspkg.CodeValidation,
+		errorspkg.NewErrBadRequest(errors.New("invalid cursor")):        errorspkg.CodeBadRequest,
+		errorspkg.Wrap(errorspkg.ErrorVersionConflict, "update animal"): errorspkg.CodeVersionConflict,
+		errorspkg.Wrap(errorspkg.ErrorNotEnoughStock, "create feeding"): errorspkg.CodeNotEnoughStock,
+		errorspkg.Wrap(errorspkg.ErrorParentDeleted, "restore feeding"): errorspkg.CodeParentDeleted,
+		errorspkg.ErrorUnknownEntity:                                    errorspkg.CodeUnknownEntity,
+		errorspkg.Wrap(errorspkg.ErrorTaskClosed, "skip feeding task"):  errorspkg.CodeTaskClosed,
+		errors.New("connection refused"):                                errorspkg.CodeInternal,
+	} {
+		assert.Equal(t, code, errorspkg.CodeOf(err), err.Error())
+	}
+}
@@ -3,13 +3,14 @@ package postgresql
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"musobaqa/farm-competition/internal/entity"
 	"musobaqa/farm-competition/internal/infrastructure/repository/postgresql/repo"
+	"musobaqa/farm-competition/internal/pkg/listquery"
 	"musobaqa/farm-competition/internal/pkg/postgres"
+	"time"
 
-	sq "github.com/Masterminds/squirrel"
 	"github.com/jackc/pgx/v4"
-	"github.com/spf13/cast"
 )
 
 type animalProductRepo struct {
@@ -45,11 +46,11 @@ func (ap *animalProductRepo) Create(ctx context.Context, animalProduct *entity.A
 
 	result, err := ap.db.Exec(ctx, query, args...)
 	if err != nil {
-		return nil, err
+		return nil, ap.db.Error(err, "animal product")
 	}
 
 	if result.RowsAffected() == 0 {
-		return nil, pgx.ErrNoRows
+		return nil, ap.db.Error(pgx.ErrNoRows, "animal product")
 	}
 
 	selectQueryBuilder := ap.db.Sq.Builder.Select(
@@ -69,7 +70,8 @@ func (ap *animalProductRepo) Create(ctx context.Context, animalProduct *entity.A
 			"p.total_capacity, " +
 			"ap.id, " +
 			"ap.capacity, " +
-			"ap.get_time")
+			"ap.get_time, " +
+			"ap.created_at")
 	selectQueryBuilder = selectQueryBuilder.From(ap.tableName + " AS ap")
 	selectQueryBuilder = selectQueryBuilder.Join("animals AS a ON a.id = ap.animal_id")
 	selectQueryBuilder = selectQueryBuilder.Join("products AS p ON p.id = ap.product_id")
@@ -112,7 +114,7 @@ func (ap *animalProductRepo) Create(ctx context.Context, animalProduct *entity.A
 		&nullGetTime,
 	)
 	if err != nil {
-		return nil, err
+		return nil, ap.db.Error(err, "animal product")
 	}
 
 	if nullAnimalBirthday.Valid {
@@ -144,25 +146,30 @@ func (ap *animalProductRepo) Update(ctx context.Context, animalProduct *entity.A
 		"get_time":   animalProduct.GetTime,
 		"capacity":   animalProduct.Capacity,
 		"updated_at": animalProduct.UpdatedAt,
+		"version":    ap.db.Sq.Expr("version + 1"),
 	}
 
 	queryBuilder := ap.db.Sq.Builder.Update(ap.tableName)
 	queryBuilder = queryBuilder.SetMap(clauses)
 	queryBuilder = queryBuilder.Where("deleted_at IS NULL")
 	queryBuilder = queryBuilder.Where(ap.db.Sq.Equal("id", animalProduct.ID))
+	if animalProduct.Version > 0 {
+		queryBuilder = queryBuilder.Where(ap.db.Sq.Equal("version", animalProduct.Version))
+	}
+	queryBuilder = queryBuilder.Suffix("RETURNING version")
 
 	query, args, err := queryBuilder.ToSql()
 	if err != nil {
 		return nil, err
 	}
 
-	result, err := ap.db.Exec(ctx, query, args...)
-	if err != nil {
-		return nil, err
+	var version int64
+	err = ap.db.QueryRow(ctx, query, args...).Scan(&version)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, versionConflict(ctx, ap.db, ap.tableName, animalProduct.ID, animalProduct.Version, ap.db.Error(pgx.ErrNoRows, "animal product"))
 	}
-
-	if result.RowsAffected() == 0 {
-		return nil, pgx.ErrNoRows
+	if err != nil {
+		return nil, ap.db.Error(err, "animal product")
 	}
 
 	selectQueryBuilder := ap.db.Sq.Builder.Select(
@@ -182,7 +189,8 @@ func (ap *animalProductRepo) Update(ctx context.Context, animalProduct *entity.A
 			"p.total_capacity, " +
 			"ap.id, " +
 			"ap.capacity, " +
-			"ap.get_time")
+			"ap.get_time, " +
+			"ap.created_at")
 	selectQueryBuilder = selectQueryBuilder.From(ap.tableName + " AS ap")
 	selectQueryBuilder = selectQueryBuilder.Join("animals AS a ON a.id = ap.animal_id")
 	selectQueryBuilder = selectQueryBuilder.Join("products AS p ON p.id = ap.product_id")
@@ -225,7 +233,7 @@ func (ap *animalProductRepo) Update(ctx context.Context, animalProduct *entity.A
 		&nullGetTime,
 	)
 	if err != nil {
-		return nil, err
+		return nil, ap.db.Error(err, "animal product")
 	}
 
 	if nullAnimalBirthday.Valid {
@@ -247,13 +255,21 @@ func (ap *animalProductRepo) Update(ctx context.Context, animalProduct *entity.A
 		animalProductRes.GetTime = nullGetTime.String
 	}
 
+	animalProductRes.Version = version
+
 	return &animalProductRes, nil
 }
 
-func (ap *animalProductRepo) Delete(ctx context.Context, animalProductID string) error {
-	queryBuilder := ap.db.Sq.Builder.Delete(ap.tableName)
+func (ap *animalProductRepo) Delete(ctx context.Context, animalProductID string, version int64) error {
+	queryBuilder := ap.db.Sq.Builder.Update(ap.tableName)
+	queryBuilder = queryBuilder.SetMap(map[string]interface{}{
+		"deleted_at": time.Now().Format(time.RFC3339),
+	})
 	queryBuilder = queryBuilder.Where("deleted_at IS NULL")
 	queryBuilder = queryBuilder.Where(ap.db.Sq.Equal("id", animalProductID))
+	if version > 0 {
+		queryBuilder = queryBuilder.Where(ap.db.Sq.Equal("version", version))
+	}
 
 	query, args, err := queryBuilder.ToSql()
 	if err != nil {
@@ -262,11 +278,11 @@ func (ap *animalProductRepo) Delete(ctx context.Context, animalProductID string)
 
 	result, err := ap.db.Exec(ctx, query, args...)
 	if err != nil {
-		return err
+		return ap.db.Error(err, "animal product")
 	}
 
 	if result.RowsAffected() == 0 {
-		return pgx.ErrNoRows
+		return versionConflict(ctx, ap.db, ap.tableName, animalProductID, version, ap.db.Error(pgx.ErrNoRows, "animal product"))
 	}
 
 	return nil
@@ -290,7 +306,8 @@ func (ap *animalProductRepo) Get(ctx context.Context, animalProductID string) (*
 			"p.total_capacity, " +
 			"ap.id, " +
 			"ap.capacity, " +
-			"ap.get_time")
+			"ap.get_time, " +
+			"ap.version")
 	selectQueryBuilder = selectQueryBuilder.From(ap.tableName + " AS ap")
 	selectQueryBuilder = selectQueryBuilder.Join("animals AS a ON a.id = ap.animal_id")
 	selectQueryBuilder = selectQueryBuilder.Join("products AS p ON p.id = ap.product_id")
@@ -331,9 +348,10 @@ func (ap *animalProductRepo) Get(ctx context.Context, animalProductID string) (*
 		&animalProductRes.ID,
 		&animalProductRes.Capacity,
 		&nullGetTime,
+		&animalProductRes.Version,
 	)
 	if err != nil {
-		return nil, err
+		return nil, ap.db.Error(err, "animal product")
 	}
 
 	if nullAnimalBirthday.Valid {
@@ -358,7 +376,31 @@ func (ap *animalProductRepo) Get(ctx context.Context, animalProductID string) (*
 	return &animalProductRes, nil
 }
 
-func (ap *animalProductRepo) List(ctx context.Context, page, limit uint64, params map[string]any) (*entity.ListAnimalProduct, error) {
+var animalProductListSchema = listquery.Schema{
+	Fields: map[string]listquery.Field{
+		"get_time":     {Column: "ap.get_time", Type: listquery.Date, Ops: listquery.DateOps, Sortable: true},
+		"animal_id":    {Column: "ap.animal_id", Ops: listquery.EnumOps},
+		"product_id":   {Column: "ap.product_id", Ops: listquery.EnumOps},
+		"capacity":     {Column: "ap.capacity", Type: listquery.Int, Ops: listquery.NumberOps, Sortable: true},
+		"animal_name":  {Column: "a.name", Ops: listquery.TextOps, Sortable: true, Searchable: true},
+		"product_name": {Column: "p.name", Ops: listquery.TextOps, Sortable: true, Searchable: true},
+		"created_at":   {Column: "ap.created_at", Type: listquery.Date, Ops: listquery.DateOps, Sortable: true},
+	},
+	DefaultOrder: []string{"ap.created_at DESC", "ap.id DESC"},
+	Keyset:       []string{"ap.created_at", "ap.id"},
+}
+
+func (ap *animalProductRepo) List(ctx context.Context, page, limit uint64, request listquery.Request) (*entity.ListAnimalProduct, error) {
+	where, orderBy, err := animalProductListSchema.Build(ap.db.Sq, request)
+	if err != nil {
+		return nil, err
+	}
+
+	seek, err := animalProductListSchema.Seek(ap.db.Sq, request)
+	if err != nil {
+		return nil, err
+	}
+
 	selectQueryBuilder := ap.db.Sq.Builder.Select(
 		"a.id, " +
 			"a.name, " +
@@ -376,21 +418,23 @@ func (ap *animalProductRepo) List(ctx context.Context, page, limit uint64, param
 			"p.total_capacity, " +
 			"ap.id, " +
 			"ap.capacity, " +
-			"ap.get_time")
+			"ap.get_time, " +
+			"ap.created_at")
 	selectQueryBuilder = selectQueryBuilder.From(ap.tableName + " AS ap")
 	selectQueryBuilder = selectQueryBuilder.Join("animals AS a ON a.id = ap.animal_id")
 	selectQueryBuilder = selectQueryBuilder.Join("products AS p ON p.id = ap.product_id")
 	selectQueryBuilder = selectQueryBuilder.Where("ap.deleted_at IS NULL")
 	selectQueryBuilder = selectQueryBuilder.Where("a.deleted_at IS NULL")
 	selectQueryBuilder = selectQueryBuilder.Where("p.deleted_at IS NULL")
-	if params["get_time"] != "" {
-		selectQueryBuilder = selectQueryBuilder.Where(ap.db.Sq.And(
-			sq.GtOrEq{"ap.get_time": cast.ToString(params["get_time"]) + " 00:00:00______"},
-			sq.LtOrEq{"ap.get_time": cast.ToString(params["get_time"]) + " 23:59:59______"},
-		))
+	selectQueryBuilder = selectQueryBuilder.Where(where)
+	selectQueryBuilder = selectQueryBuilder.OrderBy(orderBy...)
+	// one row more than asked tells whether there is a next page
+	selectQueryBuilder = selectQueryBuilder.Limit(limit + 1)
+	if seek != nil {
+		selectQueryBuilder = selectQueryBuilder.Where(seek)
+	} else {
+		selectQueryBuilder = selectQueryBuilder.Offset(limit * (page - 1))
 	}
-	selectQueryBuilder = selectQueryBuilder.Limit(limit)
-	selectQueryBuilder = selectQueryBuilder.Offset(limit * (page - 1))
 
 	selectQuery, selectArgs, err := selectQueryBuilder.ToSql()
 	if err != nil {
@@ -432,6 +476,7 @@ func (ap *animalProductRepo) List(ctx context.Context, page, limit uint64, param
 			&animalProductRes.ID,
 			&animalProductRes.Capacity,
 			&nullGetTime,
+			&animalProductRes.CreatedAt,
 		)
 
 		if err != nil {
@@ -460,6 +505,14 @@ func (ap *animalProductRepo) List(ctx context.Context, page, limit uint64, param
 		response.AnimalProducts = append(response.AnimalProducts, &animalProductRes)
 	}
 
+	if uint64(len(response.AnimalProducts)) > limit {
+		response.AnimalProducts = response.AnimalProducts[:limit]
+		if limit > 0 {
+			last := response.AnimalProducts[limit-1]
+			response.NextCursor = animalProductListSchema.NextCursor(request, last.CreatedAt, last.ID)
+		}
+	}
+
 	totalCountBuilder := ap.db.Sq.Builder.Select("COUNT(*)")
 	totalCountBuilder = totalCountBuilder.From(ap.tableName + " AS ap")
 	totalCountBuilder = totalCountBuilder.Join("animals AS a ON a.id = ap.animal_id")
@@ -467,12 +520,7 @@ func (ap *animalProductRepo) List(ctx context.Context, page, limit uint64, param
 	totalCountBuilder = totalCountBuilder.Where("ap.deleted_at IS NULL")
 	totalCountBuilder = totalCountBuilder.Where("a.deleted_at IS NULL")
 	totalCountBuilder = totalCountBuilder.Where("p.deleted_at IS NULL")
-	if params["get_time"] != "" {
-		totalCountBuilder = totalCountBuilder.Where(ap.db.Sq.And(
-			sq.GtOrEq{"ap.get_time": cast.ToString(params["get_time"]) + " 00:00:00______"},
-			sq.LtOrEq{"ap.get_time": cast.ToString(params["get_time"]) + " 23:59:59______"},
-		))
-	}
+	totalCountBuilder = totalCountBuilder.Where(where)
 
 	totalQuery, totalArgs, err := totalCountBuilder.ToSql()
 	if err != nil {
@@ -512,7 +560,7 @@ func (ap *animalProductRepo) ListAnimals(ctx context.Context, page, limit uint64
 		&response.Product.TotalCapacity,
 	)
 	if err != nil {
-		return nil, err
+		return nil, ap.db.Error(err, "animal product")
 	}
 
 	if nullProductDescription.Valid {
@@ -664,7 +712,7 @@ func (ap *animalProductRepo) ListProducts(ctx context.Context, page, limit uint6
 		&nullIsHealth,
 	)
 	if err != nil {
-		return nil, err
+		return nil, ap.db.Error(err, "animal product")
 	}
 
 	if nullAnimalBirthDay.Valid {
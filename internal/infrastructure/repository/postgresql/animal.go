@@ -4,15 +4,14 @@ import (
 	"context"
 	"database/sql"
 	"encoding/json"
+	"errors"
 	"musobaqa/farm-competition/internal/entity"
 	"musobaqa/farm-competition/internal/infrastructure/repository/postgresql/repo"
+	"musobaqa/farm-competition/internal/pkg/listquery"
 	"musobaqa/farm-competition/internal/pkg/postgres"
 	"time"
 
 	"github.com/jackc/pgx/v4"
-
-	sq "github.com/Masterminds/squirrel"
-	"github.com/spf13/cast"
 )
 
 type animalRepo struct {
@@ -91,7 +90,7 @@ func (a *animalRepo) Create(ctx context.Context, animal *entity.Animal) (*entity
 	)
 
 	if err != nil {
-		return nil, err
+		return nil, a.db.Error(err, "animal")
 	}
 	if sqlNullGenus.Valid {
 		createdAnimal.Genus = sqlNullGenus.String
@@ -119,10 +118,12 @@ func (a *animalRepo) Update(ctx context.Context, animal *entity.Animal) (*entity
 	    weight = $6,
 	    description = $7,
 	    is_health = $8,
-	    updated_at = $9
+	    updated_at = $9,
+	    version = version + 1
 	WHERE
 	    id = $10
 		AND deleted_at IS NULL
+		AND ($11 = 0 OR version = $11)
 	RETURNING
 		id,
 	    name,
@@ -132,7 +133,8 @@ func (a *animalRepo) Update(ctx context.Context, animal *entity.Animal) (*entity
 		genus,
 		weight,
 		description,
-		is_health
+		is_health,
+		version
 	`
 
 	var (
@@ -153,6 +155,7 @@ func (a *animalRepo) Update(ctx context.Context, animal *entity.Animal) (*entity
 		animal.IsHealth,
 		animal.UpdatedAt,
 		animal.ID,
+		animal.Version,
 	).Scan(
 		&updatedAnimal.ID,
 		&updatedAnimal.Name,
@@ -163,10 +166,14 @@ func (a *animalRepo) Update(ctx context.Context, animal *entity.Animal) (*entity
 		&updatedAnimal.Weight,
 		&sqlNullDescription,
 		&updatedAnimal.IsHealth,
+		&updatedAnimal.Version,
 	)
 
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, versionConflict(ctx, a.db, a.tableName, animal.ID, animal.Version, a.db.Error(err, "animal"))
+	}
 	if err != nil {
-		return nil, err
+		return nil, a.db.Error(err, "animal")
 	}
 	if sqlNullGenus.Valid {
 		updatedAnimal.Genus = sqlNullGenus.String
@@ -181,19 +188,21 @@ func (a *animalRepo) Update(ctx context.Context, animal *entity.Animal) (*entity
 	return &updatedAnimal, nil
 }
 
-func (a *animalRepo) Delete(ctx context.Context, animalID string) error {
-	query := `UPDATE animals SET deleted_at = $1 WHERE id = $2 AND deleted_at IS NULL`
+func (a *animalRepo) Delete(ctx context.Context, animalID string, version int64) error {
+	return a.db.WithTx(ctx, func(ctx context.Context) error {
+		query := `UPDATE animals SET deleted_at = $1 WHERE id = $2 AND deleted_at IS NULL AND ($3 = 0 OR version = $3)`
 
-	result, err := a.db.Exec(ctx, query, time.Now().Format(time.RFC3339), animalID)
-	if err != nil {
-		return err
-	}
+		result, err := a.db.Exec(ctx, query, time.Now().Format(time.RFC3339), animalID, version)
+		if err != nil {
+			return a.db.Error(err, "animal")
+		}
 
-	if result.RowsAffected() == 0 {
-		return pgx.ErrNoRows
-	}
+		if result.RowsAffected() == 0 {
+			return versionConflict(ctx, a.db, "animals", animalID, version, a.db.Error(pgx.ErrNoRows, "animal"))
+		}
 
-	return nil
+		return cascadeDelete(ctx, a.db, "animal", animalID)
+	})
 }
 
 func (a *animalRepo) Get(ctx context.Context, animalID string) (*entity.Animal, error) {
@@ -207,7 +216,8 @@ func (a *animalRepo) Get(ctx context.Context, animalID string) (*entity.Animal,
 		genus,
 		weight,
 		description,
-		is_health
+		is_health,
+		version
 	FROM
 	    animals
 	WHERE
@@ -232,9 +242,10 @@ func (a *animalRepo) Get(ctx context.Context, animalID string) (*entity.Animal,
 		&animal.Weight,
 		&sqlNullDescription,
 		&animal.IsHealth,
+		&animal.Version,
 	)
 	if err != nil {
-		return nil, err
+		return nil, a.db.Error(err, "animal")
 	}
 
 	if sqlNullGenus.Valid {
@@ -250,33 +261,39 @@ func (a *animalRepo) Get(ctx context.Context, animalID string) (*entity.Animal,
 	return &animal, nil
 }
 
-func (a *animalRepo) List(ctx context.Context, page, limit uint64, params map[string]any) (*entity.ListAnimal, error) {
+// animalListSchema matches gender exactly, a substring of male would also
+// match female
+var animalListSchema = listquery.Schema{
+	Fields: map[string]listquery.Field{
+		"name":        {Column: "name", Ops: listquery.TextOps, Sortable: true, Searchable: true},
+		"category":    {Column: "category_name", Ops: listquery.TextOps, Sortable: true, Searchable: true},
+		"genus":       {Column: "genus", Ops: listquery.TextOps, Sortable: true, Searchable: true},
+		"gender":      {Column: "gender", Ops: listquery.EnumOps, Sortable: true},
+		"weight":      {Column: "weight", Type: listquery.Int, Ops: listquery.NumberOps, Sortable: true},
+		"birth_day":   {Column: "birth_day", Type: listquery.Date, Ops: listquery.DateOps, Sortable: true},
+		"is_health":   {Column: "is_health", Ops: listquery.EnumOps},
+		"description": {Column: "description", Ops: listquery.TextOps, Searchable: true},
+		"created_at":  {Column: "created_at", Type: listquery.Date, Ops: listquery.DateOps, Sortable: true},
+	},
+	DefaultOrder: []string{"created_at DESC"},
+}
+
+func (a *animalRepo) List(ctx context.Context, page, limit uint64, request listquery.Request) (*entity.ListAnimal, error) {
 	var (
-		offset     = (page - 1) * limit
-		animals    = entity.ListAnimal{}
-		tenPercent = cast.ToInt(params["weight"]) / 10
-		weightUp   = cast.ToInt(params["weight"]) + tenPercent
-		weightDown = cast.ToInt(params["weight"]) - tenPercent
+		offset  = (page - 1) * limit
+		animals = entity.ListAnimal{}
 	)
 
+	where, orderBy, err := animalListSchema.Build(a.db.Sq, request)
+	if err != nil {
+		return nil, err
+	}
+
 	queryBuilder := a.db.Sq.Builder.Select("id, name, category_name, gender, birth_day, genus, weight, description, is_health")
 	queryBuilder = queryBuilder.From(a.tableName)
 	queryBuilder = queryBuilder.Where("deleted_at IS NULL")
-	queryBuilder = queryBuilder.Where(a.db.Sq.ILike("category_name", "%"+cast.ToString(params["category"])+"%"))
-	queryBuilder = queryBuilder.Where(a.db.Sq.ILike("genus", "%"+cast.ToString(params["genus"])+"%"))
-	queryBuilder = queryBuilder.Where(a.db.Sq.ILike("gender", "%"+cast.ToString(params["gender"])+"%"))
-	if cast.ToInt(params["weight"]) != 0 {
-		queryBuilder = queryBuilder.Where(a.db.Sq.And(
-			sq.GtOrEq{"weight": weightDown},
-			sq.LtOrEq{"weight": weightUp},
-		))
-		queryBuilder = queryBuilder.OrderBy("weight DESC")
-	}
-
-	if cast.ToString(params["is_health"]) != "" {
-		queryBuilder = queryBuilder.Where(a.db.Sq.Equal("is_health", cast.ToString(params["is_health"])))
-	}
-
+	queryBuilder = queryBuilder.Where(where)
+	queryBuilder = queryBuilder.OrderBy(orderBy...)
 	queryBuilder = queryBuilder.Limit(limit)
 	queryBuilder = queryBuilder.Offset(offset)
 
@@ -329,18 +346,7 @@ func (a *animalRepo) List(ctx context.Context, page, limit uint64, params map[st
 	totalQueryBuilder := a.db.Sq.Builder.Select("COUNT(*)")
 	totalQueryBuilder = totalQueryBuilder.From(a.tableName)
 	totalQueryBuilder = totalQueryBuilder.Where("deleted_at IS NULL")
-	totalQueryBuilder = totalQueryBuilder.Where(a.db.Sq.ILike("category_name", "%"+cast.ToString(params["category"])+"%"))
-	totalQueryBuilder = totalQueryBuilder.Where(a.db.Sq.ILike("genus", "%"+cast.ToString(params["genus"])+"%"))
-	totalQueryBuilder = totalQueryBuilder.Where(a.db.Sq.ILike("gender", "%"+cast.ToString(params["gender"])+"%"))
-	if cast.ToString(params["is_health"]) != "" {
-		totalQueryBuilder = totalQueryBuilder.Where(a.db.Sq.Equal("is_health", cast.ToString(params["is_health"])))
-	}
-	if cast.ToInt(params["weight"]) != 0 {
-		totalQueryBuilder = totalQueryBuilder.Where(a.db.Sq.And(
-			sq.GtOrEq{"weight": weightDown},
-			sq.LtOrEq{"weight": weightUp},
-		))
-	}
+	totalQueryBuilder = totalQueryBuilder.Where(where)
 
 	totalQuery, totalArgs, err := totalQueryBuilder.ToSql()
 	if err != nil {
@@ -373,8 +379,10 @@ func (a animalRepo) HungryAnimals(ctx context.Context, page, limit uint64) (*ent
 			"f.product_union")
 	queryBuilder = queryBuilder.From(a.infoTableName + " AS e")
 	queryBuilder = queryBuilder.Join("foods AS f ON f.id = e.eatables_id")
+	queryBuilder = queryBuilder.Join(a.tableName + " AS a ON a.id = e.animal_id")
 	queryBuilder = queryBuilder.Where("f.deleted_at IS NULL")
 	queryBuilder = queryBuilder.Where("e.deleted_at IS NULL")
+	queryBuilder = queryBuilder.Where("a.deleted_at IS NULL")
 	queryBuilder = queryBuilder.Limit(limit)
 	queryBuilder = queryBuilder.Offset(limit * (page - 1))
 
@@ -436,6 +444,7 @@ func (a animalRepo) HungryAnimals(ctx context.Context, page, limit uint64) (*ent
 			"animal_id, " +
 				"daily")
 		queryBuilderFeeding = queryBuilderFeeding.From(a.feedingTableName)
+		queryBuilderFeeding = queryBuilderFeeding.Where("deleted_at IS NULL")
 		queryBuilderFeeding = queryBuilderFeeding.Where(a.db.Sq.Equal("animal_id", key))
 
 		query, args, err := queryBuilderFeeding.ToSql()
@@ -2,7 +2,6 @@ package postgresql_test
 
 import (
 	"context"
-	"github.com/jackc/pgx/v4"
 	"github.com/spf13/cast"
 	"musobaqa/farm-competition/internal/pkg/config"
 	"strings"
@@ -13,6 +12,7 @@ import (
 	"github.com/stretchr/testify/assert"
 
 	"musobaqa/farm-competition/internal/entity"
+	errorspkg "musobaqa/farm-competition/internal/errors"
 	"musobaqa/farm-competition/internal/infrastructure/repository/postgresql"
 	"musobaqa/farm-competition/internal/pkg/postgres"
 )
@@ -97,12 +97,12 @@ func TestAnimal(t *testing.T) {
 	assert.Equal(t, getAnimal.IsHealth, updatedAnimal.IsHealth)
 
 	// Delete
-	err = repo.Delete(ctx, defaultAnimalID)
+	err = repo.Delete(ctx, defaultAnimalID, 0)
 	assert.NoError(t, err)
 	notAnimal, err := repo.Get(ctx, defaultAnimalID)
 	assert.Error(t, err)
 	assert.Nil(t, notAnimal)
-	assert.Equal(t, err, pgx.ErrNoRows)
+	assert.ErrorIs(t, err, errorspkg.ErrorNotFound)
 }
 
 func TestProduct(t *testing.T) {
@@ -168,14 +168,14 @@ func TestProduct(t *testing.T) {
 	assert.Equal(t, getProduct.TotalCapacity, updatedProduct.TotalCapacity)
 
 	// delete
-	err = repo.Delete(ctx, defaultProductID)
+	err = repo.Delete(ctx, defaultProductID, 0)
 	assert.NoError(t, err)
 	notProduct, err := repo.Get(ctx, map[string]string{
 		"id": defaultProductID,
 	})
 	assert.Error(t, err)
 	assert.Nil(t, notProduct)
-	assert.Equal(t, err, pgx.ErrNoRows)
+	assert.ErrorIs(t, err, errorspkg.ErrorNotFound)
 }
 
 func TestAnimalProduct(t *testing.T) {
@@ -337,12 +337,12 @@ func TestAnimalProduct(t *testing.T) {
 	assert.Equal(t, getAnimalProduct.Product.TotalCapacity, updatedAnimalProduct.Product.TotalCapacity)
 
 	// Delete Animal-Product
-	err = repoAnimalProduct.Delete(ctx, defaultAnimalProductID)
+	err = repoAnimalProduct.Delete(ctx, defaultAnimalProductID, 0)
 	assert.NoError(t, err)
 	notAnimalProduct, err := repoAnimalProduct.Get(ctx, defaultAnimalProductID)
 	assert.Error(t, err)
 	assert.Nil(t, notAnimalProduct)
-	assert.Equal(t, err, pgx.ErrNoRows)
+	assert.ErrorIs(t, err, errorspkg.ErrorNotFound)
 }
 
 func TestFood(t *testing.T) {
@@ -411,14 +411,14 @@ func TestFood(t *testing.T) {
 	assert.Equal(t, getFood.Description, updatedFood.Description)
 
 	// Delete
-	err = repo.Delete(ctx, defaultFoodID)
+	err = repo.Delete(ctx, defaultFoodID, 0)
 	assert.NoError(t, err)
 	notFood, err := repo.Get(ctx, map[string]string{
 		"id": defaultFoodID,
 	})
 	assert.Error(t, err)
 	assert.Nil(t, notFood)
-	assert.Equal(t, err, pgx.ErrNoRows)
+	assert.ErrorIs(t, err, errorspkg.ErrorNotFound)
 }
 
 func TestDrug(t *testing.T) {
@@ -489,14 +489,14 @@ func TestDrug(t *testing.T) {
 	assert.Equal(t, getDrug.Description, updatedDrug.Description)
 
 	// Delete
-	err = repo.Delete(ctx, defaultDrugID)
+	err = repo.Delete(ctx, defaultDrugID, 0)
 	assert.NoError(t, err)
 	notFood, err := repo.Get(ctx, map[string]string{
 		"id": defaultDrugID,
 	})
 	assert.Error(t, err)
 	assert.Nil(t, notFood)
-	assert.Equal(t, err, pgx.ErrNoRows)
+	assert.ErrorIs(t, err, errorspkg.ErrorNotFound)
 }
 
 func TestEatable(t *testing.T) {
@@ -618,7 +618,7 @@ func TestEatable(t *testing.T) {
 	assert.Equal(t, updatedEatables.Daily[1].Capacity, updatedEatablesModel.Daily[1].Capacity)
 
 	// Delete Eatables
-	err = repoEatable.Delete(ctx, defaultEatableID)
+	err = repoEatable.Delete(ctx, defaultEatableID, 0)
 	assert.NoError(t, err)
 }
 
@@ -695,10 +695,10 @@ func TestDelivery(t *testing.T) {
 	assert.Equal(t, getDelivery.Time, getTime)
 
 	// Delete
-	err = repo.Delete(ctx, defaultDeliveryID)
+	err = repo.Delete(ctx, defaultDeliveryID, 0)
 	assert.NoError(t, err)
 	notDelivery, err := repo.Get(ctx, defaultDeliveryID)
 	assert.Error(t, err)
 	assert.Nil(t, notDelivery)
-	assert.Equal(t, err, pgx.ErrNoRows)
+	assert.ErrorIs(t, err, errorspkg.ErrorNotFound)
 }
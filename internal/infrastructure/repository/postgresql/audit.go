@@ -0,0 +1,185 @@
+package postgresql
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"musobaqa/farm-competition/internal/entity"
+	errorspkg "musobaqa/farm-competition/internal/errors"
+	"musobaqa/farm-competition/internal/infrastructure/repository/postgresql/repo"
+	"musobaqa/farm-competition/internal/pkg/postgres"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v4"
+	"github.com/spf13/cast"
+)
+
+// entityTables maps entity type names used by the API to their tables
+var entityTables = map[string]string{
+	"animal":         "animals",
+	"product":        "products",
+	"food":           "foods",
+	"drug":           "drugs",
+	"delivery":       "into_store",
+	"animal_product": "animal_products",
+	"eatable_info":   "animal_eatable_info",
+	"given_eatable":  "animal_given_eatables",
+	"supplier":       "suppliers",
+	"stock_lot":      "stock_lots",
+	"webhook":        "webhook_subscriptions",
+	"feeding_task":   "feeding_tasks",
+}
+
+type auditRepo struct {
+	tableName string
+	db        *postgres.PostgresDB
+}
+
+func NewAudit(db *postgres.PostgresDB) repo.Audit {
+	return &auditRepo{
+		tableName: "audit_logs",
+		db:        db,
+	}
+}
+
+func (a *auditRepo) Create(ctx context.Context, log *entity.AuditLog) error {
+	clauses := map[string]interface{}{
+		"id":          log.ID,
+		"actor":       log.Actor,
+		"action":      log.Action,
+		"entity_type": log.EntityType,
+		"entity_id":   log.EntityID,
+		"before":      nullableJSON(log.Before),
+		"after":       nullableJSON(log.After),
+		"diff":        nullableJSON(log.Diff),
+		"request_id":  nullableString(log.RequestID),
+		"created_at":  log.CreatedAt,
+	}
+
+	queryBuilder := a.db.Sq.Builder.Insert(a.tableName)
+	queryBuilder = queryBuilder.SetMap(clauses)
+
+	query, args, err := queryBuilder.ToSql()
+	if err != nil {
+		return err
+	}
+
+	result, err := a.db.Exec(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+	if result.RowsAffected() == 0 {
+		return pgx.ErrNoRows
+	}
+
+	return nil
+}
+
+func (a *auditRepo) List(ctx context.Context, page, limit uint64, params map[string]any) (*entity.ListAuditLogs, error) {
+	filter := sq.And{}
+	for _, column := range []string{"actor", "action", "entity_type", "entity_id", "request_id"} {
+		if cast.ToString(params[column]) != "" {
+			filter = append(filter, a.db.Sq.Equal(column, cast.ToString(params[column])))
+		}
+	}
+	if cast.ToString(params["from"]) != "" {
+		filter = append(filter, sq.GtOrEq{"created_at": cast.ToString(params["from"]) + " 00:00:00"})
+	}
+	if cast.ToString(params["to"]) != "" {
+		filter = append(filter, sq.LtOrEq{"created_at": cast.ToString(params["to"]) + " 23:59:59"})
+	}
+
+	queryBuilder := a.db.Sq.Builder.Select("id, actor, action, entity_type, entity_id, before, after, diff, request_id, created_at")
+	queryBuilder = queryBuilder.From(a.tableName)
+	queryBuilder = queryBuilder.Where(filter)
+	queryBuilder = queryBuilder.OrderBy("created_at DESC")
+	queryBuilder = queryBuilder.Limit(limit)
+	queryBuilder = queryBuilder.Offset(limit * (page - 1))
+
+	query, args, err := queryBuilder.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := a.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var logs entity.ListAuditLogs
+	for rows.Next() {
+		var (
+			log           entity.AuditLog
+			nullRequestID sql.NullString
+		)
+		err = rows.Scan(
+			&log.ID,
+			&log.Actor,
+			&log.Action,
+			&log.EntityType,
+			&log.EntityID,
+			&log.Before,
+			&log.After,
+			&log.Diff,
+			&nullRequestID,
+			&log.CreatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		log.RequestID = nullRequestID.String
+
+		logs.AuditLogs = append(logs.AuditLogs, &log)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	totalQueryBuilder := a.db.Sq.Builder.Select("COUNT(*)")
+	totalQueryBuilder = totalQueryBuilder.From(a.tableName)
+	totalQueryBuilder = totalQueryBuilder.Where(filter)
+
+	totalQuery, totalArgs, err := totalQueryBuilder.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	var count = 0
+	if err := a.db.QueryRow(ctx, totalQuery, totalArgs...).Scan(&count); err != nil {
+		return nil, err
+	}
+	logs.TotalCount = uint64(count)
+
+	return &logs, nil
+}
+
+// Snapshot returns the current row of the entity as JSON, nil when the row
+// does not exist. Webhook secrets are left out of it
+func (a *auditRepo) Snapshot(ctx context.Context, entityType, entityID string) (json.RawMessage, error) {
+	table, ok := entityTables[entityType]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", errorspkg.ErrorUnknownEntity, entityType)
+	}
+
+	var snapshot json.RawMessage
+	err := a.db.QueryRow(ctx, "SELECT row_to_json(t)::jsonb - 'search_vector' - 'secret' FROM "+table+" AS t WHERE t.id::text = $1", entityID).Scan(&snapshot)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return snapshot, nil
+}
+
+// nullableJSON stores empty documents as SQL NULL
+func nullableJSON(value json.RawMessage) interface{} {
+	if len(value) == 0 {
+		return nil
+	}
+	return string(value)
+}
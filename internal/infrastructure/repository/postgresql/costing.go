@@ -0,0 +1,173 @@
+package postgresql
+
+import (
+	"context"
+	"database/sql"
+	"musobaqa/farm-competition/internal/entity"
+	"musobaqa/farm-competition/internal/infrastructure/repository/postgresql/repo"
+	"musobaqa/farm-competition/internal/pkg/postgres"
+)
+
+type costingRepo struct {
+	deliveryTableName string
+	feedingTableName  string
+	lotTableName      string
+	db                *postgres.PostgresDB
+}
+
+func NewCosting(db *postgres.PostgresDB) repo.Costing {
+	return &costingRepo{
+		deliveryTableName: "into_store",
+		feedingTableName:  "animal_given_eatables",
+		lotTableName:      "stock_lots",
+		db:                db,
+	}
+}
+
+// ListReceipts returns priced deliveries in the order they came into the store
+func (c *costingRepo) ListReceipts(ctx context.Context, category string) ([]*entity.StockReceipt, error) {
+	queryBuilder := c.db.Sq.Builder.Select("id, category, name, time, capacity, unit_price")
+	queryBuilder = queryBuilder.From(c.deliveryTableName)
+	queryBuilder = queryBuilder.Where("deleted_at IS NULL")
+	if category != "" {
+		queryBuilder = queryBuilder.Where(c.db.Sq.Equal("category", category))
+	}
+	queryBuilder = queryBuilder.OrderBy("time", "created_at")
+
+	query, args, err := queryBuilder.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := c.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var receipts []*entity.StockReceipt
+	for rows.Next() {
+		var receipt entity.StockReceipt
+		err = rows.Scan(
+			&receipt.ID,
+			&receipt.Category,
+			&receipt.Name,
+			&receipt.Time,
+			&receipt.Quantity,
+			&receipt.UnitCost,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		receipts = append(receipts, &receipt)
+	}
+
+	return receipts, rows.Err()
+}
+
+// ListIssues returns given eatables with the total daily quantity and the
+// name of the food or drug, which links them to the delivered stock
+func (c *costingRepo) ListIssues(ctx context.Context, category string) ([]*entity.StockIssue, error) {
+	queryBuilder := c.db.Sq.Builder.Select(
+		"g.id, g.animal_id, a.name, a.category_name, g.eatables_id, g.category, " +
+			"COALESCE(f.name, d.name), to_char(g.day, 'YYYY-MM-DD'), g.created_at, " +
+			"(SELECT COALESCE(SUM((daily->>'capacity')::bigint), 0) FROM jsonb_array_elements(g.daily) AS daily)")
+	queryBuilder = queryBuilder.From(c.feedingTableName + " AS g")
+	queryBuilder = queryBuilder.LeftJoin("animals AS a ON a.id = g.animal_id")
+	queryBuilder = queryBuilder.LeftJoin("foods AS f ON g.category = 'food' AND f.id = g.eatables_id")
+	queryBuilder = queryBuilder.LeftJoin("drugs AS d ON g.category = 'drug' AND d.id = g.eatables_id")
+	queryBuilder = queryBuilder.Where("g.deleted_at IS NULL")
+	if category != "" {
+		queryBuilder = queryBuilder.Where(c.db.Sq.Equal("g.category", category))
+	}
+	queryBuilder = queryBuilder.OrderBy("g.created_at")
+
+	query, args, err := queryBuilder.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := c.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var issues []*entity.StockIssue
+	for rows.Next() {
+		var (
+			issue              entity.StockIssue
+			nullAnimalName     sql.NullString
+			nullAnimalCategory sql.NullString
+			nullName           sql.NullString
+		)
+		err = rows.Scan(
+			&issue.ID,
+			&issue.AnimalID,
+			&nullAnimalName,
+			&nullAnimalCategory,
+			&issue.EatableID,
+			&issue.Category,
+			&nullName,
+			&issue.Day,
+			&issue.Time,
+			&issue.Quantity,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		issue.AnimalName = nullAnimalName.String
+		issue.AnimalCategory = nullAnimalCategory.String
+		issue.Name = nullName.String
+
+		issues = append(issues, &issue)
+	}
+
+	return issues, rows.Err()
+}
+
+// ListWriteOffs returns written off lot stock, it leaves the store like a
+// feeding but is not charged to any animal
+func (c *costingRepo) ListWriteOffs(ctx context.Context, category string) ([]*entity.StockIssue, error) {
+	queryBuilder := c.db.Sq.Builder.Select("id, category, name, to_char(written_off_at, 'YYYY-MM-DD'), written_off_at, written_off")
+	queryBuilder = queryBuilder.From(c.lotTableName)
+	queryBuilder = queryBuilder.Where("deleted_at IS NULL")
+	queryBuilder = queryBuilder.Where("written_off > 0")
+	if category != "" {
+		queryBuilder = queryBuilder.Where(c.db.Sq.Equal("category", category))
+	}
+	queryBuilder = queryBuilder.OrderBy("written_off_at")
+
+	query, args, err := queryBuilder.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := c.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var writeOffs []*entity.StockIssue
+	for rows.Next() {
+		var writeOff entity.StockIssue
+		err = rows.Scan(
+			&writeOff.ID,
+			&writeOff.Category,
+			&writeOff.Name,
+			&writeOff.Day,
+			&writeOff.Time,
+			&writeOff.Quantity,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		writeOffs = append(writeOffs, &writeOff)
+	}
+
+	return writeOffs, rows.Err()
+}
@@ -3,13 +3,13 @@ package postgresql
 import (
 	"context"
 	"database/sql"
-	sq "github.com/Masterminds/squirrel"
+	"errors"
 	"github.com/jackc/pgx/v4"
 	"musobaqa/farm-competition/internal/entity"
 	"musobaqa/farm-competition/internal/infrastructure/repository/postgresql/repo"
+	"musobaqa/farm-competition/internal/pkg/listquery"
 	"musobaqa/farm-competition/internal/pkg/postgres"
-
-	"github.com/spf13/cast"
+	"time"
 )
 
 type deliveryRepo struct {
@@ -26,14 +26,19 @@ func NewDelivery(db *postgres.PostgresDB) repo.Delivery {
 
 func (d *deliveryRepo) Create(ctx context.Context, delivery *entity.Delivery) (*entity.Delivery, error) {
 	clauses := map[string]interface{}{
-		"id":            delivery.ID,
-		"name":          delivery.Name,
-		"category":      delivery.Category,
-		"capacity":      delivery.Capacity,
-		"product_union": delivery.Union,
-		"time":          delivery.Time,
-		"created_at":    delivery.CreatedAt,
-		"updated_at":    delivery.UpdatedAt,
+		"id":             delivery.ID,
+		"name":           delivery.Name,
+		"category":       delivery.Category,
+		"capacity":       delivery.Capacity,
+		"product_union":  delivery.Union,
+		"time":           delivery.Time,
+		"supplier_id":    nullableString(delivery.SupplierID),
+		"unit_price":     delivery.UnitPrice,
+		"total_cost":     delivery.TotalCost,
+		"invoice_number": delivery.InvoiceNumber,
+		"invoice_file":   nullableString(delivery.InvoiceFile),
+		"created_at":     delivery.CreatedAt,
+		"updated_at":     delivery.UpdatedAt,
 	}
 
 	queryBuilder := d.db.Sq.Builder.Insert(d.tableName)
@@ -46,10 +51,10 @@ func (d *deliveryRepo) Create(ctx context.Context, delivery *entity.Delivery) (*
 
 	result, err := d.db.Exec(ctx, query, args...)
 	if err != nil {
-		return nil, err
+		return nil, d.db.Error(err, "delivery")
 	}
 	if result.RowsAffected() == 0 {
-		return nil, sql.ErrNoRows
+		return nil, d.db.Error(sql.ErrNoRows, "delivery")
 	}
 
 	return delivery, nil
@@ -57,39 +62,56 @@ func (d *deliveryRepo) Create(ctx context.Context, delivery *entity.Delivery) (*
 
 func (d *deliveryRepo) Update(ctx context.Context, delivery *entity.Delivery) (*entity.Delivery, error) {
 	clauses := map[string]interface{}{
-		"name":          delivery.Name,
-		"category":      delivery.Category,
-		"capacity":      delivery.Capacity,
-		"product_union": delivery.Union,
-		"time":          delivery.Time,
-		"updated_at":    delivery.UpdatedAt,
+		"name":           delivery.Name,
+		"category":       delivery.Category,
+		"capacity":       delivery.Capacity,
+		"product_union":  delivery.Union,
+		"time":           delivery.Time,
+		"supplier_id":    nullableString(delivery.SupplierID),
+		"unit_price":     delivery.UnitPrice,
+		"total_cost":     delivery.TotalCost,
+		"invoice_number": delivery.InvoiceNumber,
+		"updated_at":     delivery.UpdatedAt,
+		"version":        d.db.Sq.Expr("version + 1"),
 	}
 
 	queryBuilder := d.db.Sq.Builder.Update(d.tableName)
 	queryBuilder = queryBuilder.SetMap(clauses)
 	queryBuilder = queryBuilder.Where("deleted_at IS NULL")
 	queryBuilder = queryBuilder.Where(d.db.Sq.Equal("id", delivery.ID))
+	if delivery.Version > 0 {
+		queryBuilder = queryBuilder.Where(d.db.Sq.Equal("version", delivery.Version))
+	}
+	queryBuilder = queryBuilder.Suffix("RETURNING version")
 
 	query, args, err := queryBuilder.ToSql()
 	if err != nil {
 		return nil, err
 	}
 
-	result, err := d.db.Exec(ctx, query, args...)
-	if err != nil {
-		return nil, err
+	var version int64
+	err = d.db.QueryRow(ctx, query, args...).Scan(&version)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, versionConflict(ctx, d.db, d.tableName, delivery.ID, delivery.Version, d.db.Error(sql.ErrNoRows, "delivery"))
 	}
-	if result.RowsAffected() == 0 {
-		return nil, sql.ErrNoRows
+	if err != nil {
+		return nil, d.db.Error(err, "delivery")
 	}
+	delivery.Version = version
 
 	return delivery, nil
 }
 
-func (d *deliveryRepo) Delete(ctx context.Context, deliveryID string) error {
-	queryBuilder := d.db.Sq.Builder.Delete(d.tableName)
+func (d *deliveryRepo) Delete(ctx context.Context, deliveryID string, version int64) error {
+	queryBuilder := d.db.Sq.Builder.Update(d.tableName)
+	queryBuilder = queryBuilder.SetMap(map[string]interface{}{
+		"deleted_at": time.Now().Format(time.RFC3339),
+	})
 	queryBuilder = queryBuilder.Where("deleted_at IS NULL")
 	queryBuilder = queryBuilder.Where(d.db.Sq.Equal("id", deliveryID))
+	if version > 0 {
+		queryBuilder = queryBuilder.Where(d.db.Sq.Equal("version", version))
+	}
 
 	query, args, err := queryBuilder.ToSql()
 	if err != nil {
@@ -98,18 +120,18 @@ func (d *deliveryRepo) Delete(ctx context.Context, deliveryID string) error {
 
 	result, err := d.db.Exec(ctx, query, args...)
 	if err != nil {
-		return err
+		return d.db.Error(err, "delivery")
 	}
 
 	if result.RowsAffected() == 0 {
-		return pgx.ErrNoRows
+		return versionConflict(ctx, d.db, d.tableName, deliveryID, version, d.db.Error(pgx.ErrNoRows, "delivery"))
 	}
 
 	return nil
 }
 
 func (d *deliveryRepo) Get(ctx context.Context, deliveryID string) (*entity.Delivery, error) {
-	queryBuilder := d.db.Sq.Builder.Select("id, name, category, capacity, product_union, time")
+	queryBuilder := d.db.Sq.Builder.Select("id, name, category, capacity, product_union, time, supplier_id, unit_price, total_cost, invoice_number, invoice_file, version")
 	queryBuilder = queryBuilder.From(d.tableName)
 	queryBuilder = queryBuilder.Where("deleted_at IS NULL")
 	queryBuilder = queryBuilder.Where(d.db.Sq.Equal("id", deliveryID))
@@ -120,8 +142,11 @@ func (d *deliveryRepo) Get(ctx context.Context, deliveryID string) (*entity.Deli
 	}
 
 	var (
-		nullTimeValue sql.NullString
-		delivery      entity.Delivery
+		nullTimeValue     sql.NullString
+		nullSupplierID    sql.NullString
+		nullInvoiceNumber sql.NullString
+		nullInvoiceFile   sql.NullString
+		delivery          entity.Delivery
 	)
 	err = d.db.QueryRow(ctx, query, args...).Scan(
 		&delivery.ID,
@@ -130,32 +155,65 @@ func (d *deliveryRepo) Get(ctx context.Context, deliveryID string) (*entity.Deli
 		&delivery.Capacity,
 		&delivery.Union,
 		&nullTimeValue,
+		&nullSupplierID,
+		&delivery.UnitPrice,
+		&delivery.TotalCost,
+		&nullInvoiceNumber,
+		&nullInvoiceFile,
+		&delivery.Version,
 	)
 	if err != nil {
-		return nil, err
+		return nil, d.db.Error(err, "delivery")
 	}
 	if nullTimeValue.Valid {
 		delivery.Time = nullTimeValue.String
 	}
+	delivery.SupplierID = nullSupplierID.String
+	delivery.InvoiceNumber = nullInvoiceNumber.String
+	delivery.InvoiceFile = nullInvoiceFile.String
 
 	return &delivery, nil
 }
 
-func (d *deliveryRepo) List(ctx context.Context, page, limit uint64, params map[string]any) (*entity.ListDelivery, error) {
-	queryBuilder := d.db.Sq.Builder.Select("id, name, category, capacity, product_union, time")
+var deliveryListSchema = listquery.Schema{
+	Fields: map[string]listquery.Field{
+		"name":           {Column: "name", Ops: listquery.TextOps, Sortable: true, Searchable: true},
+		"category":       {Column: "category", Ops: listquery.TextOps, Sortable: true},
+		"time":           {Column: "time", Type: listquery.Date, Ops: listquery.DateOps, Sortable: true},
+		"supplier_id":    {Column: "supplier_id", Ops: listquery.EnumOps},
+		"capacity":       {Column: "capacity", Type: listquery.Int, Ops: listquery.NumberOps, Sortable: true},
+		"unit_price":     {Column: "unit_price", Type: listquery.Float, Ops: listquery.NumberOps, Sortable: true},
+		"total_cost":     {Column: "total_cost", Type: listquery.Float, Ops: listquery.NumberOps, Sortable: true},
+		"invoice_number": {Column: "invoice_number", Ops: listquery.TextOps, Searchable: true},
+		"created_at":     {Column: "created_at", Type: listquery.Date, Ops: listquery.DateOps, Sortable: true},
+	},
+	DefaultOrder: []string{"created_at DESC", "id DESC"},
+	Keyset:       []string{"created_at", "id"},
+}
+
+func (d *deliveryRepo) List(ctx context.Context, page, limit uint64, request listquery.Request) (*entity.ListDelivery, error) {
+	where, orderBy, err := deliveryListSchema.Build(d.db.Sq, request)
+	if err != nil {
+		return nil, err
+	}
+
+	seek, err := deliveryListSchema.Seek(d.db.Sq, request)
+	if err != nil {
+		return nil, err
+	}
+
+	queryBuilder := d.db.Sq.Builder.Select("id, name, category, capacity, product_union, time, supplier_id, unit_price, total_cost, invoice_number, invoice_file, created_at")
 	queryBuilder = queryBuilder.From(d.tableName)
 	queryBuilder = queryBuilder.Where("deleted_at IS NULL")
-	queryBuilder = queryBuilder.Where(d.db.Sq.ILike("name", "%"+cast.ToString(params["name"])+"%"))
-	queryBuilder = queryBuilder.Where(d.db.Sq.ILike("category", "%"+cast.ToString(params["category"])+"%"))
-	if params["time"] != "" {
-		queryBuilder = queryBuilder.Where(d.db.Sq.And(
-			sq.GtOrEq{"time": cast.ToString(params["time"]) + " 00:00:00______"},
-			sq.LtOrEq{"time": cast.ToString(params["time"]) + " 23:59:59______"},
-		))
-	}
-	queryBuilder = queryBuilder.Limit(limit)
-	queryBuilder = queryBuilder.Offset(limit * (page - 1))
-	queryBuilder = queryBuilder.OrderBy("time DESC")
+	queryBuilder = queryBuilder.Where(where)
+	queryBuilder = queryBuilder.OrderBy(orderBy...)
+	// one row more than asked tells whether there is a next page
+	queryBuilder = queryBuilder.Limit(limit + 1)
+	if seek != nil {
+		queryBuilder = queryBuilder.Where(seek)
+	} else {
+		queryBuilder = queryBuilder.Offset(limit * (page - 1))
+	}
 
 	query, args, err := queryBuilder.ToSql()
 	if err != nil {
@@ -170,7 +228,12 @@ func (d *deliveryRepo) List(ctx context.Context, page, limit uint64, params map[
 
 	deliveryList := entity.ListDelivery{}
 	for rows.Next() {
-		var nullTimeValue sql.NullString
+		var (
+			nullTimeValue     sql.NullString
+			nullSupplierID    sql.NullString
+			nullInvoiceNumber sql.NullString
+			nullInvoiceFile   sql.NullString
+		)
 		delivery := entity.Delivery{}
 		err := rows.Scan(
 			&delivery.ID,
@@ -179,6 +242,12 @@ func (d *deliveryRepo) List(ctx context.Context, page, limit uint64, params map[
 			&delivery.Capacity,
 			&delivery.Union,
 			&nullTimeValue,
+			&nullSupplierID,
+			&delivery.UnitPrice,
+			&delivery.TotalCost,
+			&nullInvoiceNumber,
+			&nullInvoiceFile,
+			&delivery.CreatedAt,
 		)
 		if err != nil {
 			return nil, err
@@ -186,21 +255,26 @@ func (d *deliveryRepo) List(ctx context.Context, page, limit uint64, params map[
 		if nullTimeValue.Valid {
 			delivery.Time = nullTimeValue.String
 		}
+		delivery.SupplierID = nullSupplierID.String
+		delivery.InvoiceNumber = nullInvoiceNumber.String
+		delivery.InvoiceFile = nullInvoiceFile.String
 
 		deliveryList.Deliveries = append(deliveryList.Deliveries, &delivery)
 	}
 
+	if uint64(len(deliveryList.Deliveries)) > limit {
+		deliveryList.Deliveries = deliveryList.Deliveries[:limit]
+		if limit > 0 {
+			last := deliveryList.Deliveries[limit-1]
+			deliveryList.NextCursor = deliveryListSchema.NextCursor(request, last.CreatedAt, last.ID)
+		}
+	}
+
 	totalQueryBuilder := d.db.Sq.Builder.Select("COUNT(id)")
 	totalQueryBuilder = totalQueryBuilder.From(d.tableName)
 	totalQueryBuilder = totalQueryBuilder.Where("deleted_at IS NULL")
-	totalQueryBuilder = totalQueryBuilder.Where(d.db.Sq.ILike("name", "%"+cast.ToString(params["name"])+"%"))
-	totalQueryBuilder = totalQueryBuilder.Where(d.db.Sq.ILike("category", "%"+cast.ToString(params["category"])+"%"))
-	if params["time"] != "" {
-		totalQueryBuilder = totalQueryBuilder.Where(d.db.Sq.And(
-			sq.GtOrEq{"time": cast.ToString(params["time"]) + " 00:00:00______"},
-			sq.LtOrEq{"time": cast.ToString(params["time"]) + " 23:59:59______"},
-		))
-	}
+	totalQueryBuilder = totalQueryBuilder.Where(where)
+
 	totalQuery, totalArgs, err := totalQueryBuilder.ToSql()
 	if err != nil {
 		return nil, err
@@ -214,3 +288,36 @@ func (d *deliveryRepo) List(ctx context.Context, page, limit uint64, params map[
 
 	return &deliveryList, nil
 }
+
+// SetInvoiceFile attaches a stored invoice document to the delivery
+func (d *deliveryRepo) SetInvoiceFile(ctx context.Context, deliveryID, invoiceFile string) error {
+	queryBuilder := d.db.Sq.Builder.Update(d.tableName)
+	queryBuilder = queryBuilder.Set("invoice_file", invoiceFile)
+	queryBuilder = queryBuilder.Set("updated_at", time.Now().UTC())
+	queryBuilder = queryBuilder.Set("version", d.db.Sq.Expr("version + 1"))
+	queryBuilder = queryBuilder.Where("deleted_at IS NULL")
+	queryBuilder = queryBuilder.Where(d.db.Sq.Equal("id", deliveryID))
+
+	query, args, err := queryBuilder.ToSql()
+	if err != nil {
+		return err
+	}
+
+	result, err := d.db.Exec(ctx, query, args...)
+	if err != nil {
+		return d.db.Error(err, "delivery")
+	}
+	if result.RowsAffected() == 0 {
+		return d.db.Error(pgx.ErrNoRows, "delivery")
+	}
+
+	return nil
+}
+
+// nullableString turns empty optional values into SQL NULL
+func nullableString(value string) interface{} {
+	if value == "" {
+		return nil
+	}
+	return value
+}
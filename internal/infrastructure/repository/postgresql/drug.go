@@ -3,10 +3,11 @@ package postgresql
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"github.com/jackc/pgx/v4"
-	"github.com/spf13/cast"
 	"musobaqa/farm-competition/internal/entity"
 	"musobaqa/farm-competition/internal/infrastructure/repository/postgresql/repo"
+	"musobaqa/farm-competition/internal/pkg/listquery"
 	"musobaqa/farm-competition/internal/pkg/postgres"
 	"time"
 )
@@ -69,7 +70,7 @@ func (d *drugRepo) Create(ctx context.Context, drug *entity.Drug) (*entity.Drug,
 	)
 
 	if err != nil {
-		return nil, err
+		return nil, d.db.Error(err, "drug")
 	}
 
 	if sqlNullDescription.Valid {
@@ -89,17 +90,20 @@ func (d *drugRepo) Update(ctx context.Context, drug *entity.Drug) (*entity.Drug,
 	    product_union = $3,
 	    status = $4,
 	    description = $5,
-	    updated_at = $6
+	    updated_at = $6,
+	    version = version + 1
 	WHERE
 	    id = $7
 		AND deleted_at IS NULL
+		AND ($8 = 0 OR version = $8)
 	RETURNING
 		id,
 	    name,
 		capacity,
 		product_union,
 		status,
-		description
+		description,
+		version
 	`
 
 	var (
@@ -115,6 +119,7 @@ func (d *drugRepo) Update(ctx context.Context, drug *entity.Drug) (*entity.Drug,
 		drug.Description,
 		drug.UpdatedAt,
 		drug.ID,
+		drug.Version,
 	).Scan(
 		&createdDrug.ID,
 		&createdDrug.Name,
@@ -122,10 +127,14 @@ func (d *drugRepo) Update(ctx context.Context, drug *entity.Drug) (*entity.Drug,
 		&createdDrug.Union,
 		&createdDrug.Status,
 		&sqlNullDescription,
+		&createdDrug.Version,
 	)
 
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, versionConflict(ctx, d.db, d.tableName, drug.ID, drug.Version, d.db.Error(err, "drug"))
+	}
 	if err != nil {
-		return nil, err
+		return nil, d.db.Error(err, "drug")
 	}
 
 	if sqlNullDescription.Valid {
@@ -135,19 +144,21 @@ func (d *drugRepo) Update(ctx context.Context, drug *entity.Drug) (*entity.Drug,
 	return &createdDrug, nil
 }
 
-func (d *drugRepo) Delete(ctx context.Context, drugID string) error {
-	query := `UPDATE drugs SET deleted_at = $1 WHERE id = $2 AND deleted_at IS NULL`
+func (d *drugRepo) Delete(ctx context.Context, drugID string, version int64) error {
+	return d.db.WithTx(ctx, func(ctx context.Context) error {
+		query := `UPDATE drugs SET deleted_at = $1 WHERE id = $2 AND deleted_at IS NULL AND ($3 = 0 OR version = $3)`
 
-	result, err := d.db.Exec(ctx, query, time.Now().Format(time.RFC3339), drugID)
-	if err != nil {
-		return err
-	}
+		result, err := d.db.Exec(ctx, query, time.Now().Format(time.RFC3339), drugID, version)
+		if err != nil {
+			return d.db.Error(err, "drug")
+		}
 
-	if result.RowsAffected() == 0 {
-		return pgx.ErrNoRows
-	}
+		if result.RowsAffected() == 0 {
+			return versionConflict(ctx, d.db, "drugs", drugID, version, d.db.Error(pgx.ErrNoRows, "drug"))
+		}
 
-	return nil
+		return cascadeDelete(ctx, d.db, "drug", drugID)
+	})
 }
 
 func (d *drugRepo) Get(ctx context.Context, params map[string]string) (*entity.Drug, error) {
@@ -156,7 +167,7 @@ func (d *drugRepo) Get(ctx context.Context, params map[string]string) (*entity.D
 		sqlNullDescription sql.NullString
 	)
 
-	queryBuilder := d.db.Sq.Builder.Select("id, name, capacity, product_union, status, description")
+	queryBuilder := d.db.Sq.Builder.Select("id, name, capacity, product_union, status, description, version")
 	queryBuilder = queryBuilder.From(d.tableName)
 	queryBuilder = queryBuilder.Where("deleted_at IS NULL")
 	for key, value := range params {
@@ -180,10 +191,11 @@ func (d *drugRepo) Get(ctx context.Context, params map[string]string) (*entity.D
 		&drug.Union,
 		&drug.Status,
 		&sqlNullDescription,
+		&drug.Version,
 	)
 
 	if err != nil {
-		return nil, err
+		return nil, d.db.Error(err, "drug")
 	}
 
 	if sqlNullDescription.Valid {
@@ -193,18 +205,34 @@ func (d *drugRepo) Get(ctx context.Context, params map[string]string) (*entity.D
 	return &drug, nil
 }
 
-func (d *drugRepo) List(ctx context.Context, page, limit uint64, params map[string]any) (*entity.ListDrugs, error) {
+var drugListSchema = listquery.Schema{
+	Fields: map[string]listquery.Field{
+		"name":        {Column: "name", Ops: listquery.TextOps, Sortable: true, Searchable: true},
+		"union":       {Column: "product_union", Ops: listquery.TextOps, Sortable: true},
+		"status":      {Column: "status", Ops: listquery.TextOps, Sortable: true},
+		"capacity":    {Column: "capacity", Type: listquery.Int, Ops: listquery.NumberOps, Sortable: true},
+		"description": {Column: "description", Ops: listquery.TextOps, Searchable: true},
+		"created_at":  {Column: "created_at", Type: listquery.Date, Ops: listquery.DateOps, Sortable: true},
+	},
+	DefaultOrder: []string{"created_at DESC"},
+}
+
+func (d *drugRepo) List(ctx context.Context, page, limit uint64, request listquery.Request) (*entity.ListDrugs, error) {
 	var (
 		offset = limit * (page - 1)
 		drugs  = entity.ListDrugs{}
 	)
 
+	where, orderBy, err := drugListSchema.Build(d.db.Sq, request)
+	if err != nil {
+		return nil, err
+	}
+
 	queryBuilder := d.db.Sq.Builder.Select("id, name, capacity, product_union, status, description")
 	queryBuilder = queryBuilder.From(d.tableName)
 	queryBuilder = queryBuilder.Where("deleted_at IS NULL")
-	queryBuilder = queryBuilder.Where(d.db.Sq.ILike("status", "%"+cast.ToString(params["status"])+"%"))
-	queryBuilder = queryBuilder.Where(d.db.Sq.ILike("name", "%"+cast.ToString(params["name"])+"%"))
-	queryBuilder = queryBuilder.Where(d.db.Sq.ILike("product_union", "%"+cast.ToString(params["union"])+"%"))
+	queryBuilder = queryBuilder.Where(where)
+	queryBuilder = queryBuilder.OrderBy(orderBy...)
 	queryBuilder = queryBuilder.Limit(limit)
 	queryBuilder = queryBuilder.Offset(offset)
 
@@ -245,9 +273,7 @@ func (d *drugRepo) List(ctx context.Context, page, limit uint64, params map[stri
 	totalQueryBuilder := d.db.Sq.Builder.Select("COUNT(*)")
 	totalQueryBuilder = totalQueryBuilder.From(d.tableName)
 	totalQueryBuilder = totalQueryBuilder.Where("deleted_at IS NULL")
-	totalQueryBuilder = totalQueryBuilder.Where(d.db.Sq.ILike("status", "%"+cast.ToString(params["status"])+"%"))
-	totalQueryBuilder = totalQueryBuilder.Where(d.db.Sq.ILike("name", "%"+cast.ToString(params["name"])+"%"))
-	totalQueryBuilder = totalQueryBuilder.Where(d.db.Sq.ILike("product_union", "%"+cast.ToString(params["union"])+"%"))
+	totalQueryBuilder = totalQueryBuilder.Where(where)
 
 	totalQuery, totalArgs, err := totalQueryBuilder.ToSql()
 	if err != nil {
@@ -49,11 +49,11 @@ func (e *eatableRepo) Create(ctx context.Context, eatable *entity.Eatables) (*en
 
 	result, err := e.db.Exec(ctx, query, args...)
 	if err != nil {
-		return nil, err
+		return nil, e.db.Error(err, "eatable info")
 	}
 
 	if result.RowsAffected() == 0 {
-		return nil, pgx.ErrNoRows
+		return nil, e.db.Error(pgx.ErrNoRows, "eatable info")
 	}
 
 	selectEatableBuilder := e.db.Sq.Builder.Select("id, animal_id, eatables_id, category, daily")
@@ -78,7 +78,7 @@ func (e *eatableRepo) Create(ctx context.Context, eatable *entity.Eatables) (*en
 		&dailyByte,
 	)
 	if err != nil {
-		return nil, err
+		return nil, e.db.Error(err, "eatable info")
 	}
 
 	err = json.Unmarshal(dailyByte, &response.Daily)
@@ -105,7 +105,7 @@ func (e *eatableRepo) Create(ctx context.Context, eatable *entity.Eatables) (*en
 			&response.Eatable.Union,
 		)
 		if err != nil {
-			return nil, err
+			return nil, e.db.Error(err, "eatable info")
 		}
 	} else if eatable.Category == "drug" {
 		selectDrugBuilder := e.db.Sq.Builder.Select("id, name, status, capacity, description, product_union")
@@ -127,7 +127,7 @@ func (e *eatableRepo) Create(ctx context.Context, eatable *entity.Eatables) (*en
 			&response.Eatable.Union,
 		)
 		if err != nil {
-			return nil, err
+			return nil, e.db.Error(err, "eatable info")
 		}
 	} else {
 		return nil, errors.New("unknown eatable category")
@@ -147,25 +147,30 @@ func (e *eatableRepo) Update(ctx context.Context, eatable *entity.Eatables) (*en
 		"category":    eatable.Category,
 		"daily":       dailyJson,
 		"updated_at":  eatable.UpdatedAt,
+		"version":     e.db.Sq.Expr("version + 1"),
 	}
 
 	queryBuilder := e.db.Sq.Builder.Update(e.tableName)
 	queryBuilder = queryBuilder.SetMap(clauses)
 	queryBuilder = queryBuilder.Where("deleted_at IS NULL")
 	queryBuilder = queryBuilder.Where(e.db.Sq.Equal("id", eatable.ID))
+	if eatable.Version > 0 {
+		queryBuilder = queryBuilder.Where(e.db.Sq.Equal("version", eatable.Version))
+	}
+	queryBuilder = queryBuilder.Suffix("RETURNING version")
 
 	query, args, err := queryBuilder.ToSql()
 	if err != nil {
 		return nil, err
 	}
 
-	result, err := e.db.Exec(ctx, query, args...)
-	if err != nil {
-		return nil, err
+	var version int64
+	err = e.db.QueryRow(ctx, query, args...).Scan(&version)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, versionConflict(ctx, e.db, e.tableName, eatable.ID, eatable.Version, e.db.Error(pgx.ErrNoRows, "eatable info"))
 	}
-
-	if result.RowsAffected() == 0 {
-		return nil, pgx.ErrNoRows
+	if err != nil {
+		return nil, e.db.Error(err, "eatable info")
 	}
 
 	selectEatableBuilder := e.db.Sq.Builder.Select("id, animal_id, eatables_id, category, daily")
@@ -190,7 +195,7 @@ func (e *eatableRepo) Update(ctx context.Context, eatable *entity.Eatables) (*en
 		&dailyByte,
 	)
 	if err != nil {
-		return nil, err
+		return nil, e.db.Error(err, "eatable info")
 	}
 
 	err = json.Unmarshal(dailyByte, &response.Daily)
@@ -217,7 +222,7 @@ func (e *eatableRepo) Update(ctx context.Context, eatable *entity.Eatables) (*en
 			&response.Eatable.Union,
 		)
 		if err != nil {
-			return nil, err
+			return nil, e.db.Error(err, "eatable info")
 		}
 	} else if eatable.Category == "drug" {
 		selectDrugBuilder := e.db.Sq.Builder.Select("id, name, status, capacity, description, product_union")
@@ -239,22 +244,27 @@ func (e *eatableRepo) Update(ctx context.Context, eatable *entity.Eatables) (*en
 			&response.Eatable.Union,
 		)
 		if err != nil {
-			return nil, err
+			return nil, e.db.Error(err, "eatable info")
 		}
 	} else {
 		return nil, errors.New("unknown eatable category")
 	}
 
+	response.Version = version
+
 	return &response, nil
 }
 
-func (e *eatableRepo) Delete(ctx context.Context, eatablesID string) error {
+func (e *eatableRepo) Delete(ctx context.Context, eatablesID string, version int64) error {
 	queryBuilder := e.db.Sq.Builder.Update(e.tableName)
 	queryBuilder = queryBuilder.SetMap(map[string]interface{}{
 		"deleted_at": time.Now().Format(time.RFC3339),
 	})
 	queryBuilder = queryBuilder.Where("deleted_at IS NULL")
 	queryBuilder = queryBuilder.Where(e.db.Sq.Equal("id", eatablesID))
+	if version > 0 {
+		queryBuilder = queryBuilder.Where(e.db.Sq.Equal("version", version))
+	}
 
 	query, args, err := queryBuilder.ToSql()
 	if err != nil {
@@ -262,11 +272,11 @@ func (e *eatableRepo) Delete(ctx context.Context, eatablesID string) error {
 	}
 	result, err := e.db.Exec(ctx, query, args...)
 	if err != nil {
-		return err
+		return e.db.Error(err, "eatable info")
 	}
 
 	if result.RowsAffected() == 0 {
-		return pgx.ErrNoRows
+		return versionConflict(ctx, e.db, e.tableName, eatablesID, version, e.db.Error(pgx.ErrNoRows, "eatable info"))
 	}
 
 	return nil
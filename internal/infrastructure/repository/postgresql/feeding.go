@@ -7,6 +7,7 @@ import (
 	"errors"
 	"musobaqa/farm-competition/internal/entity"
 	"musobaqa/farm-competition/internal/infrastructure/repository/postgresql/repo"
+	"musobaqa/farm-competition/internal/pkg/listquery"
 	"musobaqa/farm-competition/internal/pkg/postgres"
 	"time"
 
@@ -49,11 +50,11 @@ func (f *feedingRepo) Create(ctx context.Context, feeding *entity.Feeding) (*ent
 
 	result, err := f.db.Exec(ctx, query, args...)
 	if err != nil {
-		return nil, err
+		return nil, f.db.Error(err, "feeding")
 	}
 
 	if result.RowsAffected() == 0 {
-		return nil, pgx.ErrNoRows
+		return nil, f.db.Error(pgx.ErrNoRows, "feeding")
 	}
 
 	selectFeedingBuilder := f.db.Sq.Builder.Select("id, animal_id, eatables_id, category, day, daily")
@@ -79,7 +80,7 @@ func (f *feedingRepo) Create(ctx context.Context, feeding *entity.Feeding) (*ent
 		&dailyJson,
 	)
 	if err != nil {
-		return nil, err
+		return nil, f.db.Error(err, "feeding")
 	}
 
 	if dayNull.Valid {
@@ -110,7 +111,7 @@ func (f *feedingRepo) Create(ctx context.Context, feeding *entity.Feeding) (*ent
 			&response.Eatables.Union,
 		)
 		if err != nil {
-			return nil, err
+			return nil, f.db.Error(err, "feeding")
 		}
 	} else if feeding.Category == "drug" {
 		selectDrugBuilder := f.db.Sq.Builder.Select("id, name, status, capacity, description, product_union")
@@ -132,7 +133,7 @@ func (f *feedingRepo) Create(ctx context.Context, feeding *entity.Feeding) (*ent
 			&response.Eatables.Union,
 		)
 		if err != nil {
-			return nil, err
+			return nil, f.db.Error(err, "feeding")
 		}
 	} else {
 		return nil, errors.New("unknown feeding category")
@@ -149,25 +150,30 @@ func (f *feedingRepo) Update(ctx context.Context, feeding *entity.Feeding) (*ent
 		"day":         feeding.Day,
 		"daily":       feeding.Daily,
 		"updated_at":  feeding.UpdatedAt,
+		"version":     f.db.Sq.Expr("version + 1"),
 	}
 
 	queryBuilder := f.db.Sq.Builder.Update(f.tableName)
 	queryBuilder = queryBuilder.SetMap(clauses)
 	queryBuilder = queryBuilder.Where("deleted_at IS NULL")
 	queryBuilder = queryBuilder.Where(f.db.Sq.Equal("id", feeding.ID))
+	if feeding.Version > 0 {
+		queryBuilder = queryBuilder.Where(f.db.Sq.Equal("version", feeding.Version))
+	}
+	queryBuilder = queryBuilder.Suffix("RETURNING version")
 
 	query, args, err := queryBuilder.ToSql()
 	if err != nil {
 		return nil, err
 	}
 
-	result, err := f.db.Exec(ctx, query, args...)
-	if err != nil {
-		return nil, err
+	var version int64
+	err = f.db.QueryRow(ctx, query, args...).Scan(&version)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, versionConflict(ctx, f.db, f.tableName, feeding.ID, feeding.Version, f.db.Error(pgx.ErrNoRows, "feeding"))
 	}
-
-	if result.RowsAffected() == 0 {
-		return nil, pgx.ErrNoRows
+	if err != nil {
+		return nil, f.db.Error(err, "feeding")
 	}
 
 	selectFeedingBuilder := f.db.Sq.Builder.Select("id, animal_id, eatables_id, category, day, daily")
@@ -194,7 +200,7 @@ func (f *feedingRepo) Update(ctx context.Context, feeding *entity.Feeding) (*ent
 		&dailyJson,
 	)
 	if err != nil {
-		return nil, err
+		return nil, f.db.Error(err, "feeding")
 	}
 
 	if dayNull.Valid {
@@ -225,7 +231,7 @@ func (f *feedingRepo) Update(ctx context.Context, feeding *entity.Feeding) (*ent
 			&response.Eatables.Union,
 		)
 		if err != nil {
-			return nil, err
+			return nil, f.db.Error(err, "feeding")
 		}
 	} else if feeding.Category == "drug" {
 		selectDrugBuilder := f.db.Sq.Builder.Select("id, name, status, capacity, description, product_union")
@@ -247,16 +253,18 @@ func (f *feedingRepo) Update(ctx context.Context, feeding *entity.Feeding) (*ent
 			&response.Eatables.Union,
 		)
 		if err != nil {
-			return nil, err
+			return nil, f.db.Error(err, "feeding")
 		}
 	} else {
 		return nil, errors.New("unknown feeding category")
 	}
 
+	response.Version = version
+
 	return &response, nil
 }
 
-func (f *feedingRepo) Delete(ctx context.Context, feedingID string) error {
+func (f *feedingRepo) Delete(ctx context.Context, feedingID string, version int64) error {
 	clauses := map[string]interface{}{
 		"deleted_at": time.Now().Format(time.RFC3339),
 	}
@@ -265,6 +273,9 @@ func (f *feedingRepo) Delete(ctx context.Context, feedingID string) error {
 	queryBuilder = queryBuilder.SetMap(clauses)
 	queryBuilder = queryBuilder.Where("deleted_at is null")
 	queryBuilder = queryBuilder.Where(f.db.Sq.Equal("id", feedingID))
+	if version > 0 {
+		queryBuilder = queryBuilder.Where(f.db.Sq.Equal("version", version))
+	}
 
 	query, args, err := queryBuilder.ToSql()
 	if err != nil {
@@ -273,12 +284,169 @@ func (f *feedingRepo) Delete(ctx context.Context, feedingID string) error {
 
 	result, err := f.db.Exec(ctx, query, args...)
 	if err != nil {
-		return err
+		return f.db.Error(err, "feeding")
 	}
 
 	if result.RowsAffected() == 0 {
-		return pgx.ErrNoRows
+		return versionConflict(ctx, f.db, f.tableName, feedingID, version, f.db.Error(pgx.ErrNoRows, "feeding"))
 	}
 
 	return nil
 }
+
+var feedingListSchema = listquery.Schema{
+	Fields: map[string]listquery.Field{
+		"animal_id":   {Column: "animal_id", Ops: listquery.EnumOps},
+		"eatables_id": {Column: "eatables_id", Ops: listquery.EnumOps},
+		"category":    {Column: "category", Ops: listquery.EnumOps, Sortable: true},
+		"day":         {Column: "day", Type: listquery.Date, Ops: listquery.DateOps, Sortable: true},
+		"created_at":  {Column: "created_at", Type: listquery.Date, Ops: listquery.DateOps, Sortable: true},
+	},
+	DefaultOrder: []string{"created_at DESC", "id DESC"},
+	Keyset:       []string{"created_at", "id"},
+}
+
+func (f *feedingRepo) List(ctx context.Context, page, limit uint64, request listquery.Request) (*entity.ListFeedings, error) {
+	where, orderBy, err := feedingListSchema.Build(f.db.Sq, request)
+	if err != nil {
+		return nil, err
+	}
+
+	seek, err := feedingListSchema.Seek(f.db.Sq, request)
+	if err != nil {
+		return nil, err
+	}
+
+	queryBuilder := f.db.Sq.Builder.Select("id, animal_id, eatables_id, category, day, daily, version, created_at")
+	queryBuilder = queryBuilder.From(f.tableName)
+	queryBuilder = queryBuilder.Where("deleted_at IS NULL")
+	queryBuilder = queryBuilder.Where(where)
+	queryBuilder = queryBuilder.OrderBy(orderBy...)
+	// one row more than asked tells whether there is a next page
+	queryBuilder = queryBuilder.Limit(limit + 1)
+	if seek != nil {
+		queryBuilder = queryBuilder.Where(seek)
+	} else {
+		queryBuilder = queryBuilder.Offset(limit * (page - 1))
+	}
+
+	query, args, err := queryBuilder.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := f.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var feedings entity.ListFeedings
+	for rows.Next() {
+		var (
+			feeding   entity.FeedingRes
+			dayNull   sql.NullString
+			dailyJson []byte
+		)
+		err = rows.Scan(
+			&feeding.ID,
+			&feeding.AnimalID,
+			&feeding.Eatables.ID,
+			&feeding.Category,
+			&dayNull,
+			&dailyJson,
+			&feeding.Version,
+			&feeding.CreatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		feeding.Day = dayNull.String
+		if err := json.Unmarshal(dailyJson, &feeding.Daily); err != nil {
+			return nil, err
+		}
+
+		feedings.Feedings = append(feedings.Feedings, &feeding)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	if uint64(len(feedings.Feedings)) > limit {
+		feedings.Feedings = feedings.Feedings[:limit]
+		if limit > 0 {
+			last := feedings.Feedings[limit-1]
+			feedings.NextCursor = feedingListSchema.NextCursor(request, last.CreatedAt, last.ID)
+		}
+	}
+
+	totalQueryBuilder := f.db.Sq.Builder.Select("COUNT(*)")
+	totalQueryBuilder = totalQueryBuilder.From(f.tableName)
+	totalQueryBuilder = totalQueryBuilder.Where("deleted_at IS NULL")
+	totalQueryBuilder = totalQueryBuilder.Where(where)
+
+	totalQuery, totalArgs, err := totalQueryBuilder.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	var count = 0
+	if err := f.db.QueryRow(ctx, totalQuery, totalArgs...).Scan(&count); err != nil {
+		return nil, err
+	}
+	feedings.TotalCount = uint64(count)
+
+	return &feedings, nil
+}
+
+// Overdue lists the feedings whose time has passed today without the animal
+// being given the eatable as often as it was due, like the overdue gauge
+func (f *feedingRepo) Overdue(ctx context.Context) ([]*entity.OverdueFeeding, error) {
+	query := `
+SELECT due.animal_id, a.name, due.eatables_id, due.category, COALESCE(fd.name, dr.name, ''), due.times, COALESCE(given.times, 0)
+FROM (
+	SELECT e.animal_id, e.eatables_id, e.category, COUNT(*) AS times
+	FROM animal_eatable_info AS e
+	CROSS JOIN jsonb_array_elements(e.daily) AS d
+	WHERE e.deleted_at IS NULL AND (d->>'time')::time <= LOCALTIME
+	GROUP BY e.animal_id, e.eatables_id, e.category
+) AS due
+JOIN animals AS a ON a.id = due.animal_id AND a.deleted_at IS NULL
+LEFT JOIN foods AS fd ON due.category = 'food' AND fd.id = due.eatables_id
+LEFT JOIN drugs AS dr ON due.category = 'drug' AND dr.id = due.eatables_id
+LEFT JOIN (
+	SELECT animal_id, eatables_id, SUM(jsonb_array_length(daily)) AS times
+	FROM animal_given_eatables
+	WHERE deleted_at IS NULL AND day = CURRENT_DATE
+	GROUP BY animal_id, eatables_id
+) AS given ON given.animal_id = due.animal_id AND given.eatables_id = due.eatables_id
+WHERE due.times > COALESCE(given.times, 0)
+ORDER BY a.name, due.category`
+
+	rows, err := f.db.Query(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var overdue []*entity.OverdueFeeding
+	for rows.Next() {
+		var feeding entity.OverdueFeeding
+		err := rows.Scan(
+			&feeding.AnimalID,
+			&feeding.AnimalName,
+			&feeding.EatablesID,
+			&feeding.Category,
+			&feeding.Name,
+			&feeding.Due,
+			&feeding.Given,
+		)
+		if err != nil {
+			return nil, err
+		}
+		overdue = append(overdue, &feeding)
+	}
+
+	return overdue, rows.Err()
+}
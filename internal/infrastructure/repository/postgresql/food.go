@@ -3,10 +3,11 @@ package postgresql
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"github.com/jackc/pgx/v4"
-	"github.com/spf13/cast"
 	"musobaqa/farm-competition/internal/entity"
 	"musobaqa/farm-competition/internal/infrastructure/repository/postgresql/repo"
+	"musobaqa/farm-competition/internal/pkg/listquery"
 	"musobaqa/farm-competition/internal/pkg/postgres"
 	"time"
 )
@@ -65,7 +66,7 @@ func (a *foodRepo) Create(ctx context.Context, food *entity.Food) (*entity.Food,
 	)
 
 	if err != nil {
-		return nil, err
+		return nil, a.db.Error(err, "food")
 	}
 
 	if sqlNullDescription.Valid {
@@ -84,16 +85,19 @@ func (a *foodRepo) Update(ctx context.Context, food *entity.Food) (*entity.Food,
 		capacity = $2,
 		product_union = $3,
 		description = $4,
-		updated_at = $5
+		updated_at = $5,
+		version = version + 1
 	WHERE
 	    id = $6
 		AND deleted_at IS NULL
+		AND ($7 = 0 OR version = $7)
 	RETURNING
 		id,
 		name,
 		capacity,
 		product_union,
-		description
+		description,
+		version
 	`
 
 	var (
@@ -108,16 +112,21 @@ func (a *foodRepo) Update(ctx context.Context, food *entity.Food) (*entity.Food,
 		food.Description,
 		food.UpdatedAt,
 		food.ID,
+		food.Version,
 	).Scan(
 		&updatedFood.ID,
 		&updatedFood.Name,
 		&updatedFood.Capacity,
 		&updatedFood.Union,
 		&sqlNullDescription,
+		&updatedFood.Version,
 	)
 
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, versionConflict(ctx, a.db, a.tableName, food.ID, food.Version, a.db.Error(err, "food"))
+	}
 	if err != nil {
-		return nil, err
+		return nil, a.db.Error(err, "food")
 	}
 
 	if sqlNullDescription.Valid {
@@ -127,19 +136,21 @@ func (a *foodRepo) Update(ctx context.Context, food *entity.Food) (*entity.Food,
 	return &updatedFood, nil
 }
 
-func (a *foodRepo) Delete(ctx context.Context, foodID string) error {
-	query := `UPDATE foods SET deleted_at = $1 WHERE id = $2 AND deleted_at IS NULL`
+func (a *foodRepo) Delete(ctx context.Context, foodID string, version int64) error {
+	return a.db.WithTx(ctx, func(ctx context.Context) error {
+		query := `UPDATE foods SET deleted_at = $1 WHERE id = $2 AND deleted_at IS NULL AND ($3 = 0 OR version = $3)`
 
-	result, err := a.db.Exec(ctx, query, time.Now().Format(time.RFC3339), foodID)
-	if err != nil {
-		return err
-	}
+		result, err := a.db.Exec(ctx, query, time.Now().Format(time.RFC3339), foodID, version)
+		if err != nil {
+			return a.db.Error(err, "food")
+		}
 
-	if result.RowsAffected() == 0 {
-		return pgx.ErrNoRows
-	}
+		if result.RowsAffected() == 0 {
+			return versionConflict(ctx, a.db, "foods", foodID, version, a.db.Error(pgx.ErrNoRows, "food"))
+		}
 
-	return nil
+		return cascadeDelete(ctx, a.db, "food", foodID)
+	})
 }
 
 func (a *foodRepo) Get(ctx context.Context, params map[string]string) (*entity.Food, error) {
@@ -148,7 +159,7 @@ func (a *foodRepo) Get(ctx context.Context, params map[string]string) (*entity.F
 		sqlNullDescription sql.NullString
 	)
 
-	queryBuilder := a.db.Sq.Builder.Select("id, name, capacity, product_union, description")
+	queryBuilder := a.db.Sq.Builder.Select("id, name, capacity, product_union, description, version")
 	queryBuilder = queryBuilder.From(a.tableName)
 	queryBuilder = queryBuilder.Where("deleted_at IS NULL")
 	for key, value := range params {
@@ -171,10 +182,11 @@ func (a *foodRepo) Get(ctx context.Context, params map[string]string) (*entity.F
 		&food.Capacity,
 		&food.Union,
 		&sqlNullDescription,
+		&food.Version,
 	)
 
 	if err != nil {
-		return nil, err
+		return nil, a.db.Error(err, "food")
 	}
 
 	if sqlNullDescription.Valid {
@@ -184,17 +196,33 @@ func (a *foodRepo) Get(ctx context.Context, params map[string]string) (*entity.F
 	return &food, nil
 }
 
-func (a *foodRepo) List(ctx context.Context, page, limit uint64, params map[string]any) (*entity.ListFoods, error) {
+var foodListSchema = listquery.Schema{
+	Fields: map[string]listquery.Field{
+		"name":        {Column: "name", Ops: listquery.TextOps, Sortable: true, Searchable: true},
+		"union":       {Column: "product_union", Ops: listquery.TextOps, Sortable: true},
+		"capacity":    {Column: "capacity", Type: listquery.Int, Ops: listquery.NumberOps, Sortable: true},
+		"description": {Column: "description", Ops: listquery.TextOps, Searchable: true},
+		"created_at":  {Column: "created_at", Type: listquery.Date, Ops: listquery.DateOps, Sortable: true},
+	},
+	DefaultOrder: []string{"created_at DESC"},
+}
+
+func (a *foodRepo) List(ctx context.Context, page, limit uint64, request listquery.Request) (*entity.ListFoods, error) {
 	var (
 		offset = limit * (page - 1)
 		foods  entity.ListFoods
 	)
 
+	where, orderBy, err := foodListSchema.Build(a.db.Sq, request)
+	if err != nil {
+		return nil, err
+	}
+
 	queryBuilder := a.db.Sq.Builder.Select("id, name, capacity, product_union, description")
 	queryBuilder = queryBuilder.From(a.tableName)
 	queryBuilder = queryBuilder.Where("deleted_at IS NULL")
-	queryBuilder = queryBuilder.Where(a.db.Sq.ILike("name", "%"+cast.ToString(params["name"])+"%"))
-	queryBuilder = queryBuilder.Where(a.db.Sq.ILike("product_union", "%"+cast.ToString(params["union"])+"%"))
+	queryBuilder = queryBuilder.Where(where)
+	queryBuilder = queryBuilder.OrderBy(orderBy...)
 	queryBuilder = queryBuilder.Limit(limit)
 	queryBuilder = queryBuilder.Offset(offset)
 
@@ -235,8 +263,7 @@ func (a *foodRepo) List(ctx context.Context, page, limit uint64, params map[stri
 	totalQueryBuilder := a.db.Sq.Builder.Select("COUNT(*)")
 	totalQueryBuilder = totalQueryBuilder.From(a.tableName)
 	totalQueryBuilder = totalQueryBuilder.Where("deleted_at IS NULL")
-	totalQueryBuilder = totalQueryBuilder.Where(a.db.Sq.ILike("name", "%"+cast.ToString(params["name"])+"%"))
-	totalQueryBuilder = totalQueryBuilder.Where(a.db.Sq.ILike("product_union", "%"+cast.ToString(params["union"])+"%"))
+	totalQueryBuilder = totalQueryBuilder.Where(where)
 
 	totalQuery, totalArgs, err := totalQueryBuilder.ToSql()
 	if err != nil {
@@ -0,0 +1,42 @@
+package postgresql
+
+import (
+	"context"
+	"errors"
+	"musobaqa/farm-competition/internal/infrastructure/repository/postgresql/repo"
+	"musobaqa/farm-competition/internal/pkg/postgres"
+
+	"github.com/jackc/pgx/v4"
+)
+
+type healthRepo struct {
+	db *postgres.PostgresDB
+}
+
+func NewHealth(db *postgres.PostgresDB) repo.Health {
+	return &healthRepo{
+		db: db,
+	}
+}
+
+func (h *healthRepo) Ping(ctx context.Context) error {
+	return h.db.Ping(ctx)
+}
+
+// MigrationVersion reads the table kept by migrate, a database that was never
+// migrated is at version 0
+func (h *healthRepo) MigrationVersion(ctx context.Context) (int64, bool, error) {
+	var (
+		version int64
+		dirty   bool
+	)
+	err := h.db.QueryRow(ctx, "SELECT version, dirty FROM schema_migrations LIMIT 1").Scan(&version, &dirty)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return 0, false, nil
+	}
+	if err != nil {
+		return 0, false, err
+	}
+
+	return version, dirty, nil
+}
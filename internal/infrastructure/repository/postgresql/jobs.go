@@ -0,0 +1,199 @@
+package postgresql
+
+import (
+	"context"
+	"database/sql"
+	"musobaqa/farm-competition/internal/entity"
+	"musobaqa/farm-competition/internal/infrastructure/repository/postgresql/repo"
+	"musobaqa/farm-competition/internal/pkg/postgres"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v4"
+	"github.com/spf13/cast"
+)
+
+type jobRepo struct {
+	tableName string
+	db        *postgres.PostgresDB
+}
+
+func NewJob(db *postgres.PostgresDB) repo.Job {
+	return &jobRepo{
+		tableName: "job_runs",
+		db:        db,
+	}
+}
+
+// Lock holds the advisory lock of the job until the transaction of ctx
+// ends, replicas claiming the same job wait for each other
+func (j *jobRepo) Lock(ctx context.Context, job string) error {
+	_, err := j.db.Exec(ctx, `SELECT pg_advisory_xact_lock(hashtext('job_runs:' || $1))`, job)
+	return err
+}
+
+// Claim inserts the run unless its occurrence is taken already or another
+// run of the job is still within its lease, it reports whether it did
+func (j *jobRepo) Claim(ctx context.Context, run *entity.JobRun) (bool, error) {
+	query := `
+		INSERT INTO job_runs (id, job, scheduled_at, status, holder, started_at, lease_until)
+		SELECT $1, $2, $3, $4, $5, $6, $7
+		WHERE NOT EXISTS (
+			SELECT 1 FROM job_runs
+			WHERE job = $2 AND status = 'running' AND lease_until > $6
+		)
+		ON CONFLICT (job, scheduled_at) DO NOTHING`
+
+	result, err := j.db.Exec(ctx, query, run.ID, run.Job, run.ScheduledAt, run.Status, run.Holder, run.StartedAt, run.LeaseUntil)
+	if err != nil {
+		return false, j.db.Error(err, "job run")
+	}
+
+	return result.RowsAffected() > 0, nil
+}
+
+func (j *jobRepo) Finish(ctx context.Context, run *entity.JobRun) error {
+	clauses := map[string]interface{}{
+		"status":      run.Status,
+		"finished_at": run.FinishedAt,
+		"error":       nullableString(run.Error),
+	}
+
+	queryBuilder := j.db.Sq.Builder.Update(j.tableName)
+	queryBuilder = queryBuilder.SetMap(clauses)
+	queryBuilder = queryBuilder.Where(j.db.Sq.Equal("id", run.ID))
+
+	query, args, err := queryBuilder.ToSql()
+	if err != nil {
+		return err
+	}
+
+	result, err := j.db.Exec(ctx, query, args...)
+	if err != nil {
+		return j.db.Error(err, "job run")
+	}
+	if result.RowsAffected() == 0 {
+		return j.db.Error(pgx.ErrNoRows, "job run")
+	}
+
+	return nil
+}
+
+func (j *jobRepo) ListRuns(ctx context.Context, page, limit uint64, params map[string]any) (*entity.ListJobRuns, error) {
+	filter := sq.And{}
+	for _, column := range []string{"job", "status"} {
+		if cast.ToString(params[column]) != "" {
+			filter = append(filter, j.db.Sq.Equal(column, cast.ToString(params[column])))
+		}
+	}
+
+	queryBuilder := j.db.Sq.Builder.Select(jobRunColumns)
+	queryBuilder = queryBuilder.From(j.tableName)
+	queryBuilder = queryBuilder.Where(filter)
+	queryBuilder = queryBuilder.OrderBy("started_at DESC")
+	queryBuilder = queryBuilder.Limit(limit)
+	queryBuilder = queryBuilder.Offset(limit * (page - 1))
+
+	query, args, err := queryBuilder.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := j.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, j.db.Error(err, "job run")
+	}
+	defer rows.Close()
+
+	var runs entity.ListJobRuns
+	for rows.Next() {
+		run, err := scanJobRun(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		runs.Runs = append(runs.Runs, run)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	totalQueryBuilder := j.db.Sq.Builder.Select("COUNT(*)")
+	totalQueryBuilder = totalQueryBuilder.From(j.tableName)
+	totalQueryBuilder = totalQueryBuilder.Where(filter)
+
+	totalQuery, totalArgs, err := totalQueryBuilder.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	var count = 0
+	if err := j.db.QueryRow(ctx, totalQuery, totalArgs...).Scan(&count); err != nil {
+		return nil, j.db.Error(err, "job run")
+	}
+	runs.TotalCount = uint64(count)
+
+	return &runs, nil
+}
+
+// LastRuns returns the latest run of every job that has run
+func (j *jobRepo) LastRuns(ctx context.Context) ([]*entity.JobRun, error) {
+	rows, err := j.db.Query(ctx, `SELECT DISTINCT ON (job) `+jobRunColumns+` FROM job_runs ORDER BY job, started_at DESC`)
+	if err != nil {
+		return nil, j.db.Error(err, "job run")
+	}
+	defer rows.Close()
+
+	var runs []*entity.JobRun
+	for rows.Next() {
+		run, err := scanJobRun(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		runs = append(runs, run)
+	}
+
+	return runs, rows.Err()
+}
+
+// PurgeRuns removes the runs started before the given time that finished
+// or were lost with their replica
+func (j *jobRepo) PurgeRuns(ctx context.Context, before time.Time) (int64, error) {
+	result, err := j.db.Exec(ctx, `DELETE FROM job_runs WHERE started_at < $1 AND (status <> 'running' OR lease_until < $1)`, before)
+	if err != nil {
+		return 0, j.db.Error(err, "job run")
+	}
+
+	return result.RowsAffected(), nil
+}
+
+const jobRunColumns = "id, job, scheduled_at, status, holder, started_at, lease_until, finished_at, error"
+
+func scanJobRun(row pgx.Row) (*entity.JobRun, error) {
+	var (
+		run            entity.JobRun
+		nullFinishedAt sql.NullTime
+		nullError      sql.NullString
+	)
+	err := row.Scan(
+		&run.ID,
+		&run.Job,
+		&run.ScheduledAt,
+		&run.Status,
+		&run.Holder,
+		&run.StartedAt,
+		&run.LeaseUntil,
+		&nullFinishedAt,
+		&nullError,
+	)
+	if err != nil {
+		return nil, err
+	}
+	run.Error = nullError.String
+	if nullFinishedAt.Valid {
+		run.FinishedAt = &nullFinishedAt.Time
+	}
+
+	return &run, nil
+}
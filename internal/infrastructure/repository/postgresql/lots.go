@@ -0,0 +1,848 @@
+package postgresql
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"musobaqa/farm-competition/internal/entity"
+	errorspkg "musobaqa/farm-competition/internal/errors"
+	"musobaqa/farm-competition/internal/infrastructure/repository/postgresql/repo"
+	"musobaqa/farm-competition/internal/pkg/postgres"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v4"
+	"github.com/spf13/cast"
+)
+
+const stockLotColumns = "id, delivery_id, category, name, lot_number, to_char(received_date, 'YYYY-MM-DD'), " +
+	"to_char(expiry_date, 'YYYY-MM-DD'), quantity, remaining, written_off, write_off_reason, version, created_at, updated_at"
+
+type stockLotRepo struct {
+	tableName            string
+	consumptionTableName string
+	db                   *postgres.PostgresDB
+}
+
+func NewStockLot(db *postgres.PostgresDB) repo.StockLot {
+	return &stockLotRepo{
+		tableName:            "stock_lots",
+		consumptionTableName: "stock_lot_consumptions",
+		db:                   db,
+	}
+}
+
+// eatableTable returns the stock table of a feeding category
+func eatableTable(category string) (string, error) {
+	switch category {
+	case "food":
+		return "foods", nil
+	case "drug":
+		return "drugs", nil
+	}
+
+	return "", fmt.Errorf("unknown stock category: %s", category)
+}
+
+func scanStockLot(row pgx.Row) (*entity.StockLot, error) {
+	var (
+		lot                entity.StockLot
+		nullDeliveryID     sql.NullString
+		nullExpiryDate     sql.NullString
+		nullWriteOffReason sql.NullString
+	)
+	err := row.Scan(
+		&lot.ID,
+		&nullDeliveryID,
+		&lot.Category,
+		&lot.Name,
+		&lot.LotNumber,
+		&lot.ReceivedDate,
+		&nullExpiryDate,
+		&lot.Quantity,
+		&lot.Remaining,
+		&lot.WrittenOff,
+		&nullWriteOffReason,
+		&lot.Version,
+		&lot.CreatedAt,
+		&lot.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	lot.DeliveryID = nullDeliveryID.String
+	lot.ExpiryDate = nullExpiryDate.String
+	lot.WriteOffReason = nullWriteOffReason.String
+
+	return &lot, nil
+}
+
+func (s *stockLotRepo) Create(ctx context.Context, lot *entity.StockLot) (*entity.StockLot, error) {
+	clauses := map[string]interface{}{
+		"id":            lot.ID,
+		"delivery_id":   nullableString(lot.DeliveryID),
+		"category":      lot.Category,
+		"name":          lot.Name,
+		"lot_number":    lot.LotNumber,
+		"received_date": lot.ReceivedDate,
+		"expiry_date":   nullableString(lot.ExpiryDate),
+		"quantity":      lot.Quantity,
+		"remaining":     lot.Remaining,
+		"created_at":    lot.CreatedAt,
+		"updated_at":    lot.UpdatedAt,
+	}
+
+	queryBuilder := s.db.Sq.Builder.Insert(s.tableName)
+	queryBuilder = queryBuilder.SetMap(clauses)
+
+	query, args, err := queryBuilder.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := s.db.Exec(ctx, query, args...)
+	if err != nil {
+		return nil, s.db.Error(err, "stock lot")
+	}
+	if result.RowsAffected() == 0 {
+		return nil, s.db.Error(pgx.ErrNoRows, "stock lot")
+	}
+
+	return lot, nil
+}
+
+// Restock adds the quantity to the capacity of the food or drug with the
+// name, one is created when there is none. It returns whether it was
+func (s *stockLotRepo) Restock(ctx context.Context, restock *entity.Restock) (bool, error) {
+	table, err := eatableTable(restock.Category)
+	if err != nil {
+		return false, err
+	}
+
+	now := time.Now().UTC()
+	result, err := s.db.Exec(ctx, "UPDATE "+table+" SET capacity = capacity + $1, updated_at = $2, version = version + 1 WHERE name = $3 AND deleted_at IS NULL", restock.Quantity, now, restock.Name)
+	if err != nil {
+		return false, s.db.Error(err, "stock lot")
+	}
+	if result.RowsAffected() > 0 {
+		return false, nil
+	}
+
+	clauses := map[string]interface{}{
+		"id":            uuid.New().String(),
+		"name":          restock.Name,
+		"capacity":      restock.Quantity,
+		"product_union": restock.Union,
+		"description":   restock.Description,
+		"created_at":    now,
+		"updated_at":    now,
+	}
+	if restock.Category == "drug" {
+		clauses["status"] = restock.Status
+	}
+
+	queryBuilder := s.db.Sq.Builder.Insert(table)
+	queryBuilder = queryBuilder.SetMap(clauses)
+
+	query, args, err := queryBuilder.ToSql()
+	if err != nil {
+		return false, err
+	}
+	if _, err = s.db.Exec(ctx, query, args...); err != nil {
+		return false, s.db.Error(err, "stock lot")
+	}
+
+	return true, nil
+}
+
+func (s *stockLotRepo) Get(ctx context.Context, lotID string) (*entity.StockLot, error) {
+	queryBuilder := s.db.Sq.Builder.Select(stockLotColumns)
+	queryBuilder = queryBuilder.From(s.tableName)
+	queryBuilder = queryBuilder.Where("deleted_at IS NULL")
+	queryBuilder = queryBuilder.Where(s.db.Sq.Equal("id", lotID))
+
+	query, args, err := queryBuilder.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	lot, err := scanStockLot(s.db.QueryRow(ctx, query, args...))
+	if err != nil {
+		return nil, s.db.Error(err, "stock lot")
+	}
+
+	return lot, nil
+}
+
+func (s *stockLotRepo) List(ctx context.Context, page, limit uint64, params map[string]any) (*entity.ListStockLots, error) {
+	filter := sq.And{sq.Expr("deleted_at IS NULL")}
+	if cast.ToString(params["category"]) != "" {
+		filter = append(filter, s.db.Sq.Equal("category", cast.ToString(params["category"])))
+	}
+	if cast.ToString(params["name"]) != "" {
+		filter = append(filter, s.db.Sq.ILike("name", "%"+cast.ToString(params["name"])+"%"))
+	}
+	if cast.ToString(params["lot_number"]) != "" {
+		filter = append(filter, s.db.Sq.Equal("lot_number", cast.ToString(params["lot_number"])))
+	}
+	if !cast.ToBool(params["include_empty"]) {
+		filter = append(filter, sq.Expr("remaining > 0"))
+	}
+
+	queryBuilder := s.db.Sq.Builder.Select(stockLotColumns)
+	queryBuilder = queryBuilder.From(s.tableName)
+	queryBuilder = queryBuilder.Where(filter)
+	queryBuilder = queryBuilder.OrderBy("expiry_date ASC NULLS LAST", "received_date")
+	queryBuilder = queryBuilder.Limit(limit)
+	queryBuilder = queryBuilder.Offset(limit * (page - 1))
+
+	query, args, err := queryBuilder.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := s.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var lots entity.ListStockLots
+	for rows.Next() {
+		lot, err := scanStockLot(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		lots.Lots = append(lots.Lots, lot)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	totalQueryBuilder := s.db.Sq.Builder.Select("COUNT(*)")
+	totalQueryBuilder = totalQueryBuilder.From(s.tableName)
+	totalQueryBuilder = totalQueryBuilder.Where(filter)
+
+	totalQuery, totalArgs, err := totalQueryBuilder.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	var count = 0
+	if err := s.db.QueryRow(ctx, totalQuery, totalArgs...).Scan(&count); err != nil {
+		return nil, err
+	}
+	lots.TotalCount = uint64(count)
+
+	return &lots, nil
+}
+
+// Expiring lists lots with stock left that expire between from and until inclusive
+func (s *stockLotRepo) Expiring(ctx context.Context, from, until string) ([]*entity.StockLot, error) {
+	queryBuilder := s.db.Sq.Builder.Select(stockLotColumns)
+	queryBuilder = queryBuilder.From(s.tableName)
+	queryBuilder = queryBuilder.Where("deleted_at IS NULL")
+	queryBuilder = queryBuilder.Where("remaining > 0")
+	queryBuilder = queryBuilder.Where(sq.GtOrEq{"expiry_date": from})
+	queryBuilder = queryBuilder.Where(sq.LtOrEq{"expiry_date": until})
+	queryBuilder = queryBuilder.OrderBy("expiry_date", "name")
+
+	return s.queryLots(ctx, queryBuilder)
+}
+
+// Expired lists lots with stock left whose expiry date is before day
+func (s *stockLotRepo) Expired(ctx context.Context, day string) ([]*entity.StockLot, error) {
+	queryBuilder := s.db.Sq.Builder.Select(stockLotColumns)
+	queryBuilder = queryBuilder.From(s.tableName)
+	queryBuilder = queryBuilder.Where("deleted_at IS NULL")
+	queryBuilder = queryBuilder.Where("remaining > 0")
+	queryBuilder = queryBuilder.Where(sq.Lt{"expiry_date": day})
+	queryBuilder = queryBuilder.OrderBy("expiry_date", "name")
+
+	return s.queryLots(ctx, queryBuilder)
+}
+
+func (s *stockLotRepo) queryLots(ctx context.Context, queryBuilder sq.SelectBuilder) ([]*entity.StockLot, error) {
+	query, args, err := queryBuilder.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := s.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var lots []*entity.StockLot
+	for rows.Next() {
+		lot, err := scanStockLot(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		lots = append(lots, lot)
+	}
+
+	return lots, rows.Err()
+}
+
+// Consume takes quantity of the eatable out of its lots, first expiring lot
+// first (FEFO). Lots that are already expired on day are skipped, capacity
+// that is in no lot yet is opened as one first. The food or drug capacity is
+// decreased by the same amount. Call it inside a transaction
+func (s *stockLotRepo) Consume(ctx context.Context, feedingID, category, eatableID string, quantity int64, day string) ([]*entity.LotConsumption, error) {
+	table, err := eatableTable(category)
+	if err != nil {
+		return nil, err
+	}
+	if quantity <= 0 {
+		return nil, nil
+	}
+	if day == "" {
+		day = time.Now().Format(time.DateOnly)
+	}
+
+	var name string
+	err = s.db.QueryRow(ctx, "SELECT name FROM "+table+" WHERE id = $1 AND deleted_at IS NULL", eatableID).Scan(&name)
+	if err != nil {
+		return nil, s.db.Error(err, category)
+	}
+
+	// capacity set before it was tracked in lots is opened as a lot first
+	untracked, err := s.untracked(ctx, table, category, name)
+	if err != nil {
+		return nil, err
+	}
+	if untracked > 0 {
+		if err := s.open(ctx, category, name, untracked); err != nil {
+			return nil, err
+		}
+	}
+
+	queryBuilder := s.db.Sq.Builder.Select("id, remaining")
+	queryBuilder = queryBuilder.From(s.tableName)
+	queryBuilder = queryBuilder.Where("deleted_at IS NULL")
+	queryBuilder = queryBuilder.Where("remaining > 0")
+	queryBuilder = queryBuilder.Where(s.db.Sq.Equal("category", category))
+	queryBuilder = queryBuilder.Where(s.db.Sq.Equal("name", name))
+	queryBuilder = queryBuilder.Where(sq.Or{sq.Expr("expiry_date IS NULL"), sq.GtOrEq{"expiry_date": day}})
+	queryBuilder = queryBuilder.OrderBy("expiry_date ASC NULLS LAST", "received_date", "created_at")
+	queryBuilder = queryBuilder.Suffix("FOR UPDATE")
+
+	query, args, err := queryBuilder.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := s.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	type available struct {
+		id        string
+		remaining int64
+	}
+	var lots []available
+	for rows.Next() {
+		var lot available
+		if err := rows.Scan(&lot.id, &lot.remaining); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		lots = append(lots, lot)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	var (
+		now          = time.Now().UTC()
+		need         = quantity
+		consumptions []*entity.LotConsumption
+	)
+	for _, lot := range lots {
+		if need == 0 {
+			break
+		}
+		take := min(need, lot.remaining)
+
+		_, err = s.db.Exec(ctx, "UPDATE "+s.tableName+" SET remaining = remaining - $1, updated_at = $2, version = version + 1 WHERE id = $3", take, now, lot.id)
+		if err != nil {
+			return nil, s.db.Error(err, "stock lot")
+		}
+
+		consumption := entity.LotConsumption{
+			ID:        uuid.New().String(),
+			LotID:     lot.id,
+			FeedingID: feedingID,
+			Quantity:  take,
+			CreatedAt: now,
+		}
+		insertBuilder := s.db.Sq.Builder.Insert(s.consumptionTableName)
+		insertBuilder = insertBuilder.SetMap(map[string]interface{}{
+			"id":         consumption.ID,
+			"lot_id":     consumption.LotID,
+			"feeding_id": consumption.FeedingID,
+			"quantity":   consumption.Quantity,
+			"created_at": consumption.CreatedAt,
+		})
+		insertQuery, insertArgs, err := insertBuilder.ToSql()
+		if err != nil {
+			return nil, err
+		}
+		if _, err = s.db.Exec(ctx, insertQuery, insertArgs...); err != nil {
+			return nil, s.db.Error(err, "stock lot")
+		}
+
+		consumptions = append(consumptions, &consumption)
+		need -= take
+	}
+	if need > 0 {
+		return nil, errorspkg.ErrorNotEnoughStock
+	}
+
+	_, err = s.db.Exec(ctx, "UPDATE "+table+" SET capacity = GREATEST(capacity - $1, 0), updated_at = $2, version = version + 1 WHERE id = $3", quantity, now, eatableID)
+	if err != nil {
+		return nil, s.db.Error(err, "stock lot")
+	}
+
+	return consumptions, nil
+}
+
+// Release gives what the feeding took back to the lots it was taken from and
+// to the food or drug capacity, and returns how much each got back. Call it
+// inside a transaction
+func (s *stockLotRepo) Release(ctx context.Context, feedingID string) ([]*entity.StockLevel, error) {
+	query := `
+WITH released AS (
+	DELETE FROM ` + s.consumptionTableName + ` WHERE feeding_id = $1 RETURNING lot_id, quantity
+), returned AS (
+	SELECT lot_id, SUM(quantity) AS quantity FROM released GROUP BY lot_id
+)
+UPDATE ` + s.tableName + ` AS l SET remaining = l.remaining + r.quantity, updated_at = $2, version = l.version + 1
+FROM returned AS r
+WHERE l.id = r.lot_id
+RETURNING l.category, l.name, r.quantity`
+
+	now := time.Now().UTC()
+	rows, err := s.db.Query(ctx, query, feedingID, now)
+	if err != nil {
+		return nil, s.db.Error(err, "stock lot")
+	}
+
+	var levels []*entity.StockLevel
+	returned := map[[2]string]*entity.StockLevel{}
+	for rows.Next() {
+		var level entity.StockLevel
+		if err := rows.Scan(&level.Category, &level.Name, &level.Quantity); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		if got, ok := returned[[2]string{level.Category, level.Name}]; ok {
+			got.Quantity += level.Quantity
+			continue
+		}
+		returned[[2]string{level.Category, level.Name}] = &level
+		levels = append(levels, &level)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	for _, level := range levels {
+		table, err := eatableTable(level.Category)
+		if err != nil {
+			return nil, err
+		}
+
+		_, err = s.db.Exec(ctx, "UPDATE "+table+" SET capacity = capacity + $1, updated_at = $2, version = version + 1 WHERE name = $3 AND deleted_at IS NULL", level.Quantity, now, level.Name)
+		if err != nil {
+			return nil, s.db.Error(err, "stock lot")
+		}
+	}
+
+	return levels, nil
+}
+
+// Revise sets the lot of the delivery to the quantity, category and name it
+// was updated with and moves the difference in what is left to the food or
+// drug capacity. What was already given or written off stays taken, so the
+// quantity cannot go below it, and a lot that was given from keeps its food
+// or drug. A delivery from before lot tracking has no lot and nil is
+// returned. Call it inside a transaction
+func (s *stockLotRepo) Revise(ctx context.Context, deliveryID, category, name string, quantity int64) (*entity.StockLot, error) {
+	lot, err := s.deliveryLot(ctx, deliveryID)
+	if err != nil || lot == nil {
+		return nil, err
+	}
+
+	given := lot.Quantity - lot.Remaining - lot.WrittenOff
+	remaining := quantity - given - lot.WrittenOff
+	if remaining < 0 {
+		return nil, errorspkg.ErrorNotEnoughStock
+	}
+	moved := lot.Category != category || lot.Name != name
+	if !moved && quantity == lot.Quantity {
+		return lot, nil
+	}
+	if moved && given > 0 {
+		errValidation := errorspkg.NewErrValidation()
+		errValidation.Errors["name"] = "cannot change once stock of the delivery was given"
+		errValidation.Err = errors.New("invalid delivery update")
+		return nil, errValidation
+	}
+
+	table, err := eatableTable(lot.Category)
+	if err != nil {
+		return nil, err
+	}
+	newTable, err := eatableTable(category)
+	if err != nil {
+		return nil, err
+	}
+
+	// the stock left in the lot leaves the old food or drug and the revised
+	// stock comes into the new one, both are the same one unless it moved
+	now := time.Now().UTC()
+	_, err = s.db.Exec(ctx, "UPDATE "+table+" SET capacity = GREATEST(capacity - $1, 0), updated_at = $2, version = version + 1 WHERE name = $3 AND deleted_at IS NULL", lot.Remaining, now, lot.Name)
+	if err != nil {
+		return nil, s.db.Error(err, "stock lot")
+	}
+	result, err := s.db.Exec(ctx, "UPDATE "+newTable+" SET capacity = capacity + $1, updated_at = $2, version = version + 1 WHERE name = $3 AND deleted_at IS NULL", remaining, now, name)
+	if err != nil {
+		return nil, s.db.Error(err, "stock lot")
+	}
+	if result.RowsAffected() == 0 {
+		return nil, s.db.Error(pgx.ErrNoRows, category)
+	}
+
+	updateBuilder := s.db.Sq.Builder.Update(s.tableName)
+	updateBuilder = updateBuilder.Set("category", category)
+	updateBuilder = updateBuilder.Set("name", name)
+	updateBuilder = updateBuilder.Set("quantity", quantity)
+	updateBuilder = updateBuilder.Set("remaining", remaining)
+	updateBuilder = updateBuilder.Set("updated_at", now)
+	updateBuilder = updateBuilder.Set("version", s.db.Sq.Expr("version + 1"))
+	updateBuilder = updateBuilder.Where(s.db.Sq.Equal("id", lot.ID))
+
+	updateQuery, updateArgs, err := updateBuilder.ToSql()
+	if err != nil {
+		return nil, err
+	}
+	if _, err = s.db.Exec(ctx, updateQuery, updateArgs...); err != nil {
+		return nil, s.db.Error(err, "stock lot")
+	}
+
+	lot.Category = category
+	lot.Name = name
+	lot.Quantity = quantity
+	lot.Remaining = remaining
+	lot.UpdatedAt = now
+
+	return lot, nil
+}
+
+// Withdraw writes off what is left in the lot of the delivery, for a
+// delivery that is deleted. A delivery from before lot tracking has no lot
+// and nil is returned
+func (s *stockLotRepo) Withdraw(ctx context.Context, deliveryID, reason string) (*entity.StockLot, error) {
+	lot, err := s.deliveryLot(ctx, deliveryID)
+	if err != nil || lot == nil {
+		return nil, err
+	}
+
+	return s.WriteOff(ctx, lot.ID, reason)
+}
+
+// deliveryLot locks the lot opened for the delivery, nil when it has none
+func (s *stockLotRepo) deliveryLot(ctx context.Context, deliveryID string) (*entity.StockLot, error) {
+	queryBuilder := s.db.Sq.Builder.Select(stockLotColumns)
+	queryBuilder = queryBuilder.From(s.tableName)
+	queryBuilder = queryBuilder.Where("deleted_at IS NULL")
+	queryBuilder = queryBuilder.Where(s.db.Sq.Equal("delivery_id", deliveryID))
+	queryBuilder = queryBuilder.Suffix("FOR UPDATE")
+
+	query, args, err := queryBuilder.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	lot, err := scanStockLot(s.db.QueryRow(ctx, query, args...))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, s.db.Error(err, "stock lot")
+	}
+
+	return lot, nil
+}
+
+// Balance brings the lots of the food or drug with the name in line with its
+// capacity after it was set outside a delivery. Capacity above what is left
+// in the lots is opened as a lot, capacity below it is written off the first
+// expiring lots. Call it inside a transaction
+func (s *stockLotRepo) Balance(ctx context.Context, category, name string) error {
+	table, err := eatableTable(category)
+	if err != nil {
+		return err
+	}
+
+	untracked, err := s.untracked(ctx, table, category, name)
+	if err != nil {
+		return err
+	}
+	if untracked > 0 {
+		return s.open(ctx, category, name, untracked)
+	}
+	if untracked == 0 {
+		return nil
+	}
+
+	queryBuilder := s.db.Sq.Builder.Select("id, remaining")
+	queryBuilder = queryBuilder.From(s.tableName)
+	queryBuilder = queryBuilder.Where("deleted_at IS NULL")
+	queryBuilder = queryBuilder.Where("remaining > 0")
+	queryBuilder = queryBuilder.Where(s.db.Sq.Equal("category", category))
+	queryBuilder = queryBuilder.Where(s.db.Sq.Equal("name", name))
+	queryBuilder = queryBuilder.OrderBy("expiry_date ASC NULLS LAST", "received_date", "created_at")
+	queryBuilder = queryBuilder.Suffix("FOR UPDATE")
+
+	query, args, err := queryBuilder.ToSql()
+	if err != nil {
+		return err
+	}
+
+	rows, err := s.db.Query(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+
+	type available struct {
+		id        string
+		remaining int64
+	}
+	var lots []available
+	for rows.Next() {
+		var lot available
+		if err := rows.Scan(&lot.id, &lot.remaining); err != nil {
+			rows.Close()
+			return err
+		}
+		lots = append(lots, lot)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	var (
+		now  = time.Now().UTC()
+		over = -untracked
+	)
+	for _, lot := range lots {
+		if over == 0 {
+			break
+		}
+		take := min(over, lot.remaining)
+
+		_, err = s.db.Exec(ctx, "UPDATE "+s.tableName+" SET remaining = remaining - $1, written_off = written_off + $1, written_off_at = $2, write_off_reason = $3, updated_at = $2, version = version + 1 WHERE id = $4",
+			take, now, "capacity lowered", lot.id)
+		if err != nil {
+			return s.db.Error(err, "stock lot")
+		}
+		over -= take
+	}
+
+	return nil
+}
+
+// Rename moves the lots of a food or drug to its new name
+func (s *stockLotRepo) Rename(ctx context.Context, category, from, to string) error {
+	queryBuilder := s.db.Sq.Builder.Update(s.tableName)
+	queryBuilder = queryBuilder.Set("name", to)
+	queryBuilder = queryBuilder.Set("updated_at", time.Now().UTC())
+	queryBuilder = queryBuilder.Set("version", s.db.Sq.Expr("version + 1"))
+	queryBuilder = queryBuilder.Where("deleted_at IS NULL")
+	queryBuilder = queryBuilder.Where(s.db.Sq.Equal("category", category))
+	queryBuilder = queryBuilder.Where(s.db.Sq.Equal("name", from))
+
+	query, args, err := queryBuilder.ToSql()
+	if err != nil {
+		return err
+	}
+	if _, err = s.db.Exec(ctx, query, args...); err != nil {
+		return s.db.Error(err, "stock lot")
+	}
+
+	return nil
+}
+
+// untracked returns how much of the food or drug capacity is not in its
+// lots, it is negative when the lots hold more. A deleted food or drug has
+// nothing untracked
+func (s *stockLotRepo) untracked(ctx context.Context, table, category, name string) (int64, error) {
+	query := `
+SELECT e.capacity - COALESCE((
+	SELECT SUM(remaining) FROM stock_lots
+	WHERE deleted_at IS NULL AND category = $1 AND name = $2
+), 0)
+FROM ` + table + ` AS e
+WHERE e.name = $2 AND e.deleted_at IS NULL`
+
+	var untracked int64
+	err := s.db.QueryRow(ctx, query, category, name).Scan(&untracked)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, s.db.Error(err, category)
+	}
+
+	return untracked, nil
+}
+
+// open opens a lot without a delivery for stock that was not tracked, like
+// the OPENING lots of the migration to lot tracking
+func (s *stockLotRepo) open(ctx context.Context, category, name string, quantity int64) error {
+	now := time.Now().UTC()
+	_, err := s.Create(ctx, &entity.StockLot{
+		ID:           uuid.New().String(),
+		Category:     category,
+		Name:         name,
+		LotNumber:    "OPENING",
+		ReceivedDate: now.Format(time.DateOnly),
+		Quantity:     quantity,
+		Remaining:    quantity,
+		CreatedAt:    now,
+		UpdatedAt:    now,
+	})
+	return err
+}
+
+// WriteOff removes what is left in the lot from stock and records the reason.
+// The food or drug capacity is decreased by the written off amount
+func (s *stockLotRepo) WriteOff(ctx context.Context, lotID, reason string) (*entity.StockLot, error) {
+	queryBuilder := s.db.Sq.Builder.Select(stockLotColumns)
+	queryBuilder = queryBuilder.From(s.tableName)
+	queryBuilder = queryBuilder.Where("deleted_at IS NULL")
+	queryBuilder = queryBuilder.Where(s.db.Sq.Equal("id", lotID))
+	queryBuilder = queryBuilder.Suffix("FOR UPDATE")
+
+	query, args, err := queryBuilder.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	lot, err := scanStockLot(s.db.QueryRow(ctx, query, args...))
+	if err != nil {
+		return nil, s.db.Error(err, "stock lot")
+	}
+	if lot.Remaining == 0 {
+		return lot, nil
+	}
+
+	table, err := eatableTable(lot.Category)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	updateBuilder := s.db.Sq.Builder.Update(s.tableName)
+	updateBuilder = updateBuilder.Set("written_off", lot.WrittenOff+lot.Remaining)
+	updateBuilder = updateBuilder.Set("remaining", 0)
+	updateBuilder = updateBuilder.Set("written_off_at", now)
+	updateBuilder = updateBuilder.Set("write_off_reason", reason)
+	updateBuilder = updateBuilder.Set("updated_at", now)
+	updateBuilder = updateBuilder.Set("version", s.db.Sq.Expr("version + 1"))
+	updateBuilder = updateBuilder.Where(s.db.Sq.Equal("id", lot.ID))
+
+	updateQuery, updateArgs, err := updateBuilder.ToSql()
+	if err != nil {
+		return nil, err
+	}
+	if _, err = s.db.Exec(ctx, updateQuery, updateArgs...); err != nil {
+		return nil, s.db.Error(err, "stock lot")
+	}
+
+	_, err = s.db.Exec(ctx, "UPDATE "+table+" SET capacity = GREATEST(capacity - $1, 0), updated_at = $2, version = version + 1 WHERE name = $3 AND deleted_at IS NULL", lot.Remaining, now, lot.Name)
+	if err != nil {
+		return nil, s.db.Error(err, "stock lot")
+	}
+
+	lot.WrittenOff += lot.Remaining
+	lot.Remaining = 0
+	lot.WriteOffReason = reason
+	lot.UpdatedAt = now
+
+	return lot, nil
+}
+
+// Levels returns the quantity in stock of every food, drug and product
+func (s *stockLotRepo) Levels(ctx context.Context) ([]*entity.StockLevel, error) {
+	rows, err := s.db.Query(ctx, stockQuery+" ORDER BY 1, 2")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var levels []*entity.StockLevel
+	for rows.Next() {
+		var level entity.StockLevel
+		if err := rows.Scan(&level.Category, &level.Name, &level.Union, &level.Quantity); err != nil {
+			return nil, err
+		}
+		levels = append(levels, &level)
+	}
+
+	return levels, rows.Err()
+}
+
+// Recalculate sets the capacity of every food and drug with lots to what is
+// left in them, and returns the ones that were out of step
+func (s *stockLotRepo) Recalculate(ctx context.Context) ([]*entity.StockLevel, error) {
+	var levels []*entity.StockLevel
+	for _, category := range []string{"food", "drug"} {
+		table, err := eatableTable(category)
+		if err != nil {
+			return nil, err
+		}
+
+		query := `
+UPDATE ` + table + ` AS e SET capacity = l.remaining, updated_at = $2, version = e.version + 1
+FROM (
+	SELECT name, SUM(remaining) AS remaining FROM stock_lots
+	WHERE deleted_at IS NULL AND category = $1
+	GROUP BY name
+) AS l
+WHERE e.name = l.name AND e.deleted_at IS NULL AND e.capacity <> l.remaining
+RETURNING e.name, e.product_union, e.capacity`
+
+		rows, err := s.db.Query(ctx, query, category, time.Now().UTC())
+		if err != nil {
+			return nil, s.db.Error(err, category)
+		}
+		for rows.Next() {
+			level := entity.StockLevel{Category: category}
+			if err := rows.Scan(&level.Name, &level.Union, &level.Quantity); err != nil {
+				rows.Close()
+				return nil, err
+			}
+			levels = append(levels, &level)
+		}
+		rows.Close()
+		if err := rows.Err(); err != nil {
+			return nil, err
+		}
+	}
+
+	return levels, nil
+}
@@ -0,0 +1,181 @@
+package postgresql_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"musobaqa/farm-competition/internal/entity"
+	errorspkg "musobaqa/farm-competition/internal/errors"
+	"musobaqa/farm-competition/internal/infrastructure/repository/postgresql"
+	"musobaqa/farm-competition/internal/pkg/config"
+	"musobaqa/farm-competition/internal/pkg/postgres"
+)
+
+func TestStockLotBalance(t *testing.T) {
+	cfg, err := config.NewConfig()
+	require.NoError(t, err)
+
+	db, err := postgres.New(cfg)
+	if err != nil {
+		t.Skipf("postgres is not available: %v", err)
+	}
+	defer db.Close()
+
+	ctx := context.Background()
+	foodID, name := uuid.New().String(), "hay "+uuid.New().String()[:8]
+	_, err = db.Exec(ctx, `INSERT INTO foods (id, name, capacity, product_union, description) VALUES ($1, $2, 10, 'kg', '')`, foodID, name)
+	require.NoError(t, err)
+	defer func() {
+		_, _ = db.Exec(ctx, `DELETE FROM stock_lot_consumptions WHERE lot_id IN (SELECT id FROM stock_lots WHERE name = $1)`, name)
+		_, _ = db.Exec(ctx, `DELETE FROM stock_lots WHERE name = $1`, name)
+		_, _ = db.Exec(ctx, `DELETE FROM foods WHERE id = $1`, foodID)
+	}()
+
+	repo := postgresql.NewStockLot(db)
+	remaining := func() (lots, capacity int64) {
+		require.NoError(t, db.QueryRow(ctx, `SELECT COALESCE(SUM(remaining), 0) FROM stock_lots WHERE name = $1 AND deleted_at IS NULL`, name).Scan(&lots))
+		require.NoError(t, db.QueryRow(ctx, `SELECT capacity FROM foods WHERE id = $1`, foodID).Scan(&capacity))
+		return lots, capacity
+	}
+	consume := func(quantity int64) error {
+		return db.WithTx(ctx, func(ctx context.Context) error {
+			_, err := repo.Consume(ctx, uuid.New().String(), "food", foodID, quantity, "")
+			return err
+		})
+	}
+
+	// Capacity that is in no lot is opened as one before it is fed
+	require.NoError(t, consume(4))
+	lots, capacity := remaining()
+	assert.Equal(t, int64(6), lots)
+	assert.Equal(t, int64(6), capacity)
+
+	// A raised capacity opens a lot, a lowered one is written off
+	_, err = db.Exec(ctx, `UPDATE foods SET capacity = 15 WHERE id = $1`, foodID)
+	require.NoError(t, err)
+	require.NoError(t, repo.Balance(ctx, "food", name))
+	lots, _ = remaining()
+	assert.Equal(t, int64(15), lots)
+
+	_, err = db.Exec(ctx, `UPDATE foods SET capacity = 5 WHERE id = $1`, foodID)
+	require.NoError(t, err)
+	require.NoError(t, repo.Balance(ctx, "food", name))
+	lots, _ = remaining()
+	assert.Equal(t, int64(5), lots)
+	require.NoError(t, repo.Balance(ctx, "food", name))
+	lots, _ = remaining()
+	assert.Equal(t, int64(5), lots)
+
+	// Renamed lots follow their food
+	renamed := name + " bales"
+	require.NoError(t, repo.Rename(ctx, "food", name, renamed))
+	_, err = db.Exec(ctx, `UPDATE foods SET name = $1 WHERE id = $2`, renamed, foodID)
+	require.NoError(t, err)
+	name = renamed
+	lots, _ = remaining()
+	assert.Equal(t, int64(5), lots)
+
+	// No more than the capacity is fed
+	assert.ErrorIs(t, consume(6), errorspkg.ErrorNotEnoughStock)
+	require.NoError(t, consume(5))
+	lots, capacity = remaining()
+	assert.Zero(t, lots)
+	assert.Zero(t, capacity)
+}
+
+func TestStockLotGiveBack(t *testing.T) {
+	cfg, err := config.NewConfig()
+	require.NoError(t, err)
+
+	db, err := postgres.New(cfg)
+	if err != nil {
+		t.Skipf("postgres is not available: %v", err)
+	}
+	defer db.Close()
+
+	ctx := context.Background()
+	foodID, deliveryID, name := uuid.New().String(), uuid.New().String(), "oats "+uuid.New().String()[:8]
+	_, err = db.Exec(ctx, `INSERT INTO foods (id, name, capacity, product_union, description) VALUES ($1, $2, 10, 'kg', '')`, foodID, name)
+	require.NoError(t, err)
+	_, err = db.Exec(ctx, `INSERT INTO into_store (id, name, category, capacity, product_union) VALUES ($1, $2, 'food', 10, 'kg')`, deliveryID, name)
+	require.NoError(t, err)
+	defer func() {
+		_, _ = db.Exec(ctx, `DELETE FROM stock_lot_consumptions WHERE lot_id IN (SELECT id FROM stock_lots WHERE name = $1)`, name)
+		_, _ = db.Exec(ctx, `DELETE FROM stock_lots WHERE name = $1`, name)
+		_, _ = db.Exec(ctx, `DELETE FROM into_store WHERE id = $1`, deliveryID)
+		_, _ = db.Exec(ctx, `DELETE FROM foods WHERE id = $1`, foodID)
+	}()
+
+	repo := postgresql.NewStockLot(db)
+	lot, err := repo.Create(ctx, &entity.StockLot{
+		ID:           uuid.New().String(),
+		DeliveryID:   deliveryID,
+		Category:     "food",
+		Name:         name,
+		LotNumber:    "LOT-1",
+		ReceivedDate: "2024-01-01",
+		Quantity:     10,
+		Remaining:    10,
+		CreatedAt:    time.Now().UTC(),
+		UpdatedAt:    time.Now().UTC(),
+	})
+	require.NoError(t, err)
+
+	capacity := func() int64 {
+		var capacity int64
+		require.NoError(t, db.QueryRow(ctx, `SELECT capacity FROM foods WHERE id = $1`, foodID).Scan(&capacity))
+		return capacity
+	}
+	remaining := func() int64 {
+		res, err := repo.Get(ctx, lot.ID)
+		require.NoError(t, err)
+		return res.Remaining
+	}
+
+	// A released feeding gives back what it took, once
+	feedingID := uuid.New().String()
+	_, err = repo.Consume(ctx, feedingID, "food", foodID, 4, "")
+	require.NoError(t, err)
+	assert.Equal(t, int64(6), remaining())
+	released, err := repo.Release(ctx, feedingID)
+	require.NoError(t, err)
+	require.Len(t, released, 1)
+	assert.Equal(t, int64(4), released[0].Quantity)
+	assert.Equal(t, int64(10), remaining())
+	assert.Equal(t, int64(10), capacity())
+	released, err = repo.Release(ctx, feedingID)
+	require.NoError(t, err)
+	assert.Empty(t, released)
+	assert.Equal(t, int64(10), capacity())
+
+	// A revised delivery moves the difference into capacity, what was
+	// given stays given
+	_, err = repo.Consume(ctx, feedingID, "food", foodID, 3, "")
+	require.NoError(t, err)
+	revised, err := repo.Revise(ctx, deliveryID, "food", name, 8)
+	require.NoError(t, err)
+	assert.Equal(t, int64(5), revised.Remaining)
+	assert.Equal(t, int64(5), capacity())
+	_, err = repo.Revise(ctx, deliveryID, "food", name, 2)
+	assert.ErrorIs(t, err, errorspkg.ErrorNotEnoughStock)
+	_, err = repo.Revise(ctx, deliveryID, "drug", name, 8)
+	var invalid *errorspkg.ErrValidation
+	assert.ErrorAs(t, err, &invalid)
+
+	// A withdrawn delivery writes off what is left
+	withdrawn, err := repo.Withdraw(ctx, deliveryID, "delivery deleted")
+	require.NoError(t, err)
+	assert.Zero(t, withdrawn.Remaining)
+	assert.Equal(t, int64(5), withdrawn.WrittenOff)
+	assert.Zero(t, capacity())
+
+	// Deliveries from before lot tracking have no lot
+	withdrawn, err = repo.Withdraw(ctx, uuid.New().String(), "delivery deleted")
+	assert.NoError(t, err)
+	assert.Nil(t, withdrawn)
+}
@@ -0,0 +1,113 @@
+package postgresql
+
+import (
+	"context"
+	"musobaqa/farm-competition/internal/entity"
+	"musobaqa/farm-competition/internal/infrastructure/repository/postgresql/repo"
+	"musobaqa/farm-competition/internal/pkg/postgres"
+)
+
+const (
+	stockQuery = `
+SELECT 'food', name, product_union, capacity FROM foods WHERE deleted_at IS NULL
+UNION ALL
+SELECT 'drug', name, product_union, capacity FROM drugs WHERE deleted_at IS NULL
+UNION ALL
+SELECT 'product', name, product_union, total_capacity FROM products WHERE deleted_at IS NULL`
+
+	headCountQuery = `
+SELECT category_name, COUNT(*) FROM animals WHERE deleted_at IS NULL GROUP BY category_name`
+
+	// a feeding is overdue when its time has passed today and the animal was
+	// given that eatable fewer times today than it was due
+	overdueFeedingsQuery = `
+SELECT COALESCE(SUM(GREATEST(due.times - COALESCE(given.times, 0), 0)), 0)
+FROM (
+	SELECT e.animal_id, e.eatables_id, COUNT(*) AS times
+	FROM animal_eatable_info AS e
+	JOIN animals AS a ON a.id = e.animal_id
+	CROSS JOIN jsonb_array_elements(e.daily) AS d
+	WHERE e.deleted_at IS NULL AND a.deleted_at IS NULL AND (d->>'time')::time <= LOCALTIME
+	GROUP BY e.animal_id, e.eatables_id
+) AS due
+LEFT JOIN (
+	SELECT animal_id, eatables_id, SUM(jsonb_array_length(daily)) AS times
+	FROM animal_given_eatables
+	WHERE deleted_at IS NULL AND day = CURRENT_DATE
+	GROUP BY animal_id, eatables_id
+) AS given USING (animal_id, eatables_id)`
+
+	yieldsTodayQuery = `
+SELECT p.name, p.product_union, SUM(ap.capacity)
+FROM animal_products AS ap
+JOIN products AS p ON p.id = ap.product_id
+WHERE ap.deleted_at IS NULL AND ap.get_time >= CURRENT_DATE AND ap.get_time < CURRENT_DATE + 1
+GROUP BY p.name, p.product_union`
+)
+
+type metricsRepo struct {
+	db *postgres.PostgresDB
+}
+
+func NewMetrics(db *postgres.PostgresDB) repo.Metrics {
+	return &metricsRepo{
+		db: db,
+	}
+}
+
+func (m *metricsRepo) FarmGauges(ctx context.Context) (*entity.FarmGauges, error) {
+	var gauges entity.FarmGauges
+
+	rows, err := m.db.Query(ctx, stockQuery)
+	if err != nil {
+		return nil, err
+	}
+	for rows.Next() {
+		var level entity.StockLevel
+		if err := rows.Scan(&level.Category, &level.Name, &level.Union, &level.Quantity); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		gauges.Stock = append(gauges.Stock, &level)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	rows, err = m.db.Query(ctx, headCountQuery)
+	if err != nil {
+		return nil, err
+	}
+	for rows.Next() {
+		var headCount entity.HeadCount
+		if err := rows.Scan(&headCount.Category, &headCount.Count); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		gauges.HeadCounts = append(gauges.HeadCounts, &headCount)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	if err := m.db.QueryRow(ctx, overdueFeedingsQuery).Scan(&gauges.OverdueFeedings); err != nil {
+		return nil, err
+	}
+
+	rows, err = m.db.Query(ctx, yieldsTodayQuery)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		level := entity.StockLevel{Category: "product"}
+		if err := rows.Scan(&level.Name, &level.Union, &level.Quantity); err != nil {
+			return nil, err
+		}
+		gauges.YieldsToday = append(gauges.YieldsToday, &level)
+	}
+
+	return &gauges, rows.Err()
+}
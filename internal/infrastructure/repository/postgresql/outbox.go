@@ -0,0 +1,147 @@
+package postgresql
+
+import (
+	"context"
+	"musobaqa/farm-competition/internal/entity"
+	"musobaqa/farm-competition/internal/infrastructure/repository/postgresql/repo"
+	"musobaqa/farm-competition/internal/pkg/postgres"
+	"sort"
+	"time"
+
+	"github.com/jackc/pgx/v4"
+)
+
+type outboxRepo struct {
+	tableName string
+	db        *postgres.PostgresDB
+}
+
+func NewOutbox(db *postgres.PostgresDB) repo.Outbox {
+	return &outboxRepo{
+		tableName: "outbox_events",
+		db:        db,
+	}
+}
+
+// Add writes the events, called with the context of a transaction they are
+// only kept when it commits
+func (o *outboxRepo) Add(ctx context.Context, events ...*entity.DomainEvent) error {
+	if len(events) == 0 {
+		return nil
+	}
+
+	queryBuilder := o.db.Sq.Builder.Insert(o.tableName)
+	queryBuilder = queryBuilder.Columns("id", "type", "aggregate_type", "aggregate_id", "payload", "available_at", "created_at")
+	for _, event := range events {
+		queryBuilder = queryBuilder.Values(event.ID, event.Type, event.AggregateType, event.AggregateID, string(event.Payload), event.OccurredAt, event.OccurredAt)
+	}
+
+	query, args, err := queryBuilder.ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = o.db.Exec(ctx, query, args...)
+	return err
+}
+
+// Claim takes up to limit unpublished events that were not given up on, oldest first, and moves them
+// a lease away so other replicas leave them alone while they are published
+func (o *outboxRepo) Claim(ctx context.Context, limit uint64, lease time.Duration) ([]*entity.DomainEvent, error) {
+	query := `
+		WITH due AS (
+			SELECT id
+			FROM outbox_events
+			WHERE published_at IS NULL AND failed_at IS NULL AND available_at <= $1
+			ORDER BY created_at
+			LIMIT $2
+			FOR UPDATE SKIP LOCKED
+		)
+		UPDATE outbox_events AS o
+		SET available_at = $3
+		FROM due
+		WHERE o.id = due.id
+		RETURNING o.id, o.type, o.aggregate_type, o.aggregate_id, o.payload, o.attempts, o.created_at`
+
+	now := time.Now().UTC()
+	rows, err := o.db.Query(ctx, query, now, limit, now.Add(lease))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var events []*entity.DomainEvent
+	for rows.Next() {
+		var event entity.DomainEvent
+		err = rows.Scan(
+			&event.ID,
+			&event.Type,
+			&event.AggregateType,
+			&event.AggregateID,
+			&event.Payload,
+			&event.Attempts,
+			&event.OccurredAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		event.Key = event.ID
+
+		events = append(events, &event)
+	}
+
+	// the updated rows come back in any order
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].OccurredAt.Before(events[j].OccurredAt)
+	})
+
+	return events, rows.Err()
+}
+
+func (o *outboxRepo) MarkPublished(ctx context.Context, eventID string) error {
+	result, err := o.db.Exec(ctx, `UPDATE outbox_events SET published_at = $1, last_error = NULL WHERE id = $2`, time.Now().UTC(), eventID)
+	if err != nil {
+		return err
+	}
+	if result.RowsAffected() == 0 {
+		return o.db.Error(pgx.ErrNoRows, "outbox event")
+	}
+
+	return nil
+}
+
+// MarkFailed counts the failed attempt and keeps the event until retryAt
+func (o *outboxRepo) MarkFailed(ctx context.Context, eventID, reason string, retryAt time.Time) error {
+	result, err := o.db.Exec(ctx, `UPDATE outbox_events SET attempts = attempts + 1, last_error = $1, available_at = $2 WHERE id = $3`, reason, retryAt, eventID)
+	if err != nil {
+		return err
+	}
+	if result.RowsAffected() == 0 {
+		return o.db.Error(pgx.ErrNoRows, "outbox event")
+	}
+
+	return nil
+}
+
+// GiveUp counts the last attempt and parks the event, it is no longer claimed
+func (o *outboxRepo) GiveUp(ctx context.Context, eventID, reason string) error {
+	result, err := o.db.Exec(ctx, `UPDATE outbox_events SET attempts = attempts + 1, last_error = $1, failed_at = $2 WHERE id = $3`, reason, time.Now().UTC(), eventID)
+	if err != nil {
+		return err
+	}
+	if result.RowsAffected() == 0 {
+		return o.db.Error(pgx.ErrNoRows, "outbox event")
+	}
+
+	return nil
+}
+
+// Purge removes the events published or given up on before the given time
+func (o *outboxRepo) Purge(ctx context.Context, before time.Time) (int64, error) {
+	result, err := o.db.Exec(ctx, `DELETE FROM outbox_events WHERE published_at < $1 OR failed_at < $1`, before)
+	if err != nil {
+		return 0, o.db.Error(err, "outbox event")
+	}
+
+	return result.RowsAffected(), nil
+}
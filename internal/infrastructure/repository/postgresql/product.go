@@ -3,10 +3,11 @@ package postgresql
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"github.com/jackc/pgx/v4"
-	"github.com/spf13/cast"
 	"musobaqa/farm-competition/internal/entity"
 	"musobaqa/farm-competition/internal/infrastructure/repository/postgresql/repo"
+	"musobaqa/farm-competition/internal/pkg/listquery"
 	"musobaqa/farm-competition/internal/pkg/postgres"
 	"time"
 )
@@ -65,7 +66,7 @@ func (a *productRepo) Create(ctx context.Context, product *entity.Product) (*ent
 	)
 
 	if err != nil {
-		return nil, err
+		return nil, a.db.Error(err, "product")
 	}
 
 	if sqlNullDescription.Valid {
@@ -84,16 +85,19 @@ func (a *productRepo) Update(ctx context.Context, product *entity.Product) (*ent
 		product_union = $2,
 		description = $3,
 	    total_capacity = $4,
-		updated_at = $5
+		updated_at = $5,
+		version = version + 1
 	WHERE
 	    id = $6
 		AND deleted_at IS NULL
+		AND ($7 = 0 OR version = $7)
 	RETURNING
 		id,
 		name,
 		product_union,
 		description,
-		total_capacity
+		total_capacity,
+		version
 	`
 
 	var (
@@ -108,16 +112,21 @@ func (a *productRepo) Update(ctx context.Context, product *entity.Product) (*ent
 		product.TotalCapacity,
 		product.UpdatedAt,
 		product.ID,
+		product.Version,
 	).Scan(
 		&updatedProduct.ID,
 		&updatedProduct.Name,
 		&updatedProduct.Union,
 		&sqlNullDescription,
 		&updatedProduct.TotalCapacity,
+		&updatedProduct.Version,
 	)
 
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, versionConflict(ctx, a.db, a.tableName, product.ID, product.Version, a.db.Error(err, "product"))
+	}
 	if err != nil {
-		return nil, err
+		return nil, a.db.Error(err, "product")
 	}
 
 	if sqlNullDescription.Valid {
@@ -127,16 +136,16 @@ func (a *productRepo) Update(ctx context.Context, product *entity.Product) (*ent
 	return &updatedProduct, nil
 }
 
-func (a *productRepo) Delete(ctx context.Context, productID string) error {
-	query := `UPDATE products SET deleted_at = $1 WHERE id = $2 AND deleted_at IS NULL`
+func (a *productRepo) Delete(ctx context.Context, productID string, version int64) error {
+	query := `UPDATE products SET deleted_at = $1 WHERE id = $2 AND deleted_at IS NULL AND ($3 = 0 OR version = $3)`
 
-	result, err := a.db.Exec(ctx, query, time.Now().Format(time.RFC3339), productID)
+	result, err := a.db.Exec(ctx, query, time.Now().Format(time.RFC3339), productID, version)
 	if err != nil {
-		return err
+		return a.db.Error(err, "product")
 	}
 
 	if result.RowsAffected() == 0 {
-		return pgx.ErrNoRows
+		return versionConflict(ctx, a.db, "products", productID, version, a.db.Error(pgx.ErrNoRows, "product"))
 	}
 
 	return nil
@@ -148,7 +157,7 @@ func (a *productRepo) Get(ctx context.Context, params map[string]string) (*entit
 		sqlNullDescription sql.NullString
 	)
 
-	queryBuilder := a.db.Sq.Builder.Select("id, name, product_union, description, total_capacity")
+	queryBuilder := a.db.Sq.Builder.Select("id, name, product_union, description, total_capacity, version")
 	queryBuilder = queryBuilder.From(a.tableName)
 	queryBuilder = queryBuilder.Where("deleted_at IS NULL")
 	for key, value := range params {
@@ -171,10 +180,11 @@ func (a *productRepo) Get(ctx context.Context, params map[string]string) (*entit
 		&product.Union,
 		&sqlNullDescription,
 		&product.TotalCapacity,
+		&product.Version,
 	)
 
 	if err != nil {
-		return nil, err
+		return nil, a.db.Error(err, "product")
 	}
 
 	if sqlNullDescription.Valid {
@@ -184,17 +194,33 @@ func (a *productRepo) Get(ctx context.Context, params map[string]string) (*entit
 	return &product, nil
 }
 
-func (p *productRepo) List(ctx context.Context, page, limit uint64, params map[string]any) (*entity.ListProducts, error) {
+var productListSchema = listquery.Schema{
+	Fields: map[string]listquery.Field{
+		"name":           {Column: "name", Ops: listquery.TextOps, Sortable: true, Searchable: true},
+		"union":          {Column: "product_union", Ops: listquery.TextOps, Sortable: true},
+		"total_capacity": {Column: "total_capacity", Type: listquery.Int, Ops: listquery.NumberOps, Sortable: true},
+		"description":    {Column: "description", Ops: listquery.TextOps, Searchable: true},
+		"created_at":     {Column: "created_at", Type: listquery.Date, Ops: listquery.DateOps, Sortable: true},
+	},
+	DefaultOrder: []string{"created_at DESC"},
+}
+
+func (p *productRepo) List(ctx context.Context, page, limit uint64, request listquery.Request) (*entity.ListProducts, error) {
 	var (
 		products entity.ListProducts
 		offset   = limit * (page - 1)
 	)
 
+	where, orderBy, err := productListSchema.Build(p.db.Sq, request)
+	if err != nil {
+		return nil, err
+	}
+
 	queryBuilder := p.db.Sq.Builder.Select("id, name, product_union, total_capacity, description")
 	queryBuilder = queryBuilder.From(p.tableName)
 	queryBuilder = queryBuilder.Where("deleted_at IS NULL")
-	queryBuilder = queryBuilder.Where(p.db.Sq.ILike("name", "%"+cast.ToString(params["name"])+"%"))
-	queryBuilder = queryBuilder.Where(p.db.Sq.ILike("product_union", "%"+cast.ToString(params["union"])+"%"))
+	queryBuilder = queryBuilder.Where(where)
+	queryBuilder = queryBuilder.OrderBy(orderBy...)
 	queryBuilder = queryBuilder.Limit(limit)
 	queryBuilder = queryBuilder.Offset(offset)
 
@@ -235,8 +261,7 @@ func (p *productRepo) List(ctx context.Context, page, limit uint64, params map[s
 	totalQueryBuilder := p.db.Sq.Builder.Select("COUNT(*)")
 	totalQueryBuilder = totalQueryBuilder.From(p.tableName)
 	totalQueryBuilder = totalQueryBuilder.Where("deleted_at IS NULL")
-	totalQueryBuilder = totalQueryBuilder.Where(p.db.Sq.ILike("name", "%"+cast.ToString(params["name"])+"%"))
-	totalQueryBuilder = totalQueryBuilder.Where(p.db.Sq.ILike("product_union", "%"+cast.ToString(params["union"])+"%"))
+	totalQueryBuilder = totalQueryBuilder.Where(where)
 
 	totalQuery, totalArgs, err := totalQueryBuilder.ToSql()
 	if err != nil {
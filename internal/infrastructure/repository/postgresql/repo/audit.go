@@ -0,0 +1,13 @@
+package repo
+
+import (
+	"context"
+	"encoding/json"
+	"musobaqa/farm-competition/internal/entity"
+)
+
+type Audit interface {
+	Create(ctx context.Context, log *entity.AuditLog) error
+	List(ctx context.Context, page, limit uint64, params map[string]any) (*entity.ListAuditLogs, error)
+	Snapshot(ctx context.Context, entityType, entityID string) (json.RawMessage, error)
+}
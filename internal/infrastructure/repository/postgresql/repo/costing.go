@@ -0,0 +1,12 @@
+package repo
+
+import (
+	"context"
+	"musobaqa/farm-competition/internal/entity"
+)
+
+type Costing interface {
+	ListReceipts(ctx context.Context, category string) ([]*entity.StockReceipt, error)
+	ListIssues(ctx context.Context, category string) ([]*entity.StockIssue, error)
+	ListWriteOffs(ctx context.Context, category string) ([]*entity.StockIssue, error)
+}
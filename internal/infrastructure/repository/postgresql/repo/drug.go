@@ -3,13 +3,14 @@ package repo
 import (
 	"context"
 	"musobaqa/farm-competition/internal/entity"
+	"musobaqa/farm-competition/internal/pkg/listquery"
 )
 
 type Drug interface {
 	Create(ctx context.Context, drug *entity.Drug) (*entity.Drug, error)
 	Update(ctx context.Context, drug *entity.Drug) (*entity.Drug, error)
-	Delete(ctx context.Context, drugID string) error
+	Delete(ctx context.Context, drugID string, version int64) error
 	Get(ctx context.Context, params map[string]string) (*entity.Drug, error)
-	List(ctx context.Context, page, limit uint64, params map[string]any) (*entity.ListDrugs, error)
+	List(ctx context.Context, page, limit uint64, request listquery.Request) (*entity.ListDrugs, error)
 	UniqueDrugName(ctx context.Context, drugName string) (int, error)
 }
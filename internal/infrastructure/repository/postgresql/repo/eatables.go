@@ -8,7 +8,7 @@ import (
 type Eatable interface {
 	Create(ctx context.Context, eatable *entity.Eatables) (*entity.EatablesRes, error)
 	Update(ctx context.Context, eatable *entity.Eatables) (*entity.EatablesRes, error)
-	Delete(ctx context.Context, eatableID string) error
+	Delete(ctx context.Context, eatableID string, version int64) error
 	GetFoods(ctx context.Context, page, limit uint64, animalID string) (*entity.ListFoodEatables, error)
 	GetDrugs(ctx context.Context, page, limit uint64, animalID string) (*entity.ListDrugEatables, error)
 }
@@ -0,0 +1,8 @@
+package repo
+
+import "context"
+
+type Health interface {
+	Ping(ctx context.Context) error
+	MigrationVersion(ctx context.Context) (version int64, dirty bool, err error)
+}
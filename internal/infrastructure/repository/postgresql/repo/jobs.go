@@ -0,0 +1,16 @@
+package repo
+
+import (
+	"context"
+	"musobaqa/farm-competition/internal/entity"
+	"time"
+)
+
+type Job interface {
+	Lock(ctx context.Context, job string) error
+	Claim(ctx context.Context, run *entity.JobRun) (bool, error)
+	Finish(ctx context.Context, run *entity.JobRun) error
+	ListRuns(ctx context.Context, page, limit uint64, params map[string]any) (*entity.ListJobRuns, error)
+	LastRuns(ctx context.Context) ([]*entity.JobRun, error)
+	PurgeRuns(ctx context.Context, before time.Time) (int64, error)
+}
@@ -0,0 +1,24 @@
+package repo
+
+import (
+	"context"
+	"musobaqa/farm-competition/internal/entity"
+)
+
+type StockLot interface {
+	Create(ctx context.Context, lot *entity.StockLot) (*entity.StockLot, error)
+	Get(ctx context.Context, lotID string) (*entity.StockLot, error)
+	List(ctx context.Context, page, limit uint64, params map[string]any) (*entity.ListStockLots, error)
+	Expiring(ctx context.Context, from, until string) ([]*entity.StockLot, error)
+	Restock(ctx context.Context, restock *entity.Restock) (bool, error)
+	Consume(ctx context.Context, feedingID, category, eatableID string, quantity int64, day string) ([]*entity.LotConsumption, error)
+	WriteOff(ctx context.Context, lotID, reason string) (*entity.StockLot, error)
+	Release(ctx context.Context, feedingID string) ([]*entity.StockLevel, error)
+	Revise(ctx context.Context, deliveryID, category, name string, quantity int64) (*entity.StockLot, error)
+	Withdraw(ctx context.Context, deliveryID, reason string) (*entity.StockLot, error)
+	Balance(ctx context.Context, category, name string) error
+	Rename(ctx context.Context, category, from, to string) error
+	Expired(ctx context.Context, day string) ([]*entity.StockLot, error)
+	Levels(ctx context.Context) ([]*entity.StockLevel, error)
+	Recalculate(ctx context.Context) ([]*entity.StockLevel, error)
+}
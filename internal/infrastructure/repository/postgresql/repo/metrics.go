@@ -0,0 +1,10 @@
+package repo
+
+import (
+	"context"
+	"musobaqa/farm-competition/internal/entity"
+)
+
+type Metrics interface {
+	FarmGauges(ctx context.Context) (*entity.FarmGauges, error)
+}
@@ -0,0 +1,16 @@
+package repo
+
+import (
+	"context"
+	"musobaqa/farm-competition/internal/entity"
+	"time"
+)
+
+type Outbox interface {
+	Add(ctx context.Context, events ...*entity.DomainEvent) error
+	Claim(ctx context.Context, limit uint64, lease time.Duration) ([]*entity.DomainEvent, error)
+	MarkPublished(ctx context.Context, eventID string) error
+	MarkFailed(ctx context.Context, eventID, reason string, retryAt time.Time) error
+	GiveUp(ctx context.Context, eventID, reason string) error
+	Purge(ctx context.Context, before time.Time) (int64, error)
+}
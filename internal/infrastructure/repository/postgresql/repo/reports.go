@@ -0,0 +1,11 @@
+package repo
+
+import (
+	"context"
+	"musobaqa/farm-competition/internal/entity"
+)
+
+type ReportSnapshot interface {
+	Save(ctx context.Context, snapshot *entity.ReportSnapshot) error
+	List(ctx context.Context, params map[string]any) ([]*entity.ReportSnapshot, error)
+}
@@ -0,0 +1,14 @@
+package repo
+
+import (
+	"context"
+	"musobaqa/farm-competition/internal/entity"
+	"time"
+)
+
+type FeedingTask interface {
+	Generate(ctx context.Context, day string, now time.Time) (int64, error)
+	Get(ctx context.Context, taskID string) (*entity.FeedingTask, error)
+	List(ctx context.Context, page, limit uint64, params map[string]any) (*entity.ListFeedingTasks, error)
+	Close(ctx context.Context, task *entity.FeedingTask) (*entity.FeedingTask, error)
+}
@@ -0,0 +1,9 @@
+package repo
+
+import "context"
+
+// Transactor runs fn in a single database transaction, repository calls made
+// with the context passed to fn take part in it
+type Transactor interface {
+	WithTx(ctx context.Context, fn func(ctx context.Context) error) error
+}
@@ -0,0 +1,13 @@
+package repo
+
+import (
+	"context"
+	"musobaqa/farm-competition/internal/entity"
+	"time"
+)
+
+type Trash interface {
+	List(ctx context.Context, entityType string, page, limit uint64) (*entity.ListTrash, error)
+	Restore(ctx context.Context, entityType, entityID string) error
+	Purge(ctx context.Context, before time.Time) (map[string]int64, error)
+}
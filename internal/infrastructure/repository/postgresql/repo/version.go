@@ -0,0 +1,7 @@
+package repo
+
+import "context"
+
+type Version interface {
+	Current(ctx context.Context, entityType, entityID string) (int64, error)
+}
@@ -0,0 +1,21 @@
+package repo
+
+import (
+	"context"
+	"musobaqa/farm-competition/internal/entity"
+	"time"
+)
+
+type Webhook interface {
+	Create(ctx context.Context, subscription *entity.WebhookSubscription) (*entity.WebhookSubscription, error)
+	Update(ctx context.Context, subscription *entity.WebhookSubscription) (*entity.WebhookSubscription, error)
+	Delete(ctx context.Context, subscriptionID string, version int64) error
+	Get(ctx context.Context, subscriptionID string) (*entity.WebhookSubscription, error)
+	List(ctx context.Context, page, limit uint64, params map[string]any) (*entity.ListWebhookSubscriptions, error)
+	Enqueue(ctx context.Context, event *entity.WebhookEvent) (int64, error)
+	Claim(ctx context.Context, limit uint64, lease time.Duration) ([]*entity.WebhookDelivery, error)
+	SaveAttempt(ctx context.Context, delivery *entity.WebhookDelivery) error
+	ListDeliveries(ctx context.Context, page, limit uint64, params map[string]any) (*entity.ListWebhookDeliveries, error)
+	Redeliver(ctx context.Context, deliveryID string) (*entity.WebhookDelivery, error)
+	PurgeDeliveries(ctx context.Context, before time.Time) (int64, error)
+}
@@ -0,0 +1,94 @@
+package postgresql
+
+import (
+	"context"
+	"musobaqa/farm-competition/internal/entity"
+	"musobaqa/farm-competition/internal/infrastructure/repository/postgresql/repo"
+	"musobaqa/farm-competition/internal/pkg/postgres"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/spf13/cast"
+)
+
+type reportSnapshotRepo struct {
+	tableName string
+	db        *postgres.PostgresDB
+}
+
+func NewReportSnapshot(db *postgres.PostgresDB) repo.ReportSnapshot {
+	return &reportSnapshotRepo{
+		tableName: "report_snapshots",
+		db:        db,
+	}
+}
+
+// Save stores the snapshot, one taken again for the same report and day
+// replaces the earlier one
+func (r *reportSnapshotRepo) Save(ctx context.Context, snapshot *entity.ReportSnapshot) error {
+	query := `
+		INSERT INTO report_snapshots (id, report, day, data, created_at)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (report, day) DO UPDATE SET data = EXCLUDED.data, created_at = EXCLUDED.created_at`
+
+	_, err := r.db.Exec(ctx, query, snapshot.ID, snapshot.Report, snapshot.Day, string(snapshot.Data), snapshot.CreatedAt)
+	if err != nil {
+		return r.db.Error(err, "report snapshot")
+	}
+
+	return nil
+}
+
+// List returns the snapshots of a report between the from and to days,
+// oldest first
+func (r *reportSnapshotRepo) List(ctx context.Context, params map[string]any) ([]*entity.ReportSnapshot, error) {
+	filter := sq.And{}
+	if report := cast.ToString(params["report"]); report != "" {
+		filter = append(filter, r.db.Sq.Equal("report", report))
+	}
+	if from := cast.ToString(params["from"]); from != "" {
+		filter = append(filter, sq.GtOrEq{"day": from})
+	}
+	if to := cast.ToString(params["to"]); to != "" {
+		filter = append(filter, sq.LtOrEq{"day": to})
+	}
+
+	queryBuilder := r.db.Sq.Builder.Select("id, report, day, data, created_at")
+	queryBuilder = queryBuilder.From(r.tableName)
+	queryBuilder = queryBuilder.Where(filter)
+	queryBuilder = queryBuilder.OrderBy("day", "report")
+
+	query, args, err := queryBuilder.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, r.db.Error(err, "report snapshot")
+	}
+	defer rows.Close()
+
+	var snapshots []*entity.ReportSnapshot
+	for rows.Next() {
+		var (
+			snapshot entity.ReportSnapshot
+			day      time.Time
+		)
+		err = rows.Scan(
+			&snapshot.ID,
+			&snapshot.Report,
+			&day,
+			&snapshot.Data,
+			&snapshot.CreatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		snapshot.Day = day.Format(time.DateOnly)
+
+		snapshots = append(snapshots, &snapshot)
+	}
+
+	return snapshots, rows.Err()
+}
@@ -0,0 +1,117 @@
+package postgresql
+
+import (
+	"context"
+	"fmt"
+	"musobaqa/farm-competition/internal/entity"
+	errorspkg "musobaqa/farm-competition/internal/errors"
+	"musobaqa/farm-competition/internal/infrastructure/repository/postgresql/repo"
+	"musobaqa/farm-competition/internal/pkg/postgres"
+	"strings"
+	"unicode"
+)
+
+// searchSource is a table searched through its search_vector column, label
+// is shown under the name of a hit
+type searchSource struct {
+	entityType string
+	table      string
+	label      string
+}
+
+var searchSources = []searchSource{
+	{entityType: "animal", table: "animals", label: "category_name"},
+	{entityType: "product", table: "products", label: "product_union"},
+	{entityType: "food", table: "foods", label: "product_union"},
+	{entityType: "drug", table: "drugs", label: "status"},
+}
+
+type searchRepo struct {
+	db *postgres.PostgresDB
+}
+
+func NewSearch(db *postgres.PostgresDB) repo.Search {
+	return &searchRepo{
+		db: db,
+	}
+}
+
+// Search ranks full text matches, every word matching as a prefix, together
+// with names that are only similar to the text so misspellings still hit
+func (s *searchRepo) Search(ctx context.Context, text string, entityTypes []string, limit uint64) ([]*entity.SearchHit, error) {
+	sources, err := selectSources(entityTypes)
+	if err != nil {
+		return nil, err
+	}
+
+	var selects []string
+	for _, source := range sources {
+		selects = append(selects, fmt.Sprintf(
+			"SELECT '%s' AS entity_type, id::text AS id, name, COALESCE(%s, '') AS label, "+
+				"ts_rank(search_vector, to_tsquery('simple', $1)) + similarity(name, $2) AS rank "+
+				"FROM %s WHERE deleted_at IS NULL AND (search_vector @@ to_tsquery('simple', $1) OR name %% $2)",
+			source.entityType, source.label, source.table))
+	}
+	query := strings.Join(selects, " UNION ALL ") + " ORDER BY rank DESC, name LIMIT $3"
+
+	rows, err := s.db.Query(ctx, query, prefixQuery(text), text, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var hits []*entity.SearchHit
+	for rows.Next() {
+		var hit entity.SearchHit
+		err = rows.Scan(
+			&hit.EntityType,
+			&hit.ID,
+			&hit.Name,
+			&hit.Label,
+			&hit.Rank,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		hits = append(hits, &hit)
+	}
+
+	return hits, rows.Err()
+}
+
+func selectSources(entityTypes []string) ([]searchSource, error) {
+	if len(entityTypes) == 0 {
+		return searchSources, nil
+	}
+
+	var sources []searchSource
+	for _, entityType := range entityTypes {
+		found := false
+		for _, source := range searchSources {
+			if source.entityType == entityType {
+				sources = append(sources, source)
+				found = true
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("%w: %s", errorspkg.ErrorUnknownEntity, entityType)
+		}
+	}
+
+	return sources, nil
+}
+
+// prefixQuery turns the typed text into a tsquery matching every word as a
+// prefix, so a half typed name already finds its entity. Only letters and
+// digits are kept, the rest of the tsquery syntax never reaches Postgres
+func prefixQuery(text string) string {
+	words := strings.FieldsFunc(text, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	for i, word := range words {
+		words[i] = word + ":*"
+	}
+
+	return strings.Join(words, " & ")
+}
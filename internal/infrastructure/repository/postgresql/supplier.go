@@ -0,0 +1,323 @@
+package postgresql
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"musobaqa/farm-competition/internal/entity"
+	"musobaqa/farm-competition/internal/infrastructure/repository/postgresql/repo"
+	"musobaqa/farm-competition/internal/pkg/listquery"
+	"musobaqa/farm-competition/internal/pkg/postgres"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v4"
+	"github.com/spf13/cast"
+)
+
+type supplierRepo struct {
+	tableName         string
+	deliveryTableName string
+	db                *postgres.PostgresDB
+}
+
+func NewSupplier(db *postgres.PostgresDB) repo.Supplier {
+	return &supplierRepo{
+		tableName:         "suppliers",
+		deliveryTableName: "into_store",
+		db:                db,
+	}
+}
+
+func (s *supplierRepo) Create(ctx context.Context, supplier *entity.Supplier) (*entity.Supplier, error) {
+	clauses := map[string]interface{}{
+		"id":             supplier.ID,
+		"name":           supplier.Name,
+		"contact_person": supplier.ContactPerson,
+		"phone":          supplier.Phone,
+		"email":          supplier.Email,
+		"address":        supplier.Address,
+		"description":    supplier.Description,
+		"created_at":     supplier.CreatedAt,
+		"updated_at":     supplier.UpdatedAt,
+	}
+
+	queryBuilder := s.db.Sq.Builder.Insert(s.tableName)
+	queryBuilder = queryBuilder.SetMap(clauses)
+
+	query, args, err := queryBuilder.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := s.db.Exec(ctx, query, args...)
+	if err != nil {
+		return nil, s.db.Error(err, "supplier")
+	}
+	if result.RowsAffected() == 0 {
+		return nil, s.db.Error(pgx.ErrNoRows, "supplier")
+	}
+
+	return supplier, nil
+}
+
+func (s *supplierRepo) Update(ctx context.Context, supplier *entity.Supplier) (*entity.Supplier, error) {
+	clauses := map[string]interface{}{
+		"name":           supplier.Name,
+		"contact_person": supplier.ContactPerson,
+		"phone":          supplier.Phone,
+		"email":          supplier.Email,
+		"address":        supplier.Address,
+		"description":    supplier.Description,
+		"updated_at":     supplier.UpdatedAt,
+		"version":        s.db.Sq.Expr("version + 1"),
+	}
+
+	queryBuilder := s.db.Sq.Builder.Update(s.tableName)
+	queryBuilder = queryBuilder.SetMap(clauses)
+	queryBuilder = queryBuilder.Where("deleted_at IS NULL")
+	queryBuilder = queryBuilder.Where(s.db.Sq.Equal("id", supplier.ID))
+	if supplier.Version > 0 {
+		queryBuilder = queryBuilder.Where(s.db.Sq.Equal("version", supplier.Version))
+	}
+	queryBuilder = queryBuilder.Suffix("RETURNING version")
+
+	query, args, err := queryBuilder.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	var version int64
+	err = s.db.QueryRow(ctx, query, args...).Scan(&version)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, versionConflict(ctx, s.db, s.tableName, supplier.ID, supplier.Version, s.db.Error(pgx.ErrNoRows, "supplier"))
+	}
+	if err != nil {
+		return nil, s.db.Error(err, "supplier")
+	}
+	supplier.Version = version
+
+	return supplier, nil
+}
+
+func (s *supplierRepo) Delete(ctx context.Context, supplierID string, version int64) error {
+	query := `UPDATE suppliers SET deleted_at = $1 WHERE id = $2 AND deleted_at IS NULL AND ($3 = 0 OR version = $3)`
+
+	result, err := s.db.Exec(ctx, query, time.Now().Format(time.RFC3339), supplierID, version)
+	if err != nil {
+		return s.db.Error(err, "supplier")
+	}
+
+	if result.RowsAffected() == 0 {
+		return versionConflict(ctx, s.db, "suppliers", supplierID, version, s.db.Error(pgx.ErrNoRows, "supplier"))
+	}
+
+	return nil
+}
+
+func (s *supplierRepo) Get(ctx context.Context, supplierID string) (*entity.Supplier, error) {
+	queryBuilder := s.db.Sq.Builder.Select("id, name, contact_person, phone, email, address, description, version")
+	queryBuilder = queryBuilder.From(s.tableName)
+	queryBuilder = queryBuilder.Where("deleted_at IS NULL")
+	queryBuilder = queryBuilder.Where(s.db.Sq.Equal("id", supplierID))
+
+	query, args, err := queryBuilder.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		supplier          entity.Supplier
+		nullContactPerson sql.NullString
+		nullPhone         sql.NullString
+		nullEmail         sql.NullString
+		nullAddress       sql.NullString
+		nullDescription   sql.NullString
+	)
+	err = s.db.QueryRow(ctx, query, args...).Scan(
+		&supplier.ID,
+		&supplier.Name,
+		&nullContactPerson,
+		&nullPhone,
+		&nullEmail,
+		&nullAddress,
+		&nullDescription,
+		&supplier.Version,
+	)
+	if err != nil {
+		return nil, s.db.Error(err, "supplier")
+	}
+
+	supplier.ContactPerson = nullContactPerson.String
+	supplier.Phone = nullPhone.String
+	supplier.Email = nullEmail.String
+	supplier.Address = nullAddress.String
+	supplier.Description = nullDescription.String
+
+	return &supplier, nil
+}
+
+var supplierListSchema = listquery.Schema{
+	Fields: map[string]listquery.Field{
+		"name":           {Column: "name", Ops: listquery.TextOps, Sortable: true, Searchable: true},
+		"contact_person": {Column: "contact_person", Ops: listquery.TextOps, Sortable: true, Searchable: true},
+		"phone":          {Column: "phone", Ops: listquery.TextOps, Searchable: true},
+		"email":          {Column: "email", Ops: listquery.TextOps, Searchable: true},
+		"address":        {Column: "address", Ops: listquery.TextOps, Searchable: true},
+		"created_at":     {Column: "created_at", Type: listquery.Date, Ops: listquery.DateOps, Sortable: true},
+	},
+	DefaultOrder: []string{"name"},
+}
+
+func (s *supplierRepo) List(ctx context.Context, page, limit uint64, request listquery.Request) (*entity.ListSuppliers, error) {
+	where, orderBy, err := supplierListSchema.Build(s.db.Sq, request)
+	if err != nil {
+		return nil, err
+	}
+
+	queryBuilder := s.db.Sq.Builder.Select("id, name, contact_person, phone, email, address, description")
+	queryBuilder = queryBuilder.From(s.tableName)
+	queryBuilder = queryBuilder.Where("deleted_at IS NULL")
+	queryBuilder = queryBuilder.Where(where)
+	queryBuilder = queryBuilder.OrderBy(orderBy...)
+	queryBuilder = queryBuilder.Limit(limit)
+	queryBuilder = queryBuilder.Offset(limit * (page - 1))
+
+	query, args, err := queryBuilder.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := s.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var suppliers entity.ListSuppliers
+	for rows.Next() {
+		var (
+			supplier          entity.Supplier
+			nullContactPerson sql.NullString
+			nullPhone         sql.NullString
+			nullEmail         sql.NullString
+			nullAddress       sql.NullString
+			nullDescription   sql.NullString
+		)
+		err = rows.Scan(
+			&supplier.ID,
+			&supplier.Name,
+			&nullContactPerson,
+			&nullPhone,
+			&nullEmail,
+			&nullAddress,
+			&nullDescription,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		supplier.ContactPerson = nullContactPerson.String
+		supplier.Phone = nullPhone.String
+		supplier.Email = nullEmail.String
+		supplier.Address = nullAddress.String
+		supplier.Description = nullDescription.String
+
+		suppliers.Suppliers = append(suppliers.Suppliers, &supplier)
+	}
+
+	totalQueryBuilder := s.db.Sq.Builder.Select("COUNT(*)")
+	totalQueryBuilder = totalQueryBuilder.From(s.tableName)
+	totalQueryBuilder = totalQueryBuilder.Where("deleted_at IS NULL")
+	totalQueryBuilder = totalQueryBuilder.Where(where)
+
+	totalQuery, totalArgs, err := totalQueryBuilder.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	var count = 0
+	if err := s.db.QueryRow(ctx, totalQuery, totalArgs...).Scan(&count); err != nil {
+		return nil, err
+	}
+	suppliers.TotalCount = uint64(count)
+
+	return &suppliers, nil
+}
+
+// SpendReport sums delivery costs grouped by supplier, item (category and name) or month
+func (s *supplierRepo) SpendReport(ctx context.Context, groupBy string, params map[string]any) (*entity.SpendReport, error) {
+	var keyExpr, labelExpr, orderBy string
+	switch groupBy {
+	case "supplier":
+		keyExpr = "COALESCE(s.id::text, '')"
+		labelExpr = "COALESCE(s.name, 'unknown')"
+		orderBy = "total_cost DESC"
+	case "item":
+		keyExpr = "d.category || ':' || d.name"
+		labelExpr = "d.name"
+		orderBy = "total_cost DESC"
+	case "month":
+		keyExpr = "to_char(date_trunc('month', d.time), 'YYYY-MM')"
+		labelExpr = keyExpr
+		orderBy = "key"
+	default:
+		return nil, fmt.Errorf("unknown spend report grouping: %s", groupBy)
+	}
+
+	queryBuilder := s.db.Sq.Builder.Select(
+		keyExpr + " AS key, " +
+			labelExpr + " AS label, " +
+			"SUM(d.capacity), " +
+			"SUM(d.total_cost) AS total_cost, " +
+			"COUNT(d.id)")
+	queryBuilder = queryBuilder.From(s.deliveryTableName + " AS d")
+	queryBuilder = queryBuilder.LeftJoin(s.tableName + " AS s ON s.id = d.supplier_id")
+	queryBuilder = queryBuilder.Where("d.deleted_at IS NULL")
+	if cast.ToString(params["supplier_id"]) != "" {
+		queryBuilder = queryBuilder.Where(s.db.Sq.Equal("d.supplier_id", cast.ToString(params["supplier_id"])))
+	}
+	if cast.ToString(params["category"]) != "" {
+		queryBuilder = queryBuilder.Where(s.db.Sq.Equal("d.category", cast.ToString(params["category"])))
+	}
+	if cast.ToString(params["from"]) != "" {
+		queryBuilder = queryBuilder.Where(sq.GtOrEq{"d.time": cast.ToString(params["from"]) + " 00:00:00"})
+	}
+	if cast.ToString(params["to"]) != "" {
+		queryBuilder = queryBuilder.Where(sq.LtOrEq{"d.time": cast.ToString(params["to"]) + " 23:59:59"})
+	}
+	queryBuilder = queryBuilder.GroupBy("key", "label")
+	queryBuilder = queryBuilder.OrderBy(orderBy)
+
+	query, args, err := queryBuilder.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := s.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	report := entity.SpendReport{GroupBy: groupBy}
+	for rows.Next() {
+		var item entity.SpendReportItem
+		err = rows.Scan(
+			&item.Key,
+			&item.Label,
+			&item.Capacity,
+			&item.TotalCost,
+			&item.Deliveries,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		report.TotalCost += item.TotalCost
+		report.Items = append(report.Items, &item)
+	}
+
+	return &report, rows.Err()
+}
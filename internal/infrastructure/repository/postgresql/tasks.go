@@ -0,0 +1,243 @@
+package postgresql
+
+import (
+	"context"
+	"database/sql"
+	"musobaqa/farm-competition/internal/entity"
+	errorspkg "musobaqa/farm-competition/internal/errors"
+	"musobaqa/farm-competition/internal/infrastructure/repository/postgresql/repo"
+	"musobaqa/farm-competition/internal/pkg/postgres"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v4"
+	"github.com/spf13/cast"
+)
+
+type feedingTaskRepo struct {
+	tableName string
+	db        *postgres.PostgresDB
+}
+
+func NewFeedingTask(db *postgres.PostgresDB) repo.FeedingTask {
+	return &feedingTaskRepo{
+		tableName: "feeding_tasks",
+		db:        db,
+	}
+}
+
+// Generate inserts a pending task for every time slot in the daily plans of
+// the animals on the farm, the slots of the day that have a task already are
+// left alone, so it can run again for plans added during the day
+func (f *feedingTaskRepo) Generate(ctx context.Context, day string, now time.Time) (int64, error) {
+	query := `
+INSERT INTO feeding_tasks (id, animal_id, eatables_id, category, day, slot, capacity, created_at, updated_at)
+SELECT gen_random_uuid(), e.animal_id, e.eatables_id, e.category, $1::date, (d->>'time')::time, (d->>'capacity')::bigint, $2, $2
+FROM animal_eatable_info AS e
+JOIN animals AS a ON a.id = e.animal_id AND a.deleted_at IS NULL
+CROSS JOIN jsonb_array_elements(e.daily) AS d
+WHERE e.deleted_at IS NULL
+ON CONFLICT (animal_id, eatables_id, day, slot) WHERE deleted_at IS NULL DO NOTHING`
+
+	result, err := f.db.Exec(ctx, query, day, now)
+	if err != nil {
+		return 0, f.db.Error(err, "feeding task")
+	}
+
+	return result.RowsAffected(), nil
+}
+
+func (f *feedingTaskRepo) Get(ctx context.Context, taskID string) (*entity.FeedingTask, error) {
+	queryBuilder := f.selectBuilder()
+	queryBuilder = queryBuilder.Where(f.db.Sq.Equal("t.id", taskID))
+
+	query, args, err := queryBuilder.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	task, err := scanFeedingTask(f.db.QueryRow(ctx, query, args...))
+	if err != nil {
+		return nil, f.db.Error(err, "feeding task")
+	}
+
+	return task, nil
+}
+
+// List returns the tasks of a day in the order they are due, filtered by
+// animal, animal category, eatable category and status
+func (f *feedingTaskRepo) List(ctx context.Context, page, limit uint64, params map[string]any) (*entity.ListFeedingTasks, error) {
+	filter := sq.And{}
+	for _, field := range []struct{ param, column string }{
+		{"day", "t.day"},
+		{"status", "t.status"},
+		{"category", "t.category"},
+		{"animal_id", "t.animal_id"},
+		{"animal_category", "a.category_name"},
+	} {
+		if cast.ToString(params[field.param]) != "" {
+			filter = append(filter, f.db.Sq.Equal(field.column, cast.ToString(params[field.param])))
+		}
+	}
+
+	queryBuilder := f.selectBuilder()
+	queryBuilder = queryBuilder.Where(filter)
+	queryBuilder = queryBuilder.OrderBy("t.day", "t.slot", "a.name", "t.id")
+	queryBuilder = queryBuilder.Limit(limit)
+	queryBuilder = queryBuilder.Offset(limit * (page - 1))
+
+	query, args, err := queryBuilder.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := f.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, f.db.Error(err, "feeding task")
+	}
+	defer rows.Close()
+
+	var tasks entity.ListFeedingTasks
+	for rows.Next() {
+		task, err := scanFeedingTask(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		tasks.Tasks = append(tasks.Tasks, task)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	totalQueryBuilder := f.db.Sq.Builder.Select("COUNT(*)")
+	totalQueryBuilder = totalQueryBuilder.From(f.tableName + " AS t")
+	totalQueryBuilder = totalQueryBuilder.Join("animals AS a ON a.id = t.animal_id")
+	totalQueryBuilder = totalQueryBuilder.Where("t.deleted_at IS NULL")
+	totalQueryBuilder = totalQueryBuilder.Where(filter)
+
+	totalQuery, totalArgs, err := totalQueryBuilder.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	var count = 0
+	if err := f.db.QueryRow(ctx, totalQuery, totalArgs...).Scan(&count); err != nil {
+		return nil, f.db.Error(err, "feeding task")
+	}
+	tasks.TotalCount = uint64(count)
+
+	return &tasks, nil
+}
+
+// Close marks a pending task done or skipped. A task closed in the meantime
+// is ErrorTaskClosed, the version is checked when the task carries one
+func (f *feedingTaskRepo) Close(ctx context.Context, task *entity.FeedingTask) (*entity.FeedingTask, error) {
+	clauses := map[string]interface{}{
+		"status":            task.Status,
+		"actual_capacity":   task.ActualCapacity,
+		"skip_reason":       nullableString(task.SkipReason),
+		"given_eatables_id": nullableString(task.GivenEatablesID),
+		"completed_by":      nullableString(task.CompletedBy),
+		"completed_at":      task.CompletedAt,
+		"updated_at":        task.UpdatedAt,
+		"version":           f.db.Sq.Expr("version + 1"),
+	}
+	if task.Status == entity.FeedingTaskSkipped {
+		clauses["actual_capacity"] = nil
+	}
+
+	queryBuilder := f.db.Sq.Builder.Update(f.tableName)
+	queryBuilder = queryBuilder.SetMap(clauses)
+	queryBuilder = queryBuilder.Where("deleted_at IS NULL")
+	queryBuilder = queryBuilder.Where(f.db.Sq.Equal("id", task.ID))
+	queryBuilder = queryBuilder.Where(f.db.Sq.Equal("status", entity.FeedingTaskPending))
+	if task.Version > 0 {
+		queryBuilder = queryBuilder.Where(f.db.Sq.Equal("version", task.Version))
+	}
+
+	query, args, err := queryBuilder.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := f.db.Exec(ctx, query, args...)
+	if err != nil {
+		return nil, f.db.Error(err, "feeding task")
+	}
+
+	closed, err := f.Get(ctx, task.ID)
+	if err != nil {
+		return nil, err
+	}
+	if result.RowsAffected() == 0 {
+		if closed.Status != entity.FeedingTaskPending {
+			return nil, errorspkg.ErrorTaskClosed
+		}
+		return nil, versionConflict(ctx, f.db, f.tableName, task.ID, task.Version, f.db.Error(pgx.ErrNoRows, "feeding task"))
+	}
+
+	return closed, nil
+}
+
+// selectBuilder reads the tasks with the animal and the food or drug they
+// are for
+func (f *feedingTaskRepo) selectBuilder() sq.SelectBuilder {
+	queryBuilder := f.db.Sq.Builder.Select(
+		"t.id, t.animal_id, a.name, a.category_name, t.eatables_id, t.category",
+		"COALESCE(fd.name, dr.name, ''), COALESCE(fd.product_union, dr.product_union, '')",
+		"to_char(t.day, 'YYYY-MM-DD'), to_char(t.slot, 'HH24:MI'), t.capacity, t.status",
+		"t.actual_capacity, t.skip_reason, t.given_eatables_id, t.completed_by, t.completed_at",
+		"t.version, t.created_at, t.updated_at",
+	)
+	queryBuilder = queryBuilder.From(f.tableName + " AS t")
+	queryBuilder = queryBuilder.Join("animals AS a ON a.id = t.animal_id")
+	queryBuilder = queryBuilder.LeftJoin("foods AS fd ON t.category = 'food' AND fd.id = t.eatables_id")
+	queryBuilder = queryBuilder.LeftJoin("drugs AS dr ON t.category = 'drug' AND dr.id = t.eatables_id")
+	return queryBuilder.Where("t.deleted_at IS NULL")
+}
+
+func scanFeedingTask(row pgx.Row) (*entity.FeedingTask, error) {
+	var (
+		task            entity.FeedingTask
+		actualCapacity  sql.NullInt64
+		skipReason      sql.NullString
+		givenEatablesID sql.NullString
+		completedBy     sql.NullString
+		completedAt     sql.NullTime
+	)
+	err := row.Scan(
+		&task.ID,
+		&task.AnimalID,
+		&task.AnimalName,
+		&task.AnimalCategory,
+		&task.EatablesID,
+		&task.Category,
+		&task.Name,
+		&task.Union,
+		&task.Day,
+		&task.Slot,
+		&task.Capacity,
+		&task.Status,
+		&actualCapacity,
+		&skipReason,
+		&givenEatablesID,
+		&completedBy,
+		&completedAt,
+		&task.Version,
+		&task.CreatedAt,
+		&task.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	task.ActualCapacity = actualCapacity.Int64
+	task.SkipReason = skipReason.String
+	task.GivenEatablesID = givenEatablesID.String
+	task.CompletedBy = completedBy.String
+	if completedAt.Valid {
+		task.CompletedAt = &completedAt.Time
+	}
+
+	return &task, nil
+}
@@ -0,0 +1,99 @@
+package postgresql_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"musobaqa/farm-competition/internal/entity"
+	errorspkg "musobaqa/farm-competition/internal/errors"
+	"musobaqa/farm-competition/internal/infrastructure/repository/postgresql"
+	"musobaqa/farm-competition/internal/pkg/config"
+	"musobaqa/farm-competition/internal/pkg/postgres"
+)
+
+func TestFeedingTaskClose(t *testing.T) {
+	cfg, err := config.NewConfig()
+	require.NoError(t, err)
+
+	db, err := postgres.New(cfg)
+	if err != nil {
+		t.Skipf("postgres is not available: %v", err)
+	}
+	defer db.Close()
+
+	ctx := context.Background()
+	animal, err := postgresql.NewAnimal(db).Create(ctx, &entity.Animal{
+		ID:           uuid.New().String(),
+		Name:         "Test Animal",
+		CategoryName: "Test Category",
+		Gender:       "female",
+		BirthDay:     "2023-01-01",
+		Genus:        "Test Genus",
+		Weight:       100,
+		IsHealth:     "true",
+		CreatedAt:    time.Now().UTC(),
+		UpdatedAt:    time.Now().UTC(),
+	})
+	require.NoError(t, err)
+
+	taskIDs := []string{uuid.New().String(), uuid.New().String()}
+	for i, taskID := range taskIDs {
+		_, err := db.Exec(ctx, `INSERT INTO feeding_tasks (id, animal_id, eatables_id, category, day, slot, capacity) VALUES ($1, $2, $3, 'food', '2024-01-01', $4, 5)`,
+			taskID, animal.ID, uuid.New().String(), []string{"08:00", "18:00"}[i])
+		require.NoError(t, err)
+	}
+	defer func() {
+		_, _ = db.Exec(ctx, `DELETE FROM feeding_tasks WHERE animal_id = $1`, animal.ID)
+		_, _ = db.Exec(ctx, `DELETE FROM animals WHERE id = $1`, animal.ID)
+	}()
+
+	repo := postgresql.NewFeedingTask(db)
+	closeTask := func(taskID, status string, version int64) (*entity.FeedingTask, error) {
+		now := time.Now().UTC()
+		return repo.Close(ctx, &entity.FeedingTask{
+			ID:             taskID,
+			Status:         status,
+			ActualCapacity: 4,
+			SkipReason:     "animal is sick",
+			CompletedAt:    &now,
+			UpdatedAt:      now,
+			Version:        version,
+		})
+	}
+
+	// A stale version leaves the task pending
+	_, err = closeTask(taskIDs[0], entity.FeedingTaskDone, 2)
+	assert.ErrorIs(t, err, errorspkg.ErrorVersionConflict)
+	task, err := repo.Get(ctx, taskIDs[0])
+	require.NoError(t, err)
+	assert.Equal(t, entity.FeedingTaskPending, task.Status)
+	assert.Equal(t, int64(1), task.Version)
+
+	// The current version closes it and is bumped
+	task, err = closeTask(taskIDs[0], entity.FeedingTaskDone, 1)
+	require.NoError(t, err)
+	assert.Equal(t, entity.FeedingTaskDone, task.Status)
+	assert.Equal(t, int64(4), task.ActualCapacity)
+	assert.Equal(t, int64(2), task.Version)
+
+	// A closed task is ErrorTaskClosed, whatever the version
+	_, err = closeTask(taskIDs[0], entity.FeedingTaskSkipped, 2)
+	assert.ErrorIs(t, err, errorspkg.ErrorTaskClosed)
+	_, err = closeTask(taskIDs[0], entity.FeedingTaskSkipped, 0)
+	assert.ErrorIs(t, err, errorspkg.ErrorTaskClosed)
+
+	// Without a version the close is unconditional, a skip keeps no capacity
+	task, err = closeTask(taskIDs[1], entity.FeedingTaskSkipped, 0)
+	require.NoError(t, err)
+	assert.Equal(t, entity.FeedingTaskSkipped, task.Status)
+	assert.Equal(t, "animal is sick", task.SkipReason)
+	assert.Zero(t, task.ActualCapacity)
+
+	_, err = closeTask(uuid.New().String(), entity.FeedingTaskDone, 1)
+	assert.ErrorIs(t, err, errorspkg.ErrorNotFound)
+}
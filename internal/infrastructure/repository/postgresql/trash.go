@@ -0,0 +1,228 @@
+package postgresql
+
+import (
+	"context"
+	"fmt"
+	"musobaqa/farm-competition/internal/entity"
+	errorspkg "musobaqa/farm-competition/internal/errors"
+	"musobaqa/farm-competition/internal/infrastructure/repository/postgresql/repo"
+	"musobaqa/farm-competition/internal/pkg/postgres"
+	"time"
+
+	"github.com/jackc/pgx/v4"
+)
+
+// link describes rows of a table that point to an entity. Cascading links
+// are archived together with the entity, the rest is history that stays live
+type link struct {
+	table    string
+	column   string
+	parent   string
+	category string
+	cascade  bool
+}
+
+var links = []link{
+	{table: "animal_eatable_info", column: "animal_id", parent: "animal", cascade: true},
+	{table: "animal_eatable_info", column: "eatables_id", parent: "food", category: "food", cascade: true},
+	{table: "animal_eatable_info", column: "eatables_id", parent: "drug", category: "drug", cascade: true},
+	{table: "animal_given_eatables", column: "animal_id", parent: "animal"},
+	{table: "feeding_tasks", column: "animal_id", parent: "animal", cascade: true},
+	{table: "animal_products", column: "animal_id", parent: "animal"},
+	{table: "animal_products", column: "product_id", parent: "product"},
+	{table: "into_store", column: "supplier_id", parent: "supplier"},
+	{table: "stock_lots", column: "delivery_id", parent: "delivery"},
+	{table: "stock_lot_consumptions", column: "lot_id", parent: "stock_lot"},
+}
+
+// purgeOrder lists entity types children first, so purged children no longer
+// hold back their parents
+var purgeOrder = []string{
+	"feeding_task",
+	"given_eatable",
+	"eatable_info",
+	"animal_product",
+	"stock_lot",
+	"delivery",
+	"animal",
+	"product",
+	"food",
+	"drug",
+	"supplier",
+}
+
+func (l link) match(alias string) string {
+	condition := fmt.Sprintf("%s.%s = p.id", alias, l.column)
+	if l.category != "" {
+		condition += fmt.Sprintf(" AND %s.category = '%s'", alias, l.category)
+	}
+	return condition
+}
+
+// cascadeDelete archives the rows that only live through the deleted entity,
+// they take over its deleted_at so a restore brings them back together
+func cascadeDelete(ctx context.Context, db *postgres.PostgresDB, entityType, entityID string) error {
+	for _, l := range links {
+		if l.parent != entityType || !l.cascade {
+			continue
+		}
+
+		query := fmt.Sprintf(
+			"UPDATE %s AS c SET deleted_at = p.deleted_at FROM %s AS p WHERE p.id = $1 AND %s AND c.deleted_at IS NULL",
+			l.table, entityTables[l.parent], l.match("c"))
+		if _, err := db.Exec(ctx, query, entityID); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+type trashRepo struct {
+	db *postgres.PostgresDB
+}
+
+func NewTrash(db *postgres.PostgresDB) repo.Trash {
+	return &trashRepo{
+		db: db,
+	}
+}
+
+// List returns the deleted rows of the entity type, like Snapshot it leaves
+// webhook secrets out
+func (t *trashRepo) List(ctx context.Context, entityType string, page, limit uint64) (*entity.ListTrash, error) {
+	table, ok := entityTables[entityType]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", errorspkg.ErrorUnknownEntity, entityType)
+	}
+
+	queryBuilder := t.db.Sq.Builder.Select("t.id::text, t.deleted_at, row_to_json(t)::jsonb - 'search_vector' - 'secret'")
+	queryBuilder = queryBuilder.From(table + " AS t")
+	queryBuilder = queryBuilder.Where("t.deleted_at IS NOT NULL")
+	queryBuilder = queryBuilder.OrderBy("t.deleted_at DESC")
+	queryBuilder = queryBuilder.Limit(limit)
+	queryBuilder = queryBuilder.Offset(limit * (page - 1))
+
+	query, args, err := queryBuilder.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := t.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var trash entity.ListTrash
+	for rows.Next() {
+		item := entity.TrashItem{EntityType: entityType}
+		err = rows.Scan(
+			&item.ID,
+			&item.DeletedAt,
+			&item.Data,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		trash.Items = append(trash.Items, &item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	var count = 0
+	if err := t.db.QueryRow(ctx, "SELECT COUNT(*) FROM "+table+" WHERE deleted_at IS NOT NULL").Scan(&count); err != nil {
+		return nil, err
+	}
+	trash.TotalCount = uint64(count)
+
+	return &trash, nil
+}
+
+// Restore brings back a deleted entity together with the rows archived by its
+// cascade. It is refused while an entity it points to is still deleted
+func (t *trashRepo) Restore(ctx context.Context, entityType, entityID string) error {
+	table, ok := entityTables[entityType]
+	if !ok {
+		return fmt.Errorf("%w: %s", errorspkg.ErrorUnknownEntity, entityType)
+	}
+
+	return t.db.WithTx(ctx, func(ctx context.Context) error {
+		var deletedAt time.Time
+		err := t.db.QueryRow(ctx,
+			"SELECT deleted_at FROM "+table+" WHERE id::text = $1 AND deleted_at IS NOT NULL FOR UPDATE",
+			entityID).Scan(&deletedAt)
+		if err != nil {
+			return t.db.Error(err, entityType)
+		}
+
+		for _, l := range links {
+			if l.table != table {
+				continue
+			}
+
+			var parentDeleted bool
+			query := fmt.Sprintf(
+				"SELECT EXISTS (SELECT 1 FROM %s AS c JOIN %s AS p ON %s WHERE c.id::text = $1 AND p.deleted_at IS NOT NULL)",
+				l.table, entityTables[l.parent], l.match("c"))
+			if err := t.db.QueryRow(ctx, query, entityID).Scan(&parentDeleted); err != nil {
+				return err
+			}
+			if parentDeleted {
+				return errorspkg.ErrorParentDeleted
+			}
+		}
+
+		for _, l := range links {
+			if l.parent != entityType || !l.cascade {
+				continue
+			}
+
+			query := fmt.Sprintf(
+				"UPDATE %s AS c SET deleted_at = NULL FROM %s AS p WHERE p.id::text = $1 AND %s AND c.deleted_at = p.deleted_at",
+				l.table, table, l.match("c"))
+			if _, err := t.db.Exec(ctx, query, entityID); err != nil {
+				return err
+			}
+		}
+
+		result, err := t.db.Exec(ctx,
+			"UPDATE "+table+" SET deleted_at = NULL, updated_at = $1, version = version + 1 WHERE id::text = $2",
+			time.Now().UTC(), entityID)
+		if err != nil {
+			return err
+		}
+		if result.RowsAffected() == 0 {
+			return t.db.Error(pgx.ErrNoRows, entityType)
+		}
+
+		return nil
+	})
+}
+
+// Purge removes rows deleted before the given time for good. Rows that are
+// still referenced are kept until their references are purged as well
+func (t *trashRepo) Purge(ctx context.Context, before time.Time) (map[string]int64, error) {
+	purged := map[string]int64{}
+	for _, entityType := range purgeOrder {
+		table := entityTables[entityType]
+
+		query := "DELETE FROM " + table + " AS p WHERE p.deleted_at < $1"
+		for _, l := range links {
+			if l.parent != entityType {
+				continue
+			}
+			query += fmt.Sprintf(" AND NOT EXISTS (SELECT 1 FROM %s AS c WHERE %s)", l.table, l.match("c"))
+		}
+
+		result, err := t.db.Exec(ctx, query, before)
+		if err != nil {
+			return nil, err
+		}
+		purged[entityType] = result.RowsAffected()
+	}
+
+	return purged, nil
+}
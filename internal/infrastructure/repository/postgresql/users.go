@@ -0,0 +1,180 @@
+package postgresql
+
+import (
+	"context"
+	"musobaqa/farm-competition/internal/entity"
+	"musobaqa/farm-competition/internal/infrastructure/repository/postgresql/repo"
+	"musobaqa/farm-competition/internal/pkg/postgres"
+	"time"
+
+	"github.com/jackc/pgx/v4"
+)
+
+const userColumns = "id, email, full_name, password_hash, role, version, created_at, updated_at"
+
+type userRepo struct {
+	tableName string
+	db        *postgres.PostgresDB
+}
+
+func NewUser(db *postgres.PostgresDB) repo.User {
+	return &userRepo{
+		tableName: "users",
+		db:        db,
+	}
+}
+
+func scanUser(row pgx.Row) (*entity.User, error) {
+	var user entity.User
+	err := row.Scan(
+		&user.ID,
+		&user.Email,
+		&user.FullName,
+		&user.PasswordHash,
+		&user.Role,
+		&user.Version,
+		&user.CreatedAt,
+		&user.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &user, nil
+}
+
+func (u *userRepo) Create(ctx context.Context, user *entity.User) (*entity.User, error) {
+	clauses := map[string]interface{}{
+		"id":            user.ID,
+		"email":         user.Email,
+		"full_name":     user.FullName,
+		"password_hash": user.PasswordHash,
+		"role":          user.Role,
+		"created_at":    user.CreatedAt,
+		"updated_at":    user.UpdatedAt,
+	}
+
+	queryBuilder := u.db.Sq.Builder.Insert(u.tableName)
+	queryBuilder = queryBuilder.SetMap(clauses)
+
+	query, args, err := queryBuilder.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := u.db.Exec(ctx, query, args...); err != nil {
+		return nil, u.db.Error(err, "user")
+	}
+	user.Version = 1
+
+	return user, nil
+}
+
+func (u *userRepo) GetByEmail(ctx context.Context, email string) (*entity.User, error) {
+	queryBuilder := u.db.Sq.Builder.Select(userColumns)
+	queryBuilder = queryBuilder.From(u.tableName)
+	queryBuilder = queryBuilder.Where("deleted_at IS NULL")
+	queryBuilder = queryBuilder.Where("lower(email) = lower(?)", email)
+
+	query, args, err := queryBuilder.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := scanUser(u.db.QueryRow(ctx, query, args...))
+	if err != nil {
+		return nil, u.db.Error(err, "user")
+	}
+
+	return user, nil
+}
+
+func (u *userRepo) List(ctx context.Context, page, limit uint64, role string) (*entity.ListUsers, error) {
+	queryBuilder := u.db.Sq.Builder.Select(userColumns)
+	queryBuilder = queryBuilder.From(u.tableName)
+	queryBuilder = queryBuilder.Where("deleted_at IS NULL")
+	if role != "" {
+		queryBuilder = queryBuilder.Where(u.db.Sq.Equal("role", role))
+	}
+	queryBuilder = queryBuilder.OrderBy("email")
+	queryBuilder = queryBuilder.Limit(limit)
+	queryBuilder = queryBuilder.Offset(limit * (page - 1))
+
+	query, args, err := queryBuilder.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := u.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var users entity.ListUsers
+	for rows.Next() {
+		user, err := scanUser(rows)
+		if err != nil {
+			return nil, err
+		}
+		users.Users = append(users.Users, user)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	totalQueryBuilder := u.db.Sq.Builder.Select("COUNT(*)")
+	totalQueryBuilder = totalQueryBuilder.From(u.tableName)
+	totalQueryBuilder = totalQueryBuilder.Where("deleted_at IS NULL")
+	if role != "" {
+		totalQueryBuilder = totalQueryBuilder.Where(u.db.Sq.Equal("role", role))
+	}
+
+	totalQuery, totalArgs, err := totalQueryBuilder.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := u.db.QueryRow(ctx, totalQuery, totalArgs...).Scan(&users.TotalCount); err != nil {
+		return nil, err
+	}
+
+	return &users, nil
+}
+
+func (u *userRepo) SetRole(ctx context.Context, email, role string) (*entity.User, error) {
+	queryBuilder := u.db.Sq.Builder.Update(u.tableName)
+	queryBuilder = queryBuilder.Set("role", role)
+	queryBuilder = queryBuilder.Set("updated_at", time.Now().UTC())
+	queryBuilder = queryBuilder.Set("version", u.db.Sq.Expr("version + 1"))
+	queryBuilder = queryBuilder.Where("deleted_at IS NULL")
+	queryBuilder = queryBuilder.Where("lower(email) = lower(?)", email)
+	queryBuilder = queryBuilder.Suffix("RETURNING " + userColumns)
+
+	query, args, err := queryBuilder.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := scanUser(u.db.QueryRow(ctx, query, args...))
+	if err != nil {
+		return nil, u.db.Error(err, "user")
+	}
+
+	return user, nil
+}
+
+func (u *userRepo) Delete(ctx context.Context, email string) error {
+	query := `UPDATE users SET deleted_at = $1 WHERE lower(email) = lower($2) AND deleted_at IS NULL`
+
+	result, err := u.db.Exec(ctx, query, time.Now().Format(time.RFC3339), email)
+	if err != nil {
+		return u.db.Error(err, "user")
+	}
+
+	if result.RowsAffected() == 0 {
+		return u.db.Error(pgx.ErrNoRows, "user")
+	}
+
+	return nil
+}
@@ -0,0 +1,54 @@
+package postgresql
+
+import (
+	"context"
+	"fmt"
+	errorspkg "musobaqa/farm-competition/internal/errors"
+	"musobaqa/farm-competition/internal/infrastructure/repository/postgresql/repo"
+	"musobaqa/farm-competition/internal/pkg/postgres"
+)
+
+// versionConflict is called when an update guarded by the version touched
+// no row, it tells a stale version apart from a missing row
+func versionConflict(ctx context.Context, db *postgres.PostgresDB, table, id string, version int64, notFound error) error {
+	if version == 0 {
+		return notFound
+	}
+
+	var exists bool
+	err := db.QueryRow(ctx, "SELECT EXISTS (SELECT 1 FROM "+table+" WHERE id::text = $1 AND deleted_at IS NULL)", id).Scan(&exists)
+	if err != nil {
+		return err
+	}
+	if exists {
+		return errorspkg.ErrorVersionConflict
+	}
+
+	return notFound
+}
+
+type versionRepo struct {
+	db *postgres.PostgresDB
+}
+
+func NewVersion(db *postgres.PostgresDB) repo.Version {
+	return &versionRepo{
+		db: db,
+	}
+}
+
+// Current returns the version of a live entity
+func (v *versionRepo) Current(ctx context.Context, entityType, entityID string) (int64, error) {
+	table, ok := entityTables[entityType]
+	if !ok {
+		return 0, fmt.Errorf("%w: %s", errorspkg.ErrorUnknownEntity, entityType)
+	}
+
+	var version int64
+	err := v.db.QueryRow(ctx, "SELECT version FROM "+table+" WHERE id::text = $1 AND deleted_at IS NULL", entityID).Scan(&version)
+	if err != nil {
+		return 0, v.db.Error(err, entityType)
+	}
+
+	return version, nil
+}
@@ -0,0 +1,447 @@
+package postgresql
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"musobaqa/farm-competition/internal/entity"
+	"musobaqa/farm-competition/internal/infrastructure/repository/postgresql/repo"
+	"musobaqa/farm-competition/internal/pkg/postgres"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v4"
+	"github.com/spf13/cast"
+)
+
+type webhookRepo struct {
+	tableName         string
+	deliveryTableName string
+	db                *postgres.PostgresDB
+}
+
+func NewWebhook(db *postgres.PostgresDB) repo.Webhook {
+	return &webhookRepo{
+		tableName:         "webhook_subscriptions",
+		deliveryTableName: "webhook_deliveries",
+		db:                db,
+	}
+}
+
+func (w *webhookRepo) Create(ctx context.Context, subscription *entity.WebhookSubscription) (*entity.WebhookSubscription, error) {
+	clauses := map[string]interface{}{
+		"id":          subscription.ID,
+		"url":         subscription.URL,
+		"secret":      subscription.Secret,
+		"events":      subscription.Events,
+		"active":      subscription.Active,
+		"description": subscription.Description,
+		"created_at":  subscription.CreatedAt,
+		"updated_at":  subscription.UpdatedAt,
+	}
+
+	queryBuilder := w.db.Sq.Builder.Insert(w.tableName)
+	queryBuilder = queryBuilder.SetMap(clauses)
+
+	query, args, err := queryBuilder.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := w.db.Exec(ctx, query, args...)
+	if err != nil {
+		return nil, w.db.Error(err, "webhook")
+	}
+	if result.RowsAffected() == 0 {
+		return nil, w.db.Error(pgx.ErrNoRows, "webhook")
+	}
+
+	subscription.Version = 1
+	return subscription, nil
+}
+
+// Update keeps the secret when the subscription comes without one
+func (w *webhookRepo) Update(ctx context.Context, subscription *entity.WebhookSubscription) (*entity.WebhookSubscription, error) {
+	clauses := map[string]interface{}{
+		"url":         subscription.URL,
+		"events":      subscription.Events,
+		"active":      subscription.Active,
+		"description": subscription.Description,
+		"updated_at":  subscription.UpdatedAt,
+		"version":     w.db.Sq.Expr("version + 1"),
+	}
+	if subscription.Secret != "" {
+		clauses["secret"] = subscription.Secret
+	}
+
+	queryBuilder := w.db.Sq.Builder.Update(w.tableName)
+	queryBuilder = queryBuilder.SetMap(clauses)
+	queryBuilder = queryBuilder.Where("deleted_at IS NULL")
+	queryBuilder = queryBuilder.Where(w.db.Sq.Equal("id", subscription.ID))
+	if subscription.Version > 0 {
+		queryBuilder = queryBuilder.Where(w.db.Sq.Equal("version", subscription.Version))
+	}
+	queryBuilder = queryBuilder.Suffix("RETURNING version, created_at")
+
+	query, args, err := queryBuilder.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	err = w.db.QueryRow(ctx, query, args...).Scan(&subscription.Version, &subscription.CreatedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, versionConflict(ctx, w.db, w.tableName, subscription.ID, subscription.Version, w.db.Error(pgx.ErrNoRows, "webhook"))
+	}
+	if err != nil {
+		return nil, w.db.Error(err, "webhook")
+	}
+
+	return subscription, nil
+}
+
+// Delete removes the subscription, its pending deliveries are not sent any more
+func (w *webhookRepo) Delete(ctx context.Context, subscriptionID string, version int64) error {
+	query := `UPDATE webhook_subscriptions SET deleted_at = $1 WHERE id = $2 AND deleted_at IS NULL AND ($3 = 0 OR version = $3)`
+
+	result, err := w.db.Exec(ctx, query, time.Now().Format(time.RFC3339), subscriptionID, version)
+	if err != nil {
+		return w.db.Error(err, "webhook")
+	}
+
+	if result.RowsAffected() == 0 {
+		return versionConflict(ctx, w.db, "webhook_subscriptions", subscriptionID, version, w.db.Error(pgx.ErrNoRows, "webhook"))
+	}
+
+	return nil
+}
+
+func (w *webhookRepo) Get(ctx context.Context, subscriptionID string) (*entity.WebhookSubscription, error) {
+	queryBuilder := w.db.Sq.Builder.Select("id, url, secret, events, active, description, version, created_at, updated_at")
+	queryBuilder = queryBuilder.From(w.tableName)
+	queryBuilder = queryBuilder.Where("deleted_at IS NULL")
+	queryBuilder = queryBuilder.Where(w.db.Sq.Equal("id", subscriptionID))
+
+	query, args, err := queryBuilder.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	subscription, err := scanWebhook(w.db.QueryRow(ctx, query, args...))
+	if err != nil {
+		return nil, w.db.Error(err, "webhook")
+	}
+
+	return subscription, nil
+}
+
+func (w *webhookRepo) List(ctx context.Context, page, limit uint64, params map[string]any) (*entity.ListWebhookSubscriptions, error) {
+	filter := sq.And{sq.Expr("deleted_at IS NULL")}
+	if cast.ToString(params["event"]) != "" {
+		filter = append(filter, sq.Expr("? = ANY(events)", cast.ToString(params["event"])))
+	}
+	if cast.ToString(params["active"]) != "" {
+		filter = append(filter, w.db.Sq.Equal("active", cast.ToBool(params["active"])))
+	}
+
+	queryBuilder := w.db.Sq.Builder.Select("id, url, secret, events, active, description, version, created_at, updated_at")
+	queryBuilder = queryBuilder.From(w.tableName)
+	queryBuilder = queryBuilder.Where(filter)
+	queryBuilder = queryBuilder.OrderBy("created_at DESC")
+	queryBuilder = queryBuilder.Limit(limit)
+	queryBuilder = queryBuilder.Offset(limit * (page - 1))
+
+	query, args, err := queryBuilder.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := w.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var subscriptions entity.ListWebhookSubscriptions
+	for rows.Next() {
+		subscription, err := scanWebhook(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		subscriptions.Subscriptions = append(subscriptions.Subscriptions, subscription)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	totalQueryBuilder := w.db.Sq.Builder.Select("COUNT(*)")
+	totalQueryBuilder = totalQueryBuilder.From(w.tableName)
+	totalQueryBuilder = totalQueryBuilder.Where(filter)
+
+	totalQuery, totalArgs, err := totalQueryBuilder.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	var count = 0
+	if err := w.db.QueryRow(ctx, totalQuery, totalArgs...).Scan(&count); err != nil {
+		return nil, err
+	}
+	subscriptions.TotalCount = uint64(count)
+
+	return &subscriptions, nil
+}
+
+// Enqueue adds a pending delivery of the event for every active
+// subscription to its type, a subscription that already has the key of the
+// event is skipped. It returns the number of deliveries added
+func (w *webhookRepo) Enqueue(ctx context.Context, event *entity.WebhookEvent) (int64, error) {
+	query := `
+		INSERT INTO webhook_deliveries (id, subscription_id, event, event_key, payload, next_attempt_at, created_at)
+		SELECT gen_random_uuid(), s.id, $1, $2, $3, $4, $4
+		FROM webhook_subscriptions AS s
+		WHERE s.deleted_at IS NULL AND s.active AND $1 = ANY(s.events)
+		ON CONFLICT (subscription_id, event_key) DO NOTHING`
+
+	result, err := w.db.Exec(ctx, query, event.Type, event.Key, string(event.Payload), event.OccurredAt)
+	if err != nil {
+		return 0, err
+	}
+
+	return result.RowsAffected(), nil
+}
+
+// Claim takes up to limit due deliveries of live subscriptions and moves
+// their next attempt a lease away, so other replicas leave them alone while
+// they are being sent
+func (w *webhookRepo) Claim(ctx context.Context, limit uint64, lease time.Duration) ([]*entity.WebhookDelivery, error) {
+	query := `
+		WITH due AS (
+			SELECT d.id
+			FROM webhook_deliveries AS d
+			JOIN webhook_subscriptions AS s ON s.id = d.subscription_id
+			WHERE d.status = 'pending' AND d.next_attempt_at <= $1
+				AND s.deleted_at IS NULL AND s.active
+			ORDER BY d.next_attempt_at
+			LIMIT $2
+			FOR UPDATE OF d SKIP LOCKED
+		)
+		UPDATE webhook_deliveries AS d
+		SET next_attempt_at = $3
+		FROM due, webhook_subscriptions AS s
+		WHERE d.id = due.id AND s.id = d.subscription_id
+		RETURNING d.id, d.subscription_id, d.event, d.event_key, d.payload, d.attempts, d.created_at, s.url, s.secret`
+
+	now := time.Now().UTC()
+	rows, err := w.db.Query(ctx, query, now, limit, now.Add(lease))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var deliveries []*entity.WebhookDelivery
+	for rows.Next() {
+		delivery := entity.WebhookDelivery{Status: entity.WebhookDeliveryPending}
+		err = rows.Scan(
+			&delivery.ID,
+			&delivery.SubscriptionID,
+			&delivery.Event,
+			&delivery.EventKey,
+			&delivery.Payload,
+			&delivery.Attempts,
+			&delivery.CreatedAt,
+			&delivery.URL,
+			&delivery.Secret,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		deliveries = append(deliveries, &delivery)
+	}
+
+	return deliveries, rows.Err()
+}
+
+// SaveAttempt stores the outcome of sending the delivery
+func (w *webhookRepo) SaveAttempt(ctx context.Context, delivery *entity.WebhookDelivery) error {
+	clauses := map[string]interface{}{
+		"status":          delivery.Status,
+		"attempts":        delivery.Attempts,
+		"next_attempt_at": delivery.NextAttemptAt,
+		"response_status": nullableInt(delivery.ResponseStatus),
+		"last_error":      nullableString(delivery.LastError),
+		"delivered_at":    delivery.DeliveredAt,
+	}
+
+	queryBuilder := w.db.Sq.Builder.Update(w.deliveryTableName)
+	queryBuilder = queryBuilder.SetMap(clauses)
+	queryBuilder = queryBuilder.Where(w.db.Sq.Equal("id", delivery.ID))
+
+	query, args, err := queryBuilder.ToSql()
+	if err != nil {
+		return err
+	}
+
+	result, err := w.db.Exec(ctx, query, args...)
+	if err != nil {
+		return w.db.Error(err, "webhook delivery")
+	}
+	if result.RowsAffected() == 0 {
+		return w.db.Error(pgx.ErrNoRows, "webhook delivery")
+	}
+
+	return nil
+}
+
+func (w *webhookRepo) ListDeliveries(ctx context.Context, page, limit uint64, params map[string]any) (*entity.ListWebhookDeliveries, error) {
+	filter := sq.And{}
+	for _, column := range []string{"subscription_id", "event", "status"} {
+		if cast.ToString(params[column]) != "" {
+			filter = append(filter, w.db.Sq.Equal(column, cast.ToString(params[column])))
+		}
+	}
+
+	queryBuilder := w.db.Sq.Builder.Select(webhookDeliveryColumns)
+	queryBuilder = queryBuilder.From(w.deliveryTableName)
+	queryBuilder = queryBuilder.Where(filter)
+	queryBuilder = queryBuilder.OrderBy("created_at DESC")
+	queryBuilder = queryBuilder.Limit(limit)
+	queryBuilder = queryBuilder.Offset(limit * (page - 1))
+
+	query, args, err := queryBuilder.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := w.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, w.db.Error(err, "webhook delivery")
+	}
+	defer rows.Close()
+
+	var deliveries entity.ListWebhookDeliveries
+	for rows.Next() {
+		delivery, err := scanWebhookDelivery(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		deliveries.Deliveries = append(deliveries.Deliveries, delivery)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	totalQueryBuilder := w.db.Sq.Builder.Select("COUNT(*)")
+	totalQueryBuilder = totalQueryBuilder.From(w.deliveryTableName)
+	totalQueryBuilder = totalQueryBuilder.Where(filter)
+
+	totalQuery, totalArgs, err := totalQueryBuilder.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	var count = 0
+	if err := w.db.QueryRow(ctx, totalQuery, totalArgs...).Scan(&count); err != nil {
+		return nil, w.db.Error(err, "webhook delivery")
+	}
+	deliveries.TotalCount = uint64(count)
+
+	return &deliveries, nil
+}
+
+// Redeliver puts the delivery back in the queue with a fresh set of
+// attempts, whatever came of the earlier ones
+func (w *webhookRepo) Redeliver(ctx context.Context, deliveryID string) (*entity.WebhookDelivery, error) {
+	query := `
+		UPDATE webhook_deliveries
+		SET status = 'pending', attempts = 0, next_attempt_at = $1, delivered_at = NULL
+		WHERE id = $2
+		RETURNING ` + webhookDeliveryColumns
+
+	delivery, err := scanWebhookDelivery(w.db.QueryRow(ctx, query, time.Now().UTC(), deliveryID))
+	if err != nil {
+		return nil, w.db.Error(err, "webhook delivery")
+	}
+
+	return delivery, nil
+}
+
+// PurgeDeliveries removes the deliveries created before the given time that
+// were delivered or given up on, pending ones are kept
+func (w *webhookRepo) PurgeDeliveries(ctx context.Context, before time.Time) (int64, error) {
+	result, err := w.db.Exec(ctx, `DELETE FROM webhook_deliveries WHERE created_at < $1 AND status <> 'pending'`, before)
+	if err != nil {
+		return 0, w.db.Error(err, "webhook delivery")
+	}
+
+	return result.RowsAffected(), nil
+}
+
+const webhookDeliveryColumns = "id, subscription_id, event, event_key, payload, status, attempts, next_attempt_at, response_status, last_error, delivered_at, created_at"
+
+func scanWebhook(row pgx.Row) (*entity.WebhookSubscription, error) {
+	var (
+		subscription    entity.WebhookSubscription
+		nullDescription sql.NullString
+	)
+	err := row.Scan(
+		&subscription.ID,
+		&subscription.URL,
+		&subscription.Secret,
+		&subscription.Events,
+		&subscription.Active,
+		&nullDescription,
+		&subscription.Version,
+		&subscription.CreatedAt,
+		&subscription.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	subscription.Description = nullDescription.String
+
+	return &subscription, nil
+}
+
+func scanWebhookDelivery(row pgx.Row) (*entity.WebhookDelivery, error) {
+	var (
+		delivery           entity.WebhookDelivery
+		nullResponseStatus sql.NullInt32
+		nullLastError      sql.NullString
+		nullDeliveredAt    sql.NullTime
+	)
+	err := row.Scan(
+		&delivery.ID,
+		&delivery.SubscriptionID,
+		&delivery.Event,
+		&delivery.EventKey,
+		&delivery.Payload,
+		&delivery.Status,
+		&delivery.Attempts,
+		&delivery.NextAttemptAt,
+		&nullResponseStatus,
+		&nullLastError,
+		&nullDeliveredAt,
+		&delivery.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	delivery.ResponseStatus = int(nullResponseStatus.Int32)
+	delivery.LastError = nullLastError.String
+	if nullDeliveredAt.Valid {
+		delivery.DeliveredAt = &nullDeliveredAt.Time
+	}
+
+	return &delivery, nil
+}
+
+// nullableInt stores zero as SQL NULL
+func nullableInt(value int) interface{} {
+	if value == 0 {
+		return nil
+	}
+	return value
+}
@@ -15,6 +15,7 @@ type Cache interface {
 	Set(ctx context.Context, key string, value interface{}, expiration time.Duration) error
 	Get(ctx context.Context, key string) ([]byte, error)
 	Del(ctx context.Context, key string) error
+	Incr(ctx context.Context, key string) (int64, error)
 }
 
 func NewCache(rdb *redis.RedisDB) *cache {
@@ -71,3 +72,7 @@ func (c *cache) Del(ctx context.Context, key string) error {
 
 	return nil
 }
+
+func (c *cache) Incr(ctx context.Context, key string) (int64, error) {
+	return c.rdb.Client.Incr(ctx, key).Result()
+}
@@ -0,0 +1,94 @@
+package redis
+
+import (
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+
+	goredis "github.com/go-redis/redis/v8"
+
+	"musobaqa/farm-competition/internal/pkg/config"
+	"musobaqa/farm-competition/internal/pkg/metrics"
+)
+
+// Namespaces keeps the cached reads of every entity type under a generation
+// number. A write bumps the generation, which drops all keys of the entity at
+// once, single entities and lists alike, and leaves the old keys to expire.
+// A read racing with a write can only fill the generation it started with
+type Namespaces struct {
+	cache Cache
+	live  *config.Live
+}
+
+func NewNamespaces(cache Cache, live *config.Live) *Namespaces {
+	return &Namespaces{
+		cache: cache,
+		live:  live,
+	}
+}
+
+// Invalidate drops the cached reads of the entity types. When Redis cannot be
+// reached the keys run out with their TTL
+func (n *Namespaces) Invalidate(ctx context.Context, entityTypes ...string) {
+	for _, entityType := range entityTypes {
+		_, _ = n.cache.Incr(ctx, generationKey(entityType))
+	}
+}
+
+func (n *Namespaces) generation(ctx context.Context, entityType string) (string, error) {
+	data, err := n.cache.Get(ctx, generationKey(entityType))
+	if errors.Is(err, goredis.Nil) {
+		return "0", nil
+	}
+	if err != nil {
+		return "", err
+	}
+
+	return string(data), nil
+}
+
+func generationKey(entityType string) string {
+	return "cache:" + entityType + ":generation"
+}
+
+// Load returns the cached result of a read or runs it and caches the result.
+// Failed reads are not cached and Redis errors never fail the read, it then
+// goes to the database. Entity types without a TTL are not cached
+func Load[T any](ctx context.Context, n *Namespaces, entityType, key string, load func(context.Context) (T, error)) (T, error) {
+	ttl := n.live.Get().Cache.TTL[entityType]
+	if ttl <= 0 {
+		return load(ctx)
+	}
+
+	generation, err := n.generation(ctx, entityType)
+	if err != nil {
+		return load(ctx)
+	}
+	cacheKey := "cache:" + entityType + ":" + generation + ":" + key
+
+	if data, err := n.cache.Get(ctx, cacheKey); err == nil {
+		var cached T
+		if err := json.Unmarshal(data, &cached); err == nil {
+			metrics.ObserveCache(entityType, true)
+			return cached, nil
+		}
+	}
+	metrics.ObserveCache(entityType, false)
+
+	value, err := load(ctx)
+	if err != nil {
+		return value, err
+	}
+	_ = n.cache.Set(ctx, cacheKey, value, ttl)
+
+	return value, nil
+}
+
+// Key names a read by its method and arguments
+func Key(method string, args ...interface{}) string {
+	data, _ := json.Marshal(args)
+	sum := sha1.Sum(data)
+	return method + ":" + hex.EncodeToString(sum[:])
+}
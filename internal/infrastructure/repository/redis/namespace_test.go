@@ -0,0 +1,86 @@
+package redis_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	goredis "github.com/go-redis/redis/v8"
+	"github.com/stretchr/testify/assert"
+
+	redisrepo "musobaqa/farm-competition/internal/infrastructure/repository/redis"
+	"musobaqa/farm-competition/internal/pkg/config"
+	"musobaqa/farm-competition/internal/pkg/redis"
+)
+
+func TestNamespaces(t *testing.T) {
+	server := miniredis.RunT(t)
+	client := goredis.NewClient(&goredis.Options{Addr: server.Addr()})
+	defer client.Close()
+
+	cfg := &config.Config{}
+	cfg.Cache.TTL = map[string]time.Duration{"animal": time.Minute, "food": time.Minute}
+	namespaces := redisrepo.NewNamespaces(redisrepo.NewCache(&redis.RedisDB{Client: *client}), config.NewLive(cfg))
+
+	ctx := context.Background()
+	loads := 0
+	load := func(entityType, key string) string {
+		value, err := redisrepo.Load(ctx, namespaces, entityType, key, func(context.Context) (string, error) {
+			loads++
+			return entityType + " read " + key, nil
+		})
+		assert.NoError(t, err)
+		return value
+	}
+
+	// The first read is loaded and cached for the ttl of its entity type
+	assert.Equal(t, "animal read bella", load("animal", "bella"))
+	assert.Equal(t, "animal read bella", load("animal", "bella"))
+	assert.Equal(t, 1, loads)
+	assert.Equal(t, time.Minute, server.TTL("cache:animal:0:bella"))
+
+	// A write bumps the generation, the next read is loaded again and the
+	// old key is left to expire
+	load("food", "hay")
+	namespaces.Invalidate(ctx, "animal")
+	generation, err := server.Get("cache:animal:generation")
+	assert.NoError(t, err)
+	assert.Equal(t, "1", generation)
+	assert.Equal(t, "animal read bella", load("animal", "bella"))
+	assert.Equal(t, 3, loads)
+	assert.True(t, server.Exists("cache:animal:0:bella"))
+	assert.True(t, server.Exists("cache:animal:1:bella"))
+
+	// Other entity types keep their reads
+	load("food", "hay")
+	assert.Equal(t, 3, loads)
+	namespaces.Invalidate(ctx, "animal", "food")
+	load("food", "hay")
+	assert.Equal(t, 4, loads)
+
+	// Failed reads are not cached
+	_, err = redisrepo.Load(ctx, namespaces, "animal", "gone", func(context.Context) (string, error) {
+		return "", errors.New("not found")
+	})
+	assert.Error(t, err)
+	assert.False(t, server.Exists("cache:animal:2:gone"))
+
+	// Entity types without a ttl are not cached
+	load("drug", "aspirin")
+	load("drug", "aspirin")
+	assert.Equal(t, 6, loads)
+	assert.False(t, server.Exists("cache:drug:0:aspirin"))
+
+	// Without Redis every read is loaded
+	server.Close()
+	assert.Equal(t, "animal read bella", load("animal", "bella"))
+	assert.Equal(t, 7, loads)
+}
+
+func TestKey(t *testing.T) {
+	assert.Equal(t, redisrepo.Key("Get", "bella"), redisrepo.Key("Get", "bella"))
+	assert.NotEqual(t, redisrepo.Key("Get", "bella"), redisrepo.Key("Get", "milka"))
+	assert.NotEqual(t, redisrepo.Key("Get", "bella"), redisrepo.Key("List", "bella"))
+}
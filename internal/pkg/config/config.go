@@ -1,8 +1,21 @@
 package config
 
 import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"net"
 	"os"
+	"strconv"
+	"strings"
 	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"musobaqa/farm-competition/internal/pkg/app"
+	"musobaqa/farm-competition/internal/pkg/costing"
+	"musobaqa/farm-competition/internal/pkg/scheduler"
 )
 
 const (
@@ -10,106 +23,627 @@ const (
 )
 
 type webAddress struct {
-	Host string
-	Port string
+	Host string `yaml:"host"`
+	Port string `yaml:"port"`
+}
+
+type collector struct {
+	webAddress `yaml:",inline"`
+	// Exporter is otlp, stdout, file or none
+	Exporter string `yaml:"exporter"`
+	File     string `yaml:"file"`
+}
+
+// RateLimit allows Requests in any Window, a zero Requests turns it off
+type RateLimit struct {
+	Requests int64
+	Window   time.Duration
 }
 
 type Config struct {
-	APP         string
-	Environment string
-	LogLevel    string
+	APP         string `yaml:"app"`
+	Environment string `yaml:"environment"`
+	LogLevel    string `yaml:"log_level"`
 	Server      struct {
-		Host         string
-		Port         string
-		ReadTimeout  string
-		WriteTimeout string
-		IdleTimeout  string
-	}
+		Host         string        `yaml:"host"`
+		Port         string        `yaml:"port"`
+		ReadTimeout  time.Duration `yaml:"read_timeout"`
+		WriteTimeout time.Duration `yaml:"write_timeout"`
+		IdleTimeout  time.Duration `yaml:"idle_timeout"`
+		// GRPCPort is where the grpc api listens, next to the REST one
+		GRPCPort string `yaml:"grpc_port"`
+		// RequireIfMatch rejects updates and deletes sent without If-Match
+		RequireIfMatch bool `yaml:"require_if_match"`
+		// DrainDelay is how long readiness fails before the server stops
+		// taking connections, ShutdownTimeout bounds the requests in flight
+		DrainDelay      time.Duration `yaml:"drain_delay"`
+		ShutdownTimeout time.Duration `yaml:"shutdown_timeout"`
+	} `yaml:"server"`
 	DB struct {
-		Host     string
-		Port     string
-		Name     string
-		User     string
-		Password string
-		SSLMode  string
-	}
+		Host     string `yaml:"host"`
+		Port     string `yaml:"port"`
+		Name     string `yaml:"name"`
+		User     string `yaml:"user"`
+		Password string `yaml:"password"`
+		SSLMode  string `yaml:"sslmode"`
+		// AutoMigrate applies the embedded migrations on startup
+		AutoMigrate bool `yaml:"auto_migrate"`
+	} `yaml:"postgres"`
 	Context struct {
-		Timeout string
-	}
+		Timeout time.Duration `yaml:"timeout"`
+		// ReportTimeout replaces Timeout on the report routes
+		ReportTimeout time.Duration `yaml:"report_timeout"`
+	} `yaml:"context"`
 	Redis struct {
-		Host     string
-		Port     string
-		Password string
-		Name     string
-	}
+		Host     string `yaml:"host"`
+		Port     string `yaml:"port"`
+		Password string `yaml:"password"`
+		Name     string `yaml:"database"`
+	} `yaml:"redis"`
+	Idempotency struct {
+		TTL time.Duration `yaml:"ttl"`
+	} `yaml:"idempotency"`
+	Cache struct {
+		// TTL by entity type, entity types without one are not cached
+		TTL map[string]time.Duration `yaml:"ttl"`
+	} `yaml:"cache"`
+	// RateLimit by route group, requests are counted per client
+	RateLimit map[string]RateLimit `yaml:"rate_limit"`
+	Clients   struct {
+		// TrustedProxies are the addresses or CIDRs whose X-Forwarded-For is
+		// believed, without any the client is the remote address
+		TrustedProxies []string `yaml:"trusted_proxies"`
+		// APIKeys are the keys a client may send in X-Api-Key to be counted
+		// by key, any other key is ignored
+		APIKeys []string `yaml:"api_keys"`
+	} `yaml:"clients"`
 	Token struct {
-		Secret     string
-		AccessTTL  time.Duration
-		RefreshTTL time.Duration
-		SignInKey  string
-	}
-	OTLPCollector webAddress
+		Secret     string        `yaml:"secret"`
+		AccessTTL  time.Duration `yaml:"access_ttl"`
+		RefreshTTL time.Duration `yaml:"refresh_ttl"`
+		SignInKey  string        `yaml:"signin_key"`
+	} `yaml:"token"`
+	OTLPCollector collector `yaml:"otlp"`
+	Costing       struct {
+		Method string `yaml:"method"`
+	} `yaml:"costing"`
+	Trash struct {
+		Retention time.Duration `yaml:"retention"`
+	} `yaml:"trash"`
+	Webhooks struct {
+		// DispatchInterval is how often due deliveries are sent, at most
+		// BatchSize of them each time
+		DispatchInterval time.Duration `yaml:"dispatch_interval"`
+		BatchSize        int           `yaml:"batch_size"`
+		// Timeout bounds one attempt, a failed attempt is retried after
+		// RetryBackoff, doubled every time up to RetryMaxBackoff, until
+		// MaxAttempts are used up
+		Timeout         time.Duration `yaml:"timeout"`
+		MaxAttempts     int           `yaml:"max_attempts"`
+		RetryBackoff    time.Duration `yaml:"retry_backoff"`
+		RetryMaxBackoff time.Duration `yaml:"retry_max_backoff"`
+		// LowStock is the quantity stock.low is sent under
+		LowStock int64 `yaml:"low_stock"`
+		// Retention is how long finished deliveries stay in the log
+		Retention time.Duration `yaml:"retention"`
+	} `yaml:"webhooks"`
+	Outbox struct {
+		// RelayInterval is how often stored domain events are handed to
+		// their handlers, at most BatchSize of them each time. A failed
+		// event is tried again after RetryBackoff, doubled every time, until
+		// MaxAttempts are used up. Relayed events are kept for Retention
+		RelayInterval time.Duration `yaml:"relay_interval"`
+		BatchSize     int           `yaml:"batch_size"`
+		RetryBackoff  time.Duration `yaml:"retry_backoff"`
+		MaxAttempts   int           `yaml:"max_attempts"`
+		Retention     time.Duration `yaml:"retention"`
+	} `yaml:"outbox"`
+	Jobs struct {
+		// the schedules are cron expressions in the time zone of the server,
+		// like "*/15 * * * *", or "@every 30s"
+		PurgeTrash     string `yaml:"purge_trash"`
+		MissedFeedings string `yaml:"missed_feedings"`
+		LowStock       string `yaml:"low_stock"`
+		ReportSnapshot string `yaml:"report_snapshot"`
+		PurgeRuns      string `yaml:"purge_runs"`
+		PurgeEvents    string `yaml:"purge_events"`
+		FeedingTasks   string `yaml:"feeding_tasks"`
+		// Timeout bounds a run, a replica that dies mid run holds the job
+		// no longer than that. Finished runs are kept for History
+		Timeout time.Duration `yaml:"timeout"`
+		History time.Duration `yaml:"history"`
+	} `yaml:"jobs"`
 }
 
+var (
+	cacheEntityTypes = []string{"animal", "product", "food", "drug", "delivery", "supplier"}
+	rateLimitGroups  = []string{"api", "search", "upload"}
+)
+
+// NewConfig reads the configuration without command line flags
 func NewConfig() (*Config, error) {
+	return Load(nil)
+}
+
+// Load builds the configuration in layers, every one overriding the one
+// before: defaults, the YAML file given by -config or CONFIG_FILE, the
+// environment and the command line flags. The result is validated, all
+// problems are reported at once
+func Load(args []string) (*Config, error) {
+	config := defaults()
+
+	flags := flag.NewFlagSet("app", flag.ContinueOnError)
+	var (
+		file           = flags.String("config", os.Getenv("CONFIG_FILE"), "path of the YAML config file")
+		logLevel       = flags.String("log-level", "", "log level")
+		environment    = flags.String("environment", "", "environment, develop or production")
+		serverHost     = flags.String("server-host", "", "host the server listens on")
+		serverPort     = flags.String("server-port", "", "port the server listens on, like :8080")
+		grpcPort       = flags.String("grpc-port", "", "port the grpc server listens on, like :9090")
+		contextTimeout = flags.Duration("context-timeout", 0, "deadline of a request")
+	)
+	if err := flags.Parse(args); err != nil {
+		return nil, err
+	}
+
+	if *file != "" {
+		if err := config.readFile(*file); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := config.readEnv(); err != nil {
+		return nil, err
+	}
+
+	flags.Visit(func(f *flag.Flag) {
+		switch f.Name {
+		case "log-level":
+			config.LogLevel = *logLevel
+		case "environment":
+			config.Environment = *environment
+		case "server-host":
+			config.Server.Host = *serverHost
+		case "server-port":
+			config.Server.Port = *serverPort
+		case "grpc-port":
+			config.Server.GRPCPort = *grpcPort
+		case "context-timeout":
+			config.Context.Timeout = *contextTimeout
+		}
+	})
+
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+
+	return config, nil
+}
+
+func defaults() *Config {
 	var config Config
 
 	// general configuration
-	config.APP = getEnv("APP", "app")
-	config.Environment = getEnv("ENVIRONMENT", "develop")
-	config.LogLevel = getEnv("LOG_LEVEL", "debug")
-	config.Context.Timeout = getEnv("CONTEXT_TIMEOUT", "7s")
+	config.APP = "app"
+	config.Environment = app.EnvironmentDevelop
+	config.LogLevel = "debug"
+	config.Context.Timeout = 7 * time.Second
+	config.Context.ReportTimeout = 30 * time.Second
 
 	// server configuration
-	config.Server.Host = getEnv("SERVER_HOST", "localhost")
-	config.Server.Port = getEnv("SERVER_PORT", ":8080")
-	config.Server.ReadTimeout = getEnv("SERVER_READ_TIMEOUT", "10s")
-	config.Server.WriteTimeout = getEnv("SERVER_WRITE_TIMEOUT", "10s")
-	config.Server.IdleTimeout = getEnv("SERVER_IDLE_TIMEOUT", "120s")
+	config.Server.Host = "localhost"
+	config.Server.Port = ":8080"
+	config.Server.GRPCPort = ":9090"
+	config.Server.ReadTimeout = 10 * time.Second
+	config.Server.WriteTimeout = 35 * time.Second
+	config.Server.IdleTimeout = 120 * time.Second
+	config.Server.DrainDelay = 5 * time.Second
+	config.Server.ShutdownTimeout = 30 * time.Second
 
 	// db configuration
-	config.DB.Host = getEnv("POSTGRES_HOST", "localhost")
-	config.DB.Port = getEnv("POSTGRES_PORT", "5432")
-	config.DB.Name = getEnv("POSTGRES_DATABASE", "farm")
-	config.DB.User = getEnv("POSTGRES_USER", "postgres")
-	config.DB.Password = getEnv("POSTGRES_PASSWORD", "root")
-
-	config.DB.SSLMode = getEnv("POSTGRES_SSLMODE", "disable")
+	config.DB.Host = "localhost"
+	config.DB.Port = "5432"
+	config.DB.Name = "farm"
+	config.DB.User = "postgres"
+	config.DB.Password = "root"
+	config.DB.SSLMode = "disable"
 
 	// redis configuration
-	config.Redis.Host = getEnv("REDIS_HOST", "redis-db")
-	config.Redis.Port = getEnv("REDIS_PORT", "6379")
-	config.Redis.Password = getEnv("REDIS_PASSWORD", "")
-	config.Redis.Name = getEnv("REDIS_DATABASE", "0")
+	config.Redis.Host = "redis-db"
+	config.Redis.Port = "6379"
+	config.Redis.Name = "0"
+
+	// idempotency configuration, how long responses of keyed requests are kept
+	config.Idempotency.TTL = 24 * time.Hour
+
+	// cache configuration, 0 turns the cache of an entity type off
+	config.Cache.TTL = map[string]time.Duration{}
+	for _, entityType := range cacheEntityTypes {
+		config.Cache.TTL[entityType] = 5 * time.Minute
+	}
+
+	// rate limit configuration, a zero limit turns the limit of a group off
+	config.RateLimit = map[string]RateLimit{
+		"api":    {Requests: 300, Window: time.Minute},
+		"search": {Requests: 60, Window: time.Minute},
+		"upload": {Requests: 20, Window: time.Minute},
+	}
 
 	// token configuration
-	config.Token.Secret = getEnv("TOKEN_SECRET", "token_secret")
+	config.Token.Secret = "token_secret"
+	config.Token.AccessTTL = 2 * time.Hour
+	config.Token.RefreshTTL = 48 * time.Hour
+	config.Token.SignInKey = "debug_farming"
+
+	// otlp collector configuration
+	config.OTLPCollector.Host = "localhost"
+	config.OTLPCollector.Port = ":4317"
+	config.OTLPCollector.Exporter = "otlp"
+	config.OTLPCollector.File = "traces.json"
+
+	// costing configuration, fifo or weighted_average
+	config.Costing.Method = "fifo"
+
+	// trash configuration, deleted rows older than the retention are purged
+	config.Trash.Retention = 720 * time.Hour
+
+	// webhooks configuration, a delivery gives up after the last attempt
+	config.Webhooks.DispatchInterval = 5 * time.Second
+	config.Webhooks.BatchSize = 50
+	config.Webhooks.Timeout = 10 * time.Second
+	config.Webhooks.MaxAttempts = 8
+	config.Webhooks.RetryBackoff = 30 * time.Second
+	config.Webhooks.RetryMaxBackoff = time.Hour
+	config.Webhooks.LowStock = 10
+	config.Webhooks.Retention = 720 * time.Hour
+
+	// outbox configuration, events wait in the table until they are relayed
+	config.Outbox.RelayInterval = time.Second
+	config.Outbox.BatchSize = 100
+	config.Outbox.RetryBackoff = 5 * time.Second
+	config.Outbox.MaxAttempts = 20
+	config.Outbox.Retention = 168 * time.Hour
+
+	// jobs configuration, one replica runs each of them at a time
+	config.Jobs.PurgeTrash = "0 3 * * *"
+	config.Jobs.MissedFeedings = "*/15 * * * *"
+	config.Jobs.LowStock = "*/15 * * * *"
+	config.Jobs.ReportSnapshot = "5 0 * * *"
+	config.Jobs.PurgeRuns = "30 3 * * *"
+	config.Jobs.PurgeEvents = "45 3 * * *"
+	config.Jobs.FeedingTasks = "0 * * * *"
+	config.Jobs.Timeout = 10 * time.Minute
+	config.Jobs.History = 720 * time.Hour
+
+	return &config
+}
 
-	// access ttl parse
-	accessTTl, err := time.ParseDuration(getEnv("TOKEN_ACCESS_TTL", "2h"))
+func (c *Config) readFile(path string) error {
+	data, err := os.ReadFile(path)
 	if err != nil {
-		return nil, err
+		return fmt.Errorf("config file: %w", err)
 	}
-	// refresh ttl parse
-	refreshTTL, err := time.ParseDuration(getEnv("TOKEN_REFRESH_TTL", "48h"))
-	if err != nil {
-		return nil, err
+
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(c); err != nil {
+		return fmt.Errorf("config file %s: %w", path, err)
+	}
+
+	return nil
+}
+
+// readEnv applies the environment variables that are set, the names are the
+// ones the app has always read
+func (c *Config) readEnv() error {
+	var errs []error
+
+	text := func(key string, target *string) {
+		if value, ok := os.LookupEnv(key); ok {
+			*target = value
+		}
+	}
+	duration := func(key string, target *time.Duration) {
+		if value, ok := os.LookupEnv(key); ok {
+			parsed, err := time.ParseDuration(value)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("%s: %q is not a duration", key, value))
+				return
+			}
+			*target = parsed
+		}
+	}
+	list := func(key string, target *[]string) {
+		if value, ok := os.LookupEnv(key); ok {
+			*target = nil
+			for _, item := range strings.Split(value, ",") {
+				if item = strings.TrimSpace(item); item != "" {
+					*target = append(*target, item)
+				}
+			}
+		}
+	}
+	number := func(key string, target *int64) {
+		if value, ok := os.LookupEnv(key); ok {
+			parsed, err := strconv.ParseInt(value, 10, 64)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("%s: %q is not a number", key, value))
+				return
+			}
+			*target = parsed
+		}
+	}
+
+	// general configuration
+	text("APP", &c.APP)
+	text("ENVIRONMENT", &c.Environment)
+	text("LOG_LEVEL", &c.LogLevel)
+	duration("CONTEXT_TIMEOUT", &c.Context.Timeout)
+	duration("CONTEXT_REPORT_TIMEOUT", &c.Context.ReportTimeout)
+
+	// server configuration
+	text("SERVER_HOST", &c.Server.Host)
+	text("SERVER_PORT", &c.Server.Port)
+	text("SERVER_GRPC_PORT", &c.Server.GRPCPort)
+	duration("SERVER_READ_TIMEOUT", &c.Server.ReadTimeout)
+	duration("SERVER_WRITE_TIMEOUT", &c.Server.WriteTimeout)
+	duration("SERVER_IDLE_TIMEOUT", &c.Server.IdleTimeout)
+	if value, ok := os.LookupEnv("SERVER_REQUIRE_IF_MATCH"); ok {
+		c.Server.RequireIfMatch = value == "true"
+	}
+	duration("SERVER_DRAIN_DELAY", &c.Server.DrainDelay)
+	duration("SERVER_SHUTDOWN_TIMEOUT", &c.Server.ShutdownTimeout)
+
+	// db configuration
+	text("POSTGRES_HOST", &c.DB.Host)
+	text("POSTGRES_PORT", &c.DB.Port)
+	text("POSTGRES_DATABASE", &c.DB.Name)
+	text("POSTGRES_USER", &c.DB.User)
+	text("POSTGRES_PASSWORD", &c.DB.Password)
+	text("POSTGRES_SSLMODE", &c.DB.SSLMode)
+	if value, ok := os.LookupEnv("POSTGRES_AUTO_MIGRATE"); ok {
+		c.DB.AutoMigrate = value == "true"
+	}
+
+	// redis configuration
+	text("REDIS_HOST", &c.Redis.Host)
+	text("REDIS_PORT", &c.Redis.Port)
+	text("REDIS_PASSWORD", &c.Redis.Password)
+	text("REDIS_DATABASE", &c.Redis.Name)
+
+	duration("IDEMPOTENCY_TTL", &c.Idempotency.TTL)
+
+	// CACHE_TTL sets every entity type, CACHE_TTL_<ENTITY> one of them
+	if c.Cache.TTL == nil {
+		c.Cache.TTL = map[string]time.Duration{}
+	}
+	for _, entityType := range cacheEntityTypes {
+		ttl := c.Cache.TTL[entityType]
+		duration("CACHE_TTL", &ttl)
+		duration("CACHE_TTL_"+strings.ToUpper(entityType), &ttl)
+		c.Cache.TTL[entityType] = ttl
+	}
+
+	// RATE_LIMIT_<GROUP> takes requests/window, empty turns the limit off
+	if c.RateLimit == nil {
+		c.RateLimit = map[string]RateLimit{}
+	}
+	for _, group := range rateLimitGroups {
+		key := "RATE_LIMIT_" + strings.ToUpper(group)
+		if value, ok := os.LookupEnv(key); ok {
+			var limit RateLimit
+			if err := limit.UnmarshalText([]byte(value)); err != nil {
+				errs = append(errs, fmt.Errorf("%s: %w", key, err))
+				continue
+			}
+			c.RateLimit[group] = limit
+		}
 	}
-	config.Token.AccessTTL = accessTTl
-	config.Token.RefreshTTL = refreshTTL
-	config.Token.SignInKey = getEnv("TOKEN_SIGNIN_KEY", "debug_farming")
+
+	// clients configuration, comma separated
+	list("CLIENTS_TRUSTED_PROXIES", &c.Clients.TrustedProxies)
+	list("CLIENTS_API_KEYS", &c.Clients.APIKeys)
+
+	// token configuration
+	text("TOKEN_SECRET", &c.Token.Secret)
+	duration("TOKEN_ACCESS_TTL", &c.Token.AccessTTL)
+	duration("TOKEN_REFRESH_TTL", &c.Token.RefreshTTL)
+	text("TOKEN_SIGNIN_KEY", &c.Token.SignInKey)
 
 	// otlp collector configuration
-	config.OTLPCollector.Host = getEnv("OTLP_COLLECTOR_HOST", "localhost")
-	config.OTLPCollector.Port = getEnv("OTLP_COLLECTOR_PORT", ":4317")
+	text("OTLP_COLLECTOR_HOST", &c.OTLPCollector.Host)
+	text("OTLP_COLLECTOR_PORT", &c.OTLPCollector.Port)
+	text("OTLP_EXPORTER", &c.OTLPCollector.Exporter)
+	text("OTLP_EXPORTER_FILE", &c.OTLPCollector.File)
+
+	text("COSTING_METHOD", &c.Costing.Method)
+
+	duration("TRASH_RETENTION", &c.Trash.Retention)
+
+	// webhooks configuration
+	batchSize, maxAttempts := int64(c.Webhooks.BatchSize), int64(c.Webhooks.MaxAttempts)
+	duration("WEBHOOKS_DISPATCH_INTERVAL", &c.Webhooks.DispatchInterval)
+	number("WEBHOOKS_BATCH_SIZE", &batchSize)
+	duration("WEBHOOKS_TIMEOUT", &c.Webhooks.Timeout)
+	number("WEBHOOKS_MAX_ATTEMPTS", &maxAttempts)
+	duration("WEBHOOKS_RETRY_BACKOFF", &c.Webhooks.RetryBackoff)
+	duration("WEBHOOKS_RETRY_MAX_BACKOFF", &c.Webhooks.RetryMaxBackoff)
+	number("WEBHOOKS_LOW_STOCK", &c.Webhooks.LowStock)
+	duration("WEBHOOKS_RETENTION", &c.Webhooks.Retention)
+	c.Webhooks.BatchSize, c.Webhooks.MaxAttempts = int(batchSize), int(maxAttempts)
+
+	// outbox configuration
+	relayBatchSize, relayAttempts := int64(c.Outbox.BatchSize), int64(c.Outbox.MaxAttempts)
+	duration("OUTBOX_RELAY_INTERVAL", &c.Outbox.RelayInterval)
+	number("OUTBOX_BATCH_SIZE", &relayBatchSize)
+	duration("OUTBOX_RETRY_BACKOFF", &c.Outbox.RetryBackoff)
+	number("OUTBOX_MAX_ATTEMPTS", &relayAttempts)
+	duration("OUTBOX_RETENTION", &c.Outbox.Retention)
+	c.Outbox.BatchSize, c.Outbox.MaxAttempts = int(relayBatchSize), int(relayAttempts)
+
+	// jobs configuration
+	text("JOBS_PURGE_TRASH", &c.Jobs.PurgeTrash)
+	text("JOBS_MISSED_FEEDINGS", &c.Jobs.MissedFeedings)
+	text("JOBS_LOW_STOCK", &c.Jobs.LowStock)
+	text("JOBS_REPORT_SNAPSHOT", &c.Jobs.ReportSnapshot)
+	text("JOBS_PURGE_RUNS", &c.Jobs.PurgeRuns)
+	text("JOBS_PURGE_EVENTS", &c.Jobs.PurgeEvents)
+	text("JOBS_FEEDING_TASKS", &c.Jobs.FeedingTasks)
+	duration("JOBS_TIMEOUT", &c.Jobs.Timeout)
+	duration("JOBS_HISTORY", &c.Jobs.History)
 
-	return &config, nil
+	return errors.Join(errs...)
 }
 
-func getEnv(key, defaultValue string) string {
-	if value, exists := os.LookupEnv(key); exists {
-		return value
+// Validate reports every setting the app cannot start with
+func (c *Config) Validate() error {
+	var errs []error
+
+	check := func(ok bool, format string, args ...interface{}) {
+		if !ok {
+			errs = append(errs, fmt.Errorf(format, args...))
+		}
+	}
+	positive := func(name string, value time.Duration) {
+		check(value > 0, "%s: must be positive, got %s", name, value)
+	}
+
+	check(c.APP != "", "app: must not be empty")
+	check(c.Environment == app.EnvironmentDevelop || c.Environment == app.EnvironmentProduction,
+		"environment: %q is not %s or %s", c.Environment, app.EnvironmentDevelop, app.EnvironmentProduction)
+	check(ValidLogLevel(c.LogLevel), "log_level: %q is not a log level", c.LogLevel)
+
+	check(isPort(c.Server.Port), "server.port: %q is not a port like :8080", c.Server.Port)
+	check(isPort(c.Server.GRPCPort), "server.grpc_port: %q is not a port like :9090", c.Server.GRPCPort)
+	check(c.Server.GRPCPort != c.Server.Port, "server.grpc_port: must differ from server.port")
+	positive("server.read_timeout", c.Server.ReadTimeout)
+	positive("server.write_timeout", c.Server.WriteTimeout)
+	positive("server.idle_timeout", c.Server.IdleTimeout)
+	positive("server.shutdown_timeout", c.Server.ShutdownTimeout)
+	check(c.Server.DrainDelay >= 0, "server.drain_delay: must not be negative")
+
+	positive("context.timeout", c.Context.Timeout)
+	positive("context.report_timeout", c.Context.ReportTimeout)
+	check(c.Server.WriteTimeout > c.Context.ReportTimeout,
+		"server.write_timeout: %s has to be longer than context.report_timeout %s", c.Server.WriteTimeout, c.Context.ReportTimeout)
+
+	check(c.DB.Host != "", "postgres.host: must not be empty")
+	check(c.DB.Name != "", "postgres.name: must not be empty")
+	check(c.Redis.Host != "", "redis.host: must not be empty")
+	_, err := strconv.Atoi(c.Redis.Name)
+	check(err == nil, "redis.database: %q is not a database number", c.Redis.Name)
+
+	positive("idempotency.ttl", c.Idempotency.TTL)
+	for entityType, ttl := range c.Cache.TTL {
+		check(ttl >= 0, "cache.ttl.%s: must not be negative", entityType)
+	}
+	for group, limit := range c.RateLimit {
+		check(limit.Requests >= 0, "rate_limit.%s: requests must not be negative", group)
+		check(limit.Requests == 0 || limit.Window > 0, "rate_limit.%s: window must be positive", group)
+	}
+
+	for _, proxy := range c.Clients.TrustedProxies {
+		_, _, cidrErr := net.ParseCIDR(proxy)
+		check(cidrErr == nil || net.ParseIP(proxy) != nil, "clients.trusted_proxies: %q is not an address or CIDR", proxy)
+	}
+	for _, key := range c.Clients.APIKeys {
+		check(len(key) >= 16, "clients.api_keys: keys must be at least 16 characters")
+	}
+
+	positive("token.access_ttl", c.Token.AccessTTL)
+	positive("token.refresh_ttl", c.Token.RefreshTTL)
+	check(c.Token.SignInKey != "", "token.signin_key: must not be empty")
+
+	switch c.OTLPCollector.Exporter {
+	case "otlp", "stdout", "none":
+	case "file":
+		check(c.OTLPCollector.File != "", "otlp.file: must not be empty with the file exporter")
+	default:
+		errs = append(errs, fmt.Errorf("otlp.exporter: %q is not otlp, stdout, file or none", c.OTLPCollector.Exporter))
+	}
+
+	_, err = costing.ParseMethod(c.Costing.Method)
+	check(err == nil, "costing.method: %q is not fifo or weighted_average", c.Costing.Method)
+
+	positive("trash.retention", c.Trash.Retention)
+
+	positive("webhooks.dispatch_interval", c.Webhooks.DispatchInterval)
+	check(c.Webhooks.BatchSize > 0, "webhooks.batch_size: must be positive, got %d", c.Webhooks.BatchSize)
+	positive("webhooks.timeout", c.Webhooks.Timeout)
+	check(c.Webhooks.MaxAttempts > 0, "webhooks.max_attempts: must be positive, got %d", c.Webhooks.MaxAttempts)
+	positive("webhooks.retry_backoff", c.Webhooks.RetryBackoff)
+	check(c.Webhooks.RetryMaxBackoff >= c.Webhooks.RetryBackoff,
+		"webhooks.retry_max_backoff: %s is shorter than webhooks.retry_backoff %s", c.Webhooks.RetryMaxBackoff, c.Webhooks.RetryBackoff)
+	check(c.Webhooks.LowStock >= 0, "webhooks.low_stock: must not be negative")
+	positive("webhooks.retention", c.Webhooks.Retention)
+
+	positive("outbox.relay_interval", c.Outbox.RelayInterval)
+	check(c.Outbox.BatchSize > 0, "outbox.batch_size: must be positive, got %d", c.Outbox.BatchSize)
+	positive("outbox.retry_backoff", c.Outbox.RetryBackoff)
+	check(c.Outbox.MaxAttempts > 0, "outbox.max_attempts: must be positive, got %d", c.Outbox.MaxAttempts)
+	positive("outbox.retention", c.Outbox.Retention)
+
+	for _, job := range []struct{ name, spec string }{
+		{"jobs.purge_trash", c.Jobs.PurgeTrash},
+		{"jobs.missed_feedings", c.Jobs.MissedFeedings},
+		{"jobs.low_stock", c.Jobs.LowStock},
+		{"jobs.report_snapshot", c.Jobs.ReportSnapshot},
+		{"jobs.purge_runs", c.Jobs.PurgeRuns},
+		{"jobs.purge_events", c.Jobs.PurgeEvents},
+		{"jobs.feeding_tasks", c.Jobs.FeedingTasks},
+	} {
+		_, err := scheduler.Parse(job.spec)
+		check(err == nil, "%s: %v", job.name, err)
+	}
+	positive("jobs.timeout", c.Jobs.Timeout)
+	positive("jobs.history", c.Jobs.History)
+
+	if len(errs) > 0 {
+		return fmt.Errorf("invalid config: %w", errors.Join(errs...))
+	}
+	return nil
+}
+
+// ValidLogLevel tells whether zap knows the level
+func ValidLogLevel(level string) bool {
+	switch level {
+	case "debug", "info", "warn", "error", "dpanic", "panic", "fatal":
+		return true
+	}
+	return false
+}
+
+func isPort(value string) bool {
+	port, err := strconv.Atoi(strings.TrimPrefix(value, ":"))
+	return strings.HasPrefix(value, ":") && err == nil && port > 0 && port < 65536
+}
+
+// UnmarshalText reads limits written as 300/1m, empty turns the limit off
+func (r *RateLimit) UnmarshalText(text []byte) error {
+	value := string(text)
+	if value == "" {
+		*r = RateLimit{}
+		return nil
+	}
+
+	requests, window, found := strings.Cut(value, "/")
+	if !found {
+		return fmt.Errorf("invalid rate limit %q", value)
+	}
+
+	var (
+		limit RateLimit
+		err   error
+	)
+	limit.Requests, err = strconv.ParseInt(requests, 10, 64)
+	if err != nil || limit.Requests < 0 {
+		return fmt.Errorf("invalid rate limit %q", value)
+	}
+	limit.Window, err = time.ParseDuration(window)
+	if err != nil || limit.Window <= 0 {
+		return fmt.Errorf("invalid rate limit %q", value)
 	}
 
-	return defaultValue
+	*r = limit
+	return nil
 }
@@ -0,0 +1,69 @@
+package config_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"musobaqa/farm-competition/internal/pkg/config"
+)
+
+func TestLoad(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "config.yaml")
+	err := os.WriteFile(file, []byte("log_level: info\nserver:\n  port: :9000\n  read_timeout: 3s\nrate_limit:\n  search: 10/1s\n"), 0o644)
+	assert.NoError(t, err)
+
+	// The file overrides defaults, the environment the file and flags both
+	t.Setenv("SERVER_PORT", ":9001")
+	t.Setenv("CACHE_TTL_FOOD", "1m")
+	t.Setenv("CLIENTS_TRUSTED_PROXIES", "10.0.0.0/8, 192.168.1.2")
+	cfg, err := config.Load([]string{"-config", file, "-log-level", "warn"})
+	assert.NoError(t, err)
+	assert.Equal(t, "warn", cfg.LogLevel)
+	assert.Equal(t, ":9001", cfg.Server.Port)
+	assert.Equal(t, 3*time.Second, cfg.Server.ReadTimeout)
+	assert.Equal(t, config.RateLimit{Requests: 10, Window: time.Second}, cfg.RateLimit["search"])
+	assert.Equal(t, int64(300), cfg.RateLimit["api"].Requests)
+	assert.Equal(t, time.Minute, cfg.Cache.TTL["food"])
+	assert.Equal(t, 5*time.Minute, cfg.Cache.TTL["animal"])
+	assert.Equal(t, []string{"10.0.0.0/8", "192.168.1.2"}, cfg.Clients.TrustedProxies)
+
+	// Every invalid setting is reported
+	t.Setenv("SERVER_PORT", "9001")
+	t.Setenv("LOG_LEVEL", "loud")
+	t.Setenv("JOBS_LOW_STOCK", "every monday")
+	t.Setenv("CLIENTS_TRUSTED_PROXIES", "proxy.local")
+	_, err = config.Load(nil)
+	assert.ErrorContains(t, err, "server.port")
+	assert.ErrorContains(t, err, "clients.trusted_proxies")
+	assert.ErrorContains(t, err, "log_level")
+	assert.ErrorContains(t, err, "jobs.low_stock")
+
+	t.Setenv("CONTEXT_TIMEOUT", "soon")
+	_, err = config.Load(nil)
+	assert.ErrorContains(t, err, "CONTEXT_TIMEOUT")
+}
+
+func TestReload(t *testing.T) {
+	t.Setenv("CONFIG_FILE", "")
+	current, err := config.Load(nil)
+	assert.NoError(t, err)
+	next, err := config.Load([]string{"-log-level", "error", "-server-port", ":9999"})
+	assert.NoError(t, err)
+	next.RateLimit = map[string]config.RateLimit{"api": {Requests: 1, Window: time.Second}}
+	next.Clients.APIKeys = []string{"0123456789abcdef"}
+	next.Clients.TrustedProxies = []string{"10.0.0.1"}
+
+	// Safe settings are taken over, the others wait for a restart
+	live := config.NewLive(current)
+	restart := live.Reload(next)
+	assert.Equal(t, []string{"server", "clients"}, restart)
+	assert.Equal(t, []string{"0123456789abcdef"}, live.Get().Clients.APIKeys)
+	assert.Empty(t, live.Get().Clients.TrustedProxies)
+	assert.Equal(t, "error", live.Get().LogLevel)
+	assert.Equal(t, int64(1), live.Get().RateLimit["api"].Requests)
+	assert.Equal(t, ":8080", live.Get().Server.Port)
+}
@@ -0,0 +1,63 @@
+package config
+
+import (
+	"slices"
+	"sync/atomic"
+)
+
+// Live holds the config the running app reads the reloadable settings from,
+// a reload swaps it whole so readers never see half of one
+type Live struct {
+	config atomic.Pointer[Config]
+}
+
+func NewLive(config *Config) *Live {
+	live := &Live{}
+	live.config.Store(config)
+	return live
+}
+
+func (l *Live) Get() *Config {
+	return l.config.Load()
+}
+
+// Reload takes over the settings of next that are safe to change while the
+// app runs: log level, rate limits, API keys and cache TTLs. It returns the names of
+// the other settings that differ, they need a restart
+func (l *Live) Reload(next *Config) []string {
+	current := l.Get()
+
+	reloaded := *current
+	reloaded.LogLevel = next.LogLevel
+	reloaded.RateLimit = next.RateLimit
+	reloaded.Cache = next.Cache
+	reloaded.Clients.APIKeys = next.Clients.APIKeys
+
+	var restart []string
+	for _, setting := range []struct {
+		name    string
+		changed bool
+	}{
+		{"server", next.Server != current.Server},
+		{"postgres", next.DB != current.DB},
+		{"redis", next.Redis != current.Redis},
+		{"context", next.Context != current.Context},
+		{"idempotency", next.Idempotency != current.Idempotency},
+		{"clients", !slices.Equal(next.Clients.TrustedProxies, current.Clients.TrustedProxies)},
+		{"token", next.Token != current.Token},
+		{"otlp", next.OTLPCollector != current.OTLPCollector},
+		{"costing", next.Costing != current.Costing},
+		{"trash", next.Trash != current.Trash},
+		{"webhooks", next.Webhooks != current.Webhooks},
+		{"outbox", next.Outbox != current.Outbox},
+		{"jobs", next.Jobs != current.Jobs},
+		{"app", next.APP != current.APP || next.Environment != current.Environment},
+	} {
+		if setting.changed {
+			restart = append(restart, setting.name)
+		}
+	}
+
+	l.config.Store(&reloaded)
+	return restart
+}
@@ -0,0 +1,158 @@
+package costing
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+type Method string
+
+const (
+	FIFO            Method = "fifo"
+	WeightedAverage Method = "weighted_average"
+)
+
+// ParseMethod resolves a costing method name, empty value falls back to FIFO
+func ParseMethod(method string) (Method, error) {
+	switch Method(strings.ToLower(strings.TrimSpace(method))) {
+	case "", FIFO:
+		return FIFO, nil
+	case WeightedAverage, "average", "avg":
+		return WeightedAverage, nil
+	}
+
+	return "", fmt.Errorf("unknown costing method: %s", method)
+}
+
+// Receipt is a stock movement into the store with its purchase price
+type Receipt struct {
+	Time     time.Time
+	Quantity int64
+	UnitCost float64
+}
+
+// Issue is a stock movement out of the store, e.g. a feeding
+type Issue struct {
+	ID       string
+	Time     time.Time
+	Quantity int64
+}
+
+// IssueCost is the cost assigned to an issue. Shortage is the part of the
+// quantity that was not covered by stock on hand, it is priced with the
+// last known unit cost
+type IssueCost struct {
+	ID       string
+	Quantity int64
+	Cost     float64
+	Shortage int64
+}
+
+type Result struct {
+	Issues   []IssueCost
+	Quantity int64
+	Value    float64
+}
+
+type layer struct {
+	quantity int64
+	unitCost float64
+}
+
+type movement struct {
+	time    time.Time
+	receipt *Receipt
+	issue   *Issue
+}
+
+// Run replays receipts and issues of a single item in chronological order and
+// returns the cost of every issue together with the value of remaining stock.
+// Receipts are applied before issues recorded at the same time
+func Run(method Method, receipts []Receipt, issues []Issue) (*Result, error) {
+	if method != FIFO && method != WeightedAverage {
+		return nil, fmt.Errorf("unknown costing method: %s", method)
+	}
+
+	movements := make([]movement, 0, len(receipts)+len(issues))
+	for i := range receipts {
+		movements = append(movements, movement{time: receipts[i].Time, receipt: &receipts[i]})
+	}
+	for i := range issues {
+		movements = append(movements, movement{time: issues[i].Time, issue: &issues[i]})
+	}
+	sort.SliceStable(movements, func(i, j int) bool {
+		if movements[i].time.Equal(movements[j].time) {
+			return movements[i].receipt != nil && movements[j].issue != nil
+		}
+		return movements[i].time.Before(movements[j].time)
+	})
+
+	var (
+		result   = Result{Issues: make([]IssueCost, 0, len(issues))}
+		layers   []layer
+		quantity int64
+		value    float64
+		lastCost float64
+	)
+	for _, m := range movements {
+		if m.receipt != nil {
+			if m.receipt.Quantity <= 0 {
+				continue
+			}
+			lastCost = m.receipt.UnitCost
+			quantity += m.receipt.Quantity
+			value += float64(m.receipt.Quantity) * m.receipt.UnitCost
+			layers = append(layers, layer{quantity: m.receipt.Quantity, unitCost: m.receipt.UnitCost})
+			continue
+		}
+
+		issued := IssueCost{ID: m.issue.ID, Quantity: m.issue.Quantity}
+		need := m.issue.Quantity
+		if need <= 0 {
+			result.Issues = append(result.Issues, issued)
+			continue
+		}
+
+		switch method {
+		case FIFO:
+			for need > 0 && len(layers) > 0 {
+				take := min(need, layers[0].quantity)
+				issued.Cost += float64(take) * layers[0].unitCost
+				layers[0].quantity -= take
+				quantity -= take
+				value -= float64(take) * layers[0].unitCost
+				need -= take
+				if layers[0].quantity == 0 {
+					layers = layers[1:]
+				}
+			}
+		case WeightedAverage:
+			if quantity > 0 {
+				take := min(need, quantity)
+				average := value / float64(quantity)
+				issued.Cost += float64(take) * average
+				quantity -= take
+				value -= float64(take) * average
+				need -= take
+				lastCost = average
+			}
+		}
+
+		if need > 0 {
+			issued.Shortage = need
+			issued.Cost += float64(need) * lastCost
+		}
+		if quantity == 0 {
+			value = 0
+		}
+
+		result.Issues = append(result.Issues, issued)
+	}
+
+	result.Quantity = quantity
+	result.Value = value
+
+	return &result, nil
+}
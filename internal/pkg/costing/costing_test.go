@@ -0,0 +1,58 @@
+package costing_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"musobaqa/farm-competition/internal/pkg/costing"
+)
+
+func TestRun(t *testing.T) {
+	day := func(d int) time.Time {
+		return time.Date(2024, time.January, d, 0, 0, 0, 0, time.UTC)
+	}
+
+	receipts := []costing.Receipt{
+		{Time: day(1), Quantity: 100, UnitCost: 2},
+		{Time: day(3), Quantity: 100, UnitCost: 4},
+	}
+	issues := []costing.Issue{
+		{ID: "first", Time: day(2), Quantity: 50},
+		{ID: "second", Time: day(4), Quantity: 100},
+	}
+
+	// FIFO
+	fifo, err := costing.Run(costing.FIFO, receipts, issues)
+	assert.NoError(t, err)
+	assert.Equal(t, 100.0, fifo.Issues[0].Cost)
+	assert.Equal(t, 50*2.0+50*4.0, fifo.Issues[1].Cost)
+	assert.Equal(t, int64(50), fifo.Quantity)
+	assert.Equal(t, 200.0, fifo.Value)
+
+	// Weighted average
+	avg, err := costing.Run(costing.WeightedAverage, receipts, issues)
+	assert.NoError(t, err)
+	assert.Equal(t, 100.0, avg.Issues[0].Cost)
+	// 50 units left at 2 plus 100 at 4 gives an average of 10/3
+	assert.InDelta(t, 100*10.0/3, avg.Issues[1].Cost, 0.0001)
+	assert.Equal(t, int64(50), avg.Quantity)
+	assert.InDelta(t, 50*10.0/3, avg.Value, 0.0001)
+
+	// Shortage is priced with the last known cost
+	short, err := costing.Run(costing.FIFO, receipts[:1], []costing.Issue{{ID: "over", Time: day(2), Quantity: 120}})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(20), short.Issues[0].Shortage)
+	assert.Equal(t, 240.0, short.Issues[0].Cost)
+	assert.Equal(t, int64(0), short.Quantity)
+
+	// Receipt and issue at the same time, receipt comes first
+	same, err := costing.Run(costing.FIFO, receipts[:1], []costing.Issue{{ID: "same", Time: day(1), Quantity: 10}})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), same.Issues[0].Shortage)
+	assert.Equal(t, 20.0, same.Issues[0].Cost)
+
+	_, err = costing.ParseMethod("lifo")
+	assert.Error(t, err)
+}
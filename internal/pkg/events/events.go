@@ -0,0 +1,124 @@
+// Package events carries domain events from the usecases that cause them to
+// the parts of the app that react to them. Usecases write events to the
+// outbox in the transaction of the change, the relay hands them to a
+// Publisher once the change is committed
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"musobaqa/farm-competition/internal/entity"
+)
+
+// All subscribes a handler to every event type
+const All = "*"
+
+// Handler reacts to an event. Events are delivered at least once, a
+// handler has to cope with seeing the same event again
+type Handler func(ctx context.Context, event *entity.DomainEvent) error
+
+// Publisher hands events on, in process or to a broker
+type Publisher interface {
+	Publish(ctx context.Context, events ...*entity.DomainEvent) error
+}
+
+// New builds an event of the aggregate with data as its JSON payload
+func New(eventType, aggregateType, aggregateID string, data any) (*entity.DomainEvent, error) {
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return nil, fmt.Errorf("event %s: %w", eventType, err)
+	}
+
+	id := uuid.New().String()
+	return &entity.DomainEvent{
+		ID:            id,
+		Type:          eventType,
+		Key:           id,
+		AggregateType: aggregateType,
+		AggregateID:   aggregateID,
+		Payload:       payload,
+		OccurredAt:    time.Now().UTC(),
+	}, nil
+}
+
+// Bus is the in-process Publisher, it calls the handlers subscribed to the
+// type of an event one after the other
+type Bus struct {
+	mu       sync.RWMutex
+	handlers map[string][]Handler
+}
+
+func NewBus() *Bus {
+	return &Bus{handlers: map[string][]Handler{}}
+}
+
+// Subscribe adds a handler for the event type, or for every type with All
+func (b *Bus) Subscribe(eventType string, handler Handler) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.handlers[eventType] = append(b.handlers[eventType], handler)
+}
+
+// Publish runs the handlers of every event. A failing handler does not stop
+// the others, the failures are returned together
+func (b *Bus) Publish(ctx context.Context, events ...*entity.DomainEvent) error {
+	var errs []error
+	for _, event := range events {
+		b.mu.RLock()
+		handlers := append(append([]Handler{}, b.handlers[event.Type]...), b.handlers[All]...)
+		b.mu.RUnlock()
+
+		for _, handler := range handlers {
+			if err := handler(ctx, event); err != nil {
+				errs = append(errs, fmt.Errorf("%s %s: %w", event.Type, event.ID, err))
+			}
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// Memory is a Publisher that keeps what it is given, for tests
+type Memory struct {
+	mu     sync.Mutex
+	events []*entity.DomainEvent
+}
+
+func (m *Memory) Publish(ctx context.Context, events ...*entity.DomainEvent) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.events = append(m.events, events...)
+	return nil
+}
+
+// Events returns what was published so far, optionally of the given types
+func (m *Memory) Events(types ...string) []*entity.DomainEvent {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var events []*entity.DomainEvent
+	for _, event := range m.events {
+		if len(types) == 0 || contains(types, event.Type) {
+			events = append(events, event)
+		}
+	}
+	return events
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
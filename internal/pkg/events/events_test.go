@@ -0,0 +1,57 @@
+package events_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"musobaqa/farm-competition/internal/entity"
+	"musobaqa/farm-competition/internal/pkg/events"
+)
+
+func TestBus(t *testing.T) {
+	ctx := context.Background()
+	bus := events.NewBus()
+
+	var seen []string
+	bus.Subscribe(entity.EventYieldRecorded, func(ctx context.Context, event *entity.DomainEvent) error {
+		seen = append(seen, "yield "+event.AggregateID)
+		return errors.New("product is gone")
+	})
+	bus.Subscribe(events.All, func(ctx context.Context, event *entity.DomainEvent) error {
+		seen = append(seen, "all "+event.Type)
+		return nil
+	})
+
+	yield, err := events.New(entity.EventYieldRecorded, "animal_product", "yield-1", &entity.YieldRecorded{YieldID: "yield-1", Capacity: 12})
+	require.NoError(t, err)
+	assert.Equal(t, yield.ID, yield.Key)
+
+	var payload entity.YieldRecorded
+	require.NoError(t, json.Unmarshal(yield.Payload, &payload))
+	assert.Equal(t, int64(12), payload.Capacity)
+
+	stock, err := events.New(entity.EventStockLow, "food", "hay", &entity.StockLow{Name: "hay"})
+	require.NoError(t, err)
+
+	// the failing handler does not keep the others from running
+	err = bus.Publish(ctx, yield, stock)
+	assert.ErrorContains(t, err, "product is gone")
+	assert.Equal(t, []string{"yield yield-1", "all yield.recorded", "all stock.low"}, seen)
+}
+
+func TestMemory(t *testing.T) {
+	var memory events.Memory
+	var publisher events.Publisher = &memory
+
+	yield, _ := events.New(entity.EventYieldRecorded, "animal_product", "yield-1", nil)
+	stock, _ := events.New(entity.EventStockLow, "food", "hay", nil)
+	require.NoError(t, publisher.Publish(context.Background(), yield, stock))
+
+	assert.Len(t, memory.Events(), 2)
+	assert.Equal(t, []*entity.DomainEvent{stock}, memory.Events(entity.EventStockLow))
+}
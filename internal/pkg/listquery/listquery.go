@@ -0,0 +1,365 @@
+// Package listquery turns the filter, ordering and search parameters of list
+// endpoints into squirrel conditions, limited to the fields an entity allows.
+//
+// Filters use the form field[op]=value, a bare field=value uses the first
+// operator the field allows. Operators are eq, in, gte, lte, ilike and
+// between; in and between take comma separated values. Ordering is a comma
+// separated list of fields, a leading minus sorts descending. Date filters
+// cover whole days, so they behave the same on DATE and TIMESTAMP columns.
+//
+// Tables with a Keyset can also be paged by an opaque cursor, which walks the
+// rows newest first by (created_at, id) and stays stable while rows are added.
+package listquery
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+
+	errorspkg "musobaqa/farm-competition/internal/errors"
+	"musobaqa/farm-competition/internal/pkg/postgres"
+)
+
+type Op string
+
+const (
+	Eq      Op = "eq"
+	In      Op = "in"
+	Gte     Op = "gte"
+	Lte     Op = "lte"
+	ILike   Op = "ilike"
+	Between Op = "between"
+)
+
+// Operator sets shared by most fields, the first one is used by a bare
+// field=value
+var (
+	TextOps   = []Op{ILike, Eq, In}
+	EnumOps   = []Op{Eq, In}
+	NumberOps = []Op{Eq, In, Gte, Lte, Between}
+	DateOps   = []Op{Eq, Gte, Lte, Between}
+)
+
+// Type tells how filter values are converted before they reach the database
+type Type int
+
+const (
+	Text Type = iota
+	Int
+	Float
+	Date
+	Bool
+)
+
+type Field struct {
+	Column     string
+	Type       Type
+	Ops        []Op
+	Sortable   bool
+	Searchable bool
+}
+
+// Schema is the whitelist of an entity, fields not listed are rejected
+type Schema struct {
+	Fields       map[string]Field
+	DefaultOrder []string
+	// Keyset holds the created_at and id columns of tables paged by cursor,
+	// DefaultOrder has to sort by them descending
+	Keyset []string
+}
+
+// Request is the raw list query as sent by the client
+type Request struct {
+	Filters  map[string]string
+	Ordering []string
+	Search   string
+	Cursor   string
+}
+
+// Page sizes of every list, a request without a limit gets DefaultLimit and
+// none gets more than MaxLimit
+const (
+	DefaultLimit uint64 = 10
+	MaxLimit     uint64 = 100
+)
+
+// Page fills in the first page and the default limit when they are not given
+// and caps the limit
+func Page(page, limit uint64) (uint64, uint64) {
+	if page == 0 {
+		page = 1
+	}
+	if limit == 0 {
+		limit = DefaultLimit
+	}
+	return page, min(limit, MaxLimit)
+}
+
+// Near turns a bare number filter on the field into a between filter of the
+// whole numbers within ten percent of it, fractional values included. Other
+// values are left for Build to reject
+func (r Request) Near(field string) {
+	value, err := strconv.ParseFloat(strings.TrimSpace(r.Filters[field]), 64)
+	if err != nil || value == 0 {
+		return
+	}
+
+	low, high := math.Ceil(value*0.9), math.Floor(value*1.1)
+	if value < 0 {
+		low, high = math.Ceil(value*1.1), math.Floor(value*0.9)
+	}
+	delete(r.Filters, field)
+	r.Filters[field+"[between]"] = fmt.Sprintf("%d,%d", int64(low), int64(high))
+}
+
+var filterKey = regexp.MustCompile(`^(\w+)(?:\[(\w+)\])?$`)
+
+// Build validates the request against the schema and returns the conditions,
+// to be applied to both the list and the count query, and the ordering
+func (s Schema) Build(sqb *postgres.Squirrel, r Request) (sq.And, []string, error) {
+	keys := make([]string, 0, len(r.Filters))
+	for key := range r.Filters {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	where := sq.And{}
+	for _, key := range keys {
+		value := r.Filters[key]
+		if value == "" {
+			continue
+		}
+
+		condition, err := s.condition(sqb, key, value)
+		if err != nil {
+			return nil, nil, errorspkg.NewErrBadRequest(err)
+		}
+		where = append(where, condition)
+	}
+
+	if search := strings.TrimSpace(r.Search); search != "" {
+		var or []sq.Sqlizer
+		for _, name := range s.sortedNames() {
+			if field := s.Fields[name]; field.Searchable {
+				or = append(or, sqb.ILike(field.Column, "%"+search+"%"))
+			}
+		}
+		if len(or) > 0 {
+			where = append(where, sqb.Or(or...))
+		}
+	}
+
+	orderBy, err := s.orderBy(r.Ordering)
+	if err != nil {
+		return nil, nil, errorspkg.NewErrBadRequest(err)
+	}
+
+	if r.Cursor != "" && (len(s.Keyset) == 0 || r.ordered()) {
+		return nil, nil, errorspkg.NewErrBadRequest(errors.New("cursor cannot be used with this ordering"))
+	}
+
+	return where, orderBy, nil
+}
+
+// Seek returns the condition continuing after the request cursor, nil when
+// the request has none and is paged by offset. It only belongs to the list
+// query, the count still covers every matching row
+func (s Schema) Seek(sqb *postgres.Squirrel, r Request) (sq.Sqlizer, error) {
+	if r.Cursor == "" {
+		return nil, nil
+	}
+
+	raw, err := base64.RawURLEncoding.DecodeString(r.Cursor)
+	if err != nil {
+		return nil, errorspkg.NewErrBadRequest(errors.New("invalid cursor"))
+	}
+
+	createdAt, id, found := strings.Cut(string(raw), "|")
+	if !found || id == "" {
+		return nil, errorspkg.NewErrBadRequest(errors.New("invalid cursor"))
+	}
+
+	after, err := time.Parse(time.RFC3339Nano, createdAt)
+	if err != nil {
+		return nil, errorspkg.NewErrBadRequest(errors.New("invalid cursor"))
+	}
+
+	return sqb.Expr(fmt.Sprintf("(%s, %s) < (?, ?)", s.Keyset[0], s.Keyset[1]), after, id), nil
+}
+
+// NextCursor points after the last row of a page, it is empty when the
+// request is sorted by something else than the keyset
+func (s Schema) NextCursor(r Request, createdAt time.Time, id string) string {
+	if len(s.Keyset) == 0 || r.ordered() {
+		return ""
+	}
+
+	return base64.RawURLEncoding.EncodeToString([]byte(createdAt.Format(time.RFC3339Nano) + "|" + id))
+}
+
+// ordered tells whether the client asked for its own ordering
+func (r Request) ordered() bool {
+	for _, name := range r.Ordering {
+		if strings.TrimSpace(name) != "" {
+			return true
+		}
+	}
+	return false
+}
+
+func (s Schema) condition(sqb *postgres.Squirrel, key, value string) (sq.Sqlizer, error) {
+	match := filterKey.FindStringSubmatch(key)
+	if match == nil {
+		return nil, fmt.Errorf("invalid filter %q", key)
+	}
+
+	field, ok := s.Fields[match[1]]
+	if !ok {
+		return nil, fmt.Errorf("unknown filter field %q", match[1])
+	}
+
+	op := Op(match[2])
+	if op == "" {
+		op = field.Ops[0]
+	}
+	if !field.allows(op) {
+		return nil, fmt.Errorf("operator %q is not allowed on %q", op, match[1])
+	}
+
+	switch op {
+	case ILike:
+		return sqb.ILike(field.Column, "%"+value+"%"), nil
+	case In:
+		values, err := field.convertAll(strings.Split(value, ","))
+		if err != nil {
+			return nil, err
+		}
+		return sqb.Equal(field.Column, values), nil
+	case Between:
+		bounds := strings.Split(value, ",")
+		if len(bounds) != 2 {
+			return nil, fmt.Errorf("between on %q needs two values", match[1])
+		}
+		values, err := field.convertAll(bounds)
+		if err != nil {
+			return nil, err
+		}
+		return sqb.And(sqb.GtOrEq(field.Column, values[0]), field.upTo(sqb, values[1])), nil
+	}
+
+	converted, err := field.convert(value)
+	if err != nil {
+		return nil, err
+	}
+
+	switch op {
+	case Gte:
+		return sqb.GtOrEq(field.Column, converted), nil
+	case Lte:
+		return field.upTo(sqb, converted), nil
+	default:
+		if field.Type == Date {
+			return sqb.And(sqb.GtOrEq(field.Column, converted), field.upTo(sqb, converted)), nil
+		}
+		return sqb.Equal(field.Column, converted), nil
+	}
+}
+
+func (s Schema) orderBy(ordering []string) ([]string, error) {
+	var orderBy []string
+	for _, name := range ordering {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+
+		direction := "ASC"
+		if strings.HasPrefix(name, "-") {
+			name, direction = name[1:], "DESC"
+		}
+
+		field, ok := s.Fields[name]
+		if !ok || !field.Sortable {
+			return nil, fmt.Errorf("cannot order by %q", name)
+		}
+		orderBy = append(orderBy, field.Column+" "+direction)
+	}
+
+	if len(orderBy) == 0 {
+		return s.DefaultOrder, nil
+	}
+
+	return orderBy, nil
+}
+
+// sortedNames keeps the generated SQL stable between requests
+func (s Schema) sortedNames() []string {
+	names := make([]string, 0, len(s.Fields))
+	for name := range s.Fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func (f Field) allows(op Op) bool {
+	for _, allowed := range f.Ops {
+		if allowed == op {
+			return true
+		}
+	}
+	return false
+}
+
+// upTo is the upper bound of a filter, a date covers the whole day so it also
+// works on timestamp columns
+func (f Field) upTo(sqb *postgres.Squirrel, value interface{}) sq.Sqlizer {
+	if day, ok := value.(time.Time); ok && f.Type == Date {
+		return sqb.Lt(f.Column, day.AddDate(0, 0, 1))
+	}
+	return sqb.LtOrEq(f.Column, value)
+}
+
+func (f Field) convertAll(values []string) ([]interface{}, error) {
+	converted := make([]interface{}, 0, len(values))
+	for _, value := range values {
+		v, err := f.convert(strings.TrimSpace(value))
+		if err != nil {
+			return nil, err
+		}
+		converted = append(converted, v)
+	}
+	return converted, nil
+}
+
+func (f Field) convert(value string) (interface{}, error) {
+	var (
+		converted interface{}
+		err       error
+	)
+	switch f.Type {
+	case Int:
+		converted, err = strconv.ParseInt(value, 10, 64)
+	case Float:
+		converted, err = strconv.ParseFloat(value, 64)
+	case Date:
+		converted, err = time.Parse(time.DateOnly, value)
+	case Bool:
+		converted, err = strconv.ParseBool(value)
+	default:
+		converted = value
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid value %q for %s", value, f.Column)
+	}
+
+	return converted, nil
+}
@@ -0,0 +1,127 @@
+package listquery_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	errorspkg "musobaqa/farm-competition/internal/errors"
+	"musobaqa/farm-competition/internal/pkg/listquery"
+	"musobaqa/farm-competition/internal/pkg/postgres"
+)
+
+func TestBuild(t *testing.T) {
+	sqb := postgres.NewSquirrel()
+	schema := listquery.Schema{
+		Fields: map[string]listquery.Field{
+			"name":      {Column: "name", Ops: listquery.TextOps, Sortable: true, Searchable: true},
+			"genus":     {Column: "genus", Ops: listquery.TextOps, Searchable: true},
+			"weight":    {Column: "weight", Type: listquery.Int, Ops: listquery.NumberOps, Sortable: true},
+			"birth_day": {Column: "birth_day", Type: listquery.Date, Ops: listquery.DateOps},
+		},
+		DefaultOrder: []string{"created_at DESC"},
+	}
+
+	// Empty values add nothing, default ordering applies
+	where, orderBy, err := schema.Build(sqb, listquery.Request{Filters: map[string]string{"name": ""}})
+	assert.NoError(t, err)
+	assert.Empty(t, where)
+	assert.Equal(t, []string{"created_at DESC"}, orderBy)
+
+	// Operators, search and ordering
+	where, orderBy, err = schema.Build(sqb, listquery.Request{
+		Filters: map[string]string{
+			"name":            "bel",
+			"weight[between]": "10,20",
+			"birth_day[lte]":  "2024-01-31",
+			"genus[in]":       "cow,goat",
+		},
+		Ordering: []string{"-weight", "name"},
+		Search:   "bell",
+	})
+	assert.NoError(t, err)
+	query, args, err := where.ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "(birth_day < ? AND genus IN (?,?) AND name ILIKE ? AND (weight >= ? AND weight <= ?) AND (genus ILIKE ? OR name ILIKE ?))", query)
+	assert.Len(t, args, 8)
+	assert.Equal(t, int64(10), args[4])
+	assert.Equal(t, []string{"weight DESC", "name ASC"}, orderBy)
+
+	// Fields, operators and values outside the whitelist are bad requests
+	for _, request := range []listquery.Request{
+		{Filters: map[string]string{"password": "x"}},
+		{Filters: map[string]string{"weight[ilike]": "1"}},
+		{Filters: map[string]string{"weight": "heavy"}},
+		{Filters: map[string]string{"weight[between]": "1"}},
+		{Ordering: []string{"genus"}},
+	} {
+		_, _, err := schema.Build(sqb, request)
+		var badRequest *errorspkg.ErrBadRequest
+		assert.True(t, errors.As(err, &badRequest), request)
+	}
+}
+
+func TestCursor(t *testing.T) {
+	sqb := postgres.NewSquirrel()
+	schema := listquery.Schema{
+		Fields: map[string]listquery.Field{
+			"day": {Column: "day", Type: listquery.Date, Ops: listquery.DateOps, Sortable: true},
+		},
+		DefaultOrder: []string{"created_at DESC", "id DESC"},
+		Keyset:       []string{"created_at", "id"},
+	}
+	createdAt := time.Date(2024, time.March, 1, 10, 30, 0, 123456000, time.UTC)
+
+	// The next cursor continues after the row it was made from
+	cursor := schema.NextCursor(listquery.Request{}, createdAt, "c0ffee")
+	assert.NotEmpty(t, cursor)
+	seek, err := schema.Seek(sqb, listquery.Request{Cursor: cursor})
+	assert.NoError(t, err)
+	query, args, err := seek.ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "(created_at, id) < (?, ?)", query)
+	assert.True(t, createdAt.Equal(args[0].(time.Time)))
+	assert.Equal(t, "c0ffee", args[1])
+
+	// Page requests have no seek, custom orderings no cursor
+	seek, err = schema.Seek(sqb, listquery.Request{})
+	assert.NoError(t, err)
+	assert.Nil(t, seek)
+	assert.Empty(t, schema.NextCursor(listquery.Request{Ordering: []string{"day"}}, createdAt, "c0ffee"))
+
+	var badRequest *errorspkg.ErrBadRequest
+	_, _, err = schema.Build(sqb, listquery.Request{Cursor: cursor, Ordering: []string{"day"}})
+	assert.True(t, errors.As(err, &badRequest))
+	_, err = schema.Seek(sqb, listquery.Request{Cursor: "not a cursor"})
+	assert.True(t, errors.As(err, &badRequest))
+}
+
+func TestPage(t *testing.T) {
+	page, limit := listquery.Page(0, 0)
+	assert.Equal(t, uint64(1), page)
+	assert.Equal(t, listquery.DefaultLimit, limit)
+
+	page, limit = listquery.Page(3, 1000)
+	assert.Equal(t, uint64(3), page)
+	assert.Equal(t, listquery.MaxLimit, limit)
+}
+
+func TestNear(t *testing.T) {
+	// A bare number keeps the whole numbers within ten percent of it
+	request := listquery.Request{Filters: map[string]string{"weight": "30"}}
+	request.Near("weight")
+	assert.Equal(t, map[string]string{"weight[between]": "27,33"}, request.Filters)
+
+	// Fractional values are not truncated to an exact match
+	request = listquery.Request{Filters: map[string]string{"weight": "30.5"}}
+	request.Near("weight")
+	assert.Equal(t, map[string]string{"weight[between]": "28,33"}, request.Filters)
+
+	// Filters with an operator and values that are no number are left alone
+	request = listquery.Request{Filters: map[string]string{"weight[gte]": "30", "name": "bel"}}
+	request.Near("weight")
+	request.Near("name")
+	assert.Equal(t, map[string]string{"weight[gte]": "30", "name": "bel"}, request.Filters)
+}
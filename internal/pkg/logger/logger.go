@@ -23,7 +23,8 @@ func developmentConfig(file string) zap.Config {
 	return configZap
 }
 
-func New(level, environment string, file_name string) (*zap.Logger, error) {
+// New builds the logger on level, setting level later changes it in place
+func New(level zap.AtomicLevel, environment string, file_name string) (*zap.Logger, error) {
 	file := filepath.Join("./" + file_name)
 
 	configZap := productionConfig(file)
@@ -32,25 +33,28 @@ func New(level, environment string, file_name string) (*zap.Logger, error) {
 		configZap = developmentConfig(file)
 	}
 
+	configZap.Level = level
+	return configZap.Build()
+}
+
+// ParseLevel reads a level name, unknown names log everything
+func ParseLevel(level string) zapcore.Level {
 	switch level {
-	case "debug":
-		configZap.Level = zap.NewAtomicLevelAt(zap.DebugLevel)
 	case "info":
-		configZap.Level = zap.NewAtomicLevelAt(zap.InfoLevel)
+		return zap.InfoLevel
 	case "warn":
-		configZap.Level = zap.NewAtomicLevelAt(zap.WarnLevel)
+		return zap.WarnLevel
 	case "error":
-		configZap.Level = zap.NewAtomicLevelAt(zap.ErrorLevel)
+		return zap.ErrorLevel
 	case "dpanic":
-		configZap.Level = zap.NewAtomicLevelAt(zap.DPanicLevel)
+		return zap.DPanicLevel
 	case "panic":
-		configZap.Level = zap.NewAtomicLevelAt(zap.PanicLevel)
+		return zap.PanicLevel
 	case "fatal":
-		configZap.Level = zap.NewAtomicLevelAt(zap.FatalLevel)
+		return zap.FatalLevel
 	default:
-		configZap.Level = zap.NewAtomicLevelAt(zap.DebugLevel)
+		return zap.DebugLevel
 	}
-	return configZap.Build()
 }
 
 func Error(err error) zapcore.Field {
@@ -0,0 +1,215 @@
+// Package metrics holds the Prometheus collectors of the service and serves
+// them on /metrics. Farm gauges are read from the database on every scrape.
+package metrics
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	goredis "github.com/go-redis/redis/v8"
+	"github.com/jackc/pgx/v4/pgxpool"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+
+	"musobaqa/farm-competition/internal/entity"
+)
+
+const namespace = "farm"
+
+var (
+	registry = prometheus.NewRegistry()
+
+	httpDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "http_request_duration_seconds",
+		Help:      "Latency of HTTP requests by route and status.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method", "route", "status"})
+
+	redisDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "redis_command_duration_seconds",
+		Help:      "Latency of Redis commands.",
+		Buckets:   []float64{.0005, .001, .0025, .005, .01, .025, .05, .1, .25, .5, 1},
+	}, []string{"command", "failed"})
+
+	cacheReads = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "cache_reads_total",
+		Help:      "Cached reads by entity type and whether they were hits.",
+	}, []string{"entity", "result"})
+)
+
+func init() {
+	registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		httpDuration,
+		redisDuration,
+		cacheReads,
+	)
+}
+
+// Handler serves the metrics in the Prometheus text format
+func Handler() http.Handler {
+	return promhttp.HandlerFor(registry, promhttp.HandlerOpts{})
+}
+
+// ObserveRequest records a handled HTTP request
+func ObserveRequest(method, route, status string, took time.Duration) {
+	httpDuration.WithLabelValues(method, route, status).Observe(took.Seconds())
+}
+
+// ObserveCache records a cached read of the entity type
+func ObserveCache(entityType string, hit bool) {
+	result := "miss"
+	if hit {
+		result = "hit"
+	}
+	cacheReads.WithLabelValues(entityType, result).Inc()
+}
+
+// RegisterPool exports the connection pool stats of the database
+func RegisterPool(pool *pgxpool.Pool) {
+	registry.MustRegister(&poolCollector{pool: pool})
+}
+
+// FarmSource reads the farm gauges, it is the metrics repository
+type FarmSource interface {
+	FarmGauges(ctx context.Context) (*entity.FarmGauges, error)
+}
+
+// RegisterFarm exports stock, head counts, overdue feedings and the yields
+// recorded today, read from source with timeout on every scrape
+func RegisterFarm(source FarmSource, timeout time.Duration) {
+	registry.MustRegister(&farmCollector{source: source, timeout: timeout})
+}
+
+var (
+	poolAcquired     = prometheus.NewDesc(namespace+"_db_pool_acquired_connections", "Connections in use.", nil, nil)
+	poolIdle         = prometheus.NewDesc(namespace+"_db_pool_idle_connections", "Idle connections.", nil, nil)
+	poolTotal        = prometheus.NewDesc(namespace+"_db_pool_total_connections", "Open connections.", nil, nil)
+	poolMax          = prometheus.NewDesc(namespace+"_db_pool_max_connections", "Maximum size of the pool.", nil, nil)
+	poolAcquires     = prometheus.NewDesc(namespace+"_db_pool_acquires_total", "Connections acquired from the pool.", nil, nil)
+	poolEmptyWaits   = prometheus.NewDesc(namespace+"_db_pool_empty_acquires_total", "Acquires that waited for a connection.", nil, nil)
+	poolCanceled     = prometheus.NewDesc(namespace+"_db_pool_canceled_acquires_total", "Acquires canceled by their context.", nil, nil)
+	poolAcquireWaits = prometheus.NewDesc(namespace+"_db_pool_acquire_seconds_total", "Time spent acquiring connections.", nil, nil)
+)
+
+type poolCollector struct {
+	pool *pgxpool.Pool
+}
+
+func (c *poolCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- poolAcquired
+	ch <- poolIdle
+	ch <- poolTotal
+	ch <- poolMax
+	ch <- poolAcquires
+	ch <- poolEmptyWaits
+	ch <- poolCanceled
+	ch <- poolAcquireWaits
+}
+
+func (c *poolCollector) Collect(ch chan<- prometheus.Metric) {
+	stat := c.pool.Stat()
+	ch <- prometheus.MustNewConstMetric(poolAcquired, prometheus.GaugeValue, float64(stat.AcquiredConns()))
+	ch <- prometheus.MustNewConstMetric(poolIdle, prometheus.GaugeValue, float64(stat.IdleConns()))
+	ch <- prometheus.MustNewConstMetric(poolTotal, prometheus.GaugeValue, float64(stat.TotalConns()))
+	ch <- prometheus.MustNewConstMetric(poolMax, prometheus.GaugeValue, float64(stat.MaxConns()))
+	ch <- prometheus.MustNewConstMetric(poolAcquires, prometheus.CounterValue, float64(stat.AcquireCount()))
+	ch <- prometheus.MustNewConstMetric(poolEmptyWaits, prometheus.CounterValue, float64(stat.EmptyAcquireCount()))
+	ch <- prometheus.MustNewConstMetric(poolCanceled, prometheus.CounterValue, float64(stat.CanceledAcquireCount()))
+	ch <- prometheus.MustNewConstMetric(poolAcquireWaits, prometheus.CounterValue, stat.AcquireDuration().Seconds())
+}
+
+var (
+	farmStock   = prometheus.NewDesc(namespace+"_stock", "Stock of every food, drug and product.", []string{"category", "name", "union"}, nil)
+	farmHeads   = prometheus.NewDesc(namespace+"_animals", "Living animals by category.", []string{"category"}, nil)
+	farmOverdue = prometheus.NewDesc(namespace+"_overdue_feedings", "Feedings due by now that were not given today.", nil, nil)
+	farmYields  = prometheus.NewDesc(namespace+"_yield_today", "Products collected from animals today.", []string{"name", "union"}, nil)
+	farmUp      = prometheus.NewDesc(namespace+"_gauges_up", "Whether the farm gauges could be read.", nil, nil)
+)
+
+type farmCollector struct {
+	source  FarmSource
+	timeout time.Duration
+}
+
+func (c *farmCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- farmStock
+	ch <- farmHeads
+	ch <- farmOverdue
+	ch <- farmYields
+	ch <- farmUp
+}
+
+func (c *farmCollector) Collect(ch chan<- prometheus.Metric) {
+	ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
+	defer cancel()
+
+	gauges, err := c.source.FarmGauges(ctx)
+	if err != nil {
+		ch <- prometheus.MustNewConstMetric(farmUp, prometheus.GaugeValue, 0)
+		return
+	}
+	ch <- prometheus.MustNewConstMetric(farmUp, prometheus.GaugeValue, 1)
+
+	for _, level := range gauges.Stock {
+		ch <- prometheus.MustNewConstMetric(farmStock, prometheus.GaugeValue, float64(level.Quantity), level.Category, level.Name, level.Union)
+	}
+	for _, headCount := range gauges.HeadCounts {
+		ch <- prometheus.MustNewConstMetric(farmHeads, prometheus.GaugeValue, float64(headCount.Count), headCount.Category)
+	}
+	ch <- prometheus.MustNewConstMetric(farmOverdue, prometheus.GaugeValue, float64(gauges.OverdueFeedings))
+	for _, level := range gauges.YieldsToday {
+		ch <- prometheus.MustNewConstMetric(farmYields, prometheus.GaugeValue, float64(level.Quantity), level.Name, level.Union)
+	}
+}
+
+type redisStartKey struct{}
+
+// RedisHook times the commands of a Redis client
+type RedisHook struct{}
+
+var _ goredis.Hook = RedisHook{}
+
+func (RedisHook) BeforeProcess(ctx context.Context, cmd goredis.Cmder) (context.Context, error) {
+	return context.WithValue(ctx, redisStartKey{}, time.Now()), nil
+}
+
+func (RedisHook) AfterProcess(ctx context.Context, cmd goredis.Cmder) error {
+	observeRedis(ctx, cmd.Name(), cmd.Err())
+	return nil
+}
+
+func (RedisHook) BeforeProcessPipeline(ctx context.Context, cmds []goredis.Cmder) (context.Context, error) {
+	return context.WithValue(ctx, redisStartKey{}, time.Now()), nil
+}
+
+func (RedisHook) AfterProcessPipeline(ctx context.Context, cmds []goredis.Cmder) error {
+	var err error
+	for _, cmd := range cmds {
+		if cmd.Err() != nil {
+			err = cmd.Err()
+		}
+	}
+	observeRedis(ctx, "pipeline", err)
+	return nil
+}
+
+func observeRedis(ctx context.Context, command string, err error) {
+	start, ok := ctx.Value(redisStartKey{}).(time.Time)
+	if !ok {
+		return
+	}
+
+	// a missing key is an answer, not a failure
+	failed := "false"
+	if err != nil && err != goredis.Nil {
+		failed = "true"
+	}
+	redisDuration.WithLabelValues(command, failed).Observe(time.Since(start).Seconds())
+}
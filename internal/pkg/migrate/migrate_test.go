@@ -0,0 +1,49 @@
+package migrate_test
+
+import (
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+
+	"musobaqa/farm-competition/internal/pkg/migrate"
+	"musobaqa/farm-competition/migrations"
+)
+
+func TestLoad(t *testing.T) {
+	source := fstest.MapFS{
+		"000010_lots.up.sql":     {Data: []byte("CREATE TABLE lots ();")},
+		"000010_lots.down.sql":   {Data: []byte("DROP TABLE lots;")},
+		"000002_table.up.sql":    {Data: []byte("CREATE TABLE animals ();")},
+		"000002_table.down.sql":  {Data: []byte("DROP TABLE animals;")},
+		"embed.go":               {Data: []byte("package migrations")},
+		"seed/mock.sql":          {Data: []byte("INSERT INTO animals DEFAULT VALUES;")},
+		"000011_index.down.sql.": {Data: []byte("not a migration")},
+	}
+
+	// Ordered by version, other files are skipped
+	loaded, err := migrate.Load(source)
+	assert.NoError(t, err)
+	assert.Len(t, loaded, 2)
+	assert.Equal(t, int64(2), loaded[0].Version)
+	assert.Equal(t, "table", loaded[0].Name)
+	assert.Equal(t, "DROP TABLE animals;", loaded[0].Down)
+	assert.Equal(t, int64(10), loaded[1].Version)
+	assert.Equal(t, "CREATE TABLE lots ();", loaded[1].Up)
+
+	// A down file without its up file is refused
+	source["000012_search.down.sql"] = &fstest.MapFile{Data: []byte("DROP INDEX search;")}
+	_, err = migrate.Load(source)
+	assert.Error(t, err)
+
+	// The embedded migrations are complete
+	loaded, err = migrate.Load(migrations.FS)
+	assert.NoError(t, err)
+	assert.NotEmpty(t, loaded)
+	for i, migration := range loaded {
+		assert.NotEmpty(t, migration.Down, migration.Name)
+		if i > 0 {
+			assert.Greater(t, migration.Version, loaded[i-1].Version)
+		}
+	}
+}
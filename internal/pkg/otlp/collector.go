@@ -3,6 +3,7 @@ package otlp
 import (
 	"context"
 	"fmt"
+	"os"
 
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/exporters/otlp/otlptrace"
@@ -17,10 +18,7 @@ import (
 
 // Initializes an OTLP exporter, and configures the corresponding trace
 func InitOTLPProvider(config *configpkg.Config) (func() error, error) {
-	var (
-		ctx           = context.Background()
-		otelAgentAddr = fmt.Sprintf("%s%s", config.OTLPCollector.Host, config.OTLPCollector.Port)
-	)
+	ctx := context.Background()
 
 	res, err := resource.New(ctx,
 		resource.WithFromEnv(),
@@ -39,14 +37,18 @@ func InitOTLPProvider(config *configpkg.Config) (func() error, error) {
 		return nil, fmt.Errorf("otlp collector failed to create resource: %w", err)
 	}
 
-	traceClient := otlptracegrpc.NewClient(
-		otlptracegrpc.WithInsecure(),
-		otlptracegrpc.WithEndpoint(otelAgentAddr),
-	)
-
-	traceExporter, err := otlptrace.New(ctx, traceClient)
+	traceExporter, err := newExporter(ctx, config)
 	if err != nil {
-		return nil, fmt.Errorf("otlp collector failed to create trace exporter: %w", err)
+		return nil, err
+	}
+
+	// set global propagator to tracecontext (the default is no-op).
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+
+	// without an exporter the no-op provider stays, spans only pass on the
+	// trace context of the caller
+	if traceExporter == nil {
+		return func() error { return nil }, nil
 	}
 
 	// Register the trace exporter with a TracerProvider, using a batch
@@ -58,8 +60,6 @@ func InitOTLPProvider(config *configpkg.Config) (func() error, error) {
 		sdktrace.WithSpanProcessor(bsp),
 	)
 
-	// set global propagator to tracecontext (the default is no-op).
-	otel.SetTextMapPropagator(propagation.TraceContext{})
 	otel.SetTracerProvider(tracerProvider)
 
 	return func() error {
@@ -70,3 +70,33 @@ func InitOTLPProvider(config *configpkg.Config) (func() error, error) {
 		return nil
 	}, nil
 }
+
+// newExporter picks the exporter of the spans, the collector by default,
+// stdout or a file for local runs and none to turn exporting off
+func newExporter(ctx context.Context, config *configpkg.Config) (sdktrace.SpanExporter, error) {
+	switch config.OTLPCollector.Exporter {
+	case "none":
+		return nil, nil
+	case "stdout":
+		return newJSONExporter(os.Stdout, nil), nil
+	case "file":
+		file, err := os.OpenFile(config.OTLPCollector.File, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+		if err != nil {
+			return nil, fmt.Errorf("otlp failed to open trace file: %w", err)
+		}
+		return newJSONExporter(file, file), nil
+	case "", "otlp":
+		traceClient := otlptracegrpc.NewClient(
+			otlptracegrpc.WithInsecure(),
+			otlptracegrpc.WithEndpoint(fmt.Sprintf("%s%s", config.OTLPCollector.Host, config.OTLPCollector.Port)),
+		)
+
+		traceExporter, err := otlptrace.New(ctx, traceClient)
+		if err != nil {
+			return nil, fmt.Errorf("otlp collector failed to create trace exporter: %w", err)
+		}
+		return traceExporter, nil
+	default:
+		return nil, fmt.Errorf("unknown trace exporter %q", config.OTLPCollector.Exporter)
+	}
+}
@@ -0,0 +1,79 @@
+package otlp
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"sync"
+	"time"
+
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+// exportedSpan is the line written for every span by the stdout and file
+// exporters, enough to follow a request locally without a collector
+type exportedSpan struct {
+	TraceID    string                 `json:"trace_id"`
+	SpanID     string                 `json:"span_id"`
+	ParentID   string                 `json:"parent_id,omitempty"`
+	Name       string                 `json:"name"`
+	Kind       string                 `json:"kind"`
+	Start      time.Time              `json:"start"`
+	Duration   string                 `json:"duration"`
+	Status     string                 `json:"status,omitempty"`
+	Attributes map[string]interface{} `json:"attributes,omitempty"`
+}
+
+// jsonExporter writes spans as JSON lines
+type jsonExporter struct {
+	mu      sync.Mutex
+	encoder *json.Encoder
+	closer  io.Closer
+}
+
+// newJSONExporter writes to w and closes closer on shutdown, which is nil
+// for writers the exporter did not open itself like stdout
+func newJSONExporter(w io.Writer, closer io.Closer) *jsonExporter {
+	return &jsonExporter{encoder: json.NewEncoder(w), closer: closer}
+}
+
+func (e *jsonExporter) ExportSpans(ctx context.Context, spans []sdktrace.ReadOnlySpan) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	for _, s := range spans {
+		line := exportedSpan{
+			TraceID:  s.SpanContext().TraceID().String(),
+			SpanID:   s.SpanContext().SpanID().String(),
+			Name:     s.Name(),
+			Kind:     s.SpanKind().String(),
+			Start:    s.StartTime(),
+			Duration: s.EndTime().Sub(s.StartTime()).String(),
+		}
+		if s.Parent().IsValid() {
+			line.ParentID = s.Parent().SpanID().String()
+		}
+		if s.Status().Description != "" {
+			line.Status = s.Status().Description
+		}
+		if attributes := s.Attributes(); len(attributes) > 0 {
+			line.Attributes = make(map[string]interface{}, len(attributes))
+			for _, attribute := range attributes {
+				line.Attributes[string(attribute.Key)] = attribute.Value.AsInterface()
+			}
+		}
+
+		if err := e.encoder.Encode(line); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (e *jsonExporter) Shutdown(ctx context.Context) error {
+	if e.closer == nil {
+		return nil
+	}
+	return e.closer.Close()
+}
@@ -2,6 +2,7 @@ package postgres
 
 import (
 	"context"
+	"database/sql"
 	"errors"
 	"fmt"
 	"strings"
@@ -80,6 +81,7 @@ func (p *PostgresDB) connect(config *config.Config) error {
 	if err != nil {
 		return fmt.Errorf("unable to parse database config: %w", err)
 	}
+	pgxpoolConfig.ConnConfig.Logger = queryTracer{}
 
 	pool, err := pgxpool.ConnectConfig(context.Background(), pgxpoolConfig)
 	if err != nil {
@@ -95,16 +97,27 @@ func (p *PostgresDB) Close() {
 	p.Pool.Close()
 }
 
-func (p *PostgresDB) Error(err error) error {
+// Error turns driver errors into the domain errors of internal/errors, name
+// is the entity the query was about. Other errors are returned as they are
+func (p *PostgresDB) Error(err error, name string) error {
 	var pgErr *pgconn.PgError
 	if errors.As(err, &pgErr) {
 		switch pgErr.Code {
+		// unique_violation
 		case "23505":
-			return errorspkg.ErrorConflict
+			conflict := errorspkg.NewErrConflict(name)
+			conflict.Err = err
+			return conflict
+		// foreign_key_violation, check_violation and invalid_text_representation,
+		// the last one is what a malformed uuid gives
+		case "23503", "23514", "22P02":
+			return errorspkg.NewErrBadRequest(err)
 		}
 	}
-	if err == pgx.ErrNoRows {
-		return errorspkg.ErrorNotFound
+	if errors.Is(err, pgx.ErrNoRows) || errors.Is(err, sql.ErrNoRows) {
+		notFound := errorspkg.NewErrNotFound(name)
+		notFound.Err = err
+		return notFound
 	}
 	return err
 }
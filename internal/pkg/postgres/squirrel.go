@@ -69,8 +69,16 @@ func (s *Squirrel) Lt(key string, value interface{}) sq.Lt {
 	return sq.Lt{key: value}
 }
 
+func (s *Squirrel) GtOrEq(key string, value interface{}) sq.GtOrEq {
+	return sq.GtOrEq{key: value}
+}
+
+func (s *Squirrel) LtOrEq(key string, value interface{}) sq.LtOrEq {
+	return sq.LtOrEq{key: value}
+}
+
 func (s *Squirrel) Expr(sql string, args ...interface{}) sq.Sqlizer {
-	return sq.Expr(sql, args)
+	return sq.Expr(sql, args...)
 }
 
 func (s *Squirrel) JSONPathWhere(fieldName, jsonbOp, searchField, value string) (string, error) {
@@ -0,0 +1,69 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgx/v4"
+	otelpkg "go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.7.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// tracedOperations are the query log messages of pgx that become spans, the
+// rest is about connections
+var tracedOperations = map[string]bool{
+	"Exec":              true,
+	"Query":             true,
+	"SendBatch":         true,
+	"BatchResult.Exec":  true,
+	"BatchResult.Query": true,
+	"BatchResult.Close": true,
+	"CopyFrom":          true,
+	"Prepare failed":    true,
+}
+
+// queryTracer turns the query log of pgx into spans under the span of the
+// query context. pgx v4 logs a query once it is done, with its duration, so
+// the span is started back in time. Arguments are left out, they may hold
+// personal data
+type queryTracer struct{}
+
+func (queryTracer) Log(ctx context.Context, level pgx.LogLevel, msg string, data map[string]interface{}) {
+	if !tracedOperations[msg] || !trace.SpanFromContext(ctx).SpanContext().IsValid() {
+		return
+	}
+
+	end := time.Now()
+	start := end
+	if took, ok := data["time"].(time.Duration); ok {
+		start = end.Add(-took)
+	}
+
+	_, span := otelpkg.Tracer("postgres").Start(ctx, "SQL "+msg,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithTimestamp(start),
+		trace.WithAttributes(semconv.DBSystemPostgreSQL),
+	)
+	defer span.End(trace.WithTimestamp(end))
+
+	if sql, ok := data["sql"].(string); ok {
+		span.SetAttributes(semconv.DBStatementKey.String(sql))
+	}
+	switch rows := data["rowCount"].(type) {
+	case int:
+		span.SetAttributes(attribute.Int("db.rows", rows))
+	case int64:
+		span.SetAttributes(attribute.Int64("db.rows", rows))
+	}
+	if tag, ok := data["commandTag"].(pgconn.CommandTag); ok {
+		span.SetAttributes(attribute.Int64("db.rows", tag.RowsAffected()))
+	}
+	if err, ok := data["err"].(error); ok {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+}
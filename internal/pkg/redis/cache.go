@@ -6,6 +6,7 @@ import (
 	"github.com/go-redis/redis/v8"
 
 	"musobaqa/farm-competition/internal/pkg/config"
+	"musobaqa/farm-competition/internal/pkg/metrics"
 )
 
 type RedisDB struct {
@@ -22,5 +23,8 @@ func New(cfg *config.Config) (*RedisDB, error) {
 		Password: cfg.Redis.Password,
 		DB:       db,
 	})
+	// the hook has to be added before the client is copied, the commands of
+	// the copy still run through the original
+	rdb.AddHook(metrics.RedisHook{})
 	return &RedisDB{Client: *rdb}, nil
 }
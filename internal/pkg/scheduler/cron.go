@@ -0,0 +1,186 @@
+package scheduler
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Schedule tells when a job runs next
+type Schedule interface {
+	// Next is the first run strictly after t
+	Next(t time.Time) time.Time
+}
+
+var descriptors = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+// Parse reads a standard five field cron expression (minute, hour, day of
+// month, month, day of week), one of the @hourly, @daily, @weekly, @monthly
+// and @yearly shorthands or "@every <duration>"
+func Parse(spec string) (Schedule, error) {
+	spec = strings.TrimSpace(spec)
+
+	if rest, ok := strings.CutPrefix(spec, "@every "); ok {
+		every, err := time.ParseDuration(strings.TrimSpace(rest))
+		if err != nil {
+			return nil, fmt.Errorf("schedule %q: %w", spec, err)
+		}
+		if every < time.Second {
+			return nil, fmt.Errorf("schedule %q: must be at least a second apart", spec)
+		}
+		return Every(every), nil
+	}
+
+	expression := spec
+	if descriptor, ok := descriptors[spec]; ok {
+		expression = descriptor
+	}
+
+	fields := strings.Fields(expression)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("schedule %q: want 5 fields, got %d", spec, len(fields))
+	}
+
+	var (
+		cron   cronSchedule
+		err    error
+		bounds = []struct {
+			set      *uint64
+			min, max int
+		}{
+			{&cron.minute, 0, 59},
+			{&cron.hour, 0, 23},
+			{&cron.dom, 1, 31},
+			{&cron.month, 1, 12},
+			// 7 is sunday as well as 0
+			{&cron.dow, 0, 7},
+		}
+	)
+	for i, field := range fields {
+		*bounds[i].set, err = parseField(field, bounds[i].min, bounds[i].max)
+		if err != nil {
+			return nil, fmt.Errorf("schedule %q: %w", spec, err)
+		}
+	}
+	if cron.dow&(1<<7) != 0 {
+		cron.dow |= 1
+	}
+	cron.anyDom = fields[2] == "*"
+	cron.anyDow = fields[4] == "*"
+
+	return &cron, nil
+}
+
+// parseField turns a comma separated list of *, n, a-b with an optional /step
+// into a bit set of the values it allows
+func parseField(field string, min, max int) (uint64, error) {
+	var set uint64
+	for _, part := range strings.Split(field, ",") {
+		rangePart, stepPart, hasStep := strings.Cut(part, "/")
+
+		step := 1
+		if hasStep {
+			var err error
+			step, err = strconv.Atoi(stepPart)
+			if err != nil || step <= 0 {
+				return 0, fmt.Errorf("bad step in %q", part)
+			}
+		}
+
+		low, high := min, max
+		switch {
+		case rangePart == "*":
+		case strings.Contains(rangePart, "-"):
+			from, to, _ := strings.Cut(rangePart, "-")
+			var err error
+			if low, err = strconv.Atoi(from); err != nil {
+				return 0, fmt.Errorf("bad range in %q", part)
+			}
+			if high, err = strconv.Atoi(to); err != nil {
+				return 0, fmt.Errorf("bad range in %q", part)
+			}
+		default:
+			value, err := strconv.Atoi(rangePart)
+			if err != nil {
+				return 0, fmt.Errorf("bad value in %q", part)
+			}
+			low, high = value, value
+			// n/step runs from n to the end of the field
+			if hasStep {
+				high = max
+			}
+		}
+
+		if low < min || high > max || low > high {
+			return 0, fmt.Errorf("%q is out of %d-%d", part, min, max)
+		}
+		for value := low; value <= high; value += step {
+			set |= 1 << value
+		}
+	}
+
+	return set, nil
+}
+
+type cronSchedule struct {
+	minute, hour, dom, month, dow uint64
+	// like cron, when both days are restricted either of them matches
+	anyDom, anyDow bool
+}
+
+func (c *cronSchedule) Next(t time.Time) time.Time {
+	t = t.Truncate(time.Minute).Add(time.Minute)
+
+	// a schedule that never matches, like the 31st of february, gives up
+	// after five years
+	limit := t.AddDate(5, 0, 0)
+	for t.Before(limit) {
+		if c.month&(1<<int(t.Month())) == 0 {
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, t.Location())
+			continue
+		}
+		if !c.matchDay(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, t.Location())
+			continue
+		}
+		if c.hour&(1<<t.Hour()) == 0 {
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, t.Location())
+			continue
+		}
+		if c.minute&(1<<t.Minute()) == 0 {
+			t = t.Add(time.Minute)
+			continue
+		}
+		return t
+	}
+
+	return time.Time{}
+}
+
+func (c *cronSchedule) matchDay(t time.Time) bool {
+	dom := c.dom&(1<<t.Day()) != 0
+	dow := c.dow&(1<<int(t.Weekday())) != 0
+
+	if c.anyDom || c.anyDow {
+		return dom && dow
+	}
+	return dom || dow
+}
+
+// Every runs a job at a fixed interval. Runs fall on multiples of the
+// interval since the unix epoch, so every replica picks the same times
+type Every time.Duration
+
+func (e Every) Next(t time.Time) time.Time {
+	interval := time.Duration(e)
+	return t.Truncate(interval).Add(interval)
+}
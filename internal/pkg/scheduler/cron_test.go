@@ -0,0 +1,48 @@
+package scheduler_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"musobaqa/farm-competition/internal/pkg/scheduler"
+)
+
+func TestParse(t *testing.T) {
+	// a tuesday
+	from := time.Date(2024, time.May, 14, 10, 17, 30, 0, time.UTC)
+
+	for _, test := range []struct {
+		spec string
+		next time.Time
+	}{
+		{"* * * * *", time.Date(2024, time.May, 14, 10, 18, 0, 0, time.UTC)},
+		{"*/15 * * * *", time.Date(2024, time.May, 14, 10, 30, 0, 0, time.UTC)},
+		{"0 3 * * *", time.Date(2024, time.May, 15, 3, 0, 0, 0, time.UTC)},
+		{"5,50 9-11 * * *", time.Date(2024, time.May, 14, 10, 50, 0, 0, time.UTC)},
+		{"0 6 * * 1-5", time.Date(2024, time.May, 15, 6, 0, 0, 0, time.UTC)},
+		{"0 6 * * 7", time.Date(2024, time.May, 19, 6, 0, 0, 0, time.UTC)},
+		{"0 0 1 * *", time.Date(2024, time.June, 1, 0, 0, 0, 0, time.UTC)},
+		// both days restricted, either matches
+		{"0 0 20 * 3", time.Date(2024, time.May, 15, 0, 0, 0, 0, time.UTC)},
+		{"0 0 29 2 *", time.Date(2028, time.February, 29, 0, 0, 0, 0, time.UTC)},
+		{"@hourly", time.Date(2024, time.May, 14, 11, 0, 0, 0, time.UTC)},
+		{"@daily", time.Date(2024, time.May, 15, 0, 0, 0, 0, time.UTC)},
+		{"@every 5m", time.Date(2024, time.May, 14, 10, 20, 0, 0, time.UTC)},
+	} {
+		schedule, err := scheduler.Parse(test.spec)
+		require.NoError(t, err, test.spec)
+		assert.Equal(t, test.next, schedule.Next(from), test.spec)
+	}
+
+	for _, spec := range []string{"", "* * * *", "60 * * * *", "* 24 * * *", "* * 0 * *", "*/0 * * * *", "5-1 * * * *", "@every 100ms", "@every soon", "@often"} {
+		_, err := scheduler.Parse(spec)
+		assert.Error(t, err, spec)
+	}
+
+	never, err := scheduler.Parse("0 0 31 2 *")
+	require.NoError(t, err)
+	assert.True(t, never.Next(from).IsZero())
+}
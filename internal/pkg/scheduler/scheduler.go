@@ -0,0 +1,215 @@
+// Package scheduler runs the recurring jobs of the app on cron schedules.
+// Every replica runs the scheduler, a Locker makes sure only one of them
+// runs each occurrence of a job and keeps the history of the runs
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"musobaqa/farm-competition/internal/entity"
+)
+
+// Locker hands out the runs of jobs across replicas
+type Locker interface {
+	// Claim starts the run of the job due at scheduledAt, it returns nil
+	// when another replica has taken that run or is still running the job.
+	// An unfinished run is given up on after lease
+	Claim(ctx context.Context, job string, scheduledAt time.Time, lease time.Duration) (*entity.JobRun, error)
+	// Finish records how the run ended, runErr is nil when it succeeded
+	Finish(ctx context.Context, run *entity.JobRun, runErr error) error
+}
+
+// Func is the work of a job, it has to stop when ctx is done
+type Func func(ctx context.Context) error
+
+type job struct {
+	name     string
+	spec     string
+	schedule Schedule
+	timeout  time.Duration
+	run      Func
+
+	mu   sync.Mutex
+	next time.Time
+}
+
+type Scheduler struct {
+	locker Locker
+	logger *zap.Logger
+
+	mu      sync.Mutex
+	jobs    map[string]*job
+	started bool
+	running sync.WaitGroup
+}
+
+func New(locker Locker, logger *zap.Logger) *Scheduler {
+	return &Scheduler{
+		locker: locker,
+		logger: logger,
+		jobs:   map[string]*job{},
+	}
+}
+
+// Register adds a job that runs on the cron spec, a run is stopped after
+// timeout. Jobs are registered before Start
+func (s *Scheduler) Register(name, spec string, timeout time.Duration, run Func) error {
+	schedule, err := Parse(spec)
+	if err != nil {
+		return fmt.Errorf("job %s: %w", name, err)
+	}
+	if timeout <= 0 {
+		return fmt.Errorf("job %s: timeout must be positive", name)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.started {
+		return fmt.Errorf("job %s: scheduler is already started", name)
+	}
+	if _, ok := s.jobs[name]; ok {
+		return fmt.Errorf("job %s: registered twice", name)
+	}
+
+	s.jobs[name] = &job{
+		name:     name,
+		spec:     spec,
+		schedule: schedule,
+		timeout:  timeout,
+		run:      run,
+	}
+	return nil
+}
+
+// Start runs every job on its schedule until ctx is done, Wait tells when
+// the runs in progress have finished
+func (s *Scheduler) Start(ctx context.Context) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.started = true
+	for _, j := range s.jobs {
+		j.setNext(j.schedule.Next(time.Now()))
+		s.running.Add(1)
+		go func(j *job) {
+			defer s.running.Done()
+			s.loop(ctx, j)
+		}(j)
+	}
+}
+
+// Wait blocks until every job loop has returned after the context of Start
+// is done, a run in progress is recorded before its loop returns
+func (s *Scheduler) Wait() {
+	s.running.Wait()
+}
+
+// Jobs lists the registered jobs by name with their next run on this
+// replica, which is zero until the scheduler is started
+func (s *Scheduler) Jobs() []*entity.Job {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	jobs := make([]*entity.Job, 0, len(s.jobs))
+	for _, j := range s.jobs {
+		jobs = append(jobs, &entity.Job{
+			Name:      j.name,
+			Schedule:  j.spec,
+			Timeout:   j.timeout,
+			NextRunAt: j.getNext(),
+		})
+	}
+	sort.Slice(jobs, func(i, k int) bool {
+		return jobs[i].Name < jobs[k].Name
+	})
+
+	return jobs
+}
+
+func (s *Scheduler) loop(ctx context.Context, j *job) {
+	for {
+		next := j.getNext()
+		if next.IsZero() {
+			s.logger.Warn("job never runs again", zap.String("job", j.name), zap.String("schedule", j.spec))
+			return
+		}
+
+		timer := time.NewTimer(time.Until(next))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+
+		s.runOnce(ctx, j, next)
+		j.setNext(j.schedule.Next(time.Now()))
+	}
+}
+
+// runOnce runs the job for the occurrence at scheduledAt if this replica
+// gets to claim it
+func (s *Scheduler) runOnce(ctx context.Context, j *job, scheduledAt time.Time) {
+	logger := s.logger.With(zap.String("job", j.name), zap.Time("scheduled_at", scheduledAt))
+
+	run, err := s.locker.Claim(ctx, j.name, scheduledAt, j.timeout)
+	if err != nil {
+		if ctx.Err() == nil {
+			logger.Error("claim job run", zap.Error(err))
+		}
+		return
+	}
+	if run == nil {
+		logger.Debug("job run taken by another replica")
+		return
+	}
+
+	runCtx, cancel := context.WithTimeout(ctx, j.timeout)
+	runErr := call(runCtx, j.run)
+	cancel()
+
+	// the run is recorded even when the app is stopping
+	finishCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), 5*time.Second)
+	defer cancel()
+	if err := s.locker.Finish(finishCtx, run, runErr); err != nil {
+		logger.Error("finish job run", zap.Error(err))
+	}
+
+	if runErr != nil {
+		logger.Error("job failed", zap.Error(runErr))
+		return
+	}
+	logger.Info("job done", zap.Duration("took", time.Since(run.StartedAt)))
+}
+
+// call turns a panic of the job into its error, so it is recorded and the
+// job runs again next time
+func call(ctx context.Context, fn Func) (err error) {
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			err = errors.New(fmt.Sprint("panic: ", recovered))
+		}
+	}()
+
+	return fn(ctx)
+}
+
+func (j *job) getNext() time.Time {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return j.next
+}
+
+func (j *job) setNext(next time.Time) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	j.next = next
+}
@@ -0,0 +1,136 @@
+package scheduler_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"musobaqa/farm-competition/internal/entity"
+	"musobaqa/farm-competition/internal/pkg/scheduler"
+)
+
+// memoryLocker hands every occurrence of a job out once, like the job_runs table
+type memoryLocker struct {
+	mu   sync.Mutex
+	runs map[string]*entity.JobRun
+}
+
+func (m *memoryLocker) Claim(ctx context.Context, job string, scheduledAt time.Time, lease time.Duration) (*entity.JobRun, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	key := job + scheduledAt.String()
+	if _, ok := m.runs[key]; ok {
+		return nil, nil
+	}
+
+	run := &entity.JobRun{ID: key, Job: job, ScheduledAt: scheduledAt, Status: entity.JobRunRunning, StartedAt: time.Now()}
+	m.runs[key] = run
+	return run, nil
+}
+
+func (m *memoryLocker) Finish(ctx context.Context, run *entity.JobRun, runErr error) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	run.Status = entity.JobRunSucceeded
+	if runErr != nil {
+		run.Status, run.Error = entity.JobRunFailed, runErr.Error()
+	}
+	return nil
+}
+
+func (m *memoryLocker) statuses(job string) map[string]int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	statuses := map[string]int{}
+	for _, run := range m.runs {
+		if run.Job == job {
+			statuses[run.Status]++
+		}
+	}
+	return statuses
+}
+
+func TestScheduler(t *testing.T) {
+	locker := &memoryLocker{runs: map[string]*entity.JobRun{}}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var (
+		mu   sync.Mutex
+		runs int
+	)
+	// two replicas with the same jobs
+	for i := 0; i < 2; i++ {
+		s := scheduler.New(locker, zap.NewNop())
+		require.NoError(t, s.Register("count", "@every 1s", time.Second, func(ctx context.Context) error {
+			mu.Lock()
+			defer mu.Unlock()
+			runs++
+			return nil
+		}))
+		require.NoError(t, s.Register("broken", "@every 1s", time.Second, func(ctx context.Context) error {
+			panic("no stock table")
+		}))
+		require.NoError(t, s.Register("idle", "0 0 1 1 *", time.Minute, func(ctx context.Context) error {
+			return errors.New("not yet")
+		}))
+
+		assert.Error(t, s.Register("count", "@hourly", time.Second, nil))
+		assert.Error(t, s.Register("bad", "@sometimes", time.Second, nil))
+
+		s.Start(ctx)
+
+		jobs := s.Jobs()
+		require.Len(t, jobs, 3)
+		assert.Equal(t, "broken", jobs[0].Name)
+		assert.Equal(t, "0 0 1 1 *", jobs[2].Schedule)
+		assert.False(t, jobs[2].NextRunAt.IsZero())
+
+		assert.Error(t, s.Register("late", "@hourly", time.Second, nil))
+	}
+
+	time.Sleep(2500 * time.Millisecond)
+	cancel()
+
+	counted := locker.statuses("count")
+	mu.Lock()
+	assert.Equal(t, counted[entity.JobRunSucceeded], runs)
+	mu.Unlock()
+	assert.GreaterOrEqual(t, counted[entity.JobRunSucceeded], 2)
+
+	broken := locker.statuses("broken")
+	assert.GreaterOrEqual(t, broken[entity.JobRunFailed], 2)
+	assert.Zero(t, broken[entity.JobRunSucceeded])
+
+	assert.Empty(t, locker.statuses("idle"))
+}
+
+func TestSchedulerWait(t *testing.T) {
+	locker := &memoryLocker{runs: map[string]*entity.JobRun{}}
+	ctx, cancel := context.WithCancel(context.Background())
+
+	started := make(chan struct{})
+	s := scheduler.New(locker, zap.NewNop())
+	require.NoError(t, s.Register("slow", "@every 1s", time.Minute, func(ctx context.Context) error {
+		close(started)
+		<-ctx.Done()
+		time.Sleep(50 * time.Millisecond)
+		return ctx.Err()
+	}))
+	s.Start(ctx)
+
+	// Wait returns once the run in progress has been recorded
+	<-started
+	cancel()
+	s.Wait()
+	assert.Equal(t, map[string]int{entity.JobRunFailed: 1}, locker.statuses("slow"))
+}
@@ -4,8 +4,10 @@ package tokens
 
 import (
 	// "musobaqa/farm-competition/internal/pkg/config"
+	"errors"
 	"fmt"
 	"musobaqa/farm-competition/internal/pkg/logger"
+	"strings"
 	"time"
 
 	"github.com/dgrijalva/jwt-go"
@@ -117,3 +119,16 @@ func ExtractClaim(tokenStr string, signingKey []byte) (jwt.MapClaims, error) {
 
 	return claims, nil
 }
+
+// ErrNoToken is returned for a request sent without a token
+var ErrNoToken = errors.New("no token")
+
+// ExtractBearer extracts claims from the value of an Authorization header,
+// the REST and the grpc api both send the token this way, with or without
+// the Bearer prefix
+func ExtractBearer(header string, signingKey []byte) (jwt.MapClaims, error) {
+	if header == "" {
+		return nil, ErrNoToken
+	}
+	return ExtractClaim(strings.TrimPrefix(header, "Bearer "), signingKey)
+}
@@ -3,6 +3,8 @@ package utils
 import (
 	"strconv"
 	"strings"
+
+	"musobaqa/farm-competition/internal/pkg/listquery"
 )
 
 type QueryParam struct {
@@ -11,6 +13,7 @@ type QueryParam struct {
 	Page   uint64
 	Ordering []string
 	Search   string
+	Cursor   string
 }
 
 func ParseQueryParam(queryParams map[string][]string) (*QueryParam, []string) {
@@ -45,11 +48,16 @@ func ParseQueryParam(queryParams map[string][]string) (*QueryParam, []string) {
 			params.Search = value[0]
 			continue
 		}
+		if key == "cursor" {
+			params.Cursor = value[0]
+			continue
+		}
 		if key == "ordering" {
 			params.Ordering = strings.Split(value[0], ",")
 			continue
 		}
 		params.Filters[key] = value[0]
 	}
+	params.Page, params.Limit = listquery.Page(params.Page, params.Limit)
 	return &params, errStr
 }
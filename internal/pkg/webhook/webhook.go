@@ -0,0 +1,57 @@
+// Package webhook holds what senders and receivers of farm webhooks agree on:
+// the headers, the signature and the retry schedule, and the client that
+// sends them
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	HeaderEvent     = "X-Farm-Event"
+	HeaderDelivery  = "X-Farm-Delivery"
+	HeaderTimestamp = "X-Farm-Timestamp"
+	HeaderSignature = "X-Farm-Signature"
+
+	signaturePrefix = "sha256="
+)
+
+// Sign returns the X-Farm-Signature of a body sent at timestamp, the
+// HMAC-SHA256 of "<timestamp>.<body>" keyed by the secret of the subscription
+func Sign(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return signaturePrefix + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify tells whether signature was made by Sign with the same secret,
+// timestamp and body
+func Verify(secret string, timestamp int64, body []byte, signature string) bool {
+	if !strings.HasPrefix(signature, signaturePrefix) {
+		return false
+	}
+	return hmac.Equal([]byte(signature), []byte(Sign(secret, timestamp, body)))
+}
+
+// Backoff is how long to wait after the given failed attempt, counted from
+// one: base after the first, doubled after every next one, never above max
+func Backoff(attempt int, base, max time.Duration) time.Duration {
+	wait := base
+	for i := 1; i < attempt; i++ {
+		wait *= 2
+		if wait >= max || wait <= 0 {
+			return max
+		}
+	}
+	if wait > max {
+		return max
+	}
+	return wait
+}
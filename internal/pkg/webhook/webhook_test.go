@@ -0,0 +1,50 @@
+package webhook_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"musobaqa/farm-competition/internal/pkg/webhook"
+)
+
+func TestSign(t *testing.T) {
+	body := []byte(`{"type":"stock.low"}`)
+
+	signature := webhook.Sign("secret", 1700000000, body)
+	assert.Equal(t, "sha256=", signature[:7])
+	assert.Len(t, signature, 7+64)
+
+	assert.True(t, webhook.Verify("secret", 1700000000, body, signature))
+	assert.False(t, webhook.Verify("other", 1700000000, body, signature))
+	assert.False(t, webhook.Verify("secret", 1700000001, body, signature))
+	assert.False(t, webhook.Verify("secret", 1700000000, []byte(`{}`), signature))
+	assert.False(t, webhook.Verify("secret", 1700000000, body, signature[7:]))
+}
+
+func TestBackoff(t *testing.T) {
+	base, max := 30*time.Second, 10*time.Minute
+
+	assert.Equal(t, 30*time.Second, webhook.Backoff(1, base, max))
+	assert.Equal(t, time.Minute, webhook.Backoff(2, base, max))
+	assert.Equal(t, 4*time.Minute, webhook.Backoff(4, base, max))
+	assert.Equal(t, max, webhook.Backoff(6, base, max))
+	assert.Equal(t, max, webhook.Backoff(100, base, max))
+}
+
+func TestClient(t *testing.T) {
+	var called bool
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		called = true
+	}))
+	defer server.Close()
+
+	// The test server listens on loopback, which subscribers may not use
+	_, err := webhook.NewClient(time.Second).Post(server.URL, "application/json", nil)
+	assert.True(t, errors.Is(err, webhook.ErrAddressNotPublic))
+	assert.False(t, called)
+}
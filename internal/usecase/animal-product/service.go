@@ -4,19 +4,27 @@ import (
 	"context"
 	"github.com/google/uuid"
 	"musobaqa/farm-competition/internal/entity"
+	errorspkg "musobaqa/farm-competition/internal/errors"
 	"musobaqa/farm-competition/internal/infrastructure/repository/postgresql/repo"
+	"musobaqa/farm-competition/internal/pkg/events"
+	"musobaqa/farm-competition/internal/pkg/listquery"
+	"musobaqa/farm-competition/internal/pkg/otlp"
 	"time"
 )
 
 type animalProductService struct {
 	ctxTimeout time.Duration
 	repo       repo.AnimalProduct
+	outbox     repo.Outbox
+	tx         repo.Transactor
 }
 
-func NewAnimalProductService(timeout time.Duration, repository repo.AnimalProduct) AnimalProduct {
+func NewAnimalProductService(timeout time.Duration, repository repo.AnimalProduct, outbox repo.Outbox, tx repo.Transactor) AnimalProduct {
 	return &animalProductService{
 		ctxTimeout: timeout,
 		repo:       repository,
+		outbox:     outbox,
+		tx:         tx,
 	}
 }
 
@@ -30,34 +38,81 @@ func (ap *animalProductService) beforeUpdate(animal *entity.AnimalProductReq) {
 	animal.UpdatedAt = time.Now().UTC()
 }
 
+// Create stores the yield and records yield.recorded in the same transaction
 func (ap *animalProductService) Create(ctx context.Context, animal *entity.AnimalProductReq) (*entity.AnimalProductRes, error) {
+	ctx, span := otlp.Start(ctx, "usecase", "animalProductService.Create")
+	defer span.End()
+
 	ap.beforeCreate(animal)
 
-	return ap.repo.Create(ctx, animal)
+	var res *entity.AnimalProductRes
+	err := ap.tx.WithTx(ctx, func(ctx context.Context) error {
+		var err error
+		res, err = ap.repo.Create(ctx, animal)
+		if err != nil {
+			return err
+		}
+
+		event, err := events.New(entity.EventYieldRecorded, "animal_product", res.ID, &entity.YieldRecorded{
+			YieldID:     res.ID,
+			AnimalID:    animal.AnimalID,
+			AnimalName:  res.Animal.Name,
+			ProductID:   animal.ProductID,
+			ProductName: res.Product.Name,
+			Capacity:    res.Capacity,
+			Union:       res.Product.Union,
+			GetTime:     res.GetTime,
+		})
+		if err != nil {
+			return err
+		}
+		return ap.outbox.Add(ctx, event)
+	})
+	return res, errorspkg.Wrap(err, "create animal product")
 }
 
 func (ap *animalProductService) Update(ctx context.Context, animalProduct *entity.AnimalProductReq) (*entity.AnimalProductRes, error) {
+	ctx, span := otlp.Start(ctx, "usecase", "animalProductService.Update")
+	defer span.End()
+
 	ap.beforeUpdate(animalProduct)
 
-	return ap.repo.Update(ctx, animalProduct)
+	res, err := ap.repo.Update(ctx, animalProduct)
+	return res, errorspkg.Wrap(err, "update animal product %s", animalProduct.ID)
 }
 
-func (ap *animalProductService) Delete(ctx context.Context, animalProductID string) error {
-	return ap.repo.Delete(ctx, animalProductID)
+func (ap *animalProductService) Delete(ctx context.Context, animalProductID string, version int64) error {
+	ctx, span := otlp.Start(ctx, "usecase", "animalProductService.Delete")
+	defer span.End()
+
+	return errorspkg.Wrap(ap.repo.Delete(ctx, animalProductID, version), "delete animal product %s", animalProductID)
 }
 
 func (ap *animalProductService) Get(ctx context.Context, animalProductID string) (*entity.AnimalProductRes, error) {
-	return ap.repo.Get(ctx, animalProductID)
+	ctx, span := otlp.Start(ctx, "usecase", "animalProductService.Get")
+	defer span.End()
+
+	res, err := ap.repo.Get(ctx, animalProductID)
+	return res, errorspkg.Wrap(err, "get animal product %s", animalProductID)
 }
 
-func (ap *animalProductService) List(ctx context.Context, page, limit uint64, params map[string]any) (*entity.ListAnimalProduct, error) {
-	return ap.repo.List(ctx, page, limit, params)
+func (ap *animalProductService) List(ctx context.Context, page, limit uint64, request listquery.Request) (*entity.ListAnimalProduct, error) {
+	ctx, span := otlp.Start(ctx, "usecase", "animalProductService.List")
+	defer span.End()
+
+	return ap.repo.List(ctx, page, limit, request)
 }
 
 func (ap *animalProductService) ListAnimals(ctx context.Context, page, limit uint64, productID string) (*entity.AnimalsWithProduct, error) {
+	ctx, span := otlp.Start(ctx, "usecase", "animalProductService.ListAnimals")
+	defer span.End()
+
 	return ap.repo.ListAnimals(ctx, page, limit, productID)
 }
 
 func (ap *animalProductService) ListProducts(ctx context.Context, page, limit uint64, productID string) (*entity.ProductsWithAnimal, error) {
+	ctx, span := otlp.Start(ctx, "usecase", "animalProductService.ListProducts")
+	defer span.End()
+
 	return ap.repo.ListProducts(ctx, page, limit, productID)
 }
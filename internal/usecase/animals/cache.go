@@ -0,0 +1,54 @@
+package animals
+
+import (
+	"context"
+	"musobaqa/farm-competition/internal/entity"
+	redisrepo "musobaqa/farm-competition/internal/infrastructure/repository/redis"
+	"musobaqa/farm-competition/internal/pkg/listquery"
+)
+
+const animalCacheType = "animal"
+
+type animalCache struct {
+	Animal
+	cache *redisrepo.Namespaces
+}
+
+// NewAnimalCache caches Get and List of the usecase, its writes drop the
+// cached animals
+func NewAnimalCache(next Animal, cache *redisrepo.Namespaces) Animal {
+	return &animalCache{
+		Animal: next,
+		cache:  cache,
+	}
+}
+
+func (c *animalCache) Create(ctx context.Context, animal *entity.Animal) (*entity.Animal, error) {
+	res, err := c.Animal.Create(ctx, animal)
+	c.cache.Invalidate(ctx, animalCacheType)
+	return res, err
+}
+
+func (c *animalCache) Update(ctx context.Context, animal *entity.Animal) (*entity.Animal, error) {
+	res, err := c.Animal.Update(ctx, animal)
+	c.cache.Invalidate(ctx, animalCacheType)
+	return res, err
+}
+
+func (c *animalCache) Delete(ctx context.Context, animalID string, version int64) error {
+	err := c.Animal.Delete(ctx, animalID, version)
+	c.cache.Invalidate(ctx, animalCacheType)
+	return err
+}
+
+func (c *animalCache) Get(ctx context.Context, animalID string) (*entity.Animal, error) {
+	return redisrepo.Load(ctx, c.cache, animalCacheType, redisrepo.Key("get", animalID), func(ctx context.Context) (*entity.Animal, error) {
+		return c.Animal.Get(ctx, animalID)
+	})
+}
+
+func (c *animalCache) List(ctx context.Context, page, limit uint64, request listquery.Request) (*entity.ListAnimal, error) {
+	return redisrepo.Load(ctx, c.cache, animalCacheType, redisrepo.Key("list", page, limit, request), func(ctx context.Context) (*entity.ListAnimal, error) {
+		return c.Animal.List(ctx, page, limit, request)
+	})
+}
@@ -4,19 +4,29 @@ import (
 	"context"
 	"github.com/google/uuid"
 	"musobaqa/farm-competition/internal/entity"
+	errorspkg "musobaqa/farm-competition/internal/errors"
 	"musobaqa/farm-competition/internal/infrastructure/repository/postgresql/repo"
+	"musobaqa/farm-competition/internal/pkg/events"
+	"musobaqa/farm-competition/internal/pkg/listquery"
+	"musobaqa/farm-competition/internal/pkg/otlp"
 	"time"
+
+	"github.com/spf13/cast"
 )
 
 type animalService struct {
 	ctxTimeout time.Duration
 	repo       repo.Animal
+	outbox     repo.Outbox
+	tx         repo.Transactor
 }
 
-func NewAnimalService(timeout time.Duration, repository repo.Animal) Animal {
+func NewAnimalService(timeout time.Duration, repository repo.Animal, outbox repo.Outbox, tx repo.Transactor) Animal {
 	return &animalService{
 		ctxTimeout: timeout,
 		repo:       repository,
+		outbox:     outbox,
+		tx:         tx,
 	}
 }
 
@@ -31,29 +41,78 @@ func (a *animalService) beforeUpdate(animal *entity.Animal) {
 }
 
 func (a *animalService) Create(ctx context.Context, animal *entity.Animal) (*entity.Animal, error) {
+	ctx, span := otlp.Start(ctx, "usecase", "animalService.Create")
+	defer span.End()
+
 	a.beforeCreate(animal)
 
-	return a.repo.Create(ctx, animal)
+	res, err := a.repo.Create(ctx, animal)
+	return res, errorspkg.Wrap(err, "create animal")
 }
 
+// Update changes the animal, a change of its health records
+// animal.health_changed in the same transaction
 func (a *animalService) Update(ctx context.Context, animal *entity.Animal) (*entity.Animal, error) {
+	ctx, span := otlp.Start(ctx, "usecase", "animalService.Update")
+	defer span.End()
+
 	a.beforeUpdate(animal)
 
-	return a.repo.Update(ctx, animal)
+	var res *entity.Animal
+	err := a.tx.WithTx(ctx, func(ctx context.Context) error {
+		previous, err := a.repo.Get(ctx, animal.ID)
+		if err != nil {
+			return err
+		}
+
+		res, err = a.repo.Update(ctx, animal)
+		if err != nil {
+			return err
+		}
+
+		if cast.ToBool(previous.IsHealth) == cast.ToBool(res.IsHealth) {
+			return nil
+		}
+		event, err := events.New(entity.EventAnimalHealthChanged, "animal", res.ID, &entity.AnimalHealthChanged{
+			AnimalID:     res.ID,
+			Name:         res.Name,
+			CategoryName: res.CategoryName,
+			IsHealth:     cast.ToBool(res.IsHealth),
+			WasHealth:    cast.ToBool(previous.IsHealth),
+		})
+		if err != nil {
+			return err
+		}
+		return a.outbox.Add(ctx, event)
+	})
+	return res, errorspkg.Wrap(err, "update animal %s", animal.ID)
 }
 
-func (a *animalService) Delete(ctx context.Context, animalID string) error {
-	return a.repo.Delete(ctx, animalID)
+func (a *animalService) Delete(ctx context.Context, animalID string, version int64) error {
+	ctx, span := otlp.Start(ctx, "usecase", "animalService.Delete")
+	defer span.End()
+
+	return errorspkg.Wrap(a.repo.Delete(ctx, animalID, version), "delete animal %s", animalID)
 }
 
 func (a *animalService) Get(ctx context.Context, animalID string) (*entity.Animal, error) {
-	return a.repo.Get(ctx, animalID)
+	ctx, span := otlp.Start(ctx, "usecase", "animalService.Get")
+	defer span.End()
+
+	res, err := a.repo.Get(ctx, animalID)
+	return res, errorspkg.Wrap(err, "get animal %s", animalID)
 }
 
-func (a *animalService) List(ctx context.Context, page, limit uint64, params map[string]any) (*entity.ListAnimal, error) {
-	return a.repo.List(ctx, page, limit, params)
+func (a *animalService) List(ctx context.Context, page, limit uint64, request listquery.Request) (*entity.ListAnimal, error) {
+	ctx, span := otlp.Start(ctx, "usecase", "animalService.List")
+	defer span.End()
+
+	return a.repo.List(ctx, page, limit, request)
 }
 
 func (a *animalService) HungryAnimals(ctx context.Context, page, limit uint64) (*entity.ListAnimal, error) {
+	ctx, span := otlp.Start(ctx, "usecase", "animalService.HungryAnimals")
+	defer span.End()
+
 	return a.repo.HungryAnimals(ctx, page, limit)
 }
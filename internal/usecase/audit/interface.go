@@ -0,0 +1,14 @@
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"musobaqa/farm-competition/internal/entity"
+)
+
+type Audit interface {
+	Record(ctx context.Context, log *entity.AuditLog) error
+	Snapshot(ctx context.Context, entityType, entityID string) (json.RawMessage, error)
+	List(ctx context.Context, page, limit uint64, params map[string]any) (*entity.ListAuditLogs, error)
+	History(ctx context.Context, entityType, entityID string, page, limit uint64) (*entity.ListAuditLogs, error)
+}
@@ -0,0 +1,113 @@
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"musobaqa/farm-competition/internal/entity"
+	"musobaqa/farm-competition/internal/infrastructure/repository/postgresql/repo"
+	"musobaqa/farm-competition/internal/pkg/otlp"
+	"reflect"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type auditService struct {
+	ctxTimeout time.Duration
+	repo       repo.Audit
+}
+
+func NewAuditService(timeout time.Duration, repository repo.Audit) Audit {
+	return &auditService{
+		ctxTimeout: timeout,
+		repo:       repository,
+	}
+}
+
+func (a *auditService) beforeCreate(log *entity.AuditLog) {
+	log.ID = uuid.New().String()
+	log.CreatedAt = time.Now().UTC()
+}
+
+// Record stores the mutation with the current state of the entity as after,
+// before must be taken with Snapshot ahead of the change
+func (a *auditService) Record(ctx context.Context, log *entity.AuditLog) error {
+	ctx, span := otlp.Start(ctx, "usecase", "auditService.Record")
+	defer span.End()
+
+	a.beforeCreate(log)
+
+	after, err := a.repo.Snapshot(ctx, log.EntityType, log.EntityID)
+	if err != nil {
+		return err
+	}
+	log.After = after
+
+	log.Diff, err = diff(log.Before, log.After)
+	if err != nil {
+		return err
+	}
+
+	return a.repo.Create(ctx, log)
+}
+
+func (a *auditService) Snapshot(ctx context.Context, entityType, entityID string) (json.RawMessage, error) {
+	ctx, span := otlp.Start(ctx, "usecase", "auditService.Snapshot")
+	defer span.End()
+
+	return a.repo.Snapshot(ctx, entityType, entityID)
+}
+
+func (a *auditService) List(ctx context.Context, page, limit uint64, params map[string]any) (*entity.ListAuditLogs, error) {
+	ctx, span := otlp.Start(ctx, "usecase", "auditService.List")
+	defer span.End()
+
+	return a.repo.List(ctx, page, limit, params)
+}
+
+func (a *auditService) History(ctx context.Context, entityType, entityID string, page, limit uint64) (*entity.ListAuditLogs, error) {
+	ctx, span := otlp.Start(ctx, "usecase", "auditService.History")
+	defer span.End()
+
+	return a.repo.List(ctx, page, limit, map[string]any{
+		"entity_type": entityType,
+		"entity_id":   entityID,
+	})
+}
+
+type change struct {
+	From interface{} `json:"from"`
+	To   interface{} `json:"to"`
+}
+
+// diff lists the top level fields that differ between two JSON objects
+func diff(before, after json.RawMessage) (json.RawMessage, error) {
+	var beforeFields, afterFields map[string]interface{}
+	if len(before) > 0 {
+		if err := json.Unmarshal(before, &beforeFields); err != nil {
+			return nil, err
+		}
+	}
+	if len(after) > 0 {
+		if err := json.Unmarshal(after, &afterFields); err != nil {
+			return nil, err
+		}
+	}
+
+	changes := map[string]change{}
+	for field, value := range beforeFields {
+		if !reflect.DeepEqual(value, afterFields[field]) {
+			changes[field] = change{From: value, To: afterFields[field]}
+		}
+	}
+	for field, value := range afterFields {
+		if _, ok := beforeFields[field]; !ok {
+			changes[field] = change{To: value}
+		}
+	}
+	if len(changes) == 0 {
+		return nil, nil
+	}
+
+	return json.Marshal(changes)
+}
@@ -0,0 +1,62 @@
+package delivery
+
+import (
+	"context"
+	"musobaqa/farm-competition/internal/entity"
+	redisrepo "musobaqa/farm-competition/internal/infrastructure/repository/redis"
+	"musobaqa/farm-competition/internal/pkg/listquery"
+)
+
+const deliveryCacheType = "delivery"
+
+type deliveryCache struct {
+	Delivery
+	cache *redisrepo.Namespaces
+}
+
+// NewDeliveryCache caches Get and List of the usecase, its writes drop the
+// cached deliveries
+func NewDeliveryCache(next Delivery, cache *redisrepo.Namespaces) Delivery {
+	return &deliveryCache{
+		Delivery: next,
+		cache:    cache,
+	}
+}
+
+// Create also drops the cached foods and drugs, a delivery changes their
+// capacity
+func (c *deliveryCache) Create(ctx context.Context, delivery *entity.Delivery) (*entity.Delivery, error) {
+	res, err := c.Delivery.Create(ctx, delivery)
+	c.cache.Invalidate(ctx, deliveryCacheType, "food", "drug")
+	return res, err
+}
+
+func (c *deliveryCache) Update(ctx context.Context, delivery *entity.Delivery) (*entity.Delivery, error) {
+	res, err := c.Delivery.Update(ctx, delivery)
+	c.cache.Invalidate(ctx, deliveryCacheType)
+	return res, err
+}
+
+func (c *deliveryCache) Delete(ctx context.Context, deliveryID string, version int64) error {
+	err := c.Delivery.Delete(ctx, deliveryID, version)
+	c.cache.Invalidate(ctx, deliveryCacheType)
+	return err
+}
+
+func (c *deliveryCache) Get(ctx context.Context, deliveryID string) (*entity.Delivery, error) {
+	return redisrepo.Load(ctx, c.cache, deliveryCacheType, redisrepo.Key("get", deliveryID), func(ctx context.Context) (*entity.Delivery, error) {
+		return c.Delivery.Get(ctx, deliveryID)
+	})
+}
+
+func (c *deliveryCache) List(ctx context.Context, page, limit uint64, request listquery.Request) (*entity.ListDelivery, error) {
+	return redisrepo.Load(ctx, c.cache, deliveryCacheType, redisrepo.Key("list", page, limit, request), func(ctx context.Context) (*entity.ListDelivery, error) {
+		return c.Delivery.List(ctx, page, limit, request)
+	})
+}
+
+func (c *deliveryCache) SetInvoiceFile(ctx context.Context, deliveryID, invoiceFile string) error {
+	err := c.Delivery.SetInvoiceFile(ctx, deliveryID, invoiceFile)
+	c.cache.Invalidate(ctx, deliveryCacheType)
+	return err
+}
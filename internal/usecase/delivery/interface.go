@@ -3,12 +3,14 @@ package delivery
 import (
 	"context"
 	"musobaqa/farm-competition/internal/entity"
+	"musobaqa/farm-competition/internal/pkg/listquery"
 )
 
 type Delivery interface {
 	Create(ctx context.Context, delivery *entity.Delivery) (*entity.Delivery, error)
 	Update(ctx context.Context, delivery *entity.Delivery) (*entity.Delivery, error)
-	Delete(ctx context.Context, deliveryID string) error
+	Delete(ctx context.Context, deliveryID string, version int64) error
 	Get(ctx context.Context, deliveryID string) (*entity.Delivery, error)
-	List(ctx context.Context, page, limit uint64, params map[string]any) (*entity.ListDelivery, error)
+	List(ctx context.Context, page, limit uint64, request listquery.Request) (*entity.ListDelivery, error)
+	SetInvoiceFile(ctx context.Context, deliveryID, invoiceFile string) error
 }
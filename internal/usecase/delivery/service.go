@@ -4,19 +4,30 @@ import (
 	"context"
 	"github.com/google/uuid"
 	"musobaqa/farm-competition/internal/entity"
+	errorspkg "musobaqa/farm-competition/internal/errors"
 	"musobaqa/farm-competition/internal/infrastructure/repository/postgresql/repo"
+	"musobaqa/farm-competition/internal/pkg/events"
+	"musobaqa/farm-competition/internal/pkg/listquery"
+	"musobaqa/farm-competition/internal/pkg/otlp"
+	"musobaqa/farm-competition/internal/usecase/lots"
 	"time"
 )
 
 type deliveryService struct {
 	ctxTimeout time.Duration
 	repo       repo.Delivery
+	lots       lots.Lot
+	outbox     repo.Outbox
+	tx         repo.Transactor
 }
 
-func NewDeliveryService(timeout time.Duration, repository repo.Delivery) Delivery {
+func NewDeliveryService(timeout time.Duration, repository repo.Delivery, lot lots.Lot, outbox repo.Outbox, tx repo.Transactor) Delivery {
 	return &deliveryService{
 		ctxTimeout: timeout,
 		repo:       repository,
+		lots:       lot,
+		outbox:     outbox,
+		tx:         tx,
 	}
 }
 
@@ -24,32 +35,174 @@ func (a *deliveryService) beforeCreate(delivery *entity.Delivery) {
 	delivery.ID = uuid.New().String()
 	delivery.CreatedAt = time.Now().UTC()
 	delivery.UpdatedAt = time.Now().UTC()
+	a.calculateTotalCost(delivery)
 }
 
 func (a *deliveryService) beforeUpdate(delivery *entity.Delivery) {
 	delivery.UpdatedAt = time.Now().UTC()
+	a.calculateTotalCost(delivery)
 }
 
+// calculateTotalCost derives the invoice total from the unit price when it is not given explicitly
+func (a *deliveryService) calculateTotalCost(delivery *entity.Delivery) {
+	if delivery.TotalCost == 0 {
+		delivery.TotalCost = float64(delivery.Capacity) * delivery.UnitPrice
+	}
+}
+
+// Create stores the delivery, opens a stock lot for it, adds it to the
+// capacity of its food or drug and records delivery.received in the same
+// transaction
 func (a *deliveryService) Create(ctx context.Context, delivery *entity.Delivery) (*entity.Delivery, error) {
+	ctx, span := otlp.Start(ctx, "usecase", "deliveryService.Create")
+	defer span.End()
+
 	a.beforeCreate(delivery)
 
-	return a.repo.Create(ctx, delivery)
+	err := a.tx.WithTx(ctx, func(ctx context.Context) error {
+		if _, err := a.repo.Create(ctx, delivery); err != nil {
+			return err
+		}
+
+		lot, err := a.lots.Receive(ctx, &entity.StockLot{
+			DeliveryID:   delivery.ID,
+			Category:     delivery.Category,
+			Name:         delivery.Name,
+			LotNumber:    delivery.LotNumber,
+			ReceivedDate: receivedDate(delivery.Time),
+			ExpiryDate:   delivery.ExpiryDate,
+			Quantity:     delivery.Capacity,
+		})
+		if err != nil {
+			return err
+		}
+		delivery.LotNumber = lot.LotNumber
+
+		delivery.EatableCreated, err = a.lots.Restock(ctx, &entity.Restock{
+			Category:    delivery.Category,
+			Name:        delivery.Name,
+			Union:       delivery.Union,
+			Description: delivery.Description,
+			Status:      delivery.Status,
+			Quantity:    delivery.Capacity,
+		})
+		if err != nil {
+			return err
+		}
+
+		event, err := events.New(entity.EventDeliveryReceived, "delivery", delivery.ID, &entity.DeliveryReceived{
+			DeliveryID: delivery.ID,
+			Category:   delivery.Category,
+			Name:       delivery.Name,
+			Capacity:   delivery.Capacity,
+			Union:      delivery.Union,
+			Time:       delivery.Time,
+			SupplierID: delivery.SupplierID,
+			UnitPrice:  delivery.UnitPrice,
+			TotalCost:  delivery.TotalCost,
+			LotNumber:  delivery.LotNumber,
+		})
+		if err != nil {
+			return err
+		}
+		return a.outbox.Add(ctx, event)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return delivery, nil
+}
+
+// receivedDate cuts the date part of the delivery time
+func receivedDate(deliveryTime string) string {
+	if len(deliveryTime) < len(time.DateOnly) {
+		return ""
+	}
+	return deliveryTime[:len(time.DateOnly)]
 }
 
+// Update stores the delivery and revises its stock lot and the capacity of
+// its food or drug in the same transaction, with stock.changed
 func (a *deliveryService) Update(ctx context.Context, delivery *entity.Delivery) (*entity.Delivery, error) {
+	ctx, span := otlp.Start(ctx, "usecase", "deliveryService.Update")
+	defer span.End()
+
 	a.beforeUpdate(delivery)
 
-	return a.repo.Update(ctx, delivery)
+	var res *entity.Delivery
+	err := a.tx.WithTx(ctx, func(ctx context.Context) error {
+		var err error
+		res, err = a.repo.Update(ctx, delivery)
+		if err != nil {
+			return err
+		}
+
+		lot, err := a.lots.Revise(ctx, delivery)
+		if err != nil || lot == nil {
+			return err
+		}
+		return a.stockChanged(ctx, delivery.ID, delivery.Category, delivery.Name, "delivery_updated")
+	})
+	if err != nil {
+		return nil, errorspkg.Wrap(err, "update delivery %s", delivery.ID)
+	}
+
+	return res, nil
 }
 
-func (a *deliveryService) Delete(ctx context.Context, deliveryID string) error {
-	return a.repo.Delete(ctx, deliveryID)
+// Delete removes the delivery and writes off what is left in its stock lot
+// in the same transaction, with stock.changed
+func (a *deliveryService) Delete(ctx context.Context, deliveryID string, version int64) error {
+	ctx, span := otlp.Start(ctx, "usecase", "deliveryService.Delete")
+	defer span.End()
+
+	err := a.tx.WithTx(ctx, func(ctx context.Context) error {
+		if err := a.repo.Delete(ctx, deliveryID, version); err != nil {
+			return err
+		}
+
+		lot, err := a.lots.Withdraw(ctx, deliveryID)
+		if err != nil || lot == nil {
+			return err
+		}
+		return a.stockChanged(ctx, deliveryID, lot.Category, lot.Name, "delivery_deleted")
+	})
+	return errorspkg.Wrap(err, "delete delivery %s", deliveryID)
+}
+
+// stockChanged records stock.changed in the transaction of the change, the
+// cached foods and drugs are dropped once it is relayed
+func (a *deliveryService) stockChanged(ctx context.Context, deliveryID, category, name, reason string) error {
+	event, err := events.New(entity.EventStockChanged, "delivery", deliveryID, &entity.StockChanged{
+		Category: category,
+		Name:     name,
+		Reason:   reason,
+	})
+	if err != nil {
+		return err
+	}
+	return a.outbox.Add(ctx, event)
 }
 
 func (a *deliveryService) Get(ctx context.Context, deliveryID string) (*entity.Delivery, error) {
-	return a.repo.Get(ctx, deliveryID)
+	ctx, span := otlp.Start(ctx, "usecase", "deliveryService.Get")
+	defer span.End()
+
+	res, err := a.repo.Get(ctx, deliveryID)
+	return res, errorspkg.Wrap(err, "get delivery %s", deliveryID)
+}
+
+func (a *deliveryService) List(ctx context.Context, page, limit uint64, request listquery.Request) (*entity.ListDelivery, error) {
+	ctx, span := otlp.Start(ctx, "usecase", "deliveryService.List")
+	defer span.End()
+
+	return a.repo.List(ctx, page, limit, request)
 }
 
-func (a *deliveryService) List(ctx context.Context, page, limit uint64, params map[string]any) (*entity.ListDelivery, error) {
-	return a.repo.List(ctx, page, limit, params)
+func (a *deliveryService) SetInvoiceFile(ctx context.Context, deliveryID, invoiceFile string) error {
+	ctx, span := otlp.Start(ctx, "usecase", "deliveryService.SetInvoiceFile")
+	defer span.End()
+
+	return a.repo.SetInvoiceFile(ctx, deliveryID, invoiceFile)
 }
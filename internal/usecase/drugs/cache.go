@@ -0,0 +1,54 @@
+package drugs
+
+import (
+	"context"
+	"musobaqa/farm-competition/internal/entity"
+	redisrepo "musobaqa/farm-competition/internal/infrastructure/repository/redis"
+	"musobaqa/farm-competition/internal/pkg/listquery"
+)
+
+const drugCacheType = "drug"
+
+type drugCache struct {
+	Drug
+	cache *redisrepo.Namespaces
+}
+
+// NewDrugCache caches Get and List of the usecase, its writes drop the
+// cached drugs
+func NewDrugCache(next Drug, cache *redisrepo.Namespaces) Drug {
+	return &drugCache{
+		Drug:  next,
+		cache: cache,
+	}
+}
+
+func (c *drugCache) Create(ctx context.Context, drug *entity.Drug) (*entity.Drug, error) {
+	res, err := c.Drug.Create(ctx, drug)
+	c.cache.Invalidate(ctx, drugCacheType)
+	return res, err
+}
+
+func (c *drugCache) Update(ctx context.Context, drug *entity.Drug) (*entity.Drug, error) {
+	res, err := c.Drug.Update(ctx, drug)
+	c.cache.Invalidate(ctx, drugCacheType)
+	return res, err
+}
+
+func (c *drugCache) Delete(ctx context.Context, drugID string, version int64) error {
+	err := c.Drug.Delete(ctx, drugID, version)
+	c.cache.Invalidate(ctx, drugCacheType)
+	return err
+}
+
+func (c *drugCache) Get(ctx context.Context, params map[string]string) (*entity.Drug, error) {
+	return redisrepo.Load(ctx, c.cache, drugCacheType, redisrepo.Key("get", params), func(ctx context.Context) (*entity.Drug, error) {
+		return c.Drug.Get(ctx, params)
+	})
+}
+
+func (c *drugCache) List(ctx context.Context, page, limit uint64, request listquery.Request) (*entity.ListDrugs, error) {
+	return redisrepo.Load(ctx, c.cache, drugCacheType, redisrepo.Key("list", page, limit, request), func(ctx context.Context) (*entity.ListDrugs, error) {
+		return c.Drug.List(ctx, page, limit, request)
+	})
+}
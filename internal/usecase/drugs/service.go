@@ -4,19 +4,26 @@ import (
 	"context"
 	"github.com/google/uuid"
 	"musobaqa/farm-competition/internal/entity"
+	errorspkg "musobaqa/farm-competition/internal/errors"
 	"musobaqa/farm-competition/internal/infrastructure/repository/postgresql/repo"
+	"musobaqa/farm-competition/internal/pkg/listquery"
+	"musobaqa/farm-competition/internal/pkg/otlp"
 	"time"
 )
 
 type drugService struct {
 	ctxTimeout time.Duration
 	repo       repo.Drug
+	lots       repo.StockLot
+	tx         repo.Transactor
 }
 
-func NewDrugService(timeout time.Duration, repository repo.Drug) Drug {
+func NewDrugService(timeout time.Duration, repository repo.Drug, lots repo.StockLot, tx repo.Transactor) Drug {
 	return &drugService{
 		ctxTimeout: timeout,
 		repo:       repository,
+		lots:       lots,
+		tx:         tx,
 	}
 }
 
@@ -30,30 +37,89 @@ func (d *drugService) beforeUpdate(drug *entity.Drug) {
 	drug.UpdatedAt = time.Now().UTC()
 }
 
+// Create stores the drug and opens a lot for its capacity in the same
+// transaction, feedings take from lots only
 func (d *drugService) Create(ctx context.Context, drug *entity.Drug) (*entity.Drug, error) {
+	ctx, span := otlp.Start(ctx, "usecase", "drugService.Create")
+	defer span.End()
+
 	d.beforeCreate(drug)
 
-	return d.repo.Create(ctx, drug)
+	var res *entity.Drug
+	err := d.tx.WithTx(ctx, func(ctx context.Context) error {
+		var err error
+		res, err = d.repo.Create(ctx, drug)
+		if err != nil {
+			return err
+		}
+		return d.lots.Balance(ctx, "drug", res.Name)
+	})
+	if err != nil {
+		return nil, errorspkg.Wrap(err, "create drug")
+	}
+
+	return res, nil
 }
 
+// Update stores the drug and, in the same transaction, moves its lots to a
+// new name and opens or writes off lots for a changed capacity
 func (d *drugService) Update(ctx context.Context, drug *entity.Drug) (*entity.Drug, error) {
+	ctx, span := otlp.Start(ctx, "usecase", "drugService.Update")
+	defer span.End()
+
 	d.beforeUpdate(drug)
 
-	return d.repo.Update(ctx, drug)
+	var res *entity.Drug
+	err := d.tx.WithTx(ctx, func(ctx context.Context) error {
+		current, err := d.repo.Get(ctx, map[string]string{"id": drug.ID})
+		if err != nil {
+			return err
+		}
+
+		res, err = d.repo.Update(ctx, drug)
+		if err != nil {
+			return err
+		}
+
+		if current.Name != res.Name {
+			if err := d.lots.Rename(ctx, "drug", current.Name, res.Name); err != nil {
+				return err
+			}
+		}
+		return d.lots.Balance(ctx, "drug", res.Name)
+	})
+	if err != nil {
+		return nil, errorspkg.Wrap(err, "update drug %s", drug.ID)
+	}
+
+	return res, nil
 }
 
-func (d *drugService) Delete(ctx context.Context, drugID string) error {
-	return d.repo.Delete(ctx, drugID)
+func (d *drugService) Delete(ctx context.Context, drugID string, version int64) error {
+	ctx, span := otlp.Start(ctx, "usecase", "drugService.Delete")
+	defer span.End()
+
+	return errorspkg.Wrap(d.repo.Delete(ctx, drugID, version), "delete drug %s", drugID)
 }
 
 func (d *drugService) Get(ctx context.Context, params map[string]string) (*entity.Drug, error) {
-	return d.repo.Get(ctx, params)
+	ctx, span := otlp.Start(ctx, "usecase", "drugService.Get")
+	defer span.End()
+
+	res, err := d.repo.Get(ctx, params)
+	return res, errorspkg.Wrap(err, "get drug %v", params)
 }
 
-func (d *drugService) List(ctx context.Context, page, limit uint64, params map[string]any) (*entity.ListDrugs, error) {
-	return d.repo.List(ctx, page, limit, params)
+func (d *drugService) List(ctx context.Context, page, limit uint64, request listquery.Request) (*entity.ListDrugs, error) {
+	ctx, span := otlp.Start(ctx, "usecase", "drugService.List")
+	defer span.End()
+
+	return d.repo.List(ctx, page, limit, request)
 }
 
 func (d *drugService) UniqueDrugName(ctx context.Context, drugName string) (int, error) {
+	ctx, span := otlp.Start(ctx, "usecase", "drugService.UniqueDrugName")
+	defer span.End()
+
 	return d.repo.UniqueDrugName(ctx, drugName)
 }
@@ -4,7 +4,9 @@ import (
 	"context"
 	"github.com/google/uuid"
 	"musobaqa/farm-competition/internal/entity"
+	errorspkg "musobaqa/farm-competition/internal/errors"
 	"musobaqa/farm-competition/internal/infrastructure/repository/postgresql/repo"
+	"musobaqa/farm-competition/internal/pkg/otlp"
 	"time"
 )
 
@@ -31,25 +33,42 @@ func (d *eatablesService) beforeUpdate(drug *entity.Eatables) {
 }
 
 func (d *eatablesService) Create(ctx context.Context, drug *entity.Eatables) (*entity.EatablesRes, error) {
+	ctx, span := otlp.Start(ctx, "usecase", "eatablesService.Create")
+	defer span.End()
+
 	d.beforeCreate(drug)
 
-	return d.repo.Create(ctx, drug)
+	res, err := d.repo.Create(ctx, drug)
+	return res, errorspkg.Wrap(err, "create eatable info")
 }
 
 func (d *eatablesService) Update(ctx context.Context, drug *entity.Eatables) (*entity.EatablesRes, error) {
+	ctx, span := otlp.Start(ctx, "usecase", "eatablesService.Update")
+	defer span.End()
+
 	d.beforeUpdate(drug)
 
-	return d.repo.Update(ctx, drug)
+	res, err := d.repo.Update(ctx, drug)
+	return res, errorspkg.Wrap(err, "update eatable info %s", drug.ID)
 }
 
-func (d *eatablesService) Delete(ctx context.Context, eatableID string) error {
-	return d.repo.Delete(ctx, eatableID)
+func (d *eatablesService) Delete(ctx context.Context, eatableID string, version int64) error {
+	ctx, span := otlp.Start(ctx, "usecase", "eatablesService.Delete")
+	defer span.End()
+
+	return errorspkg.Wrap(d.repo.Delete(ctx, eatableID, version), "delete eatable info %s", eatableID)
 }
 
 func (d *eatablesService) GetDrugs(ctx context.Context, page, limit uint64, animalID string) (*entity.ListDrugEatables, error) {
+	ctx, span := otlp.Start(ctx, "usecase", "eatablesService.GetDrugs")
+	defer span.End()
+
 	return d.repo.GetDrugs(ctx, page, limit, animalID)
 }
 
 func (d *eatablesService) GetFoods(ctx context.Context, page, limit uint64, animalID string) (*entity.ListFoodEatables, error) {
+	ctx, span := otlp.Start(ctx, "usecase", "eatablesService.GetFoods")
+	defer span.End()
+
 	return d.repo.GetFoods(ctx, page, limit, animalID)
 }
@@ -3,10 +3,13 @@ package feeding
 import (
 	"context"
 	"musobaqa/farm-competition/internal/entity"
+	"musobaqa/farm-competition/internal/pkg/listquery"
 )
 
 type Feeding interface {
 	Create(ctx context.Context, feeding *entity.Feeding) (*entity.FeedingRes, error)
 	Update(ctx context.Context, eatable *entity.Feeding) (*entity.FeedingRes, error)
-	Delete(ctx context.Context, eatableID string) error
+	Delete(ctx context.Context, eatableID string, version int64) error
+	List(ctx context.Context, page, limit uint64, request listquery.Request) (*entity.ListFeedings, error)
+	Overdue(ctx context.Context) ([]*entity.OverdueFeeding, error)
 }
@@ -4,19 +4,29 @@ import (
 	"context"
 	"github.com/google/uuid"
 	"musobaqa/farm-competition/internal/entity"
+	errorspkg "musobaqa/farm-competition/internal/errors"
 	"musobaqa/farm-competition/internal/infrastructure/repository/postgresql/repo"
+	"musobaqa/farm-competition/internal/pkg/events"
+	"musobaqa/farm-competition/internal/pkg/listquery"
+	"musobaqa/farm-competition/internal/pkg/otlp"
 	"time"
 )
 
 type feedingService struct {
 	ctxTimeout time.Duration
 	repo       repo.Feeding
+	lots       repo.StockLot
+	outbox     repo.Outbox
+	tx         repo.Transactor
 }
 
-func NewFeedingService(timeout time.Duration, repository repo.Feeding) Feeding {
+func NewFeedingService(timeout time.Duration, repository repo.Feeding, lots repo.StockLot, outbox repo.Outbox, tx repo.Transactor) Feeding {
 	return &feedingService{
 		ctxTimeout: timeout,
 		repo:       repository,
+		lots:       lots,
+		outbox:     outbox,
+		tx:         tx,
 	}
 }
 
@@ -30,18 +40,128 @@ func (d *feedingService) beforeUpdate(feeding *entity.Feeding) {
 	feeding.UpdatedAt = time.Now().UTC()
 }
 
+// Create records the feeding and takes the given quantity from the first
+// expiring lots of the food or drug in the same transaction, with
+// stock.changed so the cached food or drug is dropped
 func (d *feedingService) Create(ctx context.Context, feeding *entity.Feeding) (*entity.FeedingRes, error) {
+	ctx, span := otlp.Start(ctx, "usecase", "feedingService.Create")
+	defer span.End()
+
 	d.beforeCreate(feeding)
 
-	return d.repo.Create(ctx, feeding)
+	var res *entity.FeedingRes
+	err := d.tx.WithTx(ctx, func(ctx context.Context) error {
+		var err error
+		res, err = d.repo.Create(ctx, feeding)
+		if err != nil {
+			return err
+		}
+
+		var quantity int64
+		for _, daily := range feeding.Daily {
+			quantity += daily.Capacity
+		}
+
+		_, err = d.lots.Consume(ctx, feeding.ID, feeding.Category, feeding.EatablesID, quantity, feeding.Day)
+		if err != nil {
+			return err
+		}
+
+		return d.stockChanged(ctx, feeding.Category, feeding.EatablesID)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
 }
 
+// Update gives what the feeding took back to its lots and takes the updated
+// quantity again in the same transaction, with stock.changed
 func (d *feedingService) Update(ctx context.Context, feeding *entity.Feeding) (*entity.FeedingRes, error) {
+	ctx, span := otlp.Start(ctx, "usecase", "feedingService.Update")
+	defer span.End()
+
 	d.beforeUpdate(feeding)
 
-	return d.repo.Update(ctx, feeding)
+	var res *entity.FeedingRes
+	err := d.tx.WithTx(ctx, func(ctx context.Context) error {
+		var err error
+		res, err = d.repo.Update(ctx, feeding)
+		if err != nil {
+			return err
+		}
+
+		if _, err := d.lots.Release(ctx, feeding.ID); err != nil {
+			return err
+		}
+
+		var quantity int64
+		for _, daily := range feeding.Daily {
+			quantity += daily.Capacity
+		}
+
+		_, err = d.lots.Consume(ctx, feeding.ID, feeding.Category, feeding.EatablesID, quantity, feeding.Day)
+		if err != nil {
+			return err
+		}
+
+		return d.stockChanged(ctx, feeding.Category, feeding.EatablesID)
+	})
+	if err != nil {
+		return nil, errorspkg.Wrap(err, "update feeding %s", feeding.ID)
+	}
+
+	return res, nil
+}
+
+// Delete gives what the feeding took back to its lots in the same
+// transaction, with stock.changed
+func (d *feedingService) Delete(ctx context.Context, feedingID string, version int64) error {
+	ctx, span := otlp.Start(ctx, "usecase", "feedingService.Delete")
+	defer span.End()
+
+	err := d.tx.WithTx(ctx, func(ctx context.Context) error {
+		if err := d.repo.Delete(ctx, feedingID, version); err != nil {
+			return err
+		}
+		released, err := d.lots.Release(ctx, feedingID)
+		if err != nil {
+			return err
+		}
+		for _, level := range released {
+			if err := d.stockChanged(ctx, level.Category, feedingID); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	return errorspkg.Wrap(err, "delete feeding %s", feedingID)
 }
 
-func (d *feedingService) Delete(ctx context.Context, feedingID string) error {
-	return d.repo.Delete(ctx, feedingID)
+func (d *feedingService) List(ctx context.Context, page, limit uint64, request listquery.Request) (*entity.ListFeedings, error) {
+	ctx, span := otlp.Start(ctx, "usecase", "feedingService.List")
+	defer span.End()
+
+	return d.repo.List(ctx, page, limit, request)
+}
+
+func (d *feedingService) Overdue(ctx context.Context) ([]*entity.OverdueFeeding, error) {
+	ctx, span := otlp.Start(ctx, "usecase", "feedingService.Overdue")
+	defer span.End()
+
+	return d.repo.Overdue(ctx)
+}
+
+// stockChanged records stock.changed in the transaction of the feeding, the
+// cached foods and drugs are dropped once it is relayed
+func (d *feedingService) stockChanged(ctx context.Context, category, aggregateID string) error {
+	event, err := events.New(entity.EventStockChanged, category, aggregateID, &entity.StockChanged{
+		Category: category,
+		Reason:   "feeding",
+	})
+	if err != nil {
+		return err
+	}
+	return d.outbox.Add(ctx, event)
 }
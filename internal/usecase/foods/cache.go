@@ -0,0 +1,54 @@
+package foods
+
+import (
+	"context"
+	"musobaqa/farm-competition/internal/entity"
+	redisrepo "musobaqa/farm-competition/internal/infrastructure/repository/redis"
+	"musobaqa/farm-competition/internal/pkg/listquery"
+)
+
+const foodCacheType = "food"
+
+type foodCache struct {
+	Food
+	cache *redisrepo.Namespaces
+}
+
+// NewFoodCache caches Get and List of the usecase, its writes drop the
+// cached foods
+func NewFoodCache(next Food, cache *redisrepo.Namespaces) Food {
+	return &foodCache{
+		Food:  next,
+		cache: cache,
+	}
+}
+
+func (c *foodCache) Create(ctx context.Context, food *entity.Food) (*entity.Food, error) {
+	res, err := c.Food.Create(ctx, food)
+	c.cache.Invalidate(ctx, foodCacheType)
+	return res, err
+}
+
+func (c *foodCache) Update(ctx context.Context, food *entity.Food) (*entity.Food, error) {
+	res, err := c.Food.Update(ctx, food)
+	c.cache.Invalidate(ctx, foodCacheType)
+	return res, err
+}
+
+func (c *foodCache) Delete(ctx context.Context, foodID string, version int64) error {
+	err := c.Food.Delete(ctx, foodID, version)
+	c.cache.Invalidate(ctx, foodCacheType)
+	return err
+}
+
+func (c *foodCache) Get(ctx context.Context, params map[string]string) (*entity.Food, error) {
+	return redisrepo.Load(ctx, c.cache, foodCacheType, redisrepo.Key("get", params), func(ctx context.Context) (*entity.Food, error) {
+		return c.Food.Get(ctx, params)
+	})
+}
+
+func (c *foodCache) List(ctx context.Context, page, limit uint64, request listquery.Request) (*entity.ListFoods, error) {
+	return redisrepo.Load(ctx, c.cache, foodCacheType, redisrepo.Key("list", page, limit, request), func(ctx context.Context) (*entity.ListFoods, error) {
+		return c.Food.List(ctx, page, limit, request)
+	})
+}
@@ -3,13 +3,14 @@ package foods
 import (
 	"context"
 	"musobaqa/farm-competition/internal/entity"
+	"musobaqa/farm-competition/internal/pkg/listquery"
 )
 
 type Food interface {
 	Create(ctx context.Context, food *entity.Food) (*entity.Food, error)
 	Update(ctx context.Context, food *entity.Food) (*entity.Food, error)
-	Delete(ctx context.Context, foodID string) error
+	Delete(ctx context.Context, foodID string, version int64) error
 	Get(ctx context.Context, params map[string]string) (*entity.Food, error)
-	List(ctx context.Context, page, limit uint64, params map[string]any) (*entity.ListFoods, error)
+	List(ctx context.Context, page, limit uint64, request listquery.Request) (*entity.ListFoods, error)
 	UniqueFoodName(ctx context.Context, foodName string) (int, error)
 }
@@ -4,19 +4,26 @@ import (
 	"context"
 	"github.com/google/uuid"
 	"musobaqa/farm-competition/internal/entity"
+	errorspkg "musobaqa/farm-competition/internal/errors"
 	"musobaqa/farm-competition/internal/infrastructure/repository/postgresql/repo"
+	"musobaqa/farm-competition/internal/pkg/listquery"
+	"musobaqa/farm-competition/internal/pkg/otlp"
 	"time"
 )
 
 type foodService struct {
 	ctxTimeout time.Duration
 	repo       repo.Food
+	lots       repo.StockLot
+	tx         repo.Transactor
 }
 
-func NewFoodService(timeout time.Duration, repository repo.Food) Food {
+func NewFoodService(timeout time.Duration, repository repo.Food, lots repo.StockLot, tx repo.Transactor) Food {
 	return &foodService{
 		ctxTimeout: timeout,
 		repo:       repository,
+		lots:       lots,
+		tx:         tx,
 	}
 }
 
@@ -30,30 +37,89 @@ func (f *foodService) beforeUpdate(food *entity.Food) {
 	food.UpdatedAt = time.Now().UTC()
 }
 
+// Create stores the food and opens a lot for its capacity in the same
+// transaction, feedings take from lots only
 func (f *foodService) Create(ctx context.Context, food *entity.Food) (*entity.Food, error) {
+	ctx, span := otlp.Start(ctx, "usecase", "foodService.Create")
+	defer span.End()
+
 	f.beforeCreate(food)
 
-	return f.repo.Create(ctx, food)
+	var res *entity.Food
+	err := f.tx.WithTx(ctx, func(ctx context.Context) error {
+		var err error
+		res, err = f.repo.Create(ctx, food)
+		if err != nil {
+			return err
+		}
+		return f.lots.Balance(ctx, "food", res.Name)
+	})
+	if err != nil {
+		return nil, errorspkg.Wrap(err, "create food")
+	}
+
+	return res, nil
 }
 
+// Update stores the food and, in the same transaction, moves its lots to a
+// new name and opens or writes off lots for a changed capacity
 func (f *foodService) Update(ctx context.Context, food *entity.Food) (*entity.Food, error) {
+	ctx, span := otlp.Start(ctx, "usecase", "foodService.Update")
+	defer span.End()
+
 	f.beforeUpdate(food)
 
-	return f.repo.Update(ctx, food)
+	var res *entity.Food
+	err := f.tx.WithTx(ctx, func(ctx context.Context) error {
+		current, err := f.repo.Get(ctx, map[string]string{"id": food.ID})
+		if err != nil {
+			return err
+		}
+
+		res, err = f.repo.Update(ctx, food)
+		if err != nil {
+			return err
+		}
+
+		if current.Name != res.Name {
+			if err := f.lots.Rename(ctx, "food", current.Name, res.Name); err != nil {
+				return err
+			}
+		}
+		return f.lots.Balance(ctx, "food", res.Name)
+	})
+	if err != nil {
+		return nil, errorspkg.Wrap(err, "update food %s", food.ID)
+	}
+
+	return res, nil
 }
 
-func (f *foodService) Delete(ctx context.Context, foodID string) error {
-	return f.repo.Delete(ctx, foodID)
+func (f *foodService) Delete(ctx context.Context, foodID string, version int64) error {
+	ctx, span := otlp.Start(ctx, "usecase", "foodService.Delete")
+	defer span.End()
+
+	return errorspkg.Wrap(f.repo.Delete(ctx, foodID, version), "delete food %s", foodID)
 }
 
 func (f *foodService) Get(ctx context.Context, params map[string]string) (*entity.Food, error) {
-	return f.repo.Get(ctx, params)
+	ctx, span := otlp.Start(ctx, "usecase", "foodService.Get")
+	defer span.End()
+
+	res, err := f.repo.Get(ctx, params)
+	return res, errorspkg.Wrap(err, "get food %v", params)
 }
 
-func (f *foodService) List(ctx context.Context, page, limit uint64, params map[string]any) (*entity.ListFoods, error) {
-	return f.repo.List(ctx, page, limit, params)
+func (f *foodService) List(ctx context.Context, page, limit uint64, request listquery.Request) (*entity.ListFoods, error) {
+	ctx, span := otlp.Start(ctx, "usecase", "foodService.List")
+	defer span.End()
+
+	return f.repo.List(ctx, page, limit, request)
 }
 
 func (f *foodService) UniqueFoodName(ctx context.Context, foodName string) (int, error) {
+	ctx, span := otlp.Start(ctx, "usecase", "foodService.UniqueFoodName")
+	defer span.End()
+
 	return f.repo.UniqueFoodName(ctx, foodName)
 }
@@ -0,0 +1,13 @@
+package health
+
+import (
+	"context"
+	"musobaqa/farm-competition/internal/entity"
+)
+
+type Health interface {
+	Ready(ctx context.Context) *entity.Readiness
+	// Drain fails readiness from now on, so traffic is moved away before
+	// the server shuts down
+	Drain()
+}
@@ -0,0 +1,85 @@
+package health
+
+import (
+	"context"
+	"musobaqa/farm-competition/internal/entity"
+	"musobaqa/farm-competition/internal/infrastructure/repository/postgresql/repo"
+	"musobaqa/farm-competition/internal/pkg/redis"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+type healthService struct {
+	ctxTimeout time.Duration
+	repo       repo.Health
+	redis      *redis.RedisDB
+	draining   atomic.Bool
+}
+
+func NewHealthService(timeout time.Duration, repository repo.Health, redisdb *redis.RedisDB) Health {
+	return &healthService{
+		ctxTimeout: timeout,
+		repo:       repository,
+		redis:      redisdb,
+	}
+}
+
+func (h *healthService) Drain() {
+	h.draining.Store(true)
+}
+
+// Ready checks Postgres and Redis at the same time, each within the context
+// timeout, so a hanging dependency cannot hold the probe
+func (h *healthService) Ready(ctx context.Context) *entity.Readiness {
+	ctx, cancel := context.WithTimeout(ctx, h.ctxTimeout)
+	defer cancel()
+
+	var (
+		postgresCheck = &entity.DependencyCheck{Name: "postgres"}
+		redisCheck    = &entity.DependencyCheck{Name: "redis"}
+		wg            sync.WaitGroup
+	)
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		h.check(postgresCheck, func() error {
+			if err := h.repo.Ping(ctx); err != nil {
+				return err
+			}
+
+			version, dirty, err := h.repo.MigrationVersion(ctx)
+			if err != nil {
+				return err
+			}
+			postgresCheck.Version, postgresCheck.Dirty = &version, dirty
+			return nil
+		})
+	}()
+	go func() {
+		defer wg.Done()
+		h.check(redisCheck, func() error {
+			return h.redis.Client.Ping(ctx).Err()
+		})
+	}()
+	wg.Wait()
+
+	readiness := &entity.Readiness{
+		Ready:    !h.draining.Load() && postgresCheck.Ready && redisCheck.Ready && !postgresCheck.Dirty,
+		Draining: h.draining.Load(),
+		Checks:   []*entity.DependencyCheck{postgresCheck, redisCheck},
+	}
+
+	return readiness
+}
+
+func (h *healthService) check(dependency *entity.DependencyCheck, ping func() error) {
+	start := time.Now()
+	err := ping()
+	dependency.Latency = time.Since(start)
+	if err != nil {
+		dependency.Error = err.Error()
+		return
+	}
+	dependency.Ready = true
+}
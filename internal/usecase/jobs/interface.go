@@ -0,0 +1,17 @@
+package jobs
+
+import (
+	"context"
+	"musobaqa/farm-competition/internal/entity"
+	"time"
+)
+
+// Jobs keeps the runs of the scheduled jobs, it is the scheduler.Locker of
+// the app
+type Jobs interface {
+	Claim(ctx context.Context, job string, scheduledAt time.Time, lease time.Duration) (*entity.JobRun, error)
+	Finish(ctx context.Context, run *entity.JobRun, runErr error) error
+	Runs(ctx context.Context, page, limit uint64, params map[string]any) (*entity.ListJobRuns, error)
+	LastRuns(ctx context.Context) ([]*entity.JobRun, error)
+	Purge(ctx context.Context) (int64, error)
+}
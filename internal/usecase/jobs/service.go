@@ -0,0 +1,109 @@
+package jobs
+
+import (
+	"context"
+	"musobaqa/farm-competition/internal/entity"
+	errorspkg "musobaqa/farm-competition/internal/errors"
+	"musobaqa/farm-competition/internal/infrastructure/repository/postgresql/repo"
+	"musobaqa/farm-competition/internal/pkg/otlp"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type jobService struct {
+	ctxTimeout time.Duration
+	repo       repo.Job
+	tx         repo.Transactor
+	holder     string
+	retention  time.Duration
+}
+
+// NewJobService records runs as taken by holder, the name of this replica,
+// and keeps finished runs for the retention period
+func NewJobService(timeout time.Duration, repository repo.Job, tx repo.Transactor, holder string, retention time.Duration) Jobs {
+	return &jobService{
+		ctxTimeout: timeout,
+		repo:       repository,
+		tx:         tx,
+		holder:     holder,
+		retention:  retention,
+	}
+}
+
+// Claim takes the run of the job due at scheduledAt under the advisory lock
+// of the job, so two replicas never both see it free. It returns nil when
+// the run is not this replica's to do
+func (j *jobService) Claim(ctx context.Context, job string, scheduledAt time.Time, lease time.Duration) (*entity.JobRun, error) {
+	ctx, span := otlp.Start(ctx, "usecase", "jobService.Claim")
+	defer span.End()
+
+	now := time.Now().UTC()
+	run := &entity.JobRun{
+		ID:          uuid.New().String(),
+		Job:         job,
+		ScheduledAt: scheduledAt.UTC(),
+		Status:      entity.JobRunRunning,
+		Holder:      j.holder,
+		StartedAt:   now,
+		LeaseUntil:  now.Add(lease),
+	}
+
+	var claimed bool
+	err := j.tx.WithTx(ctx, func(ctx context.Context) error {
+		if err := j.repo.Lock(ctx, job); err != nil {
+			return err
+		}
+
+		var err error
+		claimed, err = j.repo.Claim(ctx, run)
+		return err
+	})
+	if err != nil {
+		return nil, errorspkg.Wrap(err, "claim job %s", job)
+	}
+	if !claimed {
+		return nil, nil
+	}
+
+	return run, nil
+}
+
+func (j *jobService) Finish(ctx context.Context, run *entity.JobRun, runErr error) error {
+	ctx, span := otlp.Start(ctx, "usecase", "jobService.Finish")
+	defer span.End()
+
+	finishedAt := time.Now().UTC()
+	run.FinishedAt = &finishedAt
+	run.Status = entity.JobRunSucceeded
+	if runErr != nil {
+		run.Status = entity.JobRunFailed
+		run.Error = runErr.Error()
+	}
+
+	return errorspkg.Wrap(j.repo.Finish(ctx, run), "finish job %s", run.Job)
+}
+
+func (j *jobService) Runs(ctx context.Context, page, limit uint64, params map[string]any) (*entity.ListJobRuns, error) {
+	ctx, span := otlp.Start(ctx, "usecase", "jobService.Runs")
+	defer span.End()
+
+	return j.repo.ListRuns(ctx, page, limit, params)
+}
+
+func (j *jobService) LastRuns(ctx context.Context) ([]*entity.JobRun, error) {
+	ctx, span := otlp.Start(ctx, "usecase", "jobService.LastRuns")
+	defer span.End()
+
+	res, err := j.repo.LastRuns(ctx)
+	return res, errorspkg.Wrap(err, "last job runs")
+}
+
+// Purge removes the finished runs older than the retention period
+func (j *jobService) Purge(ctx context.Context) (int64, error) {
+	ctx, span := otlp.Start(ctx, "usecase", "jobService.Purge")
+	defer span.End()
+
+	res, err := j.repo.PurgeRuns(ctx, time.Now().UTC().Add(-j.retention))
+	return res, errorspkg.Wrap(err, "purge job runs")
+}
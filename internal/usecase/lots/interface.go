@@ -0,0 +1,20 @@
+package lots
+
+import (
+	"context"
+	"musobaqa/farm-competition/internal/entity"
+)
+
+type Lot interface {
+	Receive(ctx context.Context, lot *entity.StockLot) (*entity.StockLot, error)
+	Restock(ctx context.Context, restock *entity.Restock) (bool, error)
+	Revise(ctx context.Context, delivery *entity.Delivery) (*entity.StockLot, error)
+	Withdraw(ctx context.Context, deliveryID string) (*entity.StockLot, error)
+	Get(ctx context.Context, lotID string) (*entity.StockLot, error)
+	List(ctx context.Context, page, limit uint64, params map[string]any) (*entity.ListStockLots, error)
+	Expiring(ctx context.Context, days int) ([]*entity.StockLot, error)
+	WriteOff(ctx context.Context, lotID, reason string) (*entity.StockLot, error)
+	WriteOffExpired(ctx context.Context, reason string) ([]*entity.StockLot, error)
+	Levels(ctx context.Context) ([]*entity.StockLevel, error)
+	Recalculate(ctx context.Context) ([]*entity.StockLevel, error)
+}
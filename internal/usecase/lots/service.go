@@ -0,0 +1,206 @@
+package lots
+
+import (
+	"context"
+	"musobaqa/farm-competition/internal/entity"
+	errorspkg "musobaqa/farm-competition/internal/errors"
+	"musobaqa/farm-competition/internal/infrastructure/repository/postgresql/repo"
+	"musobaqa/farm-competition/internal/pkg/events"
+	"musobaqa/farm-competition/internal/pkg/otlp"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type lotService struct {
+	ctxTimeout time.Duration
+	repo       repo.StockLot
+	outbox     repo.Outbox
+	tx         repo.Transactor
+}
+
+func NewLotService(timeout time.Duration, repository repo.StockLot, outbox repo.Outbox, tx repo.Transactor) Lot {
+	return &lotService{
+		ctxTimeout: timeout,
+		repo:       repository,
+		outbox:     outbox,
+		tx:         tx,
+	}
+}
+
+func (l *lotService) beforeCreate(lot *entity.StockLot) {
+	lot.ID = uuid.New().String()
+	lot.CreatedAt = time.Now().UTC()
+	lot.UpdatedAt = time.Now().UTC()
+	if lot.ReceivedDate == "" {
+		lot.ReceivedDate = time.Now().Format(time.DateOnly)
+	}
+	if lot.LotNumber == "" {
+		lot.LotNumber = "LOT-" + lot.CreatedAt.Format("20060102") + "-" + lot.ID[:8]
+	}
+	lot.Remaining = lot.Quantity
+}
+
+func (l *lotService) Receive(ctx context.Context, lot *entity.StockLot) (*entity.StockLot, error) {
+	ctx, span := otlp.Start(ctx, "usecase", "lotService.Receive")
+	defer span.End()
+
+	l.beforeCreate(lot)
+
+	res, err := l.repo.Create(ctx, lot)
+	return res, errorspkg.Wrap(err, "create stock lot")
+}
+
+// Restock adds incoming stock to the capacity of its food or drug, and
+// creates the food or drug when there is none. It returns whether it did
+func (l *lotService) Restock(ctx context.Context, restock *entity.Restock) (bool, error) {
+	ctx, span := otlp.Start(ctx, "usecase", "lotService.Restock")
+	defer span.End()
+
+	created, err := l.repo.Restock(ctx, restock)
+	return created, errorspkg.Wrap(err, "restock %s %s", restock.Category, restock.Name)
+}
+
+// Revise brings the lot of an updated delivery and the capacity of its food
+// or drug in line with the delivery
+func (l *lotService) Revise(ctx context.Context, delivery *entity.Delivery) (*entity.StockLot, error) {
+	ctx, span := otlp.Start(ctx, "usecase", "lotService.Revise")
+	defer span.End()
+
+	lot, err := l.repo.Revise(ctx, delivery.ID, delivery.Category, delivery.Name, delivery.Capacity)
+	return lot, errorspkg.Wrap(err, "revise stock lot of delivery %s", delivery.ID)
+}
+
+// Withdraw writes off what is left of a deleted delivery
+func (l *lotService) Withdraw(ctx context.Context, deliveryID string) (*entity.StockLot, error) {
+	ctx, span := otlp.Start(ctx, "usecase", "lotService.Withdraw")
+	defer span.End()
+
+	lot, err := l.repo.Withdraw(ctx, deliveryID, "delivery deleted")
+	return lot, errorspkg.Wrap(err, "withdraw stock lot of delivery %s", deliveryID)
+}
+
+func (l *lotService) Get(ctx context.Context, lotID string) (*entity.StockLot, error) {
+	ctx, span := otlp.Start(ctx, "usecase", "lotService.Get")
+	defer span.End()
+
+	res, err := l.repo.Get(ctx, lotID)
+	return res, errorspkg.Wrap(err, "get stock lot %s", lotID)
+}
+
+func (l *lotService) List(ctx context.Context, page, limit uint64, params map[string]any) (*entity.ListStockLots, error) {
+	ctx, span := otlp.Start(ctx, "usecase", "lotService.List")
+	defer span.End()
+
+	return l.repo.List(ctx, page, limit, params)
+}
+
+func (l *lotService) Expiring(ctx context.Context, days int) ([]*entity.StockLot, error) {
+	ctx, span := otlp.Start(ctx, "usecase", "lotService.Expiring")
+	defer span.End()
+
+	today := time.Now()
+
+	return l.repo.Expiring(ctx, today.Format(time.DateOnly), today.AddDate(0, 0, days).Format(time.DateOnly))
+}
+
+func (l *lotService) WriteOff(ctx context.Context, lotID, reason string) (*entity.StockLot, error) {
+	ctx, span := otlp.Start(ctx, "usecase", "lotService.WriteOff")
+	defer span.End()
+
+	var lot *entity.StockLot
+	err := l.tx.WithTx(ctx, func(ctx context.Context) error {
+		var err error
+		lot, err = l.repo.WriteOff(ctx, lotID, reason)
+		if err != nil {
+			return err
+		}
+		return l.stockChanged(ctx, lot.ID, lot.Category, lot.Name, "write_off")
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return lot, nil
+}
+
+// WriteOffExpired writes off every lot that expired before today
+func (l *lotService) WriteOffExpired(ctx context.Context, reason string) ([]*entity.StockLot, error) {
+	ctx, span := otlp.Start(ctx, "usecase", "lotService.WriteOffExpired")
+	defer span.End()
+
+	var writtenOff []*entity.StockLot
+	err := l.tx.WithTx(ctx, func(ctx context.Context) error {
+		expired, err := l.repo.Expired(ctx, time.Now().Format(time.DateOnly))
+		if err != nil {
+			return err
+		}
+
+		for _, lot := range expired {
+			res, err := l.repo.WriteOff(ctx, lot.ID, reason)
+			if err != nil {
+				return err
+			}
+			if err := l.stockChanged(ctx, res.ID, res.Category, res.Name, "write_off"); err != nil {
+				return err
+			}
+			writtenOff = append(writtenOff, res)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return writtenOff, nil
+}
+
+func (l *lotService) Levels(ctx context.Context) ([]*entity.StockLevel, error) {
+	ctx, span := otlp.Start(ctx, "usecase", "lotService.Levels")
+	defer span.End()
+
+	return l.repo.Levels(ctx)
+}
+
+// Recalculate brings food and drug capacities back in line with their lots,
+// for stock that drifted from edits made outside the lot tracking
+func (l *lotService) Recalculate(ctx context.Context) ([]*entity.StockLevel, error) {
+	ctx, span := otlp.Start(ctx, "usecase", "lotService.Recalculate")
+	defer span.End()
+
+	var levels []*entity.StockLevel
+	err := l.tx.WithTx(ctx, func(ctx context.Context) error {
+		var err error
+		levels, err = l.repo.Recalculate(ctx)
+		if err != nil {
+			return err
+		}
+
+		for _, level := range levels {
+			if err := l.stockChanged(ctx, level.Name, level.Category, level.Name, "recalculate"); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, errorspkg.Wrap(err, "recalculate stock")
+	}
+
+	return levels, nil
+}
+
+// stockChanged records stock.changed in the transaction of the change, the
+// cached foods and drugs are dropped once it is relayed
+func (l *lotService) stockChanged(ctx context.Context, aggregateID, category, name, reason string) error {
+	event, err := events.New(entity.EventStockChanged, "stock_lot", aggregateID, &entity.StockChanged{
+		Category: category,
+		Name:     name,
+		Reason:   reason,
+	})
+	if err != nil {
+		return err
+	}
+	return l.outbox.Add(ctx, event)
+}
@@ -0,0 +1,8 @@
+package outbox
+
+import "context"
+
+type Outbox interface {
+	Relay(ctx context.Context) (int, error)
+	Purge(ctx context.Context) (int64, error)
+}
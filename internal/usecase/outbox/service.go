@@ -0,0 +1,103 @@
+package outbox
+
+import (
+	"context"
+	errorspkg "musobaqa/farm-competition/internal/errors"
+	"musobaqa/farm-competition/internal/infrastructure/repository/postgresql/repo"
+	"musobaqa/farm-competition/internal/pkg/events"
+	"musobaqa/farm-competition/internal/pkg/otlp"
+	"time"
+)
+
+// claimLease is how long claimed events are left alone by other replicas,
+// handlers that take longer see their events relayed twice
+const claimLease = time.Minute
+
+// Retry tells how often and how far apart a failing event is relayed
+type Retry struct {
+	MaxAttempts int
+	Backoff     time.Duration
+}
+
+type outboxService struct {
+	ctxTimeout time.Duration
+	repo       repo.Outbox
+	publisher  events.Publisher
+	batchSize  uint64
+	retry      Retry
+	retention  time.Duration
+}
+
+// NewOutboxService relays the events of the outbox to the publisher, a
+// failed event waits the retry backoff, doubled on every further failure,
+// until its attempts are used up. Relayed events are kept for the retention
+// period
+func NewOutboxService(timeout time.Duration, repository repo.Outbox, publisher events.Publisher, batchSize int, retry Retry, retention time.Duration) Outbox {
+	return &outboxService{
+		ctxTimeout: timeout,
+		repo:       repository,
+		publisher:  publisher,
+		batchSize:  uint64(batchSize),
+		retry:      retry,
+		retention:  retention,
+	}
+}
+
+// Relay publishes one batch of committed events, oldest first, and returns
+// how many of them were published
+func (o *outboxService) Relay(ctx context.Context) (int, error) {
+	ctx, span := otlp.Start(ctx, "usecase", "outboxService.Relay")
+	defer span.End()
+
+	pending, err := o.repo.Claim(ctx, o.batchSize, claimLease)
+	if err != nil {
+		return 0, errorspkg.Wrap(err, "claim outbox events")
+	}
+
+	published := 0
+	for _, event := range pending {
+		if err := o.publisher.Publish(ctx, event); err != nil {
+			if event.Attempts+1 >= o.retry.MaxAttempts {
+				if err := o.repo.GiveUp(ctx, event.ID, err.Error()); err != nil {
+					return published, errorspkg.Wrap(err, "give up outbox event %s", event.ID)
+				}
+				continue
+			}
+
+			retryAt := time.Now().UTC().Add(o.backoff(event.Attempts + 1))
+			if err := o.repo.MarkFailed(ctx, event.ID, err.Error(), retryAt); err != nil {
+				return published, errorspkg.Wrap(err, "mark outbox event %s failed", event.ID)
+			}
+			continue
+		}
+
+		if err := o.repo.MarkPublished(ctx, event.ID); err != nil {
+			return published, errorspkg.Wrap(err, "mark outbox event %s published", event.ID)
+		}
+		published++
+	}
+
+	return published, nil
+}
+
+// backoff doubles the retry delay with every failed attempt, up to an hour
+func (o *outboxService) backoff(attempts int) time.Duration {
+	wait := o.retry.Backoff
+	for i := 1; i < attempts && wait < time.Hour; i++ {
+		wait *= 2
+	}
+	if wait > time.Hour {
+		return time.Hour
+	}
+	return wait
+}
+
+// Purge removes the events published or given up on before the retention
+// period
+func (o *outboxService) Purge(ctx context.Context) (int64, error) {
+	ctx, span := otlp.Start(ctx, "usecase", "outboxService.Purge")
+	defer span.End()
+
+	res, err := o.repo.Purge(ctx, time.Now().UTC().Add(-o.retention))
+	return res, errorspkg.Wrap(err, "purge outbox events")
+}
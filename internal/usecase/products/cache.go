@@ -0,0 +1,54 @@
+package products
+
+import (
+	"context"
+	"musobaqa/farm-competition/internal/entity"
+	redisrepo "musobaqa/farm-competition/internal/infrastructure/repository/redis"
+	"musobaqa/farm-competition/internal/pkg/listquery"
+)
+
+const productCacheType = "product"
+
+type productCache struct {
+	Product
+	cache *redisrepo.Namespaces
+}
+
+// NewProductCache caches Get and List of the usecase, its writes drop the
+// cached products
+func NewProductCache(next Product, cache *redisrepo.Namespaces) Product {
+	return &productCache{
+		Product: next,
+		cache:   cache,
+	}
+}
+
+func (c *productCache) Create(ctx context.Context, product *entity.Product) (*entity.Product, error) {
+	res, err := c.Product.Create(ctx, product)
+	c.cache.Invalidate(ctx, productCacheType)
+	return res, err
+}
+
+func (c *productCache) Update(ctx context.Context, product *entity.Product) (*entity.Product, error) {
+	res, err := c.Product.Update(ctx, product)
+	c.cache.Invalidate(ctx, productCacheType)
+	return res, err
+}
+
+func (c *productCache) Delete(ctx context.Context, productID string, version int64) error {
+	err := c.Product.Delete(ctx, productID, version)
+	c.cache.Invalidate(ctx, productCacheType)
+	return err
+}
+
+func (c *productCache) Get(ctx context.Context, params map[string]string) (*entity.Product, error) {
+	return redisrepo.Load(ctx, c.cache, productCacheType, redisrepo.Key("get", params), func(ctx context.Context) (*entity.Product, error) {
+		return c.Product.Get(ctx, params)
+	})
+}
+
+func (c *productCache) List(ctx context.Context, page, limit uint64, request listquery.Request) (*entity.ListProducts, error) {
+	return redisrepo.Load(ctx, c.cache, productCacheType, redisrepo.Key("list", page, limit, request), func(ctx context.Context) (*entity.ListProducts, error) {
+		return c.Product.List(ctx, page, limit, request)
+	})
+}
@@ -3,13 +3,14 @@ package products
 import (
 	"context"
 	"musobaqa/farm-competition/internal/entity"
+	"musobaqa/farm-competition/internal/pkg/listquery"
 )
 
 type Product interface {
 	Create(ctx context.Context, product *entity.Product) (*entity.Product, error)
 	Update(ctx context.Context, product *entity.Product) (*entity.Product, error)
-	Delete(ctx context.Context, productID string) error
+	Delete(ctx context.Context, productID string, version int64) error
 	Get(ctx context.Context, params map[string]string) (*entity.Product, error)
-	List(ctx context.Context, page, limit uint64, params map[string]any) (*entity.ListProducts, error)
+	List(ctx context.Context, page, limit uint64, request listquery.Request) (*entity.ListProducts, error)
 	UniqueProductName(ctx context.Context, productName string) (int, error)
 }
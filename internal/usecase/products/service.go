@@ -4,7 +4,10 @@ import (
 	"context"
 	"github.com/google/uuid"
 	"musobaqa/farm-competition/internal/entity"
+	errorspkg "musobaqa/farm-competition/internal/errors"
 	"musobaqa/farm-competition/internal/infrastructure/repository/postgresql/repo"
+	"musobaqa/farm-competition/internal/pkg/listquery"
+	"musobaqa/farm-competition/internal/pkg/otlp"
 	"time"
 )
 
@@ -31,29 +34,50 @@ func (p *productService) beforeUpdate(product *entity.Product) {
 }
 
 func (p *productService) Create(ctx context.Context, product *entity.Product) (*entity.Product, error) {
+	ctx, span := otlp.Start(ctx, "usecase", "productService.Create")
+	defer span.End()
+
 	p.beforeCreate(product)
 
-	return p.repo.Create(ctx, product)
+	res, err := p.repo.Create(ctx, product)
+	return res, errorspkg.Wrap(err, "create product")
 }
 
 func (p *productService) Update(ctx context.Context, product *entity.Product) (*entity.Product, error) {
+	ctx, span := otlp.Start(ctx, "usecase", "productService.Update")
+	defer span.End()
+
 	p.beforeUpdate(product)
 
-	return p.repo.Update(ctx, product)
+	res, err := p.repo.Update(ctx, product)
+	return res, errorspkg.Wrap(err, "update product %s", product.ID)
 }
 
-func (p *productService) Delete(ctx context.Context, productID string) error {
-	return p.repo.Delete(ctx, productID)
+func (p *productService) Delete(ctx context.Context, productID string, version int64) error {
+	ctx, span := otlp.Start(ctx, "usecase", "productService.Delete")
+	defer span.End()
+
+	return errorspkg.Wrap(p.repo.Delete(ctx, productID, version), "delete product %s", productID)
 }
 
 func (p *productService) Get(ctx context.Context, params map[string]string) (*entity.Product, error) {
-	return p.repo.Get(ctx, params)
+	ctx, span := otlp.Start(ctx, "usecase", "productService.Get")
+	defer span.End()
+
+	res, err := p.repo.Get(ctx, params)
+	return res, errorspkg.Wrap(err, "get product %v", params)
 }
 
-func (p *productService) List(ctx context.Context, page, limit uint64, params map[string]any) (*entity.ListProducts, error) {
-	return p.repo.List(ctx, page, limit, params)
+func (p *productService) List(ctx context.Context, page, limit uint64, request listquery.Request) (*entity.ListProducts, error) {
+	ctx, span := otlp.Start(ctx, "usecase", "productService.List")
+	defer span.End()
+
+	return p.repo.List(ctx, page, limit, request)
 }
 
 func (p *productService) UniqueProductName(ctx context.Context, productName string) (int, error) {
+	ctx, span := otlp.Start(ctx, "usecase", "productService.UniqueProductName")
+	defer span.End()
+
 	return p.repo.UniqueProductName(ctx, productName)
 }
@@ -0,0 +1,12 @@
+package reports
+
+import (
+	"context"
+	"musobaqa/farm-competition/internal/entity"
+	"time"
+)
+
+type Reports interface {
+	Snapshot(ctx context.Context, day time.Time) ([]*entity.ReportSnapshot, error)
+	Snapshots(ctx context.Context, params map[string]any) ([]*entity.ReportSnapshot, error)
+}
@@ -0,0 +1,105 @@
+package reports
+
+import (
+	"context"
+	"encoding/json"
+	"musobaqa/farm-competition/internal/entity"
+	errorspkg "musobaqa/farm-competition/internal/errors"
+	"musobaqa/farm-competition/internal/infrastructure/repository/postgresql/repo"
+	"musobaqa/farm-competition/internal/pkg/otlp"
+	"musobaqa/farm-competition/internal/usecase/suppliers"
+	"musobaqa/farm-competition/internal/usecase/valuation"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type reportService struct {
+	ctxTimeout time.Duration
+	repo       repo.ReportSnapshot
+	valuation  valuation.Valuation
+	supplier   suppliers.Supplier
+}
+
+// NewReportService takes snapshots of the reports of the valuation and
+// supplier usecases, with their default method and grouping
+func NewReportService(timeout time.Duration, repository repo.ReportSnapshot, valuation valuation.Valuation, supplier suppliers.Supplier) Reports {
+	return &reportService{
+		ctxTimeout: timeout,
+		repo:       repository,
+		valuation:  valuation,
+		supplier:   supplier,
+	}
+}
+
+// Snapshot stores the reports of the day: the stock value as it is now and
+// the feed cost and spend of that day alone. Taking it again replaces it
+func (r *reportService) Snapshot(ctx context.Context, day time.Time) ([]*entity.ReportSnapshot, error) {
+	ctx, span := otlp.Start(ctx, "usecase", "reportService.Snapshot")
+	defer span.End()
+
+	date := day.Format(time.DateOnly)
+	period := map[string]any{"from": date, "to": date}
+
+	inventory, err := r.valuation.Inventory(ctx, "", "")
+	if err != nil {
+		return nil, errorspkg.Wrap(err, "snapshot %s", entity.ReportInventoryValuation)
+	}
+	if inventory.Items == nil {
+		inventory.Items = []*entity.ValuationItem{}
+	}
+
+	feedCost, err := r.valuation.FeedCostReport(ctx, "", "animal", period)
+	if err != nil {
+		return nil, errorspkg.Wrap(err, "snapshot %s", entity.ReportFeedCost)
+	}
+	if feedCost.Items == nil {
+		feedCost.Items = []*entity.FeedCostItem{}
+	}
+
+	spend, err := r.supplier.SpendReport(ctx, "supplier", period)
+	if err != nil {
+		return nil, errorspkg.Wrap(err, "snapshot %s", entity.ReportSpend)
+	}
+	if spend.Items == nil {
+		spend.Items = []*entity.SpendReportItem{}
+	}
+
+	var snapshots []*entity.ReportSnapshot
+	for _, report := range []struct {
+		name string
+		data any
+	}{
+		{entity.ReportInventoryValuation, inventory},
+		{entity.ReportFeedCost, feedCost},
+		{entity.ReportSpend, spend},
+	} {
+		data, err := json.Marshal(report.data)
+		if err != nil {
+			return nil, errorspkg.Wrap(err, "snapshot %s", report.name)
+		}
+
+		snapshot := &entity.ReportSnapshot{
+			ID:        uuid.New().String(),
+			Report:    report.name,
+			Day:       date,
+			Data:      data,
+			CreatedAt: time.Now().UTC(),
+		}
+		if err := r.repo.Save(ctx, snapshot); err != nil {
+			return nil, errorspkg.Wrap(err, "snapshot %s", report.name)
+		}
+
+		snapshots = append(snapshots, snapshot)
+	}
+
+	return snapshots, nil
+}
+
+func (r *reportService) Snapshots(ctx context.Context, params map[string]any) ([]*entity.ReportSnapshot, error) {
+	ctx, span := otlp.Start(ctx, "usecase", "reportService.Snapshots")
+	defer span.End()
+
+	res, err := r.repo.List(ctx, params)
+	return res, errorspkg.Wrap(err, "list report snapshots")
+}
@@ -0,0 +1,10 @@
+package search
+
+import (
+	"context"
+	"musobaqa/farm-competition/internal/entity"
+)
+
+type Search interface {
+	Search(ctx context.Context, text string, entityTypes []string, limit uint64) ([]*entity.SearchHit, error)
+}
@@ -0,0 +1,28 @@
+package search
+
+import (
+	"context"
+	"musobaqa/farm-competition/internal/entity"
+	"musobaqa/farm-competition/internal/infrastructure/repository/postgresql/repo"
+	"musobaqa/farm-competition/internal/pkg/otlp"
+	"time"
+)
+
+type searchService struct {
+	ctxTimeout time.Duration
+	repo       repo.Search
+}
+
+func NewSearchService(timeout time.Duration, repository repo.Search) Search {
+	return &searchService{
+		ctxTimeout: timeout,
+		repo:       repository,
+	}
+}
+
+func (s *searchService) Search(ctx context.Context, text string, entityTypes []string, limit uint64) ([]*entity.SearchHit, error) {
+	ctx, span := otlp.Start(ctx, "usecase", "searchService.Search")
+	defer span.End()
+
+	return s.repo.Search(ctx, text, entityTypes, limit)
+}
@@ -0,0 +1,54 @@
+package suppliers
+
+import (
+	"context"
+	"musobaqa/farm-competition/internal/entity"
+	redisrepo "musobaqa/farm-competition/internal/infrastructure/repository/redis"
+	"musobaqa/farm-competition/internal/pkg/listquery"
+)
+
+const supplierCacheType = "supplier"
+
+type supplierCache struct {
+	Supplier
+	cache *redisrepo.Namespaces
+}
+
+// NewSupplierCache caches Get and List of the usecase, its writes drop the
+// cached suppliers
+func NewSupplierCache(next Supplier, cache *redisrepo.Namespaces) Supplier {
+	return &supplierCache{
+		Supplier: next,
+		cache:    cache,
+	}
+}
+
+func (c *supplierCache) Create(ctx context.Context, supplier *entity.Supplier) (*entity.Supplier, error) {
+	res, err := c.Supplier.Create(ctx, supplier)
+	c.cache.Invalidate(ctx, supplierCacheType)
+	return res, err
+}
+
+func (c *supplierCache) Update(ctx context.Context, supplier *entity.Supplier) (*entity.Supplier, error) {
+	res, err := c.Supplier.Update(ctx, supplier)
+	c.cache.Invalidate(ctx, supplierCacheType)
+	return res, err
+}
+
+func (c *supplierCache) Delete(ctx context.Context, supplierID string, version int64) error {
+	err := c.Supplier.Delete(ctx, supplierID, version)
+	c.cache.Invalidate(ctx, supplierCacheType)
+	return err
+}
+
+func (c *supplierCache) Get(ctx context.Context, supplierID string) (*entity.Supplier, error) {
+	return redisrepo.Load(ctx, c.cache, supplierCacheType, redisrepo.Key("get", supplierID), func(ctx context.Context) (*entity.Supplier, error) {
+		return c.Supplier.Get(ctx, supplierID)
+	})
+}
+
+func (c *supplierCache) List(ctx context.Context, page, limit uint64, request listquery.Request) (*entity.ListSuppliers, error) {
+	return redisrepo.Load(ctx, c.cache, supplierCacheType, redisrepo.Key("list", page, limit, request), func(ctx context.Context) (*entity.ListSuppliers, error) {
+		return c.Supplier.List(ctx, page, limit, request)
+	})
+}
@@ -0,0 +1,16 @@
+package suppliers
+
+import (
+	"context"
+	"musobaqa/farm-competition/internal/entity"
+	"musobaqa/farm-competition/internal/pkg/listquery"
+)
+
+type Supplier interface {
+	Create(ctx context.Context, supplier *entity.Supplier) (*entity.Supplier, error)
+	Update(ctx context.Context, supplier *entity.Supplier) (*entity.Supplier, error)
+	Delete(ctx context.Context, supplierID string, version int64) error
+	Get(ctx context.Context, supplierID string) (*entity.Supplier, error)
+	List(ctx context.Context, page, limit uint64, request listquery.Request) (*entity.ListSuppliers, error)
+	SpendReport(ctx context.Context, groupBy string, params map[string]any) (*entity.SpendReport, error)
+}
@@ -0,0 +1,83 @@
+package suppliers
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"musobaqa/farm-competition/internal/entity"
+	errorspkg "musobaqa/farm-competition/internal/errors"
+	"musobaqa/farm-competition/internal/infrastructure/repository/postgresql/repo"
+	"musobaqa/farm-competition/internal/pkg/listquery"
+	"musobaqa/farm-competition/internal/pkg/otlp"
+	"time"
+)
+
+type supplierService struct {
+	ctxTimeout time.Duration
+	repo       repo.Supplier
+}
+
+func NewSupplierService(timeout time.Duration, repository repo.Supplier) Supplier {
+	return &supplierService{
+		ctxTimeout: timeout,
+		repo:       repository,
+	}
+}
+
+func (s *supplierService) beforeCreate(supplier *entity.Supplier) {
+	supplier.ID = uuid.New().String()
+	supplier.CreatedAt = time.Now().UTC()
+	supplier.UpdatedAt = time.Now().UTC()
+}
+
+func (s *supplierService) beforeUpdate(supplier *entity.Supplier) {
+	supplier.UpdatedAt = time.Now().UTC()
+}
+
+func (s *supplierService) Create(ctx context.Context, supplier *entity.Supplier) (*entity.Supplier, error) {
+	ctx, span := otlp.Start(ctx, "usecase", "supplierService.Create")
+	defer span.End()
+
+	s.beforeCreate(supplier)
+
+	res, err := s.repo.Create(ctx, supplier)
+	return res, errorspkg.Wrap(err, "create supplier")
+}
+
+func (s *supplierService) Update(ctx context.Context, supplier *entity.Supplier) (*entity.Supplier, error) {
+	ctx, span := otlp.Start(ctx, "usecase", "supplierService.Update")
+	defer span.End()
+
+	s.beforeUpdate(supplier)
+
+	res, err := s.repo.Update(ctx, supplier)
+	return res, errorspkg.Wrap(err, "update supplier %s", supplier.ID)
+}
+
+func (s *supplierService) Delete(ctx context.Context, supplierID string, version int64) error {
+	ctx, span := otlp.Start(ctx, "usecase", "supplierService.Delete")
+	defer span.End()
+
+	return errorspkg.Wrap(s.repo.Delete(ctx, supplierID, version), "delete supplier %s", supplierID)
+}
+
+func (s *supplierService) Get(ctx context.Context, supplierID string) (*entity.Supplier, error) {
+	ctx, span := otlp.Start(ctx, "usecase", "supplierService.Get")
+	defer span.End()
+
+	res, err := s.repo.Get(ctx, supplierID)
+	return res, errorspkg.Wrap(err, "get supplier %s", supplierID)
+}
+
+func (s *supplierService) List(ctx context.Context, page, limit uint64, request listquery.Request) (*entity.ListSuppliers, error) {
+	ctx, span := otlp.Start(ctx, "usecase", "supplierService.List")
+	defer span.End()
+
+	return s.repo.List(ctx, page, limit, request)
+}
+
+func (s *supplierService) SpendReport(ctx context.Context, groupBy string, params map[string]any) (*entity.SpendReport, error) {
+	ctx, span := otlp.Start(ctx, "usecase", "supplierService.SpendReport")
+	defer span.End()
+
+	return s.repo.SpendReport(ctx, groupBy, params)
+}
@@ -0,0 +1,17 @@
+package tasks
+
+import (
+	"context"
+	"musobaqa/farm-competition/internal/entity"
+	"time"
+)
+
+// FeedingTask is the to-do list of the feeders, the daily plans of the
+// animals cut into one task per time slot
+type FeedingTask interface {
+	Generate(ctx context.Context, day time.Time) (int64, error)
+	Get(ctx context.Context, taskID string) (*entity.FeedingTask, error)
+	List(ctx context.Context, page, limit uint64, params map[string]any) (*entity.ListFeedingTasks, error)
+	Done(ctx context.Context, task *entity.FeedingTask) (*entity.FeedingTask, error)
+	Skip(ctx context.Context, task *entity.FeedingTask) (*entity.FeedingTask, error)
+}
@@ -0,0 +1,130 @@
+package tasks
+
+import (
+	"context"
+	"errors"
+	"musobaqa/farm-competition/internal/entity"
+	errorspkg "musobaqa/farm-competition/internal/errors"
+	"musobaqa/farm-competition/internal/infrastructure/repository/postgresql/repo"
+	"musobaqa/farm-competition/internal/pkg/otlp"
+	"musobaqa/farm-competition/internal/usecase/feeding"
+	"strings"
+	"time"
+)
+
+type feedingTaskService struct {
+	ctxTimeout time.Duration
+	repo       repo.FeedingTask
+	feeding    feeding.Feeding
+	tx         repo.Transactor
+}
+
+func NewFeedingTaskService(timeout time.Duration, repository repo.FeedingTask, feeding feeding.Feeding, tx repo.Transactor) FeedingTask {
+	return &feedingTaskService{
+		ctxTimeout: timeout,
+		repo:       repository,
+		feeding:    feeding,
+		tx:         tx,
+	}
+}
+
+func (f *feedingTaskService) beforeClose(task *entity.FeedingTask, status string) {
+	now := time.Now().UTC()
+	task.Status = status
+	task.CompletedAt = &now
+	task.UpdatedAt = now
+}
+
+// Generate adds the missing tasks of the day from the daily plans of the
+// animals and returns how many it added
+func (f *feedingTaskService) Generate(ctx context.Context, day time.Time) (int64, error) {
+	ctx, span := otlp.Start(ctx, "usecase", "feedingTaskService.Generate")
+	defer span.End()
+
+	res, err := f.repo.Generate(ctx, day.Format(time.DateOnly), time.Now().UTC())
+	return res, errorspkg.Wrap(err, "generate feeding tasks of %s", day.Format(time.DateOnly))
+}
+
+func (f *feedingTaskService) Get(ctx context.Context, taskID string) (*entity.FeedingTask, error) {
+	ctx, span := otlp.Start(ctx, "usecase", "feedingTaskService.Get")
+	defer span.End()
+
+	res, err := f.repo.Get(ctx, taskID)
+	return res, errorspkg.Wrap(err, "get feeding task %s", taskID)
+}
+
+func (f *feedingTaskService) List(ctx context.Context, page, limit uint64, params map[string]any) (*entity.ListFeedingTasks, error) {
+	ctx, span := otlp.Start(ctx, "usecase", "feedingTaskService.List")
+	defer span.End()
+
+	return f.repo.List(ctx, page, limit, params)
+}
+
+// Done records the feeding of the task, with the planned capacity unless
+// ActualCapacity says otherwise, and closes the task in the same transaction
+func (f *feedingTaskService) Done(ctx context.Context, task *entity.FeedingTask) (*entity.FeedingTask, error) {
+	ctx, span := otlp.Start(ctx, "usecase", "feedingTaskService.Done")
+	defer span.End()
+
+	f.beforeClose(task, entity.FeedingTaskDone)
+
+	var res *entity.FeedingTask
+	err := f.tx.WithTx(ctx, func(ctx context.Context) error {
+		planned, err := f.repo.Get(ctx, task.ID)
+		if err != nil {
+			return err
+		}
+		if planned.Status != entity.FeedingTaskPending {
+			return errorspkg.ErrorTaskClosed
+		}
+		if task.ActualCapacity == 0 {
+			task.ActualCapacity = planned.Capacity
+		}
+
+		given := &entity.Feeding{
+			AnimalID:   planned.AnimalID,
+			EatablesID: planned.EatablesID,
+			Category:   planned.Category,
+			Day:        planned.Day,
+		}
+		given.Daily = append(given.Daily, struct {
+			Capacity int64  `json:"capacity"`
+			Time     string `json:"time"`
+		}{
+			Capacity: task.ActualCapacity,
+			Time:     planned.Slot,
+		})
+		if _, err := f.feeding.Create(ctx, given); err != nil {
+			return err
+		}
+		task.GivenEatablesID = given.ID
+
+		res, err = f.repo.Close(ctx, task)
+		return err
+	})
+	if err != nil {
+		return nil, errorspkg.Wrap(err, "complete feeding task %s", task.ID)
+	}
+
+	return res, nil
+}
+
+// Skip closes the task without feeding, the reason is required and kept
+// with it
+func (f *feedingTaskService) Skip(ctx context.Context, task *entity.FeedingTask) (*entity.FeedingTask, error) {
+	ctx, span := otlp.Start(ctx, "usecase", "feedingTaskService.Skip")
+	defer span.End()
+
+	task.SkipReason = strings.TrimSpace(task.SkipReason)
+	if task.SkipReason == "" {
+		errValidation := errorspkg.NewErrValidation()
+		errValidation.Errors["reason"] = "cannot be blank"
+		errValidation.Err = errors.New("invalid feeding task skip")
+		return nil, errValidation
+	}
+
+	f.beforeClose(task, entity.FeedingTaskSkipped)
+
+	res, err := f.repo.Close(ctx, task)
+	return res, errorspkg.Wrap(err, "skip feeding task %s", task.ID)
+}
@@ -0,0 +1,183 @@
+package tasks_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"musobaqa/farm-competition/internal/entity"
+	errorspkg "musobaqa/farm-competition/internal/errors"
+	"musobaqa/farm-competition/internal/pkg/listquery"
+	"musobaqa/farm-competition/internal/usecase/tasks"
+)
+
+// taskRepoStub closes tasks like the repository does, only pending ones and
+// only at the version they carry
+type taskRepoStub struct {
+	tasks  map[string]*entity.FeedingTask
+	closed int
+}
+
+func (s *taskRepoStub) Generate(ctx context.Context, day string, now time.Time) (int64, error) {
+	return 0, nil
+}
+
+func (s *taskRepoStub) Get(ctx context.Context, taskID string) (*entity.FeedingTask, error) {
+	task, ok := s.tasks[taskID]
+	if !ok {
+		return nil, errorspkg.NewErrNotFound("feeding task")
+	}
+	copied := *task
+	return &copied, nil
+}
+
+func (s *taskRepoStub) List(ctx context.Context, page, limit uint64, params map[string]any) (*entity.ListFeedingTasks, error) {
+	return &entity.ListFeedingTasks{}, nil
+}
+
+func (s *taskRepoStub) Close(ctx context.Context, task *entity.FeedingTask) (*entity.FeedingTask, error) {
+	current := s.tasks[task.ID]
+	if current.Status != entity.FeedingTaskPending {
+		return nil, errorspkg.ErrorTaskClosed
+	}
+	if task.Version > 0 && task.Version != current.Version {
+		return nil, errorspkg.ErrorVersionConflict
+	}
+
+	s.closed++
+	current.Status = task.Status
+	current.ActualCapacity = task.ActualCapacity
+	current.SkipReason = task.SkipReason
+	current.GivenEatablesID = task.GivenEatablesID
+	current.CompletedAt = task.CompletedAt
+	current.Version++
+	return s.Get(ctx, task.ID)
+}
+
+type feedingStub struct {
+	given []*entity.Feeding
+	err   error
+}
+
+func (s *feedingStub) Create(ctx context.Context, feeding *entity.Feeding) (*entity.FeedingRes, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	feeding.ID = "feeding-" + feeding.AnimalID
+	s.given = append(s.given, feeding)
+	return &entity.FeedingRes{}, nil
+}
+
+func (s *feedingStub) Update(ctx context.Context, eatable *entity.Feeding) (*entity.FeedingRes, error) {
+	return nil, nil
+}
+
+func (s *feedingStub) Delete(ctx context.Context, eatableID string, version int64) error { return nil }
+
+func (s *feedingStub) List(ctx context.Context, page, limit uint64, request listquery.Request) (*entity.ListFeedings, error) {
+	return nil, nil
+}
+
+func (s *feedingStub) Overdue(ctx context.Context) ([]*entity.OverdueFeeding, error) { return nil, nil }
+
+type txStub struct{}
+
+func (txStub) WithTx(ctx context.Context, fn func(ctx context.Context) error) error { return fn(ctx) }
+
+func newTasks() (tasks.FeedingTask, *taskRepoStub, *feedingStub) {
+	repo := &taskRepoStub{tasks: map[string]*entity.FeedingTask{}}
+	for _, id := range []string{"morning", "noon", "evening", "night"} {
+		repo.tasks[id] = &entity.FeedingTask{
+			ID:         id,
+			AnimalID:   "bella",
+			EatablesID: "hay",
+			Category:   "food",
+			Day:        "2024-01-01",
+			Slot:       "08:00",
+			Capacity:   5,
+			Status:     entity.FeedingTaskPending,
+			Version:    1,
+		}
+	}
+	feeding := &feedingStub{}
+	return tasks.NewFeedingTaskService(time.Second, repo, feeding, txStub{}), repo, feeding
+}
+
+func TestDone(t *testing.T) {
+	service, repo, feeding := newTasks()
+	ctx := context.Background()
+
+	// The planned capacity is given unless the feeder says otherwise
+	done, err := service.Done(ctx, &entity.FeedingTask{ID: "morning"})
+	require.NoError(t, err)
+	assert.Equal(t, entity.FeedingTaskDone, done.Status)
+	assert.Equal(t, int64(5), done.ActualCapacity)
+	assert.Equal(t, "feeding-bella", done.GivenEatablesID)
+	assert.NotNil(t, done.CompletedAt)
+	require.Len(t, feeding.given, 1)
+	assert.Equal(t, "hay", feeding.given[0].EatablesID)
+	assert.Equal(t, "2024-01-01", feeding.given[0].Day)
+	assert.Equal(t, int64(5), feeding.given[0].Daily[0].Capacity)
+	assert.Equal(t, "08:00", feeding.given[0].Daily[0].Time)
+
+	done, err = service.Done(ctx, &entity.FeedingTask{ID: "noon", ActualCapacity: 3, Version: 1})
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), done.ActualCapacity)
+	assert.Equal(t, int64(3), feeding.given[1].Daily[0].Capacity)
+
+	// A closed task is not fed twice
+	_, err = service.Done(ctx, &entity.FeedingTask{ID: "morning"})
+	assert.ErrorIs(t, err, errorspkg.ErrorTaskClosed)
+	assert.Equal(t, errorspkg.CodeTaskClosed, errorspkg.CodeOf(err))
+	assert.Len(t, feeding.given, 2)
+
+	// A stale version is refused
+	_, err = service.Done(ctx, &entity.FeedingTask{ID: "evening", Version: 7})
+	assert.ErrorIs(t, err, errorspkg.ErrorVersionConflict)
+
+	// The task stays pending when the feeding cannot be recorded
+	feeding.err = errorspkg.ErrorNotEnoughStock
+	_, err = service.Done(ctx, &entity.FeedingTask{ID: "night"})
+	assert.ErrorIs(t, err, errorspkg.ErrorNotEnoughStock)
+	assert.Equal(t, entity.FeedingTaskPending, repo.tasks["night"].Status)
+
+	_, err = service.Done(ctx, &entity.FeedingTask{ID: "gone"})
+	assert.ErrorIs(t, err, errorspkg.ErrorNotFound)
+	assert.Equal(t, 2, repo.closed)
+}
+
+func TestSkip(t *testing.T) {
+	service, repo, feeding := newTasks()
+	ctx := context.Background()
+
+	// A reason is required, whoever calls
+	for _, reason := range []string{"", "   ", "\n\t"} {
+		_, err := service.Skip(ctx, &entity.FeedingTask{ID: "morning", SkipReason: reason})
+		var invalid *errorspkg.ErrValidation
+		require.True(t, errors.As(err, &invalid), "%q", reason)
+		assert.Contains(t, invalid.Errors, "reason")
+	}
+	assert.Zero(t, repo.closed)
+	assert.Equal(t, entity.FeedingTaskPending, repo.tasks["morning"].Status)
+
+	// The reason is kept trimmed and nothing is fed
+	skipped, err := service.Skip(ctx, &entity.FeedingTask{ID: "morning", SkipReason: "  animal is sick\n", Version: 1})
+	require.NoError(t, err)
+	assert.Equal(t, entity.FeedingTaskSkipped, skipped.Status)
+	assert.Equal(t, "animal is sick", skipped.SkipReason)
+	assert.NotNil(t, skipped.CompletedAt)
+	assert.Empty(t, feeding.given)
+
+	// Closed tasks and stale versions are refused
+	_, err = service.Skip(ctx, &entity.FeedingTask{ID: "morning", SkipReason: "again"})
+	assert.ErrorIs(t, err, errorspkg.ErrorTaskClosed)
+	_, err = service.Done(ctx, &entity.FeedingTask{ID: "morning"})
+	assert.ErrorIs(t, err, errorspkg.ErrorTaskClosed)
+	_, err = service.Skip(ctx, &entity.FeedingTask{ID: "noon", SkipReason: "no hay", Version: 2})
+	assert.ErrorIs(t, err, errorspkg.ErrorVersionConflict)
+	assert.Equal(t, 1, repo.closed)
+}
@@ -0,0 +1,26 @@
+package trash
+
+import (
+	"context"
+	redisrepo "musobaqa/farm-competition/internal/infrastructure/repository/redis"
+)
+
+type trashCache struct {
+	Trash
+	cache *redisrepo.Namespaces
+}
+
+// NewTrashCache drops the cached reads of an entity type when one of its rows
+// is restored
+func NewTrashCache(next Trash, cache *redisrepo.Namespaces) Trash {
+	return &trashCache{
+		Trash: next,
+		cache: cache,
+	}
+}
+
+func (c *trashCache) Restore(ctx context.Context, entityType, entityID string) error {
+	err := c.Trash.Restore(ctx, entityType, entityID)
+	c.cache.Invalidate(ctx, entityType)
+	return err
+}
@@ -0,0 +1,12 @@
+package trash
+
+import (
+	"context"
+	"musobaqa/farm-competition/internal/entity"
+)
+
+type Trash interface {
+	List(ctx context.Context, entityType string, page, limit uint64) (*entity.ListTrash, error)
+	Restore(ctx context.Context, entityType, entityID string) error
+	Purge(ctx context.Context) (map[string]int64, error)
+}
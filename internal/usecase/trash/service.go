@@ -0,0 +1,45 @@
+package trash
+
+import (
+	"context"
+	"musobaqa/farm-competition/internal/entity"
+	"musobaqa/farm-competition/internal/infrastructure/repository/postgresql/repo"
+	"musobaqa/farm-competition/internal/pkg/otlp"
+	"time"
+)
+
+type trashService struct {
+	ctxTimeout time.Duration
+	repo       repo.Trash
+	retention  time.Duration
+}
+
+func NewTrashService(timeout time.Duration, repository repo.Trash, retention time.Duration) Trash {
+	return &trashService{
+		ctxTimeout: timeout,
+		repo:       repository,
+		retention:  retention,
+	}
+}
+
+func (t *trashService) List(ctx context.Context, entityType string, page, limit uint64) (*entity.ListTrash, error) {
+	ctx, span := otlp.Start(ctx, "usecase", "trashService.List")
+	defer span.End()
+
+	return t.repo.List(ctx, entityType, page, limit)
+}
+
+func (t *trashService) Restore(ctx context.Context, entityType, entityID string) error {
+	ctx, span := otlp.Start(ctx, "usecase", "trashService.Restore")
+	defer span.End()
+
+	return t.repo.Restore(ctx, entityType, entityID)
+}
+
+// Purge removes rows that stayed in the trash longer than the retention period
+func (t *trashService) Purge(ctx context.Context) (map[string]int64, error) {
+	ctx, span := otlp.Start(ctx, "usecase", "trashService.Purge")
+	defer span.End()
+
+	return t.repo.Purge(ctx, time.Now().UTC().Add(-t.retention))
+}
@@ -0,0 +1,14 @@
+package users
+
+import (
+	"context"
+	"musobaqa/farm-competition/internal/entity"
+)
+
+type User interface {
+	Create(ctx context.Context, user *entity.User) (*entity.User, error)
+	Get(ctx context.Context, email string) (*entity.User, error)
+	List(ctx context.Context, page, limit uint64, role string) (*entity.ListUsers, error)
+	SetRole(ctx context.Context, email, role string) (*entity.User, error)
+	Delete(ctx context.Context, email string) error
+}
@@ -0,0 +1,114 @@
+package users
+
+import (
+	"context"
+	"errors"
+	"musobaqa/farm-competition/internal/entity"
+	errorspkg "musobaqa/farm-competition/internal/errors"
+	"musobaqa/farm-competition/internal/infrastructure/repository/postgresql/repo"
+	"musobaqa/farm-competition/internal/pkg/etc"
+	"musobaqa/farm-competition/internal/pkg/otlp"
+	"musobaqa/farm-competition/internal/pkg/validation"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type userService struct {
+	ctxTimeout time.Duration
+	repo       repo.User
+}
+
+func NewUserService(timeout time.Duration, repository repo.User) User {
+	return &userService{
+		ctxTimeout: timeout,
+		repo:       repository,
+	}
+}
+
+// validate checks the fields of a user, the password only when it is being set
+func validate(user *entity.User, withPassword bool) error {
+	errValidation := errorspkg.NewErrValidation()
+	if !validation.IsValidEmail(user.Email) {
+		errValidation.Errors["email"] = "must be a valid email address"
+	}
+	if withPassword && !validation.IsValidPassword(user.Password) {
+		errValidation.Errors["password"] = "must be at least 8 characters with a letter and a digit"
+	}
+	if !slices.Contains(entity.Roles, user.Role) {
+		errValidation.Errors["role"] = "must be one of " + strings.Join(entity.Roles, ", ")
+	}
+	if len(errValidation.Errors) == 0 {
+		return nil
+	}
+
+	errValidation.Err = errors.New("invalid user")
+	return errValidation
+}
+
+func (u *userService) beforeCreate(user *entity.User) error {
+	hash, err := etc.HashPassword(user.Password)
+	if err != nil {
+		return err
+	}
+
+	user.ID = uuid.New().String()
+	user.Email = strings.ToLower(strings.TrimSpace(user.Email))
+	user.PasswordHash = hash
+	user.Password = ""
+	user.CreatedAt = time.Now().UTC()
+	user.UpdatedAt = time.Now().UTC()
+
+	return nil
+}
+
+func (u *userService) Create(ctx context.Context, user *entity.User) (*entity.User, error) {
+	ctx, span := otlp.Start(ctx, "usecase", "userService.Create")
+	defer span.End()
+
+	if err := validate(user, true); err != nil {
+		return nil, err
+	}
+	if err := u.beforeCreate(user); err != nil {
+		return nil, err
+	}
+
+	res, err := u.repo.Create(ctx, user)
+	return res, errorspkg.Wrap(err, "create user")
+}
+
+func (u *userService) Get(ctx context.Context, email string) (*entity.User, error) {
+	ctx, span := otlp.Start(ctx, "usecase", "userService.Get")
+	defer span.End()
+
+	res, err := u.repo.GetByEmail(ctx, email)
+	return res, errorspkg.Wrap(err, "get user %s", email)
+}
+
+func (u *userService) List(ctx context.Context, page, limit uint64, role string) (*entity.ListUsers, error) {
+	ctx, span := otlp.Start(ctx, "usecase", "userService.List")
+	defer span.End()
+
+	return u.repo.List(ctx, page, limit, role)
+}
+
+func (u *userService) SetRole(ctx context.Context, email, role string) (*entity.User, error) {
+	ctx, span := otlp.Start(ctx, "usecase", "userService.SetRole")
+	defer span.End()
+
+	if err := validate(&entity.User{Email: email, Role: role}, false); err != nil {
+		return nil, err
+	}
+
+	res, err := u.repo.SetRole(ctx, email, role)
+	return res, errorspkg.Wrap(err, "set role of user %s", email)
+}
+
+func (u *userService) Delete(ctx context.Context, email string) error {
+	ctx, span := otlp.Start(ctx, "usecase", "userService.Delete")
+	defer span.End()
+
+	return errorspkg.Wrap(u.repo.Delete(ctx, email), "delete user %s", email)
+}
@@ -0,0 +1,12 @@
+package valuation
+
+import (
+	"context"
+	"musobaqa/farm-competition/internal/entity"
+)
+
+type Valuation interface {
+	Inventory(ctx context.Context, method, category string) (*entity.InventoryValuation, error)
+	FeedingCost(ctx context.Context, method, feedingID string) (*entity.FeedingCost, error)
+	FeedCostReport(ctx context.Context, method, groupBy string, params map[string]any) (*entity.FeedCostReport, error)
+}
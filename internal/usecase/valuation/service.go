@@ -0,0 +1,277 @@
+package valuation
+
+import (
+	"context"
+	"fmt"
+	"musobaqa/farm-competition/internal/entity"
+	errorspkg "musobaqa/farm-competition/internal/errors"
+	"musobaqa/farm-competition/internal/infrastructure/repository/postgresql/repo"
+	"musobaqa/farm-competition/internal/pkg/costing"
+	"musobaqa/farm-competition/internal/pkg/math"
+	"musobaqa/farm-competition/internal/pkg/otlp"
+	"sort"
+	"time"
+
+	"github.com/spf13/cast"
+)
+
+type valuationService struct {
+	ctxTimeout time.Duration
+	repo       repo.Costing
+	method     costing.Method
+}
+
+func NewValuationService(timeout time.Duration, repository repo.Costing, method costing.Method) Valuation {
+	return &valuationService{
+		ctxTimeout: timeout,
+		repo:       repository,
+		method:     method,
+	}
+}
+
+// item identifies a stock item, deliveries and feedings are linked by category and name
+type item struct {
+	category string
+	name     string
+}
+
+type ledger struct {
+	method  costing.Method
+	results map[item]*costing.Result
+	issues  map[string]*entity.StockIssue
+	costs   map[string]costing.IssueCost
+}
+
+func (v *valuationService) resolveMethod(method string) (costing.Method, error) {
+	if method == "" {
+		return v.method, nil
+	}
+
+	return costing.ParseMethod(method)
+}
+
+// replay runs the costing engine over the whole movement history of every
+// item, the history is never filtered because earlier movements decide the cost
+func (v *valuationService) replay(ctx context.Context, method, category string) (*ledger, error) {
+	resolved, err := v.resolveMethod(method)
+	if err != nil {
+		return nil, err
+	}
+
+	receipts, err := v.repo.ListReceipts(ctx, category)
+	if err != nil {
+		return nil, err
+	}
+
+	issues, err := v.repo.ListIssues(ctx, category)
+	if err != nil {
+		return nil, err
+	}
+
+	writeOffs, err := v.repo.ListWriteOffs(ctx, category)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		itemReceipts = map[item][]costing.Receipt{}
+		itemIssues   = map[item][]costing.Issue{}
+		result       = ledger{
+			method:  resolved,
+			results: map[item]*costing.Result{},
+			issues:  map[string]*entity.StockIssue{},
+			costs:   map[string]costing.IssueCost{},
+		}
+	)
+	for _, r := range receipts {
+		key := item{category: r.Category, name: r.Name}
+		itemReceipts[key] = append(itemReceipts[key], costing.Receipt{
+			Time:     r.Time,
+			Quantity: r.Quantity,
+			UnitCost: r.UnitCost,
+		})
+	}
+	for _, i := range issues {
+		key := item{category: i.Category, name: i.Name}
+		itemIssues[key] = append(itemIssues[key], costing.Issue{
+			ID:       i.ID,
+			Time:     i.Time,
+			Quantity: i.Quantity,
+		})
+		result.issues[i.ID] = i
+	}
+	for _, w := range writeOffs {
+		key := item{category: w.Category, name: w.Name}
+		itemIssues[key] = append(itemIssues[key], costing.Issue{
+			ID:       w.ID,
+			Time:     w.Time,
+			Quantity: w.Quantity,
+		})
+	}
+
+	keys := map[item]struct{}{}
+	for key := range itemReceipts {
+		keys[key] = struct{}{}
+	}
+	for key := range itemIssues {
+		keys[key] = struct{}{}
+	}
+
+	for key := range keys {
+		res, err := costing.Run(resolved, itemReceipts[key], itemIssues[key])
+		if err != nil {
+			return nil, err
+		}
+
+		result.results[key] = res
+		for _, cost := range res.Issues {
+			result.costs[cost.ID] = cost
+		}
+	}
+
+	return &result, nil
+}
+
+func (v *valuationService) Inventory(ctx context.Context, method, category string) (*entity.InventoryValuation, error) {
+	ctx, span := otlp.Start(ctx, "usecase", "valuationService.Inventory")
+	defer span.End()
+
+	l, err := v.replay(ctx, method, category)
+	if err != nil {
+		return nil, err
+	}
+
+	valuation := entity.InventoryValuation{
+		Method: string(l.method),
+		Items:  []*entity.ValuationItem{},
+	}
+	for key, res := range l.results {
+		valuationItem := entity.ValuationItem{
+			Category: key.category,
+			Name:     key.name,
+			Quantity: res.Quantity,
+			Value:    math.RoundFloat2DecimalPrecison(res.Value),
+		}
+		if res.Quantity > 0 {
+			valuationItem.UnitCost = math.RoundFloat2DecimalPrecison(res.Value / float64(res.Quantity))
+		}
+
+		valuation.TotalValue += res.Value
+		valuation.Items = append(valuation.Items, &valuationItem)
+	}
+	valuation.TotalValue = math.RoundFloat2DecimalPrecison(valuation.TotalValue)
+
+	sort.Slice(valuation.Items, func(i, j int) bool {
+		if valuation.Items[i].Category == valuation.Items[j].Category {
+			return valuation.Items[i].Name < valuation.Items[j].Name
+		}
+		return valuation.Items[i].Category < valuation.Items[j].Category
+	})
+
+	return &valuation, nil
+}
+
+func (v *valuationService) FeedingCost(ctx context.Context, method, feedingID string) (*entity.FeedingCost, error) {
+	ctx, span := otlp.Start(ctx, "usecase", "valuationService.FeedingCost")
+	defer span.End()
+
+	l, err := v.replay(ctx, method, "")
+	if err != nil {
+		return nil, err
+	}
+
+	issue, ok := l.issues[feedingID]
+	if !ok {
+		return nil, errorspkg.NewErrNotFound("feeding")
+	}
+
+	return feedingCost(l, issue), nil
+}
+
+func (v *valuationService) FeedCostReport(ctx context.Context, method, groupBy string, params map[string]any) (*entity.FeedCostReport, error) {
+	ctx, span := otlp.Start(ctx, "usecase", "valuationService.FeedCostReport")
+	defer span.End()
+
+	if groupBy != "animal" && groupBy != "category" {
+		return nil, fmt.Errorf("unknown feed cost grouping: %s", groupBy)
+	}
+
+	l, err := v.replay(ctx, method, cast.ToString(params["category"]))
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		animalID = cast.ToString(params["animal_id"])
+		from     = cast.ToString(params["from"])
+		to       = cast.ToString(params["to"])
+		items    = map[string]*entity.FeedCostItem{}
+		report   = entity.FeedCostReport{
+			Method:  string(l.method),
+			GroupBy: groupBy,
+			Items:   []*entity.FeedCostItem{},
+		}
+	)
+	for _, issue := range l.issues {
+		if animalID != "" && issue.AnimalID != animalID {
+			continue
+		}
+		if from != "" && issue.Day < from {
+			continue
+		}
+		if to != "" && issue.Day > to {
+			continue
+		}
+
+		key, label := issue.AnimalID, issue.AnimalName
+		if groupBy == "category" {
+			key, label = issue.AnimalCategory, issue.AnimalCategory
+		}
+
+		reportItem, ok := items[key]
+		if !ok {
+			reportItem = &entity.FeedCostItem{Key: key, Label: label}
+			items[key] = reportItem
+			report.Items = append(report.Items, reportItem)
+		}
+
+		cost := l.costs[issue.ID]
+		reportItem.Quantity += cost.Quantity
+		reportItem.Cost += cost.Cost
+		reportItem.Feedings++
+		report.TotalCost += cost.Cost
+	}
+
+	for _, reportItem := range report.Items {
+		reportItem.Cost = math.RoundFloat2DecimalPrecison(reportItem.Cost)
+	}
+	report.TotalCost = math.RoundFloat2DecimalPrecison(report.TotalCost)
+
+	sort.Slice(report.Items, func(i, j int) bool {
+		return report.Items[i].Cost > report.Items[j].Cost
+	})
+
+	return &report, nil
+}
+
+func feedingCost(l *ledger, issue *entity.StockIssue) *entity.FeedingCost {
+	cost := l.costs[issue.ID]
+
+	feeding := entity.FeedingCost{
+		FeedingID: issue.ID,
+		AnimalID:  issue.AnimalID,
+		EatableID: issue.EatableID,
+		Category:  issue.Category,
+		Name:      issue.Name,
+		Day:       issue.Day,
+		Quantity:  cost.Quantity,
+		Shortage:  cost.Shortage,
+		Cost:      math.RoundFloat2DecimalPrecison(cost.Cost),
+		Method:    string(l.method),
+	}
+	if cost.Quantity > 0 {
+		feeding.UnitCost = math.RoundFloat2DecimalPrecison(cost.Cost / float64(cost.Quantity))
+	}
+
+	return &feeding
+}
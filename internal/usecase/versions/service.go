@@ -0,0 +1,28 @@
+package versions
+
+import (
+	"context"
+	"musobaqa/farm-competition/internal/infrastructure/repository/postgresql/repo"
+	"musobaqa/farm-competition/internal/pkg/otlp"
+	"time"
+)
+
+type versionService struct {
+	ctxTimeout time.Duration
+	repo       repo.Version
+}
+
+func NewVersionService(timeout time.Duration, repository repo.Version) Version {
+	return &versionService{
+		ctxTimeout: timeout,
+		repo:       repository,
+	}
+}
+
+// Current returns the version a conditional request is checked against
+func (v *versionService) Current(ctx context.Context, entityType, entityID string) (int64, error) {
+	ctx, span := otlp.Start(ctx, "usecase", "versionService.Current")
+	defer span.End()
+
+	return v.repo.Current(ctx, entityType, entityID)
+}
@@ -0,0 +1,19 @@
+package webhooks
+
+import (
+	"context"
+	"musobaqa/farm-competition/internal/entity"
+)
+
+type Webhook interface {
+	Create(ctx context.Context, subscription *entity.WebhookSubscription) (*entity.WebhookSubscription, error)
+	Update(ctx context.Context, subscription *entity.WebhookSubscription) (*entity.WebhookSubscription, error)
+	Delete(ctx context.Context, subscriptionID string, version int64) error
+	Get(ctx context.Context, subscriptionID string) (*entity.WebhookSubscription, error)
+	List(ctx context.Context, page, limit uint64, params map[string]any) (*entity.ListWebhookSubscriptions, error)
+	Publish(ctx context.Context, event *entity.DomainEvent) error
+	Dispatch(ctx context.Context) (int, error)
+	Deliveries(ctx context.Context, page, limit uint64, params map[string]any) (*entity.ListWebhookDeliveries, error)
+	Redeliver(ctx context.Context, deliveryID string) (*entity.WebhookDelivery, error)
+	Purge(ctx context.Context) (int64, error)
+}
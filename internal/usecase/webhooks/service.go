@@ -0,0 +1,278 @@
+package webhooks
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"musobaqa/farm-competition/internal/entity"
+	errorspkg "musobaqa/farm-competition/internal/errors"
+	"musobaqa/farm-competition/internal/infrastructure/repository/postgresql/repo"
+	"musobaqa/farm-competition/internal/pkg/otlp"
+	"musobaqa/farm-competition/internal/pkg/webhook"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Retry tells how often and how far apart a failing delivery is tried
+type Retry struct {
+	MaxAttempts int
+	Backoff     time.Duration
+	MaxBackoff  time.Duration
+}
+
+type webhookService struct {
+	ctxTimeout time.Duration
+	repo       repo.Webhook
+	client     *http.Client
+	batchSize  uint64
+	retry      Retry
+	retention  time.Duration
+}
+
+// NewWebhookService sends deliveries with the client, its Timeout bounds a
+// single attempt. Finished deliveries are kept for the retention period
+func NewWebhookService(timeout time.Duration, repository repo.Webhook, client *http.Client, batchSize int, retry Retry, retention time.Duration) Webhook {
+	return &webhookService{
+		ctxTimeout: timeout,
+		repo:       repository,
+		client:     client,
+		batchSize:  uint64(batchSize),
+		retry:      retry,
+		retention:  retention,
+	}
+}
+
+func (w *webhookService) beforeCreate(subscription *entity.WebhookSubscription) error {
+	subscription.ID = uuid.New().String()
+	subscription.CreatedAt = time.Now().UTC()
+	subscription.UpdatedAt = time.Now().UTC()
+
+	// subscribers that bring no secret get one, it is shown once
+	if subscription.Secret == "" {
+		secret := make([]byte, 32)
+		if _, err := rand.Read(secret); err != nil {
+			return err
+		}
+		subscription.Secret = hex.EncodeToString(secret)
+	}
+	return nil
+}
+
+func (w *webhookService) beforeUpdate(subscription *entity.WebhookSubscription) {
+	subscription.UpdatedAt = time.Now().UTC()
+}
+
+func (w *webhookService) Create(ctx context.Context, subscription *entity.WebhookSubscription) (*entity.WebhookSubscription, error) {
+	ctx, span := otlp.Start(ctx, "usecase", "webhookService.Create")
+	defer span.End()
+
+	if err := w.beforeCreate(subscription); err != nil {
+		return nil, errorspkg.Wrap(err, "create webhook")
+	}
+
+	res, err := w.repo.Create(ctx, subscription)
+	return res, errorspkg.Wrap(err, "create webhook")
+}
+
+func (w *webhookService) Update(ctx context.Context, subscription *entity.WebhookSubscription) (*entity.WebhookSubscription, error) {
+	ctx, span := otlp.Start(ctx, "usecase", "webhookService.Update")
+	defer span.End()
+
+	w.beforeUpdate(subscription)
+
+	res, err := w.repo.Update(ctx, subscription)
+	return res, errorspkg.Wrap(err, "update webhook %s", subscription.ID)
+}
+
+func (w *webhookService) Delete(ctx context.Context, subscriptionID string, version int64) error {
+	ctx, span := otlp.Start(ctx, "usecase", "webhookService.Delete")
+	defer span.End()
+
+	return errorspkg.Wrap(w.repo.Delete(ctx, subscriptionID, version), "delete webhook %s", subscriptionID)
+}
+
+func (w *webhookService) Get(ctx context.Context, subscriptionID string) (*entity.WebhookSubscription, error) {
+	ctx, span := otlp.Start(ctx, "usecase", "webhookService.Get")
+	defer span.End()
+
+	res, err := w.repo.Get(ctx, subscriptionID)
+	return res, errorspkg.Wrap(err, "get webhook %s", subscriptionID)
+}
+
+func (w *webhookService) List(ctx context.Context, page, limit uint64, params map[string]any) (*entity.ListWebhookSubscriptions, error) {
+	ctx, span := otlp.Start(ctx, "usecase", "webhookService.List")
+	defer span.End()
+
+	return w.repo.List(ctx, page, limit, params)
+}
+
+// envelope is the body of every delivery
+type envelope struct {
+	ID         string          `json:"id"`
+	Type       string          `json:"type"`
+	OccurredAt time.Time       `json:"occurred_at"`
+	Data       json.RawMessage `json:"data"`
+}
+
+// Publish queues the event for the subscriptions to its type, the dispatcher
+// sends it later. An event with a key published before is not sent twice,
+// so it can be subscribed to the event bus as it is
+func (w *webhookService) Publish(ctx context.Context, event *entity.DomainEvent) error {
+	ctx, span := otlp.Start(ctx, "usecase", "webhookService.Publish")
+	defer span.End()
+
+	payload, err := json.Marshal(envelope{
+		ID:         event.ID,
+		Type:       event.Type,
+		OccurredAt: event.OccurredAt,
+		Data:       event.Payload,
+	})
+	if err != nil {
+		return errorspkg.Wrap(err, "publish %s", event.Type)
+	}
+
+	_, err = w.repo.Enqueue(ctx, &entity.WebhookEvent{
+		ID:         event.ID,
+		Type:       event.Type,
+		Key:        event.Type + ":" + event.Key,
+		Payload:    payload,
+		OccurredAt: event.OccurredAt,
+	})
+	return errorspkg.Wrap(err, "publish %s", event.Type)
+}
+
+// Dispatch sends one batch of due deliveries at once and returns how many of
+// them were delivered
+func (w *webhookService) Dispatch(ctx context.Context) (int, error) {
+	ctx, span := otlp.Start(ctx, "usecase", "webhookService.Dispatch")
+	defer span.End()
+
+	// the lease outlives the attempts of the batch, they run side by side
+	deliveries, err := w.repo.Claim(ctx, w.batchSize, 2*w.client.Timeout)
+	if err != nil {
+		return 0, errorspkg.Wrap(err, "claim webhook deliveries")
+	}
+
+	var (
+		wg        sync.WaitGroup
+		mu        sync.Mutex
+		delivered int
+		errs      []error
+	)
+	for _, delivery := range deliveries {
+		wg.Add(1)
+		go func(delivery *entity.WebhookDelivery) {
+			defer wg.Done()
+
+			w.attempt(ctx, delivery)
+			// the attempt is recorded even when the app is stopping
+			err := w.repo.SaveAttempt(context.WithoutCancel(ctx), delivery)
+
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				errs = append(errs, errorspkg.Wrap(err, "save webhook delivery %s", delivery.ID))
+				return
+			}
+			if delivery.Status == entity.WebhookDeliveryDelivered {
+				delivered++
+			}
+		}(delivery)
+	}
+	wg.Wait()
+
+	if len(errs) > 0 {
+		return delivered, errs[0]
+	}
+	return delivered, nil
+}
+
+// attempt sends the delivery once and records the outcome on it, a failure
+// schedules the next attempt or gives up after the last one
+func (w *webhookService) attempt(ctx context.Context, delivery *entity.WebhookDelivery) {
+	delivery.Attempts++
+	delivery.ResponseStatus = 0
+	delivery.LastError = ""
+
+	status, err := w.send(ctx, delivery)
+	delivery.ResponseStatus = status
+
+	now := time.Now().UTC()
+	switch {
+	case err == nil:
+		delivery.Status = entity.WebhookDeliveryDelivered
+		delivery.DeliveredAt = &now
+		delivery.NextAttemptAt = now
+	case delivery.Attempts >= w.retry.MaxAttempts:
+		delivery.Status = entity.WebhookDeliveryFailed
+		delivery.LastError = err.Error()
+		delivery.NextAttemptAt = now
+	default:
+		delivery.Status = entity.WebhookDeliveryPending
+		delivery.LastError = err.Error()
+		delivery.NextAttemptAt = now.Add(webhook.Backoff(delivery.Attempts, w.retry.Backoff, w.retry.MaxBackoff))
+	}
+}
+
+// send posts the signed payload, any answer but a 2xx is a failure
+func (w *webhookService) send(ctx context.Context, delivery *entity.WebhookDelivery) (int, error) {
+	timestamp := time.Now().Unix()
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, delivery.URL, bytes.NewReader(delivery.Payload))
+	if err != nil {
+		return 0, err
+	}
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set("User-Agent", "farmish-webhooks")
+	request.Header.Set(webhook.HeaderEvent, delivery.Event)
+	request.Header.Set(webhook.HeaderDelivery, delivery.ID)
+	request.Header.Set(webhook.HeaderTimestamp, strconv.FormatInt(timestamp, 10))
+	request.Header.Set(webhook.HeaderSignature, webhook.Sign(delivery.Secret, timestamp, delivery.Payload))
+
+	response, err := w.client.Do(request)
+	if err != nil {
+		return 0, err
+	}
+	defer response.Body.Close()
+	// reading the body lets the connection be reused
+	_, _ = io.Copy(io.Discard, io.LimitReader(response.Body, 64<<10))
+
+	if response.StatusCode < 200 || response.StatusCode > 299 {
+		return response.StatusCode, fmt.Errorf("subscriber answered %s", response.Status)
+	}
+	return response.StatusCode, nil
+}
+
+func (w *webhookService) Deliveries(ctx context.Context, page, limit uint64, params map[string]any) (*entity.ListWebhookDeliveries, error) {
+	ctx, span := otlp.Start(ctx, "usecase", "webhookService.Deliveries")
+	defer span.End()
+
+	return w.repo.ListDeliveries(ctx, page, limit, params)
+}
+
+// Redeliver sends the delivery again on the next dispatch
+func (w *webhookService) Redeliver(ctx context.Context, deliveryID string) (*entity.WebhookDelivery, error) {
+	ctx, span := otlp.Start(ctx, "usecase", "webhookService.Redeliver")
+	defer span.End()
+
+	res, err := w.repo.Redeliver(ctx, deliveryID)
+	return res, errorspkg.Wrap(err, "redeliver webhook delivery %s", deliveryID)
+}
+
+// Purge removes the deliveries older than the retention period that were
+// delivered or given up on
+func (w *webhookService) Purge(ctx context.Context) (int64, error) {
+	ctx, span := otlp.Start(ctx, "usecase", "webhookService.Purge")
+	defer span.End()
+
+	res, err := w.repo.PurgeDeliveries(ctx, time.Now().UTC().Add(-w.retention))
+	return res, errorspkg.Wrap(err, "purge webhook deliveries")
+}
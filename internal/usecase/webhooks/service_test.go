@@ -0,0 +1,219 @@
+package webhooks_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"musobaqa/farm-competition/internal/entity"
+	"musobaqa/farm-competition/internal/pkg/webhook"
+	"musobaqa/farm-competition/internal/usecase/webhooks"
+)
+
+// webhookRepoStub hands out the queued deliveries once and keeps the
+// attempts saved for them
+type webhookRepoStub struct {
+	mu         sync.Mutex
+	due        []*entity.WebhookDelivery
+	saved      map[string]*entity.WebhookDelivery
+	enqueued   []*entity.WebhookEvent
+	saveFailed bool
+}
+
+func (s *webhookRepoStub) Create(ctx context.Context, subscription *entity.WebhookSubscription) (*entity.WebhookSubscription, error) {
+	return subscription, nil
+}
+
+func (s *webhookRepoStub) Update(ctx context.Context, subscription *entity.WebhookSubscription) (*entity.WebhookSubscription, error) {
+	return subscription, nil
+}
+
+func (s *webhookRepoStub) Delete(ctx context.Context, subscriptionID string, version int64) error {
+	return nil
+}
+
+func (s *webhookRepoStub) Get(ctx context.Context, subscriptionID string) (*entity.WebhookSubscription, error) {
+	return nil, nil
+}
+
+func (s *webhookRepoStub) List(ctx context.Context, page, limit uint64, params map[string]any) (*entity.ListWebhookSubscriptions, error) {
+	return &entity.ListWebhookSubscriptions{}, nil
+}
+
+func (s *webhookRepoStub) Enqueue(ctx context.Context, event *entity.WebhookEvent) (int64, error) {
+	s.enqueued = append(s.enqueued, event)
+	return 1, nil
+}
+
+func (s *webhookRepoStub) Claim(ctx context.Context, limit uint64, lease time.Duration) ([]*entity.WebhookDelivery, error) {
+	due := s.due
+	s.due = nil
+	return due, nil
+}
+
+func (s *webhookRepoStub) SaveAttempt(ctx context.Context, delivery *entity.WebhookDelivery) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.saveFailed {
+		return errors.New("connection reset")
+	}
+	saved := *delivery
+	s.saved[delivery.ID] = &saved
+	return nil
+}
+
+func (s *webhookRepoStub) ListDeliveries(ctx context.Context, page, limit uint64, params map[string]any) (*entity.ListWebhookDeliveries, error) {
+	return &entity.ListWebhookDeliveries{}, nil
+}
+
+func (s *webhookRepoStub) Redeliver(ctx context.Context, deliveryID string) (*entity.WebhookDelivery, error) {
+	return nil, nil
+}
+
+func (s *webhookRepoStub) PurgeDeliveries(ctx context.Context, before time.Time) (int64, error) {
+	return 0, nil
+}
+
+func TestDispatch(t *testing.T) {
+	// the subscriber checks the signature and answers by path
+	var verified sync.Map
+	subscriber := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		timestamp, _ := strconv.ParseInt(r.Header.Get(webhook.HeaderTimestamp), 10, 64)
+		if webhook.Verify("secret", timestamp, body, r.Header.Get(webhook.HeaderSignature)) {
+			verified.Store(r.Header.Get(webhook.HeaderDelivery), r.Header.Get(webhook.HeaderEvent))
+		}
+
+		switch r.URL.Path {
+		case "/ok":
+			w.WriteHeader(http.StatusNoContent)
+		case "/moved":
+			http.Redirect(w, r, "/ok", http.StatusFound)
+		default:
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer subscriber.Close()
+	gone := httptest.NewServer(http.NotFoundHandler())
+	gone.Close()
+
+	client := subscriber.Client()
+	client.Timeout = time.Second
+	client.CheckRedirect = func(*http.Request, []*http.Request) error { return http.ErrUseLastResponse }
+
+	retry := webhooks.Retry{MaxAttempts: 3, Backoff: time.Minute, MaxBackoff: time.Hour}
+	repo := &webhookRepoStub{saved: map[string]*entity.WebhookDelivery{}}
+	service := webhooks.NewWebhookService(time.Second, repo, client, 10, retry, time.Hour)
+
+	delivery := func(id, url string, attempts int) *entity.WebhookDelivery {
+		return &entity.WebhookDelivery{
+			ID:       id,
+			Event:    entity.EventDeliveryReceived,
+			Payload:  json.RawMessage(`{"id":"` + id + `"}`),
+			Status:   entity.WebhookDeliveryPending,
+			Attempts: attempts,
+			URL:      url,
+			Secret:   "secret",
+		}
+	}
+	repo.due = []*entity.WebhookDelivery{
+		delivery("ok", subscriber.URL+"/ok", 0),
+		delivery("down", subscriber.URL+"/down", 0),
+		delivery("retried", subscriber.URL+"/down", 1),
+		delivery("last", subscriber.URL+"/down", 2),
+		delivery("moved", subscriber.URL+"/moved", 0),
+		delivery("gone", gone.URL, 0),
+	}
+
+	start := time.Now().UTC()
+	delivered, err := service.Dispatch(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 1, delivered)
+	require.Len(t, repo.saved, 6)
+
+	// A 2xx answer delivers the signed payload
+	ok := repo.saved["ok"]
+	assert.Equal(t, entity.WebhookDeliveryDelivered, ok.Status)
+	assert.Equal(t, 1, ok.Attempts)
+	assert.Equal(t, http.StatusNoContent, ok.ResponseStatus)
+	assert.NotNil(t, ok.DeliveredAt)
+	assert.Empty(t, ok.LastError)
+	event, signed := verified.Load("ok")
+	assert.True(t, signed)
+	assert.Equal(t, entity.EventDeliveryReceived, event)
+
+	// Any other answer is retried after a backoff doubled every attempt
+	for id, wait := range map[string]time.Duration{"down": time.Minute, "retried": 2 * time.Minute} {
+		failed := repo.saved[id]
+		assert.Equal(t, entity.WebhookDeliveryPending, failed.Status, id)
+		assert.Equal(t, http.StatusServiceUnavailable, failed.ResponseStatus, id)
+		assert.Contains(t, failed.LastError, "503", id)
+		assert.Nil(t, failed.DeliveredAt, id)
+		assert.WithinDuration(t, start.Add(wait), failed.NextAttemptAt, 5*time.Second, id)
+	}
+
+	// The last attempt gives up
+	last := repo.saved["last"]
+	assert.Equal(t, entity.WebhookDeliveryFailed, last.Status)
+	assert.Equal(t, 3, last.Attempts)
+	assert.Contains(t, last.LastError, "503")
+
+	// Redirects are not followed and count as failures
+	moved := repo.saved["moved"]
+	assert.Equal(t, entity.WebhookDeliveryPending, moved.Status)
+	assert.Equal(t, http.StatusFound, moved.ResponseStatus)
+
+	// A subscriber that cannot be reached has no answer
+	unreachable := repo.saved["gone"]
+	assert.Equal(t, entity.WebhookDeliveryPending, unreachable.Status)
+	assert.Zero(t, unreachable.ResponseStatus)
+	assert.NotEmpty(t, unreachable.LastError)
+
+	// An attempt that cannot be saved fails the dispatch
+	repo.saveFailed = true
+	repo.due = []*entity.WebhookDelivery{delivery("unsaved", subscriber.URL+"/ok", 0)}
+	_, err = service.Dispatch(context.Background())
+	assert.ErrorContains(t, err, "save webhook delivery unsaved")
+
+	// Nothing due, nothing sent
+	delivered, err = service.Dispatch(context.Background())
+	assert.NoError(t, err)
+	assert.Zero(t, delivered)
+}
+
+func TestPublish(t *testing.T) {
+	repo := &webhookRepoStub{saved: map[string]*entity.WebhookDelivery{}}
+	service := webhooks.NewWebhookService(time.Second, repo, http.DefaultClient, 10, webhooks.Retry{MaxAttempts: 1}, time.Hour)
+
+	occurredAt := time.Date(2024, 1, 1, 8, 0, 0, 0, time.UTC)
+	err := service.Publish(context.Background(), &entity.DomainEvent{
+		ID:         "event-1",
+		Type:       entity.EventDeliveryReceived,
+		Key:        "delivery-1",
+		Payload:    json.RawMessage(`{"supplier":"hay farm"}`),
+		OccurredAt: occurredAt,
+	})
+	require.NoError(t, err)
+	require.Len(t, repo.enqueued, 1)
+
+	// Events are queued once per type and key, wrapped in the envelope
+	queued := repo.enqueued[0]
+	assert.Equal(t, entity.EventDeliveryReceived+":delivery-1", queued.Key)
+	assert.JSONEq(t, `{
+		"id": "event-1",
+		"type": "`+entity.EventDeliveryReceived+`",
+		"occurred_at": "2024-01-01T08:00:00Z",
+		"data": {"supplier": "hay farm"}
+	}`, string(queued.Payload))
+}
@@ -0,0 +1,10 @@
+DROP INDEX IF EXISTS into_store_supplier_id_idx;
+
+ALTER TABLE into_store
+    DROP COLUMN IF EXISTS invoice_file,
+    DROP COLUMN IF EXISTS invoice_number,
+    DROP COLUMN IF EXISTS total_cost,
+    DROP COLUMN IF EXISTS unit_price,
+    DROP COLUMN IF EXISTS supplier_id;
+
+DROP TABLE IF EXISTS suppliers;
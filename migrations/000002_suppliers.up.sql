@@ -0,0 +1,21 @@
+CREATE TABLE IF NOT EXISTS suppliers (
+    id UUID PRIMARY KEY,
+    name VARCHAR(100) NOT NULL,
+    contact_person VARCHAR(100),
+    phone VARCHAR(50),
+    email VARCHAR(100),
+    address TEXT,
+    description TEXT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMPTZ DEFAULT NULL
+);
+
+ALTER TABLE into_store
+    ADD COLUMN IF NOT EXISTS supplier_id UUID REFERENCES suppliers(id),
+    ADD COLUMN IF NOT EXISTS unit_price NUMERIC(14, 2) NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS total_cost NUMERIC(14, 2) NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS invoice_number VARCHAR(100),
+    ADD COLUMN IF NOT EXISTS invoice_file VARCHAR(255);
+
+CREATE INDEX IF NOT EXISTS into_store_supplier_id_idx ON into_store (supplier_id);
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS stock_lot_consumptions;
+
+DROP TABLE IF EXISTS stock_lots;
@@ -0,0 +1,38 @@
+CREATE TABLE IF NOT EXISTS stock_lots (
+    id UUID PRIMARY KEY,
+    delivery_id UUID REFERENCES into_store(id),
+    category VARCHAR(100) NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    lot_number VARCHAR(100) NOT NULL,
+    received_date DATE NOT NULL DEFAULT CURRENT_DATE,
+    expiry_date DATE,
+    quantity BIGINT NOT NULL,
+    remaining BIGINT NOT NULL CHECK (remaining >= 0),
+    written_off BIGINT NOT NULL DEFAULT 0,
+    written_off_at TIMESTAMPTZ DEFAULT NULL,
+    write_off_reason TEXT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMPTZ DEFAULT NULL
+);
+
+CREATE INDEX IF NOT EXISTS stock_lots_fefo_idx ON stock_lots (category, name, expiry_date, received_date) WHERE remaining > 0;
+
+CREATE TABLE IF NOT EXISTS stock_lot_consumptions (
+    id UUID PRIMARY KEY,
+    lot_id UUID NOT NULL REFERENCES stock_lots(id),
+    feeding_id UUID NOT NULL,
+    quantity BIGINT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS stock_lot_consumptions_feeding_id_idx ON stock_lot_consumptions (feeding_id);
+
+-- stock recorded before lot tracking becomes one opening lot per food and drug
+INSERT INTO stock_lots (id, category, name, lot_number, received_date, quantity, remaining)
+SELECT gen_random_uuid(), 'food', name, 'OPENING', CURRENT_DATE, capacity, capacity
+FROM foods WHERE deleted_at IS NULL AND capacity > 0;
+
+INSERT INTO stock_lots (id, category, name, lot_number, received_date, quantity, remaining)
+SELECT gen_random_uuid(), 'drug', name, 'OPENING', CURRENT_DATE, capacity, capacity
+FROM drugs WHERE deleted_at IS NULL AND capacity > 0;
@@ -0,0 +1,10 @@
+ALTER TABLE animals DROP COLUMN IF EXISTS version;
+ALTER TABLE products DROP COLUMN IF EXISTS version;
+ALTER TABLE animal_products DROP COLUMN IF EXISTS version;
+ALTER TABLE foods DROP COLUMN IF EXISTS version;
+ALTER TABLE drugs DROP COLUMN IF EXISTS version;
+ALTER TABLE animal_eatable_info DROP COLUMN IF EXISTS version;
+ALTER TABLE animal_given_eatables DROP COLUMN IF EXISTS version;
+ALTER TABLE into_store DROP COLUMN IF EXISTS version;
+ALTER TABLE suppliers DROP COLUMN IF EXISTS version;
+ALTER TABLE stock_lots DROP COLUMN IF EXISTS version;
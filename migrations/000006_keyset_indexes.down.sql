@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS into_store_keyset_idx;
+DROP INDEX IF EXISTS animal_given_eatables_animal_keyset_idx;
+DROP INDEX IF EXISTS animal_given_eatables_keyset_idx;
+DROP INDEX IF EXISTS animal_products_keyset_idx;
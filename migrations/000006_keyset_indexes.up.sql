@@ -0,0 +1,4 @@
+CREATE INDEX IF NOT EXISTS animal_products_keyset_idx ON animal_products (created_at DESC, id DESC) WHERE deleted_at IS NULL;
+CREATE INDEX IF NOT EXISTS animal_given_eatables_keyset_idx ON animal_given_eatables (created_at DESC, id DESC) WHERE deleted_at IS NULL;
+CREATE INDEX IF NOT EXISTS animal_given_eatables_animal_keyset_idx ON animal_given_eatables (animal_id, created_at DESC, id DESC) WHERE deleted_at IS NULL;
+CREATE INDEX IF NOT EXISTS into_store_keyset_idx ON into_store (created_at DESC, id DESC) WHERE deleted_at IS NULL;
@@ -0,0 +1,9 @@
+DROP INDEX IF EXISTS drugs_name_trgm_idx;
+DROP INDEX IF EXISTS foods_name_trgm_idx;
+DROP INDEX IF EXISTS products_name_trgm_idx;
+DROP INDEX IF EXISTS animals_name_trgm_idx;
+
+ALTER TABLE drugs DROP COLUMN IF EXISTS search_vector;
+ALTER TABLE foods DROP COLUMN IF EXISTS search_vector;
+ALTER TABLE products DROP COLUMN IF EXISTS search_vector;
+ALTER TABLE animals DROP COLUMN IF EXISTS search_vector;
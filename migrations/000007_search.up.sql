@@ -0,0 +1,36 @@
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+-- the simple configuration does not stem, names are Uzbek and Russian
+ALTER TABLE animals ADD COLUMN IF NOT EXISTS search_vector tsvector GENERATED ALWAYS AS (
+    setweight(to_tsvector('simple', coalesce(name, '')), 'A') ||
+    setweight(to_tsvector('simple', coalesce(category_name, '') || ' ' || coalesce(genus, '')), 'B') ||
+    setweight(to_tsvector('simple', coalesce(description, '')), 'C')
+) STORED;
+
+ALTER TABLE products ADD COLUMN IF NOT EXISTS search_vector tsvector GENERATED ALWAYS AS (
+    setweight(to_tsvector('simple', coalesce(name, '')), 'A') ||
+    setweight(to_tsvector('simple', coalesce(product_union, '')), 'B') ||
+    setweight(to_tsvector('simple', coalesce(description, '')), 'C')
+) STORED;
+
+ALTER TABLE foods ADD COLUMN IF NOT EXISTS search_vector tsvector GENERATED ALWAYS AS (
+    setweight(to_tsvector('simple', coalesce(name, '')), 'A') ||
+    setweight(to_tsvector('simple', coalesce(product_union, '')), 'B') ||
+    setweight(to_tsvector('simple', coalesce(description, '')), 'C')
+) STORED;
+
+ALTER TABLE drugs ADD COLUMN IF NOT EXISTS search_vector tsvector GENERATED ALWAYS AS (
+    setweight(to_tsvector('simple', coalesce(name, '')), 'A') ||
+    setweight(to_tsvector('simple', coalesce(status, '')), 'B') ||
+    setweight(to_tsvector('simple', coalesce(description, '')), 'C')
+) STORED;
+
+CREATE INDEX IF NOT EXISTS animals_search_idx ON animals USING GIN (search_vector);
+CREATE INDEX IF NOT EXISTS products_search_idx ON products USING GIN (search_vector);
+CREATE INDEX IF NOT EXISTS foods_search_idx ON foods USING GIN (search_vector);
+CREATE INDEX IF NOT EXISTS drugs_search_idx ON drugs USING GIN (search_vector);
+
+CREATE INDEX IF NOT EXISTS animals_name_trgm_idx ON animals USING GIN (name gin_trgm_ops);
+CREATE INDEX IF NOT EXISTS products_name_trgm_idx ON products USING GIN (name gin_trgm_ops);
+CREATE INDEX IF NOT EXISTS foods_name_trgm_idx ON foods USING GIN (name gin_trgm_ops);
+CREATE INDEX IF NOT EXISTS drugs_name_trgm_idx ON drugs USING GIN (name gin_trgm_ops);
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS users_email_idx;
+
+DROP TABLE IF EXISTS users;
@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS webhook_deliveries_created_at_idx;
+DROP INDEX IF EXISTS webhook_deliveries_due_idx;
+DROP INDEX IF EXISTS webhook_deliveries_event_key_idx;
+
+DROP TABLE IF EXISTS webhook_deliveries;
+DROP TABLE IF EXISTS webhook_subscriptions;
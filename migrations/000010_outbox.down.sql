@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS outbox_events_aggregate_idx;
+DROP INDEX IF EXISTS outbox_events_unpublished_idx;
+
+DROP TABLE IF EXISTS outbox_events;
@@ -0,0 +1,8 @@
+DROP INDEX IF EXISTS report_snapshots_report_day_idx;
+
+DROP TABLE IF EXISTS report_snapshots;
+
+DROP INDEX IF EXISTS job_runs_started_at_idx;
+DROP INDEX IF EXISTS job_runs_occurrence_idx;
+
+DROP TABLE IF EXISTS job_runs;
@@ -0,0 +1,25 @@
+CREATE TABLE IF NOT EXISTS job_runs (
+    id UUID PRIMARY KEY,
+    job VARCHAR(100) NOT NULL,
+    scheduled_at TIMESTAMPTZ NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'running' CHECK (status IN ('running', 'succeeded', 'failed')),
+    holder VARCHAR(255) NOT NULL,
+    started_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    lease_until TIMESTAMPTZ NOT NULL,
+    finished_at TIMESTAMPTZ,
+    error TEXT
+);
+
+-- an occurrence of a job runs on one replica only
+CREATE UNIQUE INDEX IF NOT EXISTS job_runs_occurrence_idx ON job_runs (job, scheduled_at);
+CREATE INDEX IF NOT EXISTS job_runs_started_at_idx ON job_runs (started_at);
+
+CREATE TABLE IF NOT EXISTS report_snapshots (
+    id UUID PRIMARY KEY,
+    report VARCHAR(50) NOT NULL,
+    day DATE NOT NULL,
+    data JSONB NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS report_snapshots_report_day_idx ON report_snapshots (report, day);
@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS feeding_tasks_day_idx;
+DROP INDEX IF EXISTS feeding_tasks_slot_idx;
+
+DROP TABLE IF EXISTS feeding_tasks;
@@ -0,0 +1,23 @@
+CREATE TABLE IF NOT EXISTS feeding_tasks (
+    id UUID PRIMARY KEY,
+    animal_id UUID NOT NULL REFERENCES animals (id),
+    eatables_id UUID NOT NULL,
+    category VARCHAR(100) NOT NULL,
+    day DATE NOT NULL,
+    slot TIME NOT NULL,
+    capacity BIGINT NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'done', 'skipped')),
+    actual_capacity BIGINT,
+    skip_reason TEXT,
+    given_eatables_id UUID,
+    completed_by VARCHAR(100),
+    completed_at TIMESTAMPTZ,
+    version BIGINT NOT NULL DEFAULT 1,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMPTZ DEFAULT NULL
+);
+
+-- one task per time slot of an eatable in the plan of an animal and day
+CREATE UNIQUE INDEX IF NOT EXISTS feeding_tasks_slot_idx ON feeding_tasks (animal_id, eatables_id, day, slot) WHERE deleted_at IS NULL;
+CREATE INDEX IF NOT EXISTS feeding_tasks_day_idx ON feeding_tasks (day, slot) WHERE deleted_at IS NULL;
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS outbox_events_created_at_idx;
+DROP INDEX IF EXISTS outbox_events_unpublished_idx;
+CREATE INDEX IF NOT EXISTS outbox_events_unpublished_idx ON outbox_events (available_at) WHERE published_at IS NULL;
+
+ALTER TABLE outbox_events DROP COLUMN IF EXISTS failed_at;
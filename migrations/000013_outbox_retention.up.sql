@@ -0,0 +1,7 @@
+-- events that used up their attempts are parked with failed_at and no
+-- longer claimed, published and parked events are purged after retention
+ALTER TABLE outbox_events ADD COLUMN IF NOT EXISTS failed_at TIMESTAMPTZ;
+
+DROP INDEX IF EXISTS outbox_events_unpublished_idx;
+CREATE INDEX IF NOT EXISTS outbox_events_unpublished_idx ON outbox_events (available_at) WHERE published_at IS NULL AND failed_at IS NULL;
+CREATE INDEX IF NOT EXISTS outbox_events_created_at_idx ON outbox_events (created_at);
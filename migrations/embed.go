@@ -0,0 +1,15 @@
+// Package migrations ships the schema and the sample data inside the binary,
+// the files stay usable by the migrate CLI
+package migrations
+
+import "embed"
+
+// FS holds the numbered up and down migrations
+//
+//go:embed *.sql
+var FS embed.FS
+
+// Seed holds the sample data loaded by app seed
+//
+//go:embed seed/*.sql
+var Seed embed.FS
@@ -1,3 +1,4 @@
+-- sample farm, safe to run again: rows that are already there are skipped
 INSERT INTO animals (id, name, category_name, gender, birth_day, genus, weight, description, is_health, created_at, updated_at) VALUES
 ('550e8400-e29b-41d4-a716-446655440000', 'test1', 'sheep', 'male', '2015-08-15', 'genus1', 30, 'King of the Jungle', 'true', CURRENT_TIMESTAMP, CURRENT_TIMESTAMP),
 ('550e8400-e29b-41d4-a716-446655440001', 'test2', 'goat', 'female', '2010-04-12', 'genus2', 21, 'Largest land animal', 'false', CURRENT_TIMESTAMP, CURRENT_TIMESTAMP),
@@ -8,25 +9,33 @@ INSERT INTO animals (id, name, category_name, gender, birth_day, genus, weight,
 ('550e8400-e29b-41d4-a716-446655440006', 'test7', 'cow', 'female', '2020-03-15', 'genus3', 300, 'Colorful bird', 'false', CURRENT_TIMESTAMP, CURRENT_TIMESTAMP),
 ('550e8400-e29b-41d4-a716-446655440007', 'test8', 'horse', 'male', '2013-07-21', 'genus1', 200, 'Hard-shelled reptile', 'true', CURRENT_TIMESTAMP, CURRENT_TIMESTAMP),
 ('550e8400-e29b-41d4-a716-446655440008', 'test9', 'goat', 'female', '2017-02-14', 'genus5', 35, 'Large marsupial', 'true', CURRENT_TIMESTAMP, CURRENT_TIMESTAMP),
-('550e8400-e29b-41d4-a716-446655440009', 'test10', 'sheep', 'male', '2011-12-09', 'genus7', 36, 'Bird of prey', 'false', CURRENT_TIMESTAMP, CURRENT_TIMESTAMP);
+('550e8400-e29b-41d4-a716-446655440009', 'test10', 'sheep', 'male', '2011-12-09', 'genus7', 36, 'Bird of prey', 'false', CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)
+ON CONFLICT (id) DO NOTHING;
 
 
 INSERT INTO products (id, name, product_union, description, total_capacity, created_at, updated_at) VALUES
-('660e8400-e29b-41d4-a716-446655440000', 'goat milk', 'litre', 'Fresh cow milk', 1000, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP),
+('660e8400-e29b-41d4-a716-446655440000', 'goat milk', 'litre', 'Fresh goat milk', 1000, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP),
 ('660e8400-e29b-41d4-a716-446655440001', 'eggs', 'piece', 'Fresh eggs', 2000, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP),
-('660e8400-e29b-41d4-a716-446655440002', 'meat', 'kilogramm', 'Sheep wool', 500, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP),
-('660e8400-e29b-41d4-a716-446655440009', 'wool', 'kilogramm', 'Silk threads', 300, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP),
-('660e8400-e29b-41d4-a716-446655440009', 'cow milk', 'litre', 'Silk threads', 300, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP);
+('660e8400-e29b-41d4-a716-446655440002', 'meat', 'kilogramm', 'Sheep meat', 500, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP),
+('660e8400-e29b-41d4-a716-446655440003', 'goat meat', 'kilogramm', 'Goat meat', 400, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP),
+('660e8400-e29b-41d4-a716-446655440004', 'cow milk', 'litre', 'Fresh cow milk', 1500, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP),
+('660e8400-e29b-41d4-a716-446655440005', 'beef', 'kilogramm', 'Cow meat', 600, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP),
+('660e8400-e29b-41d4-a716-446655440006', 'lamb', 'kilogramm', 'Young sheep meat', 250, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP),
+('660e8400-e29b-41d4-a716-446655440007', 'sheep wool', 'kilogramm', 'Sheep wool', 300, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP),
+('660e8400-e29b-41d4-a716-446655440008', 'cream', 'litre', 'Cow milk cream', 200, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP),
+('660e8400-e29b-41d4-a716-446655440009', 'horse milk', 'litre', 'Mare milk for kumis', 150, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)
+ON CONFLICT (id) DO NOTHING;
 
 
 INSERT INTO animal_products (id, animal_id, product_id, capacity, get_time, created_at, updated_at) VALUES
 ('770e8400-e29b-41d4-a716-446655440000', '550e8400-e29b-41d4-a716-446655440000', '660e8400-e29b-41d4-a716-446655440007', 10, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP),
-('770e8400-e29b-41d4-a716-446655440009', '550e8400-e29b-41d4-a716-446655440009', '660e8400-e29b-41d4-a716-446655440006', 60, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP);
 ('770e8400-e29b-41d4-a716-446655440001', '550e8400-e29b-41d4-a716-446655440001', '660e8400-e29b-41d4-a716-446655440000', 200, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP),
-('770e8400-e29b-41d4-a716-446655440002', '550e8400-e29b-41d4-a716-446655440002', '660e8400-e29b-41d4-a716-446655440001', 50, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP),
+('770e8400-e29b-41d4-a716-446655440002', '550e8400-e29b-41d4-a716-446655440002', '660e8400-e29b-41d4-a716-446655440009', 50, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP),
 ('770e8400-e29b-41d4-a716-446655440003', '550e8400-e29b-41d4-a716-446655440003', '660e8400-e29b-41d4-a716-446655440004', 20, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP),
 ('770e8400-e29b-41d4-a716-446655440004', '550e8400-e29b-41d4-a716-446655440004', '660e8400-e29b-41d4-a716-446655440002', 15, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP),
 ('770e8400-e29b-41d4-a716-446655440005', '550e8400-e29b-41d4-a716-446655440005', '660e8400-e29b-41d4-a716-446655440005', 30, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP),
 ('770e8400-e29b-41d4-a716-446655440006', '550e8400-e29b-41d4-a716-446655440006', '660e8400-e29b-41d4-a716-446655440008', 40, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP),
 ('770e8400-e29b-41d4-a716-446655440007', '550e8400-e29b-41d4-a716-446655440007', '660e8400-e29b-41d4-a716-446655440009', 25, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP),
 ('770e8400-e29b-41d4-a716-446655440008', '550e8400-e29b-41d4-a716-446655440008', '660e8400-e29b-41d4-a716-446655440003', 100, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP),
+('770e8400-e29b-41d4-a716-446655440009', '550e8400-e29b-41d4-a716-446655440009', '660e8400-e29b-41d4-a716-446655440006', 60, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)
+ON CONFLICT (id) DO NOTHING;